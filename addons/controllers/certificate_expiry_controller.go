@@ -0,0 +1,133 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	clusterapipatchutil "sigs.k8s.io/cluster-api/util/patch"
+	clusterApiPredicates "sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
+)
+
+// CertificateExpiryReconciler reconciles the ControlPlaneCertificatesValid condition of Clusters, which turns false when
+// the kubeadm certificates of a control plane machine or a certificate authority expire within the expiry window
+type CertificateExpiryReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	Config *addonconfig.CertificateExpiryControllerConfig
+	ctx    context.Context
+}
+
+// NewCertificateExpiryReconciler returns a reconciler for the certificate expiry of Clusters
+func NewCertificateExpiryReconciler(c client.Client, log logr.Logger, scheme *runtime.Scheme,
+	config *addonconfig.CertificateExpiryControllerConfig) *CertificateExpiryReconciler {
+
+	return &CertificateExpiryReconciler{
+		Client: c,
+		Log:    log,
+		Scheme: scheme,
+		Config: config,
+	}
+}
+
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigs,verbs=get
+
+// SetupWithManager performs the setup actions for a certificate expiry controller, using the passed in mgr.
+func (r *CertificateExpiryReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&clusterapiv1beta1.Cluster{}).
+		Watches(
+			&source.Kind{Type: &clusterapiv1beta1.Machine{}},
+			handler.EnqueueRequestsFromMapFunc(r.ControlPlaneMachineToCluster),
+		).
+		WithOptions(options).
+		WithEventFilter(clusterApiPredicates.ResourceNotPaused(r.Log)).
+		Named("certificateExpiry-controller").
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	r.ctx = ctx
+	return nil
+}
+
+// ControlPlaneMachineToCluster returns the Cluster of a control plane Machine
+func (r *CertificateExpiryReconciler) ControlPlaneMachineToCluster(o client.Object) []ctrl.Request {
+	clusterName, ok := o.GetLabels()[clusterapiv1beta1.ClusterLabelName]
+	if _, isControlPlane := o.GetLabels()[clusterapiv1beta1.MachineControlPlaneLabelName]; !ok || !isControlPlane {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: clusterName}}}
+}
+
+// Reconcile sets the ControlPlaneCertificatesValid condition of a Cluster and requeues it when the condition is due to change
+func (r *CertificateExpiryReconciler) Reconcile(_ context.Context, req ctrl.Request) (_ ctrl.Result, retErr error) {
+	log := r.Log.WithValues(constants.ClusterNamespaceLogKey, req.Namespace, constants.ClusterNameLogKey, req.Name)
+
+	cluster := &clusterapiv1beta1.Cluster{}
+	if err := r.Client.Get(r.ctx, req.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Cluster not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errors.Wrap(err, "unable to fetch cluster")
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	expiries, err := util.GetCertificatesExpiry(r.ctx, r.Client, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := clusterapipatchutil.NewHelper(cluster, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer func() {
+		if err := patchHelper.Patch(r.ctx, cluster, clusterapipatchutil.WithOwnedConditions{
+			Conditions: []clusterapiv1beta1.ConditionType{constants.ControlPlaneCertificatesValidCondition},
+		}); err != nil {
+			if retErr == nil {
+				retErr = err
+			}
+			log.Error(err, "error patching cluster")
+		}
+	}()
+
+	// clusters without kubeadm control plane, e.g. with a managed control plane, have no expiry to report
+	if len(expiries) == 0 {
+		conditions.Delete(cluster, constants.ControlPlaneCertificatesValidCondition)
+		return ctrl.Result{}, nil
+	}
+
+	condition, requeueAfter := util.GetCertificatesExpiryCondition(expiries, r.Config.ExpiryWindow, time.Now())
+	if condition.Reason != conditions.GetReason(cluster, constants.ControlPlaneCertificatesValidCondition) && condition.Reason != "" {
+		log.Info("Control plane certificates are expiring", "reason", condition.Reason, "message", condition.Message)
+	}
+	conditions.Set(cluster, condition)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
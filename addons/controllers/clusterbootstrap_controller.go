@@ -51,6 +51,9 @@ import (
 	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 )
 
+// errPackageDependenciesNotReady is returned when the PackageInstall of a package is held back by its dependencies
+var errPackageDependenciesNotReady = errors.New("package dependencies are not ready")
+
 // ClusterBootstrapReconciler reconciles a ClusterBootstrap object
 type ClusterBootstrapReconciler struct {
 	client.Client
@@ -87,6 +90,7 @@ type ClusterBootstrapWatchInputs struct {
 
 // +kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=clusterBootstraps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=clusterBootstraps/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=clusterbootstraprollouts,verbs=get;list;watch
 
 // SetupWithManager performs the setup actions for an ClusterBootstrap controller, using the passed in mgr.
 func (r *ClusterBootstrapReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -222,16 +226,20 @@ func (r *ClusterBootstrapReconciler) reconcileNormal(cluster *clusterapiv1beta1.
 		return ctrl.Result{}, err
 	}
 
-	_, err = r.createOrPatchResourcesForCorePackages(cluster, clusterBootstrap, remoteClient, log)
+	corePackagesResult, err := r.createOrPatchResourcesForCorePackages(cluster, clusterBootstrap, remoteClient, log)
 	if err != nil {
 		return ctrl.Result{RequeueAfter: constants.RequeueAfterDuration}, err
 	}
 
-	_, err = r.createOrPatchResourcesForAdditionalPackages(cluster, clusterBootstrap, remoteClient, log)
+	additionalPackagesResult, err := r.createOrPatchResourcesForAdditionalPackages(cluster, clusterBootstrap, remoteClient, log)
 	if err != nil {
 		return ctrl.Result{RequeueAfter: constants.RequeueAfterDuration}, err
 	}
 
+	// Requeue while the PackageInstalls of some packages are held back by their dependencies
+	if corePackagesResult.RequeueAfter > 0 || additionalPackagesResult.RequeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: constants.RequeueAfterDuration}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -251,12 +259,17 @@ func (r *ClusterBootstrapReconciler) createOrPatchResourcesForCorePackages(clust
 	// local envtest run when any above component is missing.
 	corePackages = removeCorePackagesNils(corePackages)
 
+	dependenciesPending := false
 	for _, corePackage := range corePackages {
 		// There are different ways to have all the resources created or patched on remote cluster. Current solution is
 		// to handle packages in sequence order. I.e., Create all resources for CNI first, and then CPI, CSI. It is also
 		// possible to create all resources in a different order or in parallel. We will consider to use goroutines to create
 		// all resources in parallel on remote cluster if there is performance issue from sequential ordering.
-		if err := r.createOrPatchAddonResourcesOnRemote(cluster, corePackage, remoteClient); err != nil {
+		if err := r.createOrPatchAddonResourcesOnRemote(cluster, clusterBootstrap, corePackage, remoteClient); err != nil {
+			if errors.Is(err, errPackageDependenciesNotReady) {
+				dependenciesPending = true
+				continue
+			}
 			// For core packages, we require all their creation or patching to succeed, so if error happens against any of the
 			// packages, we return error and let the reconciler retry again.
 			log.Error(err, fmt.Sprintf("unable to create or patch all the required resources for %s on cluster: %s/%s",
@@ -275,6 +288,9 @@ func (r *ClusterBootstrapReconciler) createOrPatchResourcesForCorePackages(clust
 			}
 		}
 	}
+	if dependenciesPending {
+		return ctrl.Result{RequeueAfter: constants.RequeueAfterDuration}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -283,8 +299,13 @@ func (r *ClusterBootstrapReconciler) createOrPatchResourcesForAdditionalPackages
 	remoteClient client.Client,
 	log logr.Logger) (ctrl.Result, error) {
 
+	dependenciesPending := false
 	for _, additionalPkg := range clusterBootstrap.Spec.AdditionalPackages {
-		if err := r.createOrPatchAddonResourcesOnRemote(cluster, additionalPkg, remoteClient); err != nil {
+		if err := r.createOrPatchAddonResourcesOnRemote(cluster, clusterBootstrap, additionalPkg, remoteClient); err != nil {
+			if errors.Is(err, errPackageDependenciesNotReady) {
+				dependenciesPending = true
+				continue
+			}
 			// Logging has been handled in createOrPatchAddonResourcesOnRemote()
 			return ctrl.Result{}, err
 		}
@@ -311,6 +332,9 @@ func (r *ClusterBootstrapReconciler) createOrPatchResourcesForAdditionalPackages
 		return ctrl.Result{Requeue: true}, err
 	}
 
+	if dependenciesPending {
+		return ctrl.Result{RequeueAfter: constants.RequeueAfterDuration}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -355,6 +379,11 @@ func (r *ClusterBootstrapReconciler) addFinalizer(o, deepCopy client.Object) err
 // handleClusterUnpause unpauses the cluster if the cluster pause annotation is set by cluster pause webhook (cluster has "tkg.tanzu.vmware.com/paused" annotation)
 func (r *ClusterBootstrapReconciler) handleClusterUnpause(cluster *clusterapiv1beta1.Cluster, clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, log logr.Logger) error {
 	if cluster.Spec.Paused && cluster.Annotations != nil {
+		// a cluster paused by a user stays paused until the user resumes it
+		if _, ok := cluster.Annotations[constants.ClusterPausedByAnnotation]; ok {
+			log.Info(fmt.Sprintf("cluster %s/%s is paused by %s, not unpausing it", cluster.Namespace, cluster.Name, cluster.Annotations[constants.ClusterPausedByAnnotation]))
+			return nil
+		}
 		if value, ok := cluster.Annotations[constants.ClusterPauseLabel]; ok && value == clusterBootstrap.Status.ResolvedTKR {
 			patchedCluster := cluster.DeepCopy()
 			delete(patchedCluster.Annotations, constants.ClusterPauseLabel)
@@ -420,6 +449,17 @@ func (r *ClusterBootstrapReconciler) createOrPatchClusterBootstrapFromTemplate(c
 	}
 	// Handle ClusterBootstrap update when TKR version of the cluster is upgraded
 	if tkrName != clusterBootstrap.Status.ResolvedTKR {
+		rollout, err := util.GetClusterBootstrapRolloutHoldingBack(r.context, r.Client, r.Config.SystemNamespace, tkrName, cluster)
+		if err != nil {
+			return nil, err
+		}
+		// Hold back the update until the cluster is admitted by the staged rollout of the TKR. The cluster stays paused
+		// meanwhile: handleClusterUnpause only unpauses it once the ClusterBootstrap resolves to the new TKR.
+		if rollout != nil {
+			log.Info(fmt.Sprintf("ClusterBootstrap upgrade to TKR %s is waiting for admission by ClusterBootstrapRollout %s/%s, the cluster stays paused until then",
+				tkrName, rollout.Namespace, rollout.Name))
+			return clusterBootstrap, nil
+		}
 		log.Info(fmt.Sprintf("Upgrading ClusterBootstrap from TKR %s to TKR %s", clusterBootstrap.Status.ResolvedTKR, tkrName))
 		return r.patchClusterBootstrapFromTemplate(cluster, clusterBootstrap, clusterBootstrapTemplate, clusterBootstrapHelper, tkrName, log)
 	}
@@ -520,6 +560,7 @@ func (r *ClusterBootstrapReconciler) mergeClusterBootstrapPackagesWithTemplate(
 		updatedClusterBootstrap.Spec.Kapp = clusterBootstrapTemplate.Spec.Kapp.DeepCopy()
 	} else {
 		updatedClusterBootstrap.Spec.Kapp.RefName = clusterBootstrapTemplate.Spec.Kapp.RefName
+		mergePackageDependencies(updatedClusterBootstrap.Spec.Kapp, clusterBootstrapTemplate.Spec.Kapp)
 	}
 
 	// CSI and CPI can be nil, only update if it's present
@@ -530,6 +571,7 @@ func (r *ClusterBootstrapReconciler) mergeClusterBootstrapPackagesWithTemplate(
 		packages = append(packages, newCSIPkg)
 	} else {
 		updatedClusterBootstrap.Spec.CSI.RefName = clusterBootstrapTemplate.Spec.CSI.RefName
+		mergePackageDependencies(updatedClusterBootstrap.Spec.CSI, clusterBootstrapTemplate.Spec.CSI)
 	}
 
 	if updatedClusterBootstrap.Spec.CPI == nil {
@@ -538,6 +580,7 @@ func (r *ClusterBootstrapReconciler) mergeClusterBootstrapPackagesWithTemplate(
 		packages = append(packages, newCPIPkg)
 	} else {
 		updatedClusterBootstrap.Spec.CPI.RefName = clusterBootstrapTemplate.Spec.CPI.RefName
+		mergePackageDependencies(updatedClusterBootstrap.Spec.CPI, clusterBootstrapTemplate.Spec.CPI)
 	}
 
 	// Since we don't allow users to delete additional packages in our webhook
@@ -569,6 +612,7 @@ func (r *ClusterBootstrapReconciler) mergeClusterBootstrapPackagesWithTemplate(
 		// Find the one to one match for additional package in new ClusterBootstrapTemplate and old ClusterBootstrap and update
 		if pkg, ok := additionalPackageMap[packageRefName]; ok {
 			pkg.RefName = templatePkg.RefName
+			mergePackageDependencies(pkg, templatePkg)
 		} else {
 			// If new additional package is added in ClusterBootstrapTemplate, just add it to updated ClusterBootstrap
 			newPkg := templatePkg.DeepCopy()
@@ -580,6 +624,23 @@ func (r *ClusterBootstrapReconciler) mergeClusterBootstrapPackagesWithTemplate(
 	return packages, nil
 }
 
+// mergePackageDependencies adds the dependencies declared in the ClusterBootstrapTemplate of the new TKR to a package,
+// keeping the dependencies already declared in the ClusterBootstrap
+func mergePackageDependencies(pkg, templatePkg *runtanzuv1alpha3.ClusterBootstrapPackage) {
+	for _, dependency := range templatePkg.DependsOn {
+		found := false
+		for _, existing := range pkg.DependsOn {
+			if existing == dependency {
+				found = true
+				break
+			}
+		}
+		if !found {
+			pkg.DependsOn = append(pkg.DependsOn, dependency)
+		}
+	}
+}
+
 // createOrPatchKappPackageInstall contains the logic that create/update PackageInstall CR for kapp-controller on
 // mgmt cluster. The kapp-controller running on mgmt cluster reconciles the PackageInstall CR and creates kapp-controller resources
 // on remote workload cluster. This is required for a workload cluster and its corresponding package installations to be functional.
@@ -912,7 +973,7 @@ func (r *ClusterBootstrapReconciler) createOrPatchAddonRBACOnRemote(cluster *clu
 // createOrPatchAddonResourcesOnRemote creates or patches the resources for a cluster bootstrap package on remote workload
 // cluster. The resources are [Package CR, Secret for PackageInstall, PackageInstall CR].
 func (r *ClusterBootstrapReconciler) createOrPatchAddonResourcesOnRemote(cluster *clusterapiv1beta1.Cluster,
-	cbPkg *runtanzuv1alpha3.ClusterBootstrapPackage, clusterClient client.Client) error {
+	clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, cbPkg *runtanzuv1alpha3.ClusterBootstrapPackage, clusterClient client.Client) error {
 
 	remotePackage, err := r.createOrPatchPackageOnRemote(cluster, cbPkg, clusterClient)
 	if err != nil {
@@ -943,6 +1004,17 @@ func (r *ClusterBootstrapReconciler) createOrPatchAddonResourcesOnRemote(cluster
 		return nil
 	}
 
+	// The PackageInstall is held back until the packages it depends on have been reconciled successfully
+	ready, err := r.packageDependenciesReady(cluster, clusterBootstrap, cbPkg, clusterClient)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		r.Log.Info(fmt.Sprintf("skip creating the packageInstall for the package %s on cluster %s/%s until its dependencies %v are reconciled",
+			remotePackage.Name, cluster.Namespace, cluster.Name, cbPkg.DependsOn))
+		return errPackageDependenciesNotReady
+	}
+
 	pkgi, err := r.createOrPatchPackageInstallOnRemote(cluster, cbPkg, remoteSecret, clusterClient)
 	if err != nil {
 		return err
@@ -953,6 +1025,34 @@ func (r *ClusterBootstrapReconciler) createOrPatchAddonResourcesOnRemote(cluster
 	return nil
 }
 
+// packageDependenciesReady tells whether the PackageInstalls of the packages a ClusterBootstrapPackage depends on
+// have been reconciled successfully on the cluster
+func (r *ClusterBootstrapReconciler) packageDependenciesReady(cluster *clusterapiv1beta1.Cluster,
+	clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, cbPkg *runtanzuv1alpha3.ClusterBootstrapPackage, clusterClient client.Client) (bool, error) {
+
+	for _, dependency := range cbPkg.DependsOn {
+		// kapp-controller is always deployed prior to the other packages
+		if clusterBootstrap.Spec.Kapp != nil && util.PackageMatchesDependency(clusterBootstrap.Spec.Kapp.RefName, dependency) {
+			continue
+		}
+		pkgi := &kapppkgiv1alpha1.PackageInstall{}
+		key := client.ObjectKey{Namespace: r.Config.SystemNamespace, Name: util.GeneratePackageInstallName(cluster.Name, dependency)}
+		if err := clusterClient.Get(r.context, key, pkgi); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if pkgi.Status.ObservedGeneration != pkgi.Generation {
+			return false, nil
+		}
+		if summary := util.SummarizeAppConditions(pkgi.Status.Conditions); summary == nil || summary.Type != kappctrlv1alpha1.ReconcileSucceeded {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (r *ClusterBootstrapReconciler) patchSecretWithTKGSDataValues(cluster *clusterapiv1beta1.Cluster, secret *corev1.Secret) error {
 	// Add TKR NodeSelector info if it's a TKGS cluster
 	infraRef, err := util.GetInfraProvider(cluster)
@@ -1310,6 +1410,10 @@ func (r *ClusterBootstrapReconciler) watchesForClusterBootstrap() []ClusterBoots
 			&source.Kind{Type: &corev1.Secret{}},
 			handler.EnqueueRequestsFromMapFunc(r.SecretsToClusters),
 		},
+		{
+			&source.Kind{Type: &runtanzuv1alpha3.ClusterBootstrapRollout{}},
+			handler.EnqueueRequestsFromMapFunc(r.ClusterBootstrapRolloutToClusters),
+		},
 	}
 }
 
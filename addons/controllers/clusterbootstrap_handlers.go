@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -80,6 +81,29 @@ func (r *ClusterBootstrapReconciler) ClusterBootstrapToClusters(o client.Object)
 	return []ctrl.Request{{NamespacedName: client.ObjectKeyFromObject(cluster)}}
 }
 
+// ClusterBootstrapRolloutToClusters returns a list of Requests with the Cluster ObjectKeys admitted by a ClusterBootstrapRollout
+func (r *ClusterBootstrapReconciler) ClusterBootstrapRolloutToClusters(o client.Object) []ctrl.Request {
+	rollout, ok := o.(*runtanzuv1alpha3.ClusterBootstrapRollout)
+	if !ok {
+		r.Log.Error(errors.New("invalid type"),
+			"Expected to receive ClusterBootstrapRollout resource",
+			"actualType", fmt.Sprintf("%T", o))
+		return nil
+	}
+
+	r.Log.V(4).Info("Mapping ClusterBootstrapRollout to clusters", constants.NameLogKey, rollout.Name)
+
+	var requests []ctrl.Request
+	for _, key := range rollout.Status.AdmittedClusters {
+		namespace, name, found := strings.Cut(key, "/")
+		if !found {
+			continue
+		}
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	}
+	return requests
+}
+
 // SecretsToClusters is the Map Function for watching Secrets that filters the events on
 // objects of type secret and returns requests for reconcile if required
 func (r *ClusterBootstrapReconciler) SecretsToClusters(o client.Object) []ctrl.Request {
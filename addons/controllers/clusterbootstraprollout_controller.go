@@ -0,0 +1,185 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterapipatchutil "sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+// ClusterBootstrapRolloutReconciler reconciles a ClusterBootstrapRollout object. It admits the clusters allowed to update
+// their ClusterBootstrap to the ClusterBootstrapTemplate of a TKR; the ClusterBootstrap controller holds back the others.
+type ClusterBootstrapRolloutReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	Config *addonconfig.ClusterBootstrapRolloutControllerConfig
+	ctx    context.Context
+}
+
+// NewClusterBootstrapRolloutReconciler returns a reconciler for ClusterBootstrapRollout
+func NewClusterBootstrapRolloutReconciler(c client.Client, log logr.Logger, scheme *runtime.Scheme,
+	config *addonconfig.ClusterBootstrapRolloutControllerConfig) *ClusterBootstrapRolloutReconciler {
+
+	return &ClusterBootstrapRolloutReconciler{
+		Client: c,
+		Log:    log,
+		Scheme: scheme,
+		Config: config,
+	}
+}
+
+// +kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=clusterbootstraprollouts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=clusterbootstraprollouts/status,verbs=get;update;patch
+
+// SetupWithManager performs the setup actions for a ClusterBootstrapRollout controller, using the passed in mgr.
+func (r *ClusterBootstrapRolloutReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&runtanzuv1alpha3.ClusterBootstrapRollout{}).
+		Watches(
+			&source.Kind{Type: &clusterapiv1beta1.Cluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.ClusterToRollouts),
+		).
+		Watches(
+			&source.Kind{Type: &runtanzuv1alpha3.ClusterBootstrap{}},
+			handler.EnqueueRequestsFromMapFunc(r.ClusterBootstrapToRollouts),
+		).
+		WithOptions(options).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	r.ctx = ctx
+	return nil
+}
+
+// Reconcile admits the next clusters of the rollout and records the progress in the status of the ClusterBootstrapRollout
+func (r *ClusterBootstrapRolloutReconciler) Reconcile(_ context.Context, req ctrl.Request) (_ ctrl.Result, retErr error) {
+	log := r.Log.WithValues(constants.NamespaceLogKey, req.Namespace, constants.NameLogKey, req.Name)
+
+	rollout := &runtanzuv1alpha3.ClusterBootstrapRollout{}
+	if err := r.Client.Get(r.ctx, req.NamespacedName, rollout); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("ClusterBootstrapRollout not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errors.Wrap(err, "unable to fetch ClusterBootstrapRollout")
+	}
+
+	if !rollout.DeletionTimestamp.IsZero() || rollout.Namespace != r.Config.SystemNamespace {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := clusterapipatchutil.NewHelper(rollout, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer func() {
+		if err := patchHelper.Patch(r.ctx, rollout); err != nil {
+			if retErr == nil {
+				retErr = err
+			}
+			log.Error(err, "error patching ClusterBootstrapRollout")
+		}
+	}()
+
+	rolloutClusters, err := r.getRolloutClusters(rollout)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	previousPhase := rollout.Status.Phase
+	rollout.Status = util.PlanRollout(rollout, rolloutClusters)
+	if previousPhase != rollout.Status.Phase {
+		log.Info(fmt.Sprintf("ClusterBootstrapRollout is %s: %s", rollout.Status.Phase, rollout.Status.Message))
+	}
+	return ctrl.Result{}, nil
+}
+
+// getRolloutClusters returns the clusters governed by the rollout
+func (r *ClusterBootstrapRolloutReconciler) getRolloutClusters(rollout *runtanzuv1alpha3.ClusterBootstrapRollout) ([]util.RolloutCluster, error) {
+	selector, err := util.GetClusterBootstrapRolloutSelector(rollout)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterList := &clusterapiv1beta1.ClusterList{}
+	if err := r.Client.List(r.ctx, clusterList, client.MatchingLabels{constants.TKRLabelClassyClusters: rollout.Spec.TKR}); err != nil {
+		return nil, errors.Wrap(err, "unable to list clusters")
+	}
+
+	var rolloutClusters []util.RolloutCluster
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if !selector.Matches(labels.Set(cluster.Labels)) || !cluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{}
+		if err := r.Client.Get(r.ctx, client.ObjectKeyFromObject(cluster), clusterBootstrap); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "unable to fetch ClusterBootstrap for cluster %s/%s", cluster.Namespace, cluster.Name)
+			}
+			clusterBootstrap = nil
+		}
+		rolloutClusters = append(rolloutClusters, util.GetRolloutCluster(rollout, cluster, clusterBootstrap))
+	}
+	return rolloutClusters, nil
+}
+
+// ClusterToRollouts returns a list of Requests with the ClusterBootstrapRollouts of the TKR the cluster is upgraded to
+func (r *ClusterBootstrapRolloutReconciler) ClusterToRollouts(o client.Object) []ctrl.Request {
+	return r.tkrToRollouts(o.GetLabels()[constants.TKRLabelClassyClusters])
+}
+
+// ClusterBootstrapToRollouts returns a list of Requests with the ClusterBootstrapRollouts of the TKR the ClusterBootstrap resolves to
+func (r *ClusterBootstrapRolloutReconciler) ClusterBootstrapToRollouts(o client.Object) []ctrl.Request {
+	clusterBootstrap, ok := o.(*runtanzuv1alpha3.ClusterBootstrap)
+	if !ok {
+		r.Log.Error(errors.New("invalid type"),
+			"Expected to receive ClusterBootstrap resource",
+			"actualType", fmt.Sprintf("%T", o))
+		return nil
+	}
+	return r.tkrToRollouts(clusterBootstrap.Status.ResolvedTKR)
+}
+
+func (r *ClusterBootstrapRolloutReconciler) tkrToRollouts(tkrName string) []ctrl.Request {
+	if tkrName == "" {
+		return nil
+	}
+
+	rolloutList := &runtanzuv1alpha3.ClusterBootstrapRolloutList{}
+	if err := r.Client.List(r.ctx, rolloutList, client.InNamespace(r.Config.SystemNamespace)); err != nil {
+		r.Log.Error(err, "Error listing ClusterBootstrapRollouts", constants.TKRNameLogKey, tkrName)
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for i := range rolloutList.Items {
+		if rolloutList.Items[i].Spec.TKR == tkrName {
+			requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&rolloutList.Items[i])})
+		}
+	}
+	return requests
+}
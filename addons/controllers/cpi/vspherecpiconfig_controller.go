@@ -6,30 +6,23 @@ package controllers
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/go-logr/logr"
-	v1 "k8s.io/api/core/v1"
+	"github.com/pkg/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	capvvmwarev1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/vmware/v1beta1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
-	clusterapiutil "sigs.k8s.io/cluster-api/util"
-	clusterapipatchutil "sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
-	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
 	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/configcontroller"
 	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
-	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
-	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
 	cpiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cpi/v1alpha1"
 )
 
@@ -78,170 +71,71 @@ var vsphereCPIProviderServiceAccountAggregatedClusterRole = &rbacv1.ClusterRole{
 //+kubebuilder:rbac:groups=cpi.tanzu.vmware.com,resources=vspherecpiconfigs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=vmware.infrastructure.cluster.x-k8s.io,resources=providerserviceaccounts,verbs=get;create;list;watch;update;patch
 
-// Reconcile the VSphereCPIConfig CRD
-func (r *VSphereCPIConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger := r.Log.WithValues("VSphereCPIConfig", req.NamespacedName)
-
-	logger.Info("Start reconciliation for VSphereCPIConfig")
-
-	// fetch VSphereCPIConfig resource
-	cpiConfig := &cpiv1alpha1.VSphereCPIConfig{}
-	if err := r.Client.Get(ctx, req.NamespacedName, cpiConfig); err != nil {
-		if apierrors.IsNotFound(err) {
-			logger.Info("VSphereCPIConfig resource not found")
-			return ctrl.Result{}, nil
-		}
-		logger.Error(err, "Unable to fetch VSphereCPIConfig resource")
-		return ctrl.Result{}, err
-	}
-
-	// deep copy VSphereCPIConfig to avoid issues if in the future other controllers where interacting with the same copy
-	cpiConfig = cpiConfig.DeepCopy()
-
-	cluster, err := cutil.GetOwnerCluster(ctx, r.Client, cpiConfig, req.Namespace, constants.CPIDefaultRefName)
-	if err != nil {
-		if apierrors.IsNotFound(err) && cluster != nil {
-			logger.Info(fmt.Sprintf("'%s/%s' is listed as owner reference but could not be found",
-				cluster.Namespace, cluster.Name))
-			return ctrl.Result{}, nil
-		}
-		logger.Error(err, "could not determine owner cluster")
-		return ctrl.Result{}, err
-	}
-
-	if cpiConfig.Spec.VSphereCPI.Mode == nil {
-		logger.Info("VSphere CPI mode is not provided.")
-		return ctrl.Result{}, nil // no need to requeue if CPI mode is not provided
-	}
-	if res, err := r.reconcileVSphereCPIConfig(ctx, cpiConfig, cluster); err != nil {
-		logger.Error(err, "Failed to reconcile VSphereCPIConfig")
-		return res, err
+// Definition describes how a VSphereCPIConfig is mapped to the vsphere-cpi data values secret. The vCenter or Supervisor
+// endpoints and credentials are derived from the VSphereCluster of the cluster; in paravirtual mode, the
+// ProviderServiceAccount of the cloud controller manager is deployed as well. VSphereCPIConfigs without a mode are
+// not reconciled.
+func (r *VSphereCPIConfigReconciler) Definition() *configcontroller.Definition {
+	return &configcontroller.Definition{
+		Kind:      constants.VSphereCPIConfigKind,
+		AddonName: constants.CPIAddonName,
+		NewObject: func() configcontroller.Object { return &cpiv1alpha1.VSphereCPIConfig{} },
+		NewList:   func() client.ObjectList { return &cpiv1alpha1.VSphereCPIConfigList{} },
+		Skip: func(config configcontroller.Object) bool {
+			return config.(*cpiv1alpha1.VSphereCPIConfig).Spec.VSphereCPI.Mode == nil
+		},
+		DataValues: func(ctx context.Context, _ client.Client, config configcontroller.Object, cluster *clusterapiv1beta1.Cluster) (interface{}, error) {
+			return r.mapCPIConfigToDataValues(ctx, config.(*cpiv1alpha1.VSphereCPIConfig), cluster)
+		},
+		ReconcileDependents: func(ctx context.Context, _ client.Client, config configcontroller.Object, cluster *clusterapiv1beta1.Cluster) error {
+			return r.reconcileProviderServiceAccount(ctx, config.(*cpiv1alpha1.VSphereCPIConfig), cluster)
+		},
 	}
-	return ctrl.Result{}, nil
 }
 
-// reconcileVSphereCPIConfig reconciles VSphereCPIConfig with its owner cluster
-func (r *VSphereCPIConfigReconciler) reconcileVSphereCPIConfig(ctx context.Context, cpiConfig *cpiv1alpha1.VSphereCPIConfig, cluster *clusterapiv1beta1.Cluster) (_ ctrl.Result, retErr error) {
-	patchHelper, err := clusterapipatchutil.NewHelper(cpiConfig, r.Client)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	// patch VSphereCPIConfig before returning the function
-	defer func() {
-		r.Log.Info("Patching VSphereCPIConfig")
-		if err := patchHelper.Patch(ctx, cpiConfig); err != nil {
-			r.Log.Error(err, "Error patching VSphereCPIConfig")
-			retErr = err
-		}
-		r.Log.Info("Successfully patched VSphereCPIConfig")
-	}()
+// reconcileProviderServiceAccount deploys the provider service account of the cloud controller manager in paravirtual mode
+func (r *VSphereCPIConfigReconciler) reconcileProviderServiceAccount(ctx context.Context,
+	cpiConfig *cpiv1alpha1.VSphereCPIConfig, cluster *clusterapiv1beta1.Cluster) error {
 
-	if !cpiConfig.GetDeletionTimestamp().IsZero() {
-		return ctrl.Result{}, nil
-	}
-	if err = r.reconcileVSphereCPIConfigNormal(ctx, cpiConfig, cluster); err != nil {
-		r.Log.Error(err, "Error reconciling VSphereCPIConfig to create/patch data values secret")
-		return ctrl.Result{}, err
-	}
-	r.Log.Info("Successfully reconciled VSphereCPIConfig")
-	return ctrl.Result{}, nil
-}
-
-// reconcileVSphereCPIConfigNormal triggers when a VSphereCPIConfig is not being deleted
-// it ensures the owner reference of the VSphereCPIConfig and generates the data values secret for CPI
-func (r *VSphereCPIConfigReconciler) reconcileVSphereCPIConfigNormal(ctx context.Context,
-	cpiConfig *cpiv1alpha1.VSphereCPIConfig, cluster *clusterapiv1beta1.Cluster) (retErr error) {
-	// add owner reference to VSphereCPIConfig if not already added by TanzuClusterBootstrap Controller
-	ownerReference := metav1.OwnerReference{
-		APIVersion: clusterapiv1beta1.GroupVersion.String(),
-		Kind:       cluster.Kind,
-		Name:       cluster.Name,
-		UID:        cluster.UID,
-	}
-	r.Log.Info("Ensure VSphereCPIConfig has the cluster as owner reference")
-	if !clusterapiutil.HasOwnerRef(cpiConfig.OwnerReferences, ownerReference) {
-		r.Log.Info("Adding owner reference to VSphereCPIConfig")
-		cpiConfig.OwnerReferences = clusterapiutil.EnsureOwnerRef(cpiConfig.OwnerReferences, ownerReference)
-	}
-	secret := &v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      util.GenerateDataValueSecretName(cluster.Name, constants.CPIAddonName),
-			Namespace: cpiConfig.Namespace,
-		},
-		Type: v1.SecretTypeOpaque,
+	if *cpiConfig.Spec.VSphereCPI.Mode != VSphereCPIParavirtualMode {
+		return nil
 	}
-	secret.SetOwnerReferences([]metav1.OwnerReference{ownerReference})
 
-	mutateFn := func() error {
-		secret.StringData = make(map[string]string)
-		cpiConfigSpec, err := r.mapCPIConfigToDataValues(ctx, cpiConfig, cluster)
-		if err != nil {
-			r.Log.Error(err, "Error while mapping VSphereCPIConfig to data values")
-			return err
-		}
-		yamlBytes, err := cpiConfigSpec.Serialize()
-		if err != nil {
-			r.Log.Error(err, "Error marshaling VSphereCPIConfig to Yaml")
-			return err
-		}
-		secret.StringData[constants.TKGDataValueFileName] = string(yamlBytes)
-		r.Log.Info("Mutated VSphereCPIConfig data values")
+	// create an aggregated cluster role RBAC that will be inherited by CAPV (https://kubernetes.io/docs/reference/access-authn-authz/rbac/#aggregated-clusterroles)
+	// CAPV needs to hold these rules before it can grant it to serviceAccount for CPI
+	clusterRole := vsphereCPIProviderServiceAccountAggregatedClusterRole.DeepCopy()
+	_, err := controllerutil.CreateOrPatch(ctx, r.Client, clusterRole, func() error {
+		clusterRole.Rules = providerServiceAccountRBACRules
 		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to create or patch cluster role %s", clusterRole.Name)
 	}
-	result, err := controllerutil.CreateOrPatch(ctx, r.Client, secret, mutateFn)
+
+	vsphereCluster, err := cutil.VSphereClusterParavirtualForCAPICluster(ctx, r.Client, cluster)
 	if err != nil {
-		r.Log.Error(err, "Error creating or patching VSphereCPIConfig data values secret")
 		return err
 	}
-
-	// deploy the provider service account for paravirtual mode
-	if *cpiConfig.Spec.VSphereCPI.Mode == VSphereCPIParavirtualMode {
-		// create an aggregated cluster role RBAC that will be inherited by CAPV (https://kubernetes.io/docs/reference/access-authn-authz/rbac/#aggregated-clusterroles)
-		// CAPV needs to hold these rules before it can grant it to serviceAccount for CPI
-
-		_, err := controllerutil.CreateOrPatch(ctx, r.Client, vsphereCPIProviderServiceAccountAggregatedClusterRole, func() error {
-			vsphereCPIProviderServiceAccountAggregatedClusterRole.Rules = providerServiceAccountRBACRules
-			return nil
-		})
-		if err != nil {
-			r.Log.Error(err, "Error creating or patching cluster role", "name", vsphereCPIProviderServiceAccountAggregatedClusterRole)
-			return err
-		}
-
-		vsphereCluster, err := cutil.VSphereClusterParavirtualForCAPICluster(ctx, r.Client, cluster)
-		if err != nil {
-			return err
-		}
-		serviceAccount := &capvvmwarev1beta1.ProviderServiceAccount{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      getCCMName(vsphereCluster),
-				Namespace: vsphereCluster.Namespace,
-			},
-		}
-		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, serviceAccount, func() error {
-			serviceAccount.Spec = r.mapCPIConfigToProviderServiceAccountSpec(vsphereCluster)
-			return controllerutil.SetControllerReference(vsphereCluster, serviceAccount, r.Scheme)
-		})
-		if err != nil {
-			r.Log.Error(err, "Error creating or updating ProviderServiceAccount for VSphere CPI")
-		}
+	serviceAccount := &capvvmwarev1beta1.ProviderServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getCCMName(vsphereCluster),
+			Namespace: vsphereCluster.Namespace,
+		},
 	}
-
-	r.Log.Info(fmt.Sprintf("Resource '%s' data values secret '%s'", constants.CPIAddonName, result))
-	// update the secret reference in VSphereCPIConfig status
-	cpiConfig.Status.SecretRef = secret.Name
-	return nil
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, serviceAccount, func() error {
+		serviceAccount.Spec = r.mapCPIConfigToProviderServiceAccountSpec(vsphereCluster)
+		return controllerutil.SetControllerReference(vsphereCluster, serviceAccount, r.Scheme)
+	})
+	return errors.Wrap(err, "unable to create or update the ProviderServiceAccount for VSphere CPI")
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *VSphereCPIConfigReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager, options controller.Options) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&cpiv1alpha1.VSphereCPIConfig{}).
-		WithOptions(options).
-		WithEventFilter(predicates.ConfigOfKindWithoutAnnotation(constants.TKGAnnotationTemplateConfig, constants.VSphereCPIConfigKind, r.Config.SystemNamespace, r.Log)).
-		Watches(
-			&source.Kind{Type: &clusterapiv1beta1.Cluster{}},
-			handler.EnqueueRequestsFromMapFunc(r.ClusterToVSphereCPIConfig),
-		).
-		Complete(r)
+func (r *VSphereCPIConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&configcontroller.Reconciler{
+		Client:     r.Client,
+		Log:        r.Log,
+		Scheme:     r.Scheme,
+		Config:     r.Config.ConfigControllerConfig,
+		Definition: r.Definition(),
+	}).SetupWithManager(ctx, mgr, options)
 }
@@ -9,8 +9,6 @@ import (
 	"net/url"
 	"strconv"
 
-	"sigs.k8s.io/controller-runtime/pkg/client"
-
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -22,36 +20,12 @@ import (
 	capvidentity "sigs.k8s.io/cluster-api-provider-vsphere/pkg/identity"
 	capvmanager "sigs.k8s.io/cluster-api-provider-vsphere/pkg/manager"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
-	ctrl "sigs.k8s.io/controller-runtime"
 
 	cutil "github.com/vmware-tanzu/tanzu-framework/addons/controllers/utils"
 	pkgtypes "github.com/vmware-tanzu/tanzu-framework/addons/pkg/types"
 	cpiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cpi/v1alpha1"
 )
 
-// ClusterToVSphereCPIConfig returns a list of Requests with VSphereCPIConfig ObjectKey based on Cluster events
-func (r *VSphereCPIConfigReconciler) ClusterToVSphereCPIConfig(o client.Object) []ctrl.Request {
-	cluster, ok := o.(*clusterapiv1beta1.Cluster)
-	if !ok {
-		r.Log.Error(errors.New("invalid type"),
-			"Expected to receive Cluster resource",
-			"actualType", fmt.Sprintf("%T", o))
-		return nil
-	}
-
-	r.Log.V(4).Info("Mapping Cluster to VSphereCPIConfig")
-
-	cs := &cpiv1alpha1.VSphereCPIConfigList{}
-	_ = r.List(context.Background(), cs)
-	var requests []ctrl.Request
-
-	for _, cpiConfig := range cs.Items {
-		requests = performEnqueueForCPIConfigIfOwnedByCluster(&cpiConfig, cluster, r.Config.ConfigControllerConfig, r.Log, requests)
-	}
-
-	return requests
-}
-
 // mapCPIConfigToDataValuesNonParavirtual generates CPI data values for non-paravirtual modes
 func (r *VSphereCPIConfigReconciler) mapCPIConfigToDataValuesNonParavirtual( // nolint
 	ctx context.Context,
@@ -12,7 +12,9 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -221,31 +223,41 @@ func (r *PackageInstallStatusReconciler) reconcile(clusterClient client.Client,
 		clusterBootstrap.Spec.CSI,
 	}, clusterBootstrap.Spec.AdditionalPackages...)
 
+	// refNames are the packages which are considered for the aggregated Ready condition
+	var refNames []string
+
 	for _, pkg := range packages {
 		if pkg == nil {
 			continue
 		}
+		refNames = append(refNames, pkg.RefName)
 		if err := r.reconcileClusterBootstrapStatus(clusterClient, clusterBootstrap, clusterObjKey, pkg.RefName, r.Config.SystemNamespace, log); err != nil {
 			errorList = append(errorList, err)
 			// in case of error, just log the error and continue with collecting PackageInstallStatus for other packages
 			// if a condition corresponding to the package is existing in the ClusterBootstrapStatus, we delete it as the corresponding pkgi or package resources do not exist for the package anymore
 			log.Error(err, fmt.Sprintf("failed to reconcile PackageInstallStatus for package '%s/%s'", r.Config.SystemNamespace, pkg.RefName))
 			r.removeConditionIfExistsForPkgName(clusterBootstrap, pkg.RefName)
+			util.RemovePackageStatus(clusterBootstrap, pkg.RefName)
 		}
 	}
 
 	// kapp ctrl pkgi exists only for the workload cluster.
 	// it is installed under cluster.Namespace in the management cluster and should be handled separately
 	if clusterRole == clusterRoleWorkload && clusterBootstrap.Spec.Kapp != nil {
+		refNames = append(refNames, clusterBootstrap.Spec.Kapp.RefName)
 		if err := r.reconcileClusterBootstrapStatus(r.Client, clusterBootstrap, clusterObjKey, clusterBootstrap.Spec.Kapp.RefName, cluster.Namespace, log); err != nil {
 			errorList = append(errorList, err)
 			// in case of error, just log the error and proceed with patching the ClusterBootstrapStatus for all packages in a single patch operation
 			// if a condition corresponding to the package is existing in the ClusterBootstrapStatus, we delete it as the corresponding pkgi or package resources do not exist for the package anymore
 			log.Error(err, fmt.Sprintf("failed to reconcile PackageInstallStatus for package '%s/%s'", cluster.Namespace, clusterBootstrap.Spec.Kapp.RefName))
 			r.removeConditionIfExistsForPkgName(clusterBootstrap, clusterBootstrap.Spec.Kapp.RefName)
+			util.RemovePackageStatus(clusterBootstrap, clusterBootstrap.Spec.Kapp.RefName)
 		}
 	}
 
+	util.PrunePackageStatuses(clusterBootstrap, refNames)
+	util.SetClusterBootstrapReadyCondition(clusterBootstrap, refNames)
+
 	return retErr
 }
 
@@ -283,6 +295,18 @@ func (r *PackageInstallStatusReconciler) reconcileClusterBootstrapStatus(
 	// for each package, create a single summary condition from the condition slice
 	pkgiCondition := util.SummarizeAppConditions(pkgi.Status.Conditions)
 
+	// record the per-package status regardless of the summarized condition, so the resolved version and values are visible early
+	pkgStatus := &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+		RefName:          pkgName,
+		Version:          pkgVersion,
+		Message:          util.GetKappUsefulErrorMessage(pkgi.Status.UsefulErrorMessage),
+		ValuesSecretHash: r.getValuesSecretHash(clusterClient, pkgi, log),
+	}
+	if pkgiCondition != nil {
+		pkgStatus.Phase = string(pkgiCondition.Type)
+	}
+	util.SetPackageStatus(clusterBootstrap, pkgStatus, metav1.Now())
+
 	// in case of encountering an empty(nil) PackageInstall condition, just return err=nil and proceed with handling the next package
 	if pkgiCondition == nil {
 		log.Info(fmt.Sprintf("empty condition for '%s/%s'", pkgiNamespace, pkgiName))
@@ -321,6 +345,23 @@ func (r *PackageInstallStatusReconciler) reconcileClusterBootstrapStatus(
 	return nil
 }
 
+// getValuesSecretHash returns the hash of the data values secret referenced by the PackageInstall.
+// An empty string is returned when the PackageInstall has no values or the secret cannot be read.
+func (r *PackageInstallStatusReconciler) getValuesSecretHash(clusterClient client.Client, pkgi *kapppkgiv1alpha1.PackageInstall, log logr.Logger) string {
+	for _, values := range pkgi.Spec.Values {
+		if values.SecretRef == nil || values.SecretRef.Name == "" {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := clusterClient.Get(r.ctx, client.ObjectKey{Namespace: pkgi.Namespace, Name: values.SecretRef.Name}, secret); err != nil {
+			log.Info(fmt.Sprintf("unable to get data values secret '%s/%s'", pkgi.Namespace, values.SecretRef.Name), "error", err.Error())
+			return ""
+		}
+		return util.GetSecretDataHash(secret)
+	}
+	return ""
+}
+
 // removeConditionIfExistsForPkgName removes the corresponding condition for the provided pkgRefName from the clusterBootstrapStatus if existing
 func (r *PackageInstallStatusReconciler) removeConditionIfExistsForPkgName(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, pkgRefName string) {
 	for i, existingCond := range clusterBootstrap.Status.Conditions {
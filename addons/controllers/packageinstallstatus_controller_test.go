@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -110,20 +111,20 @@ var _ = Describe("PackageInstallStatus Reconciler", func() {
 			updatePkgInstallStatus(mngAntreaObjKey, "")
 			mngClusterBootstrap := clusterBootstrapGet(client.ObjectKeyFromObject(mngCluster))
 			// Antrea is already installed into management cluster's tkg-system namespace
-			Expect(len(mngClusterBootstrap.Status.Conditions)).Should(Equal(1))
+			Expect(len(packageConditions(&mngClusterBootstrap.Status))).Should(Equal(1))
 			antreaCondType := "Antrea-" + clusterapiv1beta1.ConditionType(v1alpha1.ReconcileSucceeded)
-			Expect(mngClusterBootstrap.Status.Conditions[0].Type).Should(Equal(antreaCondType))
+			Expect(packageConditions(&mngClusterBootstrap.Status)[0].Type).Should(Equal(antreaCondType))
 			// verify for workload cluster
 			updatePkgInstallStatus(wlcAntreaObjKey, "")
 			updatePkgInstallStatus(wlcKappObjKey, "")
 			wlcClusterBootstrap := clusterBootstrapGet(client.ObjectKeyFromObject(wlcCluster))
-			Expect(len(wlcClusterBootstrap.Status.Conditions)).Should(Equal(0))
+			Expect(len(packageConditions(&wlcClusterBootstrap.Status))).Should(Equal(0))
 
 			By("verifying ClusterBootstrap 'Status.Conditions' gets updated for managed packages")
 			// verify for management cluster
 			mngClusterBootstrapStatus := waitForClusterBootstrapStatus(client.ObjectKeyFromObject(mngCluster), antreaCondType)
-			Expect(len(mngClusterBootstrapStatus.Conditions)).Should(Equal(1))
-			Expect(mngClusterBootstrapStatus.Conditions[0].Type).Should(Equal(antreaCondType))
+			Expect(len(packageConditions(mngClusterBootstrapStatus))).Should(Equal(1))
+			Expect(packageConditions(mngClusterBootstrapStatus)[0].Type).Should(Equal(antreaCondType))
 			// verify for workload cluster
 			updatePkgInstallStatus(wlcAntreaObjKey, kappctrlv1alpha1.ReconcileSucceeded)
 			updatePkgInstallStatus(wlcKappObjKey, kappctrlv1alpha1.Reconciling)
@@ -131,13 +132,40 @@ var _ = Describe("PackageInstallStatus Reconciler", func() {
 			kappCondType := "Kapp-Controller-" + clusterapiv1beta1.ConditionType(v1alpha1.Reconciling)
 			waitForClusterBootstrapStatus(client.ObjectKeyFromObject(wlcCluster), antreaCondType)
 			wlcClusterBootstrapStatus := waitForClusterBootstrapStatus(client.ObjectKeyFromObject(wlcCluster), kappCondType)
-			Expect(len(wlcClusterBootstrapStatus.Conditions)).Should(Equal(2))
-			Expect(wlcClusterBootstrapStatus.Conditions[0].Type).Should(Equal(antreaCondType))
-			Expect(wlcClusterBootstrapStatus.Conditions[1].Type).Should(Equal(kappCondType))
+			Expect(len(packageConditions(wlcClusterBootstrapStatus))).Should(Equal(2))
+			Expect(packageConditions(wlcClusterBootstrapStatus)[0].Type).Should(Equal(antreaCondType))
+			Expect(packageConditions(wlcClusterBootstrapStatus)[1].Type).Should(Equal(kappCondType))
+
+			By("verifying ClusterBootstrap 'Status.Packages' and the aggregated Ready condition")
+			wlcClusterBootstrap = clusterBootstrapGet(client.ObjectKeyFromObject(wlcCluster))
+			ready := conditions.Get(wlcClusterBootstrap, clusterapiv1beta1.ReadyCondition)
+			Expect(ready).ShouldNot(BeNil())
+			Expect(ready.Status).Should(Equal(corev1.ConditionFalse))
+			Expect(ready.Message).Should(ContainSubstring(wlcClusterBootstrap.Spec.Kapp.RefName))
+			var kappStatus *runtanzuv1alpha3.ClusterBootstrapPackageStatus
+			for i := range wlcClusterBootstrap.Status.Packages {
+				if wlcClusterBootstrap.Status.Packages[i].RefName == wlcClusterBootstrap.Spec.Kapp.RefName {
+					kappStatus = &wlcClusterBootstrap.Status.Packages[i]
+				}
+			}
+			Expect(kappStatus).ShouldNot(BeNil())
+			Expect(kappStatus.Phase).Should(Equal(string(kappctrlv1alpha1.Reconciling)))
+			Expect(kappStatus.Version).ShouldNot(BeEmpty())
 		})
 	})
 })
 
+// packageConditions returns the ClusterBootstrap conditions that correspond to packages, excluding the aggregated Ready condition
+func packageConditions(status *runtanzuv1alpha3.ClusterBootstrapStatus) clusterapiv1beta1.Conditions {
+	var conds clusterapiv1beta1.Conditions
+	for _, cond := range status.Conditions {
+		if cond.Type != clusterapiv1beta1.ReadyCondition {
+			conds = append(conds, cond)
+		}
+	}
+	return conds
+}
+
 // updatePkgInstallStatus simulates kapp controller PackageInstall status update
 func updatePkgInstallStatus(objKey client.ObjectKey, appCondType kappctrlv1alpha1.AppConditionType) {
 	pkgInstall := &kapppkgiv1alpha1.PackageInstall{}
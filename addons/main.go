@@ -110,6 +110,7 @@ type addonFlags struct {
 	enablePprof                     bool
 	pprofBindAddress                string
 	tlsMinVersion                   string
+	certificateExpiryWindow         time.Duration
 }
 
 func parseAddonFlags(addonFlags *addonFlags) {
@@ -153,6 +154,8 @@ func parseAddonFlags(addonFlags *addonFlags) {
 	flag.BoolVar(&addonFlags.enablePprof, "enable-pprof", false, "Enable pprof web server")
 	flag.StringVar(&addonFlags.pprofBindAddress, "pprof-bind-addr", ":18318", "Bind address of pprof web server if enabled")
 	flag.StringVar(&addonFlags.tlsMinVersion, "tls-min-version", "1.2", "minimum TLS version in use by the webhook server. Recommended values are \"1.2\" and \"1.3\".")
+	flag.DurationVar(&addonFlags.certificateExpiryWindow, "certificate-expiry-window", constants.DefaultCertificateExpiryWindow,
+		"Time before the expiry of the control plane certificates of a cluster when its ControlPlaneCertificatesValid condition turns false (e.g. 720h). 0 disables the certificate expiry controller")
 
 	flag.Parse()
 }
@@ -248,6 +251,9 @@ func main() {
 	}
 
 	enableClusterMetadata(ctx, mgr)
+	if flags.certificateExpiryWindow > 0 {
+		enableCertificateExpiry(ctx, mgr, flags)
+	}
 	setupChecks(mgr)
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -269,6 +275,22 @@ func enableClusterMetadata(ctx context.Context, mgr ctrl.Manager) {
 	}
 }
 
+func enableCertificateExpiry(ctx context.Context, mgr ctrl.Manager, flags *addonFlags) {
+	certificateExpiryReconciler := controllers.NewCertificateExpiryReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("CertificateExpiryController"),
+		mgr.GetScheme(),
+		&addonconfig.CertificateExpiryControllerConfig{
+			ExpiryWindow: flags.certificateExpiryWindow,
+		},
+	)
+
+	if err := certificateExpiryReconciler.SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "certificateexpiry")
+		os.Exit(1)
+	}
+}
+
 func setupChecks(mgr ctrl.Manager) {
 	if err := mgr.AddReadyzCheck("ping", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to create ready check")
@@ -416,6 +438,19 @@ func enableClusterBootstrapAndConfigControllers(ctx context.Context, mgr ctrl.Ma
 		setupLog.Error(err, "unable to create controller", "controller", "clusterbootstrap")
 		os.Exit(1)
 	}
+
+	rolloutReconciler := controllers.NewClusterBootstrapRolloutReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("ClusterBootstrapRolloutController"),
+		mgr.GetScheme(),
+		&addonconfig.ClusterBootstrapRolloutControllerConfig{
+			SystemNamespace: flags.addonNamespace,
+		},
+	)
+	if err := rolloutReconciler.SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: 1}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "clusterbootstraprollout")
+		os.Exit(1)
+	}
 }
 
 func enableWebhooks(ctx context.Context, mgr ctrl.Manager, flags *addonFlags) {
@@ -44,6 +44,18 @@ type PackageInstallStatusControllerConfig struct {
 	SystemNamespace string
 }
 
+// ClusterBootstrapRolloutControllerConfig contains configuration information related to ClusterBootstrapRollout
+type ClusterBootstrapRolloutControllerConfig struct {
+	// The namespace where the ClusterBootstrapRollout objects are created, i.e., tkg-system
+	SystemNamespace string
+}
+
+// CertificateExpiryControllerConfig contains configuration information related to the certificate expiry of Clusters
+type CertificateExpiryControllerConfig struct {
+	// ExpiryWindow is the window before their expiry in which the certificates are reported as expiring
+	ExpiryWindow time.Duration
+}
+
 // ConfigControllerConfig contains common configuration information of config controller
 type ConfigControllerConfig struct {
 	// The namespace where the template config objects will be created, i.e., tkg-system
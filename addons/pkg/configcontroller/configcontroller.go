@@ -0,0 +1,330 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configcontroller implements a generic reconciler for addon config CRs. An addon config CR is mapped to the data
+// values secret of its addon package according to a Definition.
+package configcontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterapiutil "sigs.k8s.io/cluster-api/util"
+	clusterapipatchutil "sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
+	"github.com/vmware-tanzu/tanzu-framework/addons/predicates"
+)
+
+const (
+	// DataValuesSecretReadyCondition reports whether the data values secret has been generated from the addon config
+	DataValuesSecretReadyCondition = "DataValuesSecretReady"
+
+	// DataValuesSecretGeneratedReason is the reason of a true DataValuesSecretReady condition
+	DataValuesSecretGeneratedReason = "DataValuesSecretGenerated"
+	// DataValuesMappingFailedReason is used when the addon config can't be mapped to data values
+	DataValuesMappingFailedReason = "DataValuesMappingFailed"
+	// DataValuesSecretFailedReason is used when the data values secret can't be created or patched
+	DataValuesSecretFailedReason = "DataValuesSecretFailed"
+	// OwnerClusterNotFoundReason is used when the cluster the addon config belongs to can't be found
+	OwnerClusterNotFoundReason = "OwnerClusterNotFound"
+	// DependentsFailedReason is used when the objects the addon needs besides the data values secret can't be reconciled
+	DependentsFailedReason = "DependentsFailed"
+
+	// DataValuesHashAnnotation is the annotation recording the hash of the data values secret content
+	DataValuesHashAnnotation = "tkg.tanzu.vmware.com/data-values-hash"
+)
+
+// Object is an addon config CR reconciled by the generic Reconciler
+type Object interface {
+	client.Object
+	GetConditions() []metav1.Condition
+	SetConditions(conditions []metav1.Condition)
+	SetSecretRef(name string)
+}
+
+// Serializer is implemented by data values that are not serialized as plain YAML
+type Serializer interface {
+	Serialize() ([]byte, error)
+}
+
+// Definition describes how an addon config CR is mapped to the data values secret of its addon package
+type Definition struct {
+	// Kind is the kind of the addon config CR, e.g. KubevipCPIConfig
+	Kind string
+	// AddonName is the name of the addon; the data values secret is named after the cluster and the addon
+	AddonName string
+	// NewObject returns an empty addon config CR
+	NewObject func() Object
+	// NewList returns an empty list of addon config CRs. When set, Cluster events are mapped to the addon config CRs
+	// the Cluster owns.
+	NewList func() client.ObjectList
+	// DataValues converts the addon config CR to the data values of the addon package. Defaults derived from the cluster
+	// are applied here. The data values are serialized as YAML unless they implement Serializer.
+	DataValues func(ctx context.Context, c client.Client, config Object, cluster *clusterapiv1beta1.Cluster) (interface{}, error)
+	// ClusterNameFallback assumes the owner cluster is named after the addon config CR when the addon config CR does not
+	// have a Cluster owner reference yet. Otherwise the addon config CR is not reconciled until it is owned by a Cluster.
+	ClusterNameFallback bool
+	// Skip tells whether the addon config CR is not complete enough to be reconciled. Skipped addon config CRs are left
+	// unchanged until they are updated.
+	Skip func(config Object) bool
+	// ReconcileDependents creates or patches the objects the addon needs besides its data values secret. It is called
+	// once the data values secret has been generated.
+	ReconcileDependents func(ctx context.Context, c client.Client, config Object, cluster *clusterapiv1beta1.Cluster) error
+}
+
+// Reconciler reconciles an addon config CR according to its Definition: it makes the owner cluster the owner of the
+// addon config CR, generates the data values secret and reports the outcome through the status, conditions and events.
+type Reconciler struct {
+	Client     client.Client
+	Log        logr.Logger
+	Scheme     *runtime.Scheme
+	Recorder   record.EventRecorder
+	Config     addonconfig.ConfigControllerConfig
+	Definition *Definition
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(strings.ToLower(r.Definition.Kind) + "-controller")
+	}
+	blder := ctrl.NewControllerManagedBy(mgr).
+		For(r.Definition.NewObject()).
+		WithOptions(options).
+		WithEventFilter(predicates.ConfigOfKindWithoutAnnotation(constants.TKGAnnotationTemplateConfig, r.Definition.Kind, r.Config.SystemNamespace, r.Log))
+	if r.Definition.NewList != nil {
+		blder = blder.Watches(
+			&source.Kind{Type: &clusterapiv1beta1.Cluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.ClusterToConfigs),
+		)
+	}
+	return blder.Complete(r)
+}
+
+// Reconcile generates the data values secret of the addon config CR
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, retErr error) {
+	log := r.Log.WithValues(r.Definition.Kind, req.NamespacedName)
+
+	config := r.Definition.NewObject()
+	if err := r.Client.Get(ctx, req.NamespacedName, config); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("%s resource not found", r.Definition.Kind))
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, fmt.Sprintf("Unable to fetch %s resource", r.Definition.Kind))
+		return ctrl.Result{}, err
+	}
+	if !config.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if _, ok := config.GetAnnotations()[constants.TKGAnnotationTemplateConfig]; ok {
+		log.Info(fmt.Sprintf("%s is a config template, skipping reconciliation", r.Definition.Kind))
+		return ctrl.Result{}, nil
+	}
+	if r.Definition.Skip != nil && r.Definition.Skip(config) {
+		log.Info(fmt.Sprintf("%s is not complete, skipping reconciliation", r.Definition.Kind))
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := clusterapipatchutil.NewHelper(config, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, config); err != nil {
+			log.Error(err, fmt.Sprintf("Error patching %s", r.Definition.Kind))
+			if retErr == nil {
+				retErr = err
+			}
+		}
+	}()
+
+	cluster, err := r.getOwnerCluster(ctx, config)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		log.Info("Owner cluster not found, skipping reconciliation")
+		r.setCondition(config, metav1.ConditionFalse, OwnerClusterNotFoundReason, "owner cluster not found")
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.reconcileNormal(ctx, config, cluster); err != nil {
+		log.Error(err, fmt.Sprintf("Error reconciling %s to create/patch data values secret", r.Definition.Kind))
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileNormal ensures the cluster owns the addon config CR and creates or patches the data values secret
+func (r *Reconciler) reconcileNormal(ctx context.Context, config Object, cluster *clusterapiv1beta1.Cluster) error {
+	ownerReference := metav1.OwnerReference{
+		APIVersion: clusterapiv1beta1.GroupVersion.String(),
+		Kind:       constants.ClusterKind,
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}
+	if !clusterapiutil.HasOwnerRef(config.GetOwnerReferences(), ownerReference) {
+		config.SetOwnerReferences(clusterapiutil.EnsureOwnerRef(config.GetOwnerReferences(), ownerReference))
+	}
+
+	dataValues, err := r.Definition.DataValues(ctx, r.Client, config, cluster)
+	if err != nil {
+		r.setCondition(config, metav1.ConditionFalse, DataValuesMappingFailedReason, err.Error())
+		r.Recorder.Event(config, corev1.EventTypeWarning, DataValuesMappingFailedReason, err.Error())
+		return errors.Wrapf(err, "unable to map %s to data values", r.Definition.Kind)
+	}
+	yamlBytes, err := serialize(dataValues)
+	if err != nil {
+		r.setCondition(config, metav1.ConditionFalse, DataValuesMappingFailedReason, err.Error())
+		return errors.Wrapf(err, "unable to serialize the data values of %s", r.Definition.Kind)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      util.GenerateDataValueSecretName(cluster.Name, r.Definition.AddonName),
+			Namespace: config.GetNamespace(),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	result, err := controllerutil.CreateOrPatch(ctx, r.Client, secret, func() error {
+		secret.SetOwnerReferences(clusterapiutil.EnsureOwnerRef(secret.GetOwnerReferences(), ownerReference))
+		secret.Data = map[string][]byte{constants.TKGDataValueFileName: yamlBytes}
+		annotations := secret.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[DataValuesHashAnnotation] = util.GetSecretDataHash(secret)
+		secret.SetAnnotations(annotations)
+		return nil
+	})
+	if err != nil {
+		r.setCondition(config, metav1.ConditionFalse, DataValuesSecretFailedReason, err.Error())
+		r.Recorder.Event(config, corev1.EventTypeWarning, DataValuesSecretFailedReason, err.Error())
+		return errors.Wrapf(err, "unable to create or patch the data values secret of %s", r.Definition.Kind)
+	}
+
+	if result != controllerutil.OperationResultNone {
+		r.Log.Info(fmt.Sprintf("Resource '%s' data values secret '%s'", r.Definition.AddonName, result))
+		r.Recorder.Eventf(config, corev1.EventTypeNormal, DataValuesSecretGeneratedReason, "Data values secret %s %s", secret.Name, result)
+	}
+	config.SetSecretRef(secret.Name)
+
+	if r.Definition.ReconcileDependents != nil {
+		if err := r.Definition.ReconcileDependents(ctx, r.Client, config, cluster); err != nil {
+			r.setCondition(config, metav1.ConditionFalse, DependentsFailedReason, err.Error())
+			r.Recorder.Event(config, corev1.EventTypeWarning, DependentsFailedReason, err.Error())
+			return errors.Wrapf(err, "unable to reconcile the dependent objects of %s", r.Definition.Kind)
+		}
+	}
+	r.setCondition(config, metav1.ConditionTrue, DataValuesSecretGeneratedReason, "")
+	return nil
+}
+
+// getOwnerCluster returns the cluster owning the addon config CR, nil if it can't be determined or does not exist
+func (r *Reconciler) getOwnerCluster(ctx context.Context, config Object) (*clusterapiv1beta1.Cluster, error) {
+	clusterName := ""
+	if r.Definition.ClusterNameFallback {
+		clusterName = config.GetName()
+	}
+	for _, ownerRef := range config.GetOwnerReferences() {
+		if strings.EqualFold(ownerRef.Kind, constants.ClusterKind) {
+			clusterName = ownerRef.Name
+			break
+		}
+	}
+	if clusterName == "" {
+		return nil, nil
+	}
+
+	cluster := &clusterapiv1beta1.Cluster{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: config.GetNamespace(), Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "unable to fetch cluster '%s/%s'", config.GetNamespace(), clusterName)
+	}
+	return cluster, nil
+}
+
+func (r *Reconciler) setCondition(config Object, status metav1.ConditionStatus, reason, message string) {
+	conditions := config.GetConditions()
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               DataValuesSecretReadyCondition,
+		Status:             status,
+		ObservedGeneration: config.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	config.SetConditions(conditions)
+}
+
+// ClusterToConfigs returns a list of Requests with the addon config CRs owned by the Cluster
+func (r *Reconciler) ClusterToConfigs(o client.Object) []ctrl.Request {
+	cluster, ok := o.(*clusterapiv1beta1.Cluster)
+	if !ok {
+		r.Log.Error(errors.New("invalid type"),
+			"Expected to receive Cluster resource",
+			"actualType", fmt.Sprintf("%T", o))
+		return nil
+	}
+
+	r.Log.V(4).Info(fmt.Sprintf("Mapping Cluster to %s", r.Definition.Kind))
+
+	configs := r.Definition.NewList()
+	if err := r.Client.List(context.Background(), configs, client.InNamespace(cluster.Namespace)); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error listing %s", r.Definition.Kind))
+		return nil
+	}
+	items, err := meta.ExtractList(configs)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error extracting %s list", r.Definition.Kind))
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, item := range items {
+		config, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		// avoid enqueuing reconcile requests for template configs in event handler of Cluster CR
+		if _, ok := config.GetAnnotations()[constants.TKGAnnotationTemplateConfig]; ok && config.GetNamespace() == r.Config.SystemNamespace {
+			continue
+		}
+		for _, ownerRef := range config.GetOwnerReferences() {
+			if ownerRef.Kind == constants.ClusterKind && ownerRef.Name == cluster.Name {
+				requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+func serialize(dataValues interface{}) ([]byte, error) {
+	if serializer, ok := dataValues.(Serializer); ok {
+		return serializer.Serialize()
+	}
+	return yaml.Marshal(dataValues)
+}
@@ -0,0 +1,378 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package configcontroller_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	antrea "github.com/vmware-tanzu/tanzu-framework/addons/controllers/antrea"
+	awsebscsi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/awsebscsi"
+	azurediskcsi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/azurediskcsi"
+	azurefilecsi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/azurefilecsi"
+	calico "github.com/vmware-tanzu/tanzu-framework/addons/controllers/calico"
+	cpi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/cpi"
+	kubevipcpi "github.com/vmware-tanzu/tanzu-framework/addons/controllers/kubevipcpi"
+	addonconfig "github.com/vmware-tanzu/tanzu-framework/addons/pkg/config"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/configcontroller"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/util"
+	cniv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cni/v1alpha1"
+	cpiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/cpi/v1alpha1"
+	csiv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/addonconfigs/csi/v1alpha1"
+)
+
+const (
+	testClusterName = "test-cluster"
+	testNamespace   = "default"
+)
+
+// definitionCase is an addon config mapped by a Definition, together with what its data values are expected to contain
+type definitionCase struct {
+	definition *configcontroller.Definition
+	// newConfig returns an addon config CR named after the test cluster, without owner references
+	newConfig func() configcontroller.Object
+	// secretRef returns the data values secret referenced by the status of the addon config CR
+	secretRef func(config configcontroller.Object) *string
+	// dataValues is a fragment expected in the data values secret
+	dataValues string
+	// objects are the objects the data values are derived from, besides the cluster
+	objects []client.Object
+}
+
+var _ = Describe("Generic addon config Reconciler", func() {
+	var (
+		ctx      context.Context
+		scheme   *runtime.Scheme
+		cluster  *clusterapiv1beta1.Cluster
+		recorder *record.FakeRecorder
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(clusterapiv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(cpiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(csiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(cniv1alpha1.AddToScheme(scheme)).To(Succeed())
+		cluster = &clusterapiv1beta1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace, UID: "test-cluster-uid"},
+			Spec: clusterapiv1beta1.ClusterSpec{
+				ClusterNetwork: &clusterapiv1beta1.ClusterNetwork{
+					Pods:     &clusterapiv1beta1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+					Services: &clusterapiv1beta1.NetworkRanges{CIDRBlocks: []string{"10.96.0.0/12"}},
+				},
+				InfrastructureRef: &corev1.ObjectReference{Kind: constants.InfrastructureRefDocker},
+			},
+		}
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	newReconciler := func(definition *configcontroller.Definition, objs ...client.Object) *configcontroller.Reconciler {
+		return &configcontroller.Reconciler{
+			Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Log:        zap.New(zap.UseDevMode(true)),
+			Scheme:     scheme,
+			Recorder:   recorder,
+			Config:     addonconfig.ConfigControllerConfig{SystemNamespace: constants.TKGSystemNS},
+			Definition: definition,
+		}
+	}
+
+	ownedBy := func(config configcontroller.Object, cluster *clusterapiv1beta1.Cluster) configcontroller.Object {
+		config.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: clusterapiv1beta1.GroupVersion.String(),
+			Kind:       constants.ClusterKind,
+			Name:       cluster.Name,
+			UID:        cluster.UID,
+		}})
+		return config
+	}
+
+	reconcile := func(r *configcontroller.Reconciler, config configcontroller.Object) (configcontroller.Object, error) {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+		reconciled := r.Definition.NewObject()
+		Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(config), reconciled)).To(Succeed())
+		return reconciled, err
+	}
+
+	table.DescribeTable("should generate the data values secret of the addon config",
+		func(c definitionCase) {
+			r := newReconciler(c.definition, append(c.objects, cluster, ownedBy(c.newConfig(), cluster))...)
+			config, err := reconcile(r, c.newConfig())
+			Expect(err).ToNot(HaveOccurred())
+
+			secretName := util.GenerateDataValueSecretName(testClusterName, c.definition.AddonName)
+			Expect(c.secretRef(config)).To(HaveValue(Equal(secretName)))
+			condition := meta.FindStatusCondition(config.GetConditions(), configcontroller.DataValuesSecretReadyCondition)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(configcontroller.DataValuesSecretGeneratedReason))
+
+			secret := &corev1.Secret{}
+			Expect(r.Client.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: secretName}, secret)).To(Succeed())
+			Expect(secret.Type).To(Equal(corev1.SecretTypeOpaque))
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+			Expect(secret.OwnerReferences[0].Name).To(Equal(testClusterName))
+			Expect(string(secret.Data[constants.TKGDataValueFileName])).To(ContainSubstring(c.dataValues))
+			hash := secret.Annotations[configcontroller.DataValuesHashAnnotation]
+			Expect(hash).ToNot(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring(configcontroller.DataValuesSecretGeneratedReason)))
+
+			By("not touching the secret when the data values are unchanged")
+			_, err = reconcile(r, c.newConfig())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.Client.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: secretName}, secret)).To(Succeed())
+			Expect(secret.Annotations[configcontroller.DataValuesHashAnnotation]).To(Equal(hash))
+			Expect(recorder.Events).ToNot(Receive())
+		},
+		table.Entry("KubevipCPIConfig", kubevipCase()),
+		table.Entry("AwsEbsCSIConfig", awsEbsCase()),
+		table.Entry("AzureDiskCSIConfig", azureDiskCase()),
+		table.Entry("AzureFileCSIConfig", azureFileCase()),
+		table.Entry("AntreaConfig", antreaCase()),
+		table.Entry("CalicoConfig", calicoCase()),
+		table.Entry("OracleCPIConfig", oracleCase()),
+	)
+
+	table.DescribeTable("should report a missing owner cluster",
+		func(c definitionCase) {
+			r := newReconciler(c.definition, ownedBy(c.newConfig(), cluster))
+			config, err := reconcile(r, c.newConfig())
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(c.secretRef(config)).To(BeNil())
+			condition := meta.FindStatusCondition(config.GetConditions(), configcontroller.DataValuesSecretReadyCondition)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(configcontroller.OwnerClusterNotFoundReason))
+		},
+		table.Entry("KubevipCPIConfig", kubevipCase()),
+		table.Entry("AwsEbsCSIConfig", awsEbsCase()),
+	)
+
+	It("should report data values that can't be derived from the cluster", func() {
+		c := calicoCase()
+		cluster.Spec.ClusterNetwork = nil
+		r := newReconciler(c.definition, cluster, ownedBy(c.newConfig(), cluster))
+		config, err := reconcile(r, c.newConfig())
+		Expect(err).To(HaveOccurred())
+
+		Expect(c.secretRef(config)).To(BeNil())
+		condition := meta.FindStatusCondition(config.GetConditions(), configcontroller.DataValuesSecretReadyCondition)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(configcontroller.DataValuesMappingFailedReason))
+		Expect(recorder.Events).To(Receive(ContainSubstring("cluster.Spec.ClusterNetwork is not set")))
+	})
+
+	table.DescribeTable("should skip config templates",
+		func(c definitionCase) {
+			config := c.newConfig()
+			config.SetNamespace(constants.TKGSystemNS)
+			config.SetAnnotations(map[string]string{constants.TKGAnnotationTemplateConfig: "true"})
+			r := newReconciler(c.definition, cluster, config.DeepCopyObject().(configcontroller.Object))
+			reconciled, err := reconcile(r, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconciled.GetConditions()).To(BeEmpty())
+			Expect(reconciled.GetOwnerReferences()).To(BeEmpty())
+			Expect(c.secretRef(reconciled)).To(BeNil())
+		},
+		table.Entry("AntreaConfig", antreaCase()),
+		table.Entry("CalicoConfig", calicoCase()),
+		table.Entry("OracleCPIConfig", oracleCase()),
+	)
+
+	It("should skip addon configs that are not complete", func() {
+		definition := (&cpi.VSphereCPIConfigReconciler{}).Definition()
+		config := &cpiv1alpha1.VSphereCPIConfig{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace}}
+		r := newReconciler(definition, cluster, ownedBy(config.DeepCopy(), cluster))
+		reconciled, err := reconcile(r, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reconciled.GetConditions()).To(BeEmpty())
+		Expect(reconciled.(*cpiv1alpha1.VSphereCPIConfig).Status.SecretRef).To(BeEmpty())
+	})
+
+	It("should report dependent objects that can't be reconciled", func() {
+		c := kubevipCase()
+		definition := *c.definition
+		definition.ReconcileDependents = func(context.Context, client.Client, configcontroller.Object, *clusterapiv1beta1.Cluster) error {
+			return errors.New("provider service account not ready")
+		}
+		r := newReconciler(&definition, cluster, ownedBy(c.newConfig(), cluster))
+		config, err := reconcile(r, c.newConfig())
+		Expect(err).To(HaveOccurred())
+
+		// the data values secret is generated before the dependent objects
+		Expect(c.secretRef(config)).ToNot(BeNil())
+		condition := meta.FindStatusCondition(config.GetConditions(), configcontroller.DataValuesSecretReadyCondition)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(configcontroller.DependentsFailedReason))
+		Expect(condition.Message).To(Equal("provider service account not ready"))
+	})
+
+	It("should fall back to the cluster named after the addon config when enabled", func() {
+		c := awsEbsCase()
+		r := newReconciler(c.definition, cluster, c.newConfig())
+		config, err := reconcile(r, c.newConfig())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.GetOwnerReferences()).To(HaveLen(1))
+		Expect(config.GetOwnerReferences()[0].UID).To(Equal(cluster.UID))
+
+		c = kubevipCase()
+		r = newReconciler(c.definition, cluster, c.newConfig())
+		config, err = reconcile(r, c.newConfig())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.GetOwnerReferences()).To(BeEmpty())
+		Expect(c.secretRef(config)).To(BeNil())
+	})
+
+	It("should map a Cluster to the addon configs it owns", func() {
+		c := kubevipCase()
+		other := c.newConfig()
+		other.SetName("other")
+		template := ownedBy(c.newConfig(), cluster)
+		template.SetNamespace(constants.TKGSystemNS)
+		template.SetAnnotations(map[string]string{constants.TKGAnnotationTemplateConfig: "true"})
+		r := newReconciler(c.definition, cluster, ownedBy(c.newConfig(), cluster), other, template)
+
+		Expect(r.ClusterToConfigs(cluster)).To(ConsistOf(ctrl.Request{
+			NamespacedName: client.ObjectKey{Namespace: testNamespace, Name: testClusterName},
+		}))
+	})
+})
+
+func kubevipCase() definitionCase {
+	cidrs := "10.0.0.0/24"
+	return definitionCase{
+		definition: kubevipcpi.Definition,
+		newConfig: func() configcontroller.Object {
+			return &cpiv1alpha1.KubevipCPIConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace},
+				Spec:       cpiv1alpha1.KubevipCPIConfigSpec{LoadbalancerCIDRs: &cidrs},
+			}
+		},
+		secretRef: func(config configcontroller.Object) *string {
+			return config.(*cpiv1alpha1.KubevipCPIConfig).Status.SecretRef
+		},
+		dataValues: "loadbalancerCIDRs: 10.0.0.0/24",
+	}
+}
+
+func awsEbsCase() definitionCase {
+	replicas := int32(2)
+	return definitionCase{
+		definition: awsebscsi.Definition,
+		newConfig: func() configcontroller.Object {
+			config := &csiv1alpha1.AwsEbsCSIConfig{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace}}
+			config.Spec.AwsEbsCSI.DeploymentReplicas = &replicas
+			return config
+		},
+		secretRef: func(config configcontroller.Object) *string {
+			return config.(*csiv1alpha1.AwsEbsCSIConfig).Status.SecretRef
+		},
+		dataValues: "deployment_replicas: 2",
+	}
+}
+
+func azureDiskCase() definitionCase {
+	return definitionCase{
+		definition: azurediskcsi.Definition,
+		newConfig: func() configcontroller.Object {
+			config := &csiv1alpha1.AzureDiskCSIConfig{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace}}
+			config.Spec.AzureDiskCSI.HTTPProxy = "http://proxy:3128"
+			return config
+		},
+		secretRef: func(config configcontroller.Object) *string {
+			return config.(*csiv1alpha1.AzureDiskCSIConfig).Status.SecretRef
+		},
+		dataValues: "http_proxy: http://proxy:3128",
+	}
+}
+
+func azureFileCase() definitionCase {
+	return definitionCase{
+		definition: azurefilecsi.Definition,
+		newConfig: func() configcontroller.Object {
+			config := &csiv1alpha1.AzureFileCSIConfig{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace}}
+			config.Spec.AzureFileCSI.Namespace = "kube-system"
+			return config
+		},
+		secretRef: func(config configcontroller.Object) *string {
+			return config.(*csiv1alpha1.AzureFileCSIConfig).Status.SecretRef
+		},
+		dataValues: "namespace: kube-system",
+	}
+}
+
+func antreaCase() definitionCase {
+	return definitionCase{
+		definition: antrea.Definition,
+		newConfig: func() configcontroller.Object {
+			config := &cniv1alpha1.AntreaConfig{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace}}
+			config.Spec.Antrea.AntreaConfigDataValue.TrafficEncapMode = "noEncap"
+			return config
+		},
+		secretRef: func(config configcontroller.Object) *string {
+			return stringOrNil(config.(*cniv1alpha1.AntreaConfig).Status.SecretRef)
+		},
+		dataValues: "serviceCIDR: 10.96.0.0/12",
+	}
+}
+
+func calicoCase() definitionCase {
+	return definitionCase{
+		definition: calico.Definition,
+		newConfig: func() configcontroller.Object {
+			config := &cniv1alpha1.CalicoConfig{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace}}
+			config.Spec.Calico.Config.VethMTU = 1420
+			return config
+		},
+		secretRef: func(config configcontroller.Object) *string {
+			return stringOrNil(config.(*cniv1alpha1.CalicoConfig).Status.SecretRef)
+		},
+		dataValues: "clusterCIDR: 192.168.0.0/16",
+	}
+}
+
+func oracleCase() definitionCase {
+	return definitionCase{
+		definition: cpi.OracleDefinition,
+		newConfig: func() configcontroller.Object {
+			return &cpiv1alpha1.OracleCPIConfig{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace}}
+		},
+		secretRef: func(config configcontroller.Object) *string {
+			return stringOrNil(config.(*cpiv1alpha1.OracleCPIConfig).Status.SecretRef)
+		},
+		dataValues: "region: us-sanjose-1",
+		objects: []client.Object{&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "capoci-auth-config", Namespace: "cluster-api-provider-oci-system"},
+			Data:       map[string][]byte{"region": []byte("us-sanjose-1")},
+		}},
+	}
+}
+
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
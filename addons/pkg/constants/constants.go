@@ -303,6 +303,9 @@ const (
 	// ClusterPauseLabel is the label on the Cluster Object to indicate the cluster is paused by TKG
 	ClusterPauseLabel = "tkg.tanzu.vmware.com/paused"
 
+	// ClusterPausedByAnnotation is the annotation on the Cluster Object to indicate the cluster is paused by a user
+	ClusterPausedByAnnotation = "tkg.tanzu.vmware.com/paused-by"
+
 	// CustomClusterBootstrap is the annotation in the cluster object to indicate that a custom ClusterBootstrap object will be provided
 	CustomClusterBootstrap = "tkg.tanzu.vmware.com/custom-clusterbootstrap"
 
@@ -356,6 +359,30 @@ const (
 	// for example
 	// "run.tanzu.vmware.com/skip-packageinstall-deletion": "vsphere-cpi,antrea,load-balancer-and-ingress-service"
 	SkipDeletePackageInstallAnnotation = "run.tanzu.vmware.com/skip-packageinstall-deletion"
+
+	// PackagesReconcilingReason is the reason of the ClusterBootstrap Ready condition when some packages are still being reconciled
+	PackagesReconcilingReason = "PackagesReconciling"
+
+	// PackagesReconcileFailedReason is the reason of the ClusterBootstrap Ready condition when some packages failed to reconcile
+	PackagesReconcileFailedReason = "PackagesReconcileFailed"
+
+	// PackageStatusMessageMaxLength is the maximum length of the error excerpt recorded in the ClusterBootstrap package status
+	PackageStatusMessageMaxLength = 1024
+
+	// DefaultCertificateExpiryWindow is the default window before their expiry in which certificates are reported as expiring
+	DefaultCertificateExpiryWindow = 30 * 24 * time.Hour
+
+	// CertificateExpiryRequeueAfter is the maximum interval between two checks of the certificates of a cluster
+	CertificateExpiryRequeueAfter = 24 * time.Hour
+
+	// ControlPlaneCertificatesValidCondition reports whether the control plane certificates of a Cluster expire after the expiry window
+	ControlPlaneCertificatesValidCondition clusterapiv1beta1.ConditionType = "ControlPlaneCertificatesValid"
+
+	// CertificatesExpiringSoonReason is the reason of the ControlPlaneCertificatesValid condition when certificates expire within the expiry window
+	CertificatesExpiringSoonReason = "CertificatesExpiringSoon"
+
+	// CertificatesExpiredReason is the reason of the ControlPlaneCertificatesValid condition when certificates are expired
+	CertificatesExpiredReason = "CertificatesExpired"
 )
 
 var (
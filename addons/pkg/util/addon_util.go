@@ -5,8 +5,11 @@ package util
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -306,3 +309,21 @@ func GetServiceCIDRs(cluster *clusterapiv1beta1.Cluster) (string, string, error)
 
 	return serviceCIDR, serviceCIDRv6, nil
 }
+
+// GetSecretDataHash returns the SHA256 hash of the secret's data. Keys are hashed in order so the result is stable.
+func GetSecretDataHash(secret *corev1.Secret) string {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(secret.Data[key])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
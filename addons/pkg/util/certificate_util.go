@@ -0,0 +1,121 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/apis/run/util/certificates"
+)
+
+// CertificateExpiry is the expiry date of certificates of a cluster
+type CertificateExpiry struct {
+	// Name identifies the certificates, e.g. Machine/wc-control-plane-abcde or Secret/wc-ca
+	Name      string
+	ExpiresAt time.Time
+	// CertificateAuthority is true for the certificate of a certificate authority, which rotating the control plane
+	// machines does not renew
+	CertificateAuthority bool
+}
+
+// GetCertificatesExpiry returns the expiry dates of the kubeadm certificates of the control plane machines and of the
+// certificate authorities of a cluster, sorted by expiry. The expiry of the kubeadm certificates of a machine is read
+// from its certificates expiry annotation, or estimated from the creation of its bootstrap config.
+func GetCertificatesExpiry(ctx context.Context, c client.Client, cluster *clusterapiv1beta1.Cluster) ([]CertificateExpiry, error) {
+	machines := &clusterapiv1beta1.MachineList{}
+	if err := c.List(ctx, machines, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterapiv1beta1.ClusterLabelName: cluster.Name}); err != nil {
+		return nil, errors.Wrap(err, "unable to list machines")
+	}
+
+	var expiries []CertificateExpiry
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if _, isControlPlane := machine.Labels[clusterapiv1beta1.MachineControlPlaneLabelName]; !isControlPlane || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		expiresAt, err := machineCertificatesExpiry(ctx, c, machine)
+		if err != nil {
+			return nil, err
+		}
+		expiries = append(expiries, CertificateExpiry{Name: "Machine/" + machine.Name, ExpiresAt: expiresAt})
+	}
+
+	for _, ca := range certificates.CertificateAuthorities {
+		secret := &corev1.Secret{}
+		key := client.ObjectKey{Namespace: cluster.Namespace, Name: ca.SecretName(cluster.Name)}
+		if err := c.Get(ctx, key, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "unable to get secret %s", key)
+		}
+		expiresAt, err := certificates.CertificateExpiry(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid certificate in secret %s", key)
+		}
+		expiries = append(expiries, CertificateExpiry{Name: "Secret/" + secret.Name, ExpiresAt: expiresAt, CertificateAuthority: true})
+	}
+
+	sort.SliceStable(expiries, func(i, j int) bool { return expiries[i].ExpiresAt.Before(expiries[j].ExpiresAt) })
+	return expiries, nil
+}
+
+func machineCertificatesExpiry(ctx context.Context, c client.Client, machine *clusterapiv1beta1.Machine) (time.Time, error) {
+	// an invalid annotation is ignored, and the expiry estimated as if the certificates had not been renewed
+	if expiresAt, ok, err := certificates.AnnotatedMachineCertificatesExpiry(machine); err == nil && ok {
+		return expiresAt, nil
+	}
+	return certificates.EstimatedMachineCertificatesExpiry(machine, func(config *unstructured.Unstructured) error {
+		return c.Get(ctx, client.ObjectKeyFromObject(config), config)
+	})
+}
+
+// GetCertificatesExpiryCondition returns the ControlPlaneCertificatesValid condition of the earliest of the sorted
+// expiry dates, together with the time after which the condition changes, at most CertificateExpiryRequeueAfter.
+// The message of the condition tells when the earliest certificate is a certificate authority, as rotating the
+// control plane machines does not renew it.
+func GetCertificatesExpiryCondition(expiries []CertificateExpiry, window time.Duration, now time.Time) (*clusterapiv1beta1.Condition, time.Duration) {
+	earliest := expiries[0]
+	expiringAt := earliest.ExpiresAt.Add(-window)
+	subject, expire := earliest.Name+" certificates", "expire"
+	var note string
+	if earliest.CertificateAuthority {
+		subject, expire = earliest.Name+" certificate authority", "expires"
+		note = ", it is not renewed by tanzu cluster certificates rotate"
+	}
+
+	var condition *clusterapiv1beta1.Condition
+	var changeAt time.Time
+	switch {
+	case now.Before(expiringAt):
+		condition = conditions.TrueCondition(constants.ControlPlaneCertificatesValidCondition)
+		changeAt = expiringAt
+	case now.Before(earliest.ExpiresAt):
+		condition = conditions.FalseCondition(constants.ControlPlaneCertificatesValidCondition, constants.CertificatesExpiringSoonReason,
+			clusterapiv1beta1.ConditionSeverityWarning, "%s %s at %s%s", subject, expire, earliest.ExpiresAt.UTC().Format(time.RFC3339), note)
+		changeAt = earliest.ExpiresAt
+	default:
+		condition = conditions.FalseCondition(constants.ControlPlaneCertificatesValidCondition, constants.CertificatesExpiredReason,
+			clusterapiv1beta1.ConditionSeverityError, "%s expired at %s%s", subject, earliest.ExpiresAt.UTC().Format(time.RFC3339), note)
+		changeAt = now.Add(constants.CertificateExpiryRequeueAfter)
+	}
+
+	requeueAfter := changeAt.Sub(now)
+	if requeueAfter > constants.CertificateExpiryRequeueAfter {
+		requeueAfter = constants.CertificateExpiryRequeueAfter
+	}
+	return condition, requeueAfter
+}
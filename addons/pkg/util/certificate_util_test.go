@@ -0,0 +1,185 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1beta1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/apis/run/util/certificates"
+)
+
+var _ = Describe("Certificate expiry", func() {
+	var start time.Time
+
+	BeforeEach(func() {
+		start = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	})
+
+	Context("GetCertificatesExpiry()", func() {
+		var (
+			cluster *clusterapiv1beta1.Cluster
+			objects []client.Object
+		)
+
+		newMachine := func(name string, controlPlane bool, annotations map[string]string) *clusterapiv1beta1.Machine {
+			machine := &clusterapiv1beta1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(start),
+					Labels:            map[string]string{clusterapiv1beta1.ClusterLabelName: "wc"},
+					Annotations:       annotations,
+				},
+				Spec: clusterapiv1beta1.MachineSpec{
+					ClusterName: "wc",
+					Bootstrap: clusterapiv1beta1.Bootstrap{ConfigRef: &corev1.ObjectReference{
+						APIVersion: bootstrapv1beta1.GroupVersion.String(), Kind: "KubeadmConfig", Name: name + "-config"}},
+				},
+			}
+			if controlPlane {
+				machine.Labels[clusterapiv1beta1.MachineControlPlaneLabelName] = ""
+			}
+			return machine
+		}
+
+		newCertificate := func(notAfter time.Time) []byte {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "kubernetes"},
+				NotBefore:    notAfter.Add(-time.Hour),
+				NotAfter:     notAfter,
+				IsCA:         true,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+			return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		}
+
+		getCertificatesExpiry := func() ([]CertificateExpiry, error) {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(clusterapiv1beta1.AddToScheme(scheme)).To(Succeed())
+			Expect(bootstrapv1beta1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+			return GetCertificatesExpiry(context.TODO(), c, cluster)
+		}
+
+		BeforeEach(func() {
+			cluster = &clusterapiv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "wc", Namespace: "default"}}
+			objects = []client.Object{
+				newMachine("wc-control-plane-a", true, nil),
+				newMachine("wc-control-plane-b", true, map[string]string{certificates.MachineCertificatesExpiryAnnotation: "2023-01-01T00:00:00Z"}),
+				newMachine("wc-md-0-a", false, nil),
+				&bootstrapv1beta1.KubeadmConfig{ObjectMeta: metav1.ObjectMeta{
+					Name: "wc-control-plane-a-config", Namespace: "default", CreationTimestamp: metav1.NewTime(start.Add(time.Minute))}},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "wc-ca", Namespace: "default"},
+					Data:       map[string][]byte{corev1.TLSCertKey: newCertificate(start.Add(10 * certificates.KubeadmCertificatesValidity))},
+				},
+			}
+		})
+
+		It("should return the expiry of the control plane machines and certificate authorities sorted by expiry", func() {
+			expiries, err := getCertificatesExpiry()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expiries).To(HaveLen(3))
+			Expect(expiries[0]).To(Equal(CertificateExpiry{
+				Name: "Machine/wc-control-plane-b", ExpiresAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}))
+			Expect(expiries[1].Name).To(Equal("Machine/wc-control-plane-a"))
+			Expect(expiries[1].ExpiresAt).To(BeTemporally("==", start.Add(time.Minute).Add(certificates.KubeadmCertificatesValidity)))
+			Expect(expiries[2].Name).To(Equal("Secret/wc-ca"))
+			Expect(expiries[2].CertificateAuthority).To(BeTrue())
+			Expect(expiries[2].ExpiresAt).To(BeTemporally("==", start.Add(10*certificates.KubeadmCertificatesValidity)))
+		})
+
+		It("should return no expiry for clusters without kubeadm control plane", func() {
+			cluster.Name = "other"
+			expiries, err := getCertificatesExpiry()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expiries).To(BeEmpty())
+		})
+
+		It("should fail when a certificate authority secret is invalid", func() {
+			objects = append(objects, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "wc-etcd", Namespace: "default"},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("invalid")},
+			})
+			_, err := getCertificatesExpiry()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid certificate in secret default/wc-etcd"))
+		})
+	})
+
+	Context("GetCertificatesExpiryCondition()", func() {
+		var expiries []CertificateExpiry
+
+		BeforeEach(func() {
+			expiries = []CertificateExpiry{
+				{Name: "Machine/wc-control-plane-a", ExpiresAt: start.Add(40 * 24 * time.Hour)},
+				{Name: "Secret/wc-ca", ExpiresAt: start.Add(10 * certificates.KubeadmCertificatesValidity), CertificateAuthority: true},
+			}
+		})
+
+		It("should be true and requeue daily before the expiry window", func() {
+			condition, requeueAfter := GetCertificatesExpiryCondition(expiries, constants.DefaultCertificateExpiryWindow, start)
+			Expect(condition.Type).To(Equal(constants.ControlPlaneCertificatesValidCondition))
+			Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+			Expect(requeueAfter).To(Equal(constants.CertificateExpiryRequeueAfter))
+		})
+
+		It("should requeue when the expiry window starts", func() {
+			now := start.Add(9*24*time.Hour + time.Hour)
+			condition, requeueAfter := GetCertificatesExpiryCondition(expiries, constants.DefaultCertificateExpiryWindow, now)
+			Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+			Expect(requeueAfter).To(Equal(23 * time.Hour))
+		})
+
+		It("should be false with a warning within the expiry window", func() {
+			now := start.Add(20 * 24 * time.Hour)
+			condition, _ := GetCertificatesExpiryCondition(expiries, constants.DefaultCertificateExpiryWindow, now)
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(constants.CertificatesExpiringSoonReason))
+			Expect(condition.Severity).To(Equal(clusterapiv1beta1.ConditionSeverityWarning))
+			Expect(condition.Message).To(Equal("Machine/wc-control-plane-a certificates expire at 2022-07-11T10:00:00Z"))
+		})
+
+		It("should be false with an error once expired", func() {
+			now := start.Add(50 * 24 * time.Hour)
+			condition, requeueAfter := GetCertificatesExpiryCondition(expiries, constants.DefaultCertificateExpiryWindow, now)
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(constants.CertificatesExpiredReason))
+			Expect(condition.Severity).To(Equal(clusterapiv1beta1.ConditionSeverityError))
+			Expect(requeueAfter).To(Equal(constants.CertificateExpiryRequeueAfter))
+		})
+
+		It("should tell that rotating the control plane does not renew an expiring certificate authority", func() {
+			expiries = []CertificateExpiry{{Name: "Secret/wc-ca", ExpiresAt: start.Add(40 * 24 * time.Hour), CertificateAuthority: true}}
+			now := start.Add(20 * 24 * time.Hour)
+			condition, _ := GetCertificatesExpiryCondition(expiries, constants.DefaultCertificateExpiryWindow, now)
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Message).To(Equal(
+				"Secret/wc-ca certificate authority expires at 2022-07-11T10:00:00Z, it is not renewed by tanzu cluster certificates rotate"))
+		})
+	})
+})
@@ -4,12 +4,18 @@
 package util
 
 import (
+	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
 	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 )
 
 // SummarizeAppConditions summarizes the provided conditions slice into a single condition with the following logic:
@@ -58,3 +64,100 @@ func HasSameState(i, j *clusterapiv1beta1.Condition) bool {
 		i.Message == j.Message &&
 		i.Reason == j.Reason
 }
+
+// SetPackageStatus adds or updates the status of a package in the ClusterBootstrap's 'Status.Packages'.
+// The LastTransitionTime is only updated when the package's phase changes. When a package transitions from
+// 'Reconciling' to a terminal phase, the time spent reconciling is recorded as the ReconcileDuration.
+func SetPackageStatus(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, status *runtanzuv1alpha3.ClusterBootstrapPackageStatus, now metav1.Time) {
+	if len(status.Message) > constants.PackageStatusMessageMaxLength {
+		// truncate on a rune boundary so that the message remains valid UTF-8
+		end := constants.PackageStatusMessageMaxLength
+		for end > 0 && !utf8.RuneStart(status.Message[end]) {
+			end--
+		}
+		status.Message = status.Message[:end]
+	}
+
+	for i := range clusterBootstrap.Status.Packages {
+		existing := &clusterBootstrap.Status.Packages[i]
+		if existing.RefName != status.RefName {
+			continue
+		}
+		if existing.Phase == status.Phase {
+			status.LastTransitionTime = existing.LastTransitionTime
+			status.ReconcileDuration = existing.ReconcileDuration
+		} else {
+			status.LastTransitionTime = now
+			status.ReconcileDuration = existing.ReconcileDuration
+			if existing.Phase == string(v1alpha1.Reconciling) &&
+				(status.Phase == string(v1alpha1.ReconcileSucceeded) || status.Phase == string(v1alpha1.ReconcileFailed)) {
+				status.ReconcileDuration = &metav1.Duration{Duration: now.Sub(existing.LastTransitionTime.Time)}
+			}
+		}
+		*existing = *status
+		return
+	}
+
+	status.LastTransitionTime = now
+	clusterBootstrap.Status.Packages = append(clusterBootstrap.Status.Packages, *status)
+}
+
+// RemovePackageStatus removes the status of a package from the ClusterBootstrap's 'Status.Packages' if existing
+func RemovePackageStatus(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, refName string) {
+	packages := clusterBootstrap.Status.Packages[:0]
+	for _, pkgStatus := range clusterBootstrap.Status.Packages {
+		if pkgStatus.RefName != refName {
+			packages = append(packages, pkgStatus)
+		}
+	}
+	clusterBootstrap.Status.Packages = packages
+}
+
+// PrunePackageStatuses removes the statuses of the packages which are no longer referenced by the ClusterBootstrap,
+// refNames being the packages it references
+func PrunePackageStatuses(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, refNames []string) {
+	packages := clusterBootstrap.Status.Packages[:0]
+	for _, pkgStatus := range clusterBootstrap.Status.Packages {
+		for _, refName := range refNames {
+			if pkgStatus.RefName == refName {
+				packages = append(packages, pkgStatus)
+				break
+			}
+		}
+	}
+	clusterBootstrap.Status.Packages = packages
+}
+
+// SetClusterBootstrapReadyCondition sets the ClusterBootstrap's aggregated Ready condition based on the status of the provided packages.
+// The condition is true only when all packages have been reconciled successfully, otherwise its message lists the blocking packages.
+func SetClusterBootstrapReadyCondition(clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap, refNames []string) {
+	var blocking []string
+	failed := false
+	for _, refName := range refNames {
+		phase := "Unknown"
+		for _, pkgStatus := range clusterBootstrap.Status.Packages {
+			if pkgStatus.RefName == refName && pkgStatus.Phase != "" {
+				phase = pkgStatus.Phase
+				break
+			}
+		}
+		if phase == string(v1alpha1.ReconcileSucceeded) {
+			continue
+		}
+		if phase == string(v1alpha1.ReconcileFailed) {
+			failed = true
+		}
+		blocking = append(blocking, fmt.Sprintf("%s (%s)", refName, phase))
+	}
+
+	switch {
+	case len(blocking) == 0:
+		conditions.MarkTrue(clusterBootstrap, clusterapiv1beta1.ReadyCondition)
+	case failed:
+		conditions.MarkFalse(clusterBootstrap, clusterapiv1beta1.ReadyCondition, constants.PackagesReconcileFailedReason,
+			clusterapiv1beta1.ConditionSeverityError, "blocking packages: %s", strings.Join(blocking, ", "))
+	default:
+		conditions.MarkFalse(clusterBootstrap, clusterapiv1beta1.ReadyCondition, constants.PackagesReconcilingReason,
+			clusterapiv1beta1.ConditionSeverityInfo, "blocking packages: %s", strings.Join(blocking, ", "))
+	}
+}
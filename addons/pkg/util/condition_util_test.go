@@ -4,11 +4,20 @@
 package util
 
 import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
 	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
 )
 
 const (
@@ -117,3 +126,111 @@ var _ = Describe("Summarize AppConditions", func() {
 		})
 	})
 })
+
+var _ = Describe("ClusterBootstrap package status", func() {
+	const (
+		antreaRefName = "antrea.tanzu.vmware.com.1.2.3--vmware.1-tkg.1"
+		csiRefName    = "vsphere-csi.tanzu.vmware.com.2.5.0--vmware.1-tkg.1"
+	)
+
+	var (
+		clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap
+		start            metav1.Time
+	)
+
+	BeforeEach(func() {
+		clusterBootstrap = &runtanzuv1alpha3.ClusterBootstrap{}
+		start = metav1.NewTime(time.Now().Truncate(time.Second))
+	})
+
+	Context("SetPackageStatus()", func() {
+		It("should record the transition time and the reconcile duration", func() {
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: antreaRefName, Phase: string(v1alpha1.Reconciling)}, start)
+			Expect(clusterBootstrap.Status.Packages).To(HaveLen(1))
+			Expect(clusterBootstrap.Status.Packages[0].LastTransitionTime).To(Equal(start))
+
+			// same phase keeps the transition time
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: antreaRefName, Phase: string(v1alpha1.Reconciling), Version: "1.2.3"}, metav1.NewTime(start.Add(time.Minute)))
+			Expect(clusterBootstrap.Status.Packages[0].LastTransitionTime).To(Equal(start))
+			Expect(clusterBootstrap.Status.Packages[0].Version).To(Equal("1.2.3"))
+
+			end := metav1.NewTime(start.Add(2 * time.Minute))
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: antreaRefName, Phase: string(v1alpha1.ReconcileSucceeded), Version: "1.2.3"}, end)
+			Expect(clusterBootstrap.Status.Packages).To(HaveLen(1))
+			Expect(clusterBootstrap.Status.Packages[0].LastTransitionTime).To(Equal(end))
+			Expect(clusterBootstrap.Status.Packages[0].ReconcileDuration.Duration).To(Equal(2 * time.Minute))
+		})
+
+		It("should truncate long messages", func() {
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: antreaRefName, Message: strings.Repeat("x", constants.PackageStatusMessageMaxLength+1)}, start)
+			Expect(clusterBootstrap.Status.Packages[0].Message).To(HaveLen(constants.PackageStatusMessageMaxLength))
+		})
+
+		It("should truncate long messages on a rune boundary", func() {
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: antreaRefName, Message: "x" + strings.Repeat("é", constants.PackageStatusMessageMaxLength)}, start)
+			message := clusterBootstrap.Status.Packages[0].Message
+			Expect(utf8.ValidString(message)).To(BeTrue())
+			Expect(message).To(HaveLen(constants.PackageStatusMessageMaxLength - 1))
+		})
+	})
+
+	Context("PrunePackageStatuses()", func() {
+		It("should remove the packages which are no longer referenced", func() {
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{RefName: antreaRefName}, start)
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{RefName: csiRefName}, start)
+			PrunePackageStatuses(clusterBootstrap, []string{csiRefName})
+			Expect(clusterBootstrap.Status.Packages).To(HaveLen(1))
+			Expect(clusterBootstrap.Status.Packages[0].RefName).To(Equal(csiRefName))
+		})
+	})
+
+	Context("RemovePackageStatus()", func() {
+		It("should remove only the provided package", func() {
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{RefName: antreaRefName}, start)
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{RefName: csiRefName}, start)
+			RemovePackageStatus(clusterBootstrap, antreaRefName)
+			Expect(clusterBootstrap.Status.Packages).To(HaveLen(1))
+			Expect(clusterBootstrap.Status.Packages[0].RefName).To(Equal(csiRefName))
+		})
+	})
+
+	Context("SetClusterBootstrapReadyCondition()", func() {
+		It("should be true only when all packages succeeded", func() {
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: antreaRefName, Phase: string(v1alpha1.ReconcileSucceeded)}, start)
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: csiRefName, Phase: string(v1alpha1.Reconciling)}, start)
+
+			SetClusterBootstrapReadyCondition(clusterBootstrap, []string{antreaRefName, csiRefName})
+			ready := conditions.Get(clusterBootstrap, clusterapiv1beta1.ReadyCondition)
+			Expect(ready.Status).To(Equal(corev1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(constants.PackagesReconcilingReason))
+			Expect(ready.Message).To(ContainSubstring(csiRefName))
+			Expect(ready.Message).NotTo(ContainSubstring(antreaRefName))
+
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: csiRefName, Phase: string(v1alpha1.ReconcileFailed)}, start)
+			SetClusterBootstrapReadyCondition(clusterBootstrap, []string{antreaRefName, csiRefName})
+			ready = conditions.Get(clusterBootstrap, clusterapiv1beta1.ReadyCondition)
+			Expect(ready.Reason).To(Equal(constants.PackagesReconcileFailedReason))
+			Expect(ready.Severity).To(Equal(clusterapiv1beta1.ConditionSeverityError))
+
+			SetPackageStatus(clusterBootstrap, &runtanzuv1alpha3.ClusterBootstrapPackageStatus{
+				RefName: csiRefName, Phase: string(v1alpha1.ReconcileSucceeded)}, start)
+			SetClusterBootstrapReadyCondition(clusterBootstrap, []string{antreaRefName, csiRefName})
+			Expect(conditions.IsTrue(clusterBootstrap, clusterapiv1beta1.ReadyCondition)).To(BeTrue())
+		})
+
+		It("should treat packages without status as blocking", func() {
+			SetClusterBootstrapReadyCondition(clusterBootstrap, []string{antreaRefName})
+			ready := conditions.Get(clusterBootstrap, clusterapiv1beta1.ReadyCondition)
+			Expect(ready.Status).To(Equal(corev1.ConditionFalse))
+			Expect(ready.Message).To(ContainSubstring(antreaRefName + " (Unknown)"))
+		})
+	})
+})
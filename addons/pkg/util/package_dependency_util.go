@@ -0,0 +1,93 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"strings"
+
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+// PackageMatchesDependency tells whether a ClusterBootstrapPackage refName, i.e. the name of a Package CR, designates
+// the package named by a dependency. Package CRs are named after their Package.Spec.RefName followed by their version,
+// e.g. cert-manager.tanzu.vmware.com.1.7.2+vmware.1-tkg.1, so a dependency matches either the full name or that prefix.
+func PackageMatchesDependency(pkgRefName, dependency string) bool {
+	return pkgRefName == dependency || strings.HasPrefix(pkgRefName, dependency+".")
+}
+
+// FindPackageDependency returns the index of the package designated by the dependency, -1 if none of the packages match
+func FindPackageDependency(pkgs []*runtanzuv1alpha3.ClusterBootstrapPackage, dependency string) int {
+	for i, pkg := range pkgs {
+		if pkg != nil && PackageMatchesDependency(pkg.RefName, dependency) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindPackageDependencyCycle returns the refNames of the packages forming a dependency cycle, nil if there is none.
+// Dependencies that do not designate any of the packages are ignored.
+func FindPackageDependencyCycle(pkgs []*runtanzuv1alpha3.ClusterBootstrapPackage) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(pkgs))
+	var path []int
+
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		state[i] = visiting
+		path = append(path, i)
+		for _, dependency := range pkgs[i].DependsOn {
+			j := FindPackageDependency(pkgs, dependency)
+			if j == -1 {
+				continue
+			}
+			switch state[j] {
+			case visiting:
+				var cycle []string
+				for k := len(path) - 1; k >= 0; k-- {
+					cycle = append([]string{pkgs[path[k]].RefName}, cycle...)
+					if path[k] == j {
+						break
+					}
+				}
+				return append(cycle, pkgs[j].RefName)
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i, pkg := range pkgs {
+		if pkg == nil || state[i] != unvisited {
+			continue
+		}
+		if cycle := visit(i); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// GetClusterBootstrapPackages returns all the packages of a ClusterBootstrap spec, core packages first
+func GetClusterBootstrapPackages(spec *runtanzuv1alpha3.ClusterBootstrapTemplateSpec) []*runtanzuv1alpha3.ClusterBootstrapPackage {
+	if spec == nil {
+		return nil
+	}
+	var pkgs []*runtanzuv1alpha3.ClusterBootstrapPackage
+	for _, pkg := range append([]*runtanzuv1alpha3.ClusterBootstrapPackage{spec.CNI, spec.CSI, spec.CPI, spec.Kapp}, spec.AdditionalPackages...) {
+		if pkg != nil {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+var _ = Describe("ClusterBootstrapPackage dependencies", func() {
+	const (
+		antrea      = "antrea.tanzu.vmware.com.1.5.3+vmware.1-tkg.1"
+		certManager = "cert-manager.tanzu.vmware.com.1.7.2+vmware.1-tkg.1"
+		metrics     = "metrics-server.tanzu.vmware.com.0.6.1+vmware.1-tkg.1"
+	)
+
+	Context("PackageMatchesDependency()", func() {
+		It("should match the Package CR name and its refName prefix", func() {
+			Expect(PackageMatchesDependency(certManager, certManager)).To(BeTrue())
+			Expect(PackageMatchesDependency(certManager, "cert-manager.tanzu.vmware.com")).To(BeTrue())
+			Expect(PackageMatchesDependency(certManager, "cert-manager")).To(BeTrue())
+			Expect(PackageMatchesDependency(certManager, "cert")).To(BeFalse())
+			Expect(PackageMatchesDependency(antrea, "cert-manager.tanzu.vmware.com")).To(BeFalse())
+		})
+	})
+
+	Context("FindPackageDependencyCycle()", func() {
+		var spec *runtanzuv1alpha3.ClusterBootstrapTemplateSpec
+
+		BeforeEach(func() {
+			spec = &runtanzuv1alpha3.ClusterBootstrapTemplateSpec{
+				CNI: &runtanzuv1alpha3.ClusterBootstrapPackage{RefName: antrea},
+				AdditionalPackages: []*runtanzuv1alpha3.ClusterBootstrapPackage{
+					{RefName: certManager, DependsOn: []string{"antrea.tanzu.vmware.com"}},
+					{RefName: metrics, DependsOn: []string{"cert-manager.tanzu.vmware.com", "antrea.tanzu.vmware.com"}},
+				},
+			}
+		})
+
+		It("should not report acyclic dependencies", func() {
+			pkgs := GetClusterBootstrapPackages(spec)
+			Expect(pkgs).To(HaveLen(3))
+			Expect(FindPackageDependencyCycle(pkgs)).To(BeNil())
+			Expect(FindPackageDependency(pkgs, "cert-manager.tanzu.vmware.com")).To(Equal(1))
+			Expect(FindPackageDependency(pkgs, "contour.tanzu.vmware.com")).To(Equal(-1))
+		})
+
+		It("should report the packages forming a cycle", func() {
+			spec.CNI.DependsOn = []string{"metrics-server.tanzu.vmware.com"}
+			Expect(FindPackageDependencyCycle(GetClusterBootstrapPackages(spec))).To(Equal([]string{antrea, metrics, certManager, antrea}))
+		})
+
+		It("should report packages depending on themselves", func() {
+			spec.AdditionalPackages[0].DependsOn = []string{"cert-manager"}
+			Expect(FindPackageDependencyCycle(GetClusterBootstrapPackages(spec))).To(Equal([]string{certManager, certManager}))
+		})
+	})
+})
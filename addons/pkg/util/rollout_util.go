@@ -0,0 +1,230 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+// RolloutClusterState is the state of a cluster governed by a ClusterBootstrapRollout
+type RolloutClusterState string
+
+const (
+	// RolloutClusterPending means the ClusterBootstrap of the cluster has not been updated to the rolled out TKR
+	RolloutClusterPending RolloutClusterState = "Pending"
+	// RolloutClusterUpdating means the packages of the cluster are being reconciled after the update
+	RolloutClusterUpdating RolloutClusterState = "Updating"
+	// RolloutClusterReady means the packages of the cluster have been reconciled successfully after the update
+	RolloutClusterReady RolloutClusterState = "Ready"
+	// RolloutClusterFailed means some packages of the cluster failed to reconcile after the update
+	RolloutClusterFailed RolloutClusterState = "Failed"
+)
+
+// RolloutCluster describes a cluster governed by a ClusterBootstrapRollout
+type RolloutCluster struct {
+	// Key identifies the cluster in <namespace>/<name> form
+	Key string
+	// Order is the value of the ordering label of the cluster, nil if the cluster does not have the label
+	Order *string
+	State RolloutClusterState
+}
+
+// RolloutClusterKey returns the key identifying a cluster in the status of a ClusterBootstrapRollout
+func RolloutClusterKey(cluster *clusterapiv1beta1.Cluster) string {
+	return cluster.Namespace + "/" + cluster.Name
+}
+
+// GetClusterBootstrapRolloutForTKR returns the ClusterBootstrapRollout of a TKR in the namespace, nil if the TKR is not rolled out.
+// When several ClusterBootstrapRollouts exist for the same TKR, the first one by name is returned.
+func GetClusterBootstrapRolloutForTKR(ctx context.Context, c client.Client, namespace, tkrName string) (*runtanzuv1alpha3.ClusterBootstrapRollout, error) {
+	rolloutList := &runtanzuv1alpha3.ClusterBootstrapRolloutList{}
+	if err := c.List(ctx, rolloutList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	var found *runtanzuv1alpha3.ClusterBootstrapRollout
+	for i := range rolloutList.Items {
+		rollout := &rolloutList.Items[i]
+		if rollout.Spec.TKR != tkrName || !rollout.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if found == nil || rollout.Name < found.Name {
+			found = rollout
+		}
+	}
+	return found, nil
+}
+
+// GetClusterBootstrapRolloutSelector returns the selector of the clusters governed by a ClusterBootstrapRollout
+func GetClusterBootstrapRolloutSelector(rollout *runtanzuv1alpha3.ClusterBootstrapRollout) (labels.Selector, error) {
+	if rollout.Spec.ClusterSelector == nil {
+		return labels.Everything(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(rollout.Spec.ClusterSelector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cluster selector of ClusterBootstrapRollout %s/%s", rollout.Namespace, rollout.Name)
+	}
+	return selector, nil
+}
+
+// GetClusterBootstrapRolloutHoldingBack returns the ClusterBootstrapRollout of a TKR which selects the cluster and has not
+// admitted it yet, nil if the cluster may update its ClusterBootstrap to the TKR. The cluster pause webhook keeps a cluster
+// upgraded to the TKR paused until its ClusterBootstrap is updated, so a held back cluster also stays paused for Cluster API.
+func GetClusterBootstrapRolloutHoldingBack(ctx context.Context, c client.Client, namespace, tkrName string,
+	cluster *clusterapiv1beta1.Cluster) (*runtanzuv1alpha3.ClusterBootstrapRollout, error) {
+
+	rollout, err := GetClusterBootstrapRolloutForTKR(ctx, c, namespace, tkrName)
+	if err != nil || rollout == nil || rollout.IsAdmitted(cluster.Namespace, cluster.Name) {
+		return nil, err
+	}
+	selector, err := GetClusterBootstrapRolloutSelector(rollout)
+	if err != nil {
+		return nil, err
+	}
+	if !selector.Matches(labels.Set(cluster.Labels)) {
+		return nil, nil
+	}
+	return rollout, nil
+}
+
+// GetRolloutCluster returns the rollout view of a cluster given its ClusterBootstrap, which may be nil if not created yet
+func GetRolloutCluster(rollout *runtanzuv1alpha3.ClusterBootstrapRollout, cluster *clusterapiv1beta1.Cluster,
+	clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap) RolloutCluster {
+
+	rolloutCluster := RolloutCluster{Key: RolloutClusterKey(cluster), State: RolloutClusterPending}
+	if rollout.Spec.OrderByLabel != "" {
+		if value, ok := cluster.Labels[rollout.Spec.OrderByLabel]; ok {
+			rolloutCluster.Order = &value
+		}
+	}
+	if clusterBootstrap == nil || clusterBootstrap.Status.ResolvedTKR != rollout.Spec.TKR {
+		return rolloutCluster
+	}
+
+	readyCondition := conditions.Get(clusterBootstrap, clusterapiv1beta1.ReadyCondition)
+	switch {
+	case readyCondition == nil:
+		rolloutCluster.State = RolloutClusterUpdating
+	case readyCondition.Status == "True":
+		rolloutCluster.State = RolloutClusterReady
+	case readyCondition.Reason == constants.PackagesReconcileFailedReason:
+		rolloutCluster.State = RolloutClusterFailed
+	default:
+		rolloutCluster.State = RolloutClusterUpdating
+	}
+	return rolloutCluster
+}
+
+// PlanRollout computes the status of a ClusterBootstrapRollout from the state of the clusters it governs.
+// Clusters are admitted in the order given by the ordering label, BatchSize at most being updated at the same time.
+// Admitted clusters are never revoked. The rollout pauses after the canary clusters are ready and, if PauseOnFailure is set,
+// when an admitted cluster fails; a new value of the promote annotation resumes it.
+func PlanRollout(rollout *runtanzuv1alpha3.ClusterBootstrapRollout, clusters []RolloutCluster) runtanzuv1alpha3.ClusterBootstrapRolloutStatus {
+	status := *rollout.Status.DeepCopy()
+
+	clusters = append([]RolloutCluster(nil), clusters...)
+	sort.SliceStable(clusters, func(i, j int) bool {
+		oi, oj := clusters[i].Order, clusters[j].Order
+		if oi != nil && oj != nil && *oi != *oj {
+			return *oi < *oj
+		}
+		if (oi == nil) != (oj == nil) {
+			return oi != nil
+		}
+		return clusters[i].Key < clusters[j].Key
+	})
+
+	admitted := map[string]bool{}
+	for _, key := range status.AdmittedClusters {
+		admitted[key] = true
+	}
+
+	status.ReadyClusters, status.FailedClusters = nil, nil
+	var pending []string
+	inProgress := 0
+	for _, cluster := range clusters {
+		if !admitted[cluster.Key] {
+			// clusters updated before being governed by the rollout are ignored
+			if cluster.State == RolloutClusterPending {
+				pending = append(pending, cluster.Key)
+			}
+			continue
+		}
+		switch cluster.State {
+		case RolloutClusterReady:
+			status.ReadyClusters = append(status.ReadyClusters, cluster.Key)
+		case RolloutClusterFailed:
+			status.FailedClusters = append(status.FailedClusters, cluster.Key)
+		default:
+			inProgress++
+		}
+	}
+
+	if promotion := rollout.Annotations[runtanzuv1alpha3.ClusterBootstrapRolloutPromoteAnnotation]; promotion != "" && promotion != status.ObservedPromotion {
+		status.ObservedPromotion = promotion
+		status.AcceptedFailures = int32(len(status.FailedClusters))
+		if len(status.AdmittedClusters) >= int(rollout.Spec.Canary) {
+			status.CanaryPromoted = true
+		}
+	}
+
+	if rollout.Annotations[runtanzuv1alpha3.ClusterBootstrapRolloutAbortAnnotation] == "true" {
+		status.Phase = runtanzuv1alpha3.RolloutPhaseAborted
+		status.Message = "rollout aborted"
+		return status
+	}
+
+	if rollout.PausesOnFailure() && int32(len(status.FailedClusters)) > status.AcceptedFailures {
+		status.Phase = runtanzuv1alpha3.RolloutPhasePaused
+		status.Message = fmt.Sprintf("failed clusters: %s", strings.Join(status.FailedClusters, ", "))
+		return status
+	}
+
+	limit := len(status.AdmittedClusters) + len(pending)
+	inCanary := rollout.Spec.Canary > 0 && !status.CanaryPromoted
+	if inCanary && int(rollout.Spec.Canary) < limit {
+		limit = int(rollout.Spec.Canary)
+	}
+
+	batchSize := int(rollout.Spec.BatchSize)
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	for _, key := range pending {
+		if len(status.AdmittedClusters) >= limit || inProgress >= batchSize {
+			break
+		}
+		status.AdmittedClusters = append(status.AdmittedClusters, key)
+		inProgress++
+		pending = pending[1:]
+	}
+
+	switch {
+	case inProgress > 0:
+		status.Phase = runtanzuv1alpha3.RolloutPhaseProgressing
+		status.Message = fmt.Sprintf("%d of %d clusters admitted", len(status.AdmittedClusters), len(status.AdmittedClusters)+len(pending))
+	case len(pending) > 0:
+		status.Phase = runtanzuv1alpha3.RolloutPhasePaused
+		status.Message = "canary clusters are ready, waiting for promotion"
+	default:
+		status.Phase = runtanzuv1alpha3.RolloutPhaseCompleted
+		status.Message = fmt.Sprintf("%d clusters updated", len(status.AdmittedClusters))
+		if len(status.FailedClusters) > 0 {
+			status.Message += fmt.Sprintf(", failed clusters: %s", strings.Join(status.FailedClusters, ", "))
+		}
+	}
+	return status
+}
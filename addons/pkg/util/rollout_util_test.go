@@ -0,0 +1,219 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/tanzu-framework/addons/pkg/constants"
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+)
+
+var _ = Describe("ClusterBootstrapRollout planning", func() {
+	var rollout *runtanzuv1alpha3.ClusterBootstrapRollout
+
+	order := func(s string) *string { return &s }
+
+	BeforeEach(func() {
+		rollout = &runtanzuv1alpha3.ClusterBootstrapRollout{
+			ObjectMeta: metav1.ObjectMeta{Name: "rollout", Namespace: "tkg-system"},
+			Spec: runtanzuv1alpha3.ClusterBootstrapRolloutSpec{
+				TKR:            "v1.23.5",
+				BatchSize:      2,
+				OrderByLabel:   "wave",
+				PauseOnFailure: pointer.Bool(true),
+			},
+		}
+	})
+
+	Context("GetClusterBootstrapRolloutHoldingBack()", func() {
+		var cluster *clusterapiv1beta1.Cluster
+
+		BeforeEach(func() {
+			cluster = &clusterapiv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "wc", Namespace: "default"}}
+		})
+
+		newClient := func(objects ...runtime.Object) client.Client {
+			scheme := runtime.NewScheme()
+			Expect(runtanzuv1alpha3.AddToScheme(scheme)).To(Succeed())
+			return fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+		}
+
+		It("should hold back clusters not admitted by the rollout of the TKR", func() {
+			c := newClient(rollout)
+			holdingBack, err := GetClusterBootstrapRolloutHoldingBack(context.Background(), c, "tkg-system", "v1.23.5", cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holdingBack).NotTo(BeNil())
+			Expect(holdingBack.Name).To(Equal("rollout"))
+		})
+
+		It("should let admitted clusters update", func() {
+			rollout.Status.AdmittedClusters = []string{"default/wc"}
+			c := newClient(rollout)
+			holdingBack, err := GetClusterBootstrapRolloutHoldingBack(context.Background(), c, "tkg-system", "v1.23.5", cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holdingBack).To(BeNil())
+		})
+
+		It("should let clusters outside the cluster selector of the rollout update", func() {
+			rollout.Spec.ClusterSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+			cluster.Labels = map[string]string{"env": "dev"}
+			c := newClient(rollout)
+			holdingBack, err := GetClusterBootstrapRolloutHoldingBack(context.Background(), c, "tkg-system", "v1.23.5", cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holdingBack).To(BeNil())
+
+			cluster.Labels["env"] = "prod"
+			holdingBack, err = GetClusterBootstrapRolloutHoldingBack(context.Background(), c, "tkg-system", "v1.23.5", cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holdingBack).NotTo(BeNil())
+		})
+
+		It("should let clusters update to a TKR without rollout", func() {
+			c := newClient(rollout)
+			holdingBack, err := GetClusterBootstrapRolloutHoldingBack(context.Background(), c, "tkg-system", "v1.24.2", cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holdingBack).To(BeNil())
+
+			holdingBack, err = GetClusterBootstrapRolloutHoldingBack(context.Background(), c, "other", "v1.23.5", cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holdingBack).To(BeNil())
+		})
+	})
+
+	Context("GetRolloutCluster()", func() {
+		var (
+			cluster          *clusterapiv1beta1.Cluster
+			clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap
+		)
+
+		BeforeEach(func() {
+			cluster = &clusterapiv1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{
+				Name: "wc", Namespace: "default", Labels: map[string]string{"wave": "1"}}}
+			clusterBootstrap = &runtanzuv1alpha3.ClusterBootstrap{
+				Status: runtanzuv1alpha3.ClusterBootstrapStatus{ResolvedTKR: "v1.23.5"}}
+		})
+
+		It("should report clusters not updated yet as pending", func() {
+			clusterBootstrap.Status.ResolvedTKR = "v1.22.9"
+			rolloutCluster := GetRolloutCluster(rollout, cluster, clusterBootstrap)
+			Expect(rolloutCluster.Key).To(Equal("default/wc"))
+			Expect(*rolloutCluster.Order).To(Equal("1"))
+			Expect(rolloutCluster.State).To(Equal(RolloutClusterPending))
+		})
+
+		It("should derive the state from the Ready condition", func() {
+			Expect(GetRolloutCluster(rollout, cluster, clusterBootstrap).State).To(Equal(RolloutClusterUpdating))
+
+			conditions.MarkFalse(clusterBootstrap, clusterapiv1beta1.ReadyCondition, constants.PackagesReconcileFailedReason,
+				clusterapiv1beta1.ConditionSeverityError, "")
+			Expect(GetRolloutCluster(rollout, cluster, clusterBootstrap).State).To(Equal(RolloutClusterFailed))
+
+			conditions.MarkTrue(clusterBootstrap, clusterapiv1beta1.ReadyCondition)
+			Expect(GetRolloutCluster(rollout, cluster, clusterBootstrap).State).To(Equal(RolloutClusterReady))
+		})
+	})
+
+	Context("PlanRollout()", func() {
+		It("should admit clusters in label order, unlabeled clusters last", func() {
+			clusters := []RolloutCluster{
+				{Key: "default/c", State: RolloutClusterPending},
+				{Key: "default/b", Order: order("2"), State: RolloutClusterPending},
+				{Key: "default/a", Order: order("1"), State: RolloutClusterPending},
+			}
+			status := PlanRollout(rollout, clusters)
+			Expect(status.AdmittedClusters).To(Equal([]string{"default/a", "default/b"}))
+			Expect(status.Phase).To(Equal(runtanzuv1alpha3.RolloutPhaseProgressing))
+
+			rollout.Status = status
+			clusters = []RolloutCluster{
+				{Key: "default/c", State: RolloutClusterPending},
+				{Key: "default/b", Order: order("2"), State: RolloutClusterUpdating},
+				{Key: "default/a", Order: order("1"), State: RolloutClusterReady},
+			}
+			status = PlanRollout(rollout, clusters)
+			Expect(status.AdmittedClusters).To(Equal([]string{"default/a", "default/b", "default/c"}))
+			Expect(status.ReadyClusters).To(Equal([]string{"default/a"}))
+
+			rollout.Status = status
+			clusters[0].State, clusters[1].State = RolloutClusterReady, RolloutClusterReady
+			status = PlanRollout(rollout, clusters)
+			Expect(status.Phase).To(Equal(runtanzuv1alpha3.RolloutPhaseCompleted))
+		})
+
+		It("should pause on failure until promoted", func() {
+			rollout.Spec.BatchSize = 1
+			rollout.Status.AdmittedClusters = []string{"default/a"}
+			clusters := []RolloutCluster{
+				{Key: "default/a", State: RolloutClusterFailed},
+				{Key: "default/b", State: RolloutClusterPending},
+			}
+			status := PlanRollout(rollout, clusters)
+			Expect(status.Phase).To(Equal(runtanzuv1alpha3.RolloutPhasePaused))
+			Expect(status.AdmittedClusters).To(Equal([]string{"default/a"}))
+
+			rollout.Status = status
+			rollout.Annotations = map[string]string{runtanzuv1alpha3.ClusterBootstrapRolloutPromoteAnnotation: "1"}
+			status = PlanRollout(rollout, clusters)
+			Expect(status.Phase).To(Equal(runtanzuv1alpha3.RolloutPhaseProgressing))
+			Expect(status.AcceptedFailures).To(Equal(int32(1)))
+			Expect(status.AdmittedClusters).To(Equal([]string{"default/a", "default/b"}))
+		})
+
+		It("should pause on failure by default and keep going when disabled", func() {
+			rollout.Spec.BatchSize = 1
+			rollout.Status.AdmittedClusters = []string{"default/a"}
+			clusters := []RolloutCluster{
+				{Key: "default/a", State: RolloutClusterFailed},
+				{Key: "default/b", State: RolloutClusterPending},
+			}
+			rollout.Spec.PauseOnFailure = nil
+			Expect(PlanRollout(rollout, clusters).Phase).To(Equal(runtanzuv1alpha3.RolloutPhasePaused))
+
+			rollout.Spec.PauseOnFailure = pointer.Bool(false)
+			status := PlanRollout(rollout, clusters)
+			Expect(status.Phase).To(Equal(runtanzuv1alpha3.RolloutPhaseProgressing))
+			Expect(status.AdmittedClusters).To(Equal([]string{"default/a", "default/b"}))
+		})
+
+		It("should pause after the canary clusters are ready", func() {
+			rollout.Spec.Canary = 1
+			clusters := []RolloutCluster{
+				{Key: "default/a", State: RolloutClusterPending},
+				{Key: "default/b", State: RolloutClusterPending},
+			}
+			status := PlanRollout(rollout, clusters)
+			Expect(status.AdmittedClusters).To(Equal([]string{"default/a"}))
+
+			rollout.Status = status
+			clusters[0].State = RolloutClusterReady
+			status = PlanRollout(rollout, clusters)
+			Expect(status.Phase).To(Equal(runtanzuv1alpha3.RolloutPhasePaused))
+			Expect(status.AdmittedClusters).To(Equal([]string{"default/a"}))
+
+			rollout.Status = status
+			rollout.Annotations = map[string]string{runtanzuv1alpha3.ClusterBootstrapRolloutPromoteAnnotation: "go"}
+			status = PlanRollout(rollout, clusters)
+			Expect(status.CanaryPromoted).To(BeTrue())
+			Expect(status.AdmittedClusters).To(Equal([]string{"default/a", "default/b"}))
+		})
+
+		It("should not admit clusters once aborted", func() {
+			rollout.Annotations = map[string]string{runtanzuv1alpha3.ClusterBootstrapRolloutAbortAnnotation: "true"}
+			status := PlanRollout(rollout, []RolloutCluster{{Key: "default/a", State: RolloutClusterPending}})
+			Expect(status.Phase).To(Equal(runtanzuv1alpha3.RolloutPhaseAborted))
+			Expect(status.AdmittedClusters).To(BeEmpty())
+		})
+	})
+})
@@ -6,6 +6,7 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -157,6 +158,38 @@ func getFieldPath(fieldName string) *field.Path {
 	return field.NewPath("spec").Child(fieldName)
 }
 
+// validatePackageDependencies validates that the dependencies of each package designate another package of the spec
+// and that the dependencies do not form a cycle
+func validatePackageDependencies(spec *runv1alpha3.ClusterBootstrapTemplateSpec) field.ErrorList {
+	if spec == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	pkgs := util.GetClusterBootstrapPackages(spec)
+	validate := func(pkg *runv1alpha3.ClusterBootstrapPackage, fldPath *field.Path) {
+		if pkg == nil {
+			return
+		}
+		for idx, dependency := range pkg.DependsOn {
+			if util.FindPackageDependency(pkgs, dependency) == -1 {
+				allErrs = append(allErrs, field.NotFound(fldPath.Child("dependsOn").Index(idx), dependency))
+			}
+		}
+	}
+	validate(spec.CNI, getFieldPath("cni"))
+	validate(spec.CSI, getFieldPath("csi"))
+	validate(spec.CPI, getFieldPath("cpi"))
+	validate(spec.Kapp, getFieldPath("kapp"))
+	for idx, pkg := range spec.AdditionalPackages {
+		validate(pkg, getFieldPath("additionalPackages").Index(idx))
+	}
+
+	if cycle := util.FindPackageDependencyCycle(pkgs); cycle != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), strings.Join(cycle, " -> "), "package dependencies form a cycle"))
+	}
+	return allErrs
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (wh *ClusterBootstrap) ValidateCreate(ctx context.Context, obj runtime.Object) error {
 	clusterBootstrap, ok := obj.(*runv1alpha3.ClusterBootstrap)
@@ -197,6 +230,8 @@ func (wh *ClusterBootstrap) ValidateCreate(ctx context.Context, obj runtime.Obje
 		}
 	}
 
+	allErrs = append(allErrs, validatePackageDependencies(clusterBootstrap.Spec)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -402,6 +437,8 @@ func (wh *ClusterBootstrap) ValidateUpdate(ctx context.Context, oldObj, newObj r
 		allErrs = append(allErrs, err...)
 	}
 
+	allErrs = append(allErrs, validatePackageDependencies(newClusterBootstrap.Spec)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -261,6 +261,10 @@ type AntreaConfigStatus struct {
 	// Reference to the data value secret created by controller
 	// +kubebuilder:validation:Optional
 	SecretRef string `json:"secretRef,omitempty"`
+
+	// Conditions describe the reconciliation of the data values secret
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -290,6 +294,21 @@ type AntreaConfigList struct {
 	Items           []AntreaConfig `json:"items"`
 }
 
+// GetConditions returns the conditions of the AntreaConfig
+func (c *AntreaConfig) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the AntreaConfig
+func (c *AntreaConfig) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// SetSecretRef records the name of the data values secret generated for the AntreaConfig
+func (c *AntreaConfig) SetSecretRef(name string) {
+	c.Status.SecretRef = name
+}
+
 func init() {
 	SchemeBuilder.Register(&AntreaConfig{}, &AntreaConfigList{})
 }
@@ -38,6 +38,10 @@ type CalicoConfigStatus struct {
 	// SecretRef is the name of the data value secret created by calico controller.
 	//+ kubebuilder:validation:Optional
 	SecretRef string `json:"secretRef,omitempty"`
+
+	// Conditions describe the reconciliation of the data values secret
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -64,6 +68,21 @@ type CalicoConfigList struct {
 	Items           []CalicoConfig `json:"items"`
 }
 
+// GetConditions returns the conditions of the CalicoConfig
+func (c *CalicoConfig) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the CalicoConfig
+func (c *CalicoConfig) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// SetSecretRef records the name of the data values secret generated for the CalicoConfig
+func (c *CalicoConfig) SetSecretRef(name string) {
+	c.Status.SecretRef = name
+}
+
 func init() {
 	SchemeBuilder.Register(&CalicoConfig{}, &CalicoConfigList{})
 }
@@ -33,6 +33,10 @@ type KubevipCPIConfigStatus struct {
 	// Name of the secret created by kubevip cloudprovider config controller
 	//+ kubebuilder:validation:Optional
 	SecretRef *string `json:"secretRef,omitempty"`
+
+	// Conditions describe the reconciliation of the data values secret
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -56,6 +60,21 @@ type KubevipCPIConfigList struct {
 	Items           []KubevipCPIConfig `json:"items"`
 }
 
+// GetConditions returns the conditions of the KubevipCPIConfig
+func (c *KubevipCPIConfig) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the KubevipCPIConfig
+func (c *KubevipCPIConfig) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// SetSecretRef records the name of the data values secret generated for the KubevipCPIConfig
+func (c *KubevipCPIConfig) SetSecretRef(name string) {
+	c.Status.SecretRef = &name
+}
+
 func init() {
 	SchemeBuilder.Register(&KubevipCPIConfig{}, &KubevipCPIConfigList{})
 }
@@ -71,6 +71,10 @@ type OracleCPIConfigStatus struct {
 	// Name of the data value secret created by Oracle CPI controller
 	//+ kubebuilder:validation:Optional
 	SecretRef string `json:"secretRef,omitempty"`
+
+	// Conditions describe the reconciliation of the data values secret
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -97,6 +101,21 @@ type OracleCPIConfigList struct {
 	Items           []OracleCPIConfig `json:"items"`
 }
 
+// GetConditions returns the conditions of the OracleCPIConfig
+func (c *OracleCPIConfig) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the OracleCPIConfig
+func (c *OracleCPIConfig) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// SetSecretRef records the name of the data values secret generated for the OracleCPIConfig
+func (c *OracleCPIConfig) SetSecretRef(name string) {
+	c.Status.SecretRef = name
+}
+
 func init() {
 	SchemeBuilder.Register(&OracleCPIConfig{}, &OracleCPIConfigList{})
 }
@@ -159,6 +159,10 @@ type VSphereCPIConfigStatus struct {
 	// Name of the data value secret created by vSphere CPI controller
 	//+ kubebuilder:validation:Optional
 	SecretRef string `json:"secretRef,omitempty"`
+
+	// Conditions describe the reconciliation of the data values secret
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -185,6 +189,21 @@ type VSphereCPIConfigList struct {
 	Items           []VSphereCPIConfig `json:"items"`
 }
 
+// GetConditions returns the conditions of the VSphereCPIConfig
+func (c *VSphereCPIConfig) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the VSphereCPIConfig
+func (c *VSphereCPIConfig) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// SetSecretRef records the name of the data values secret generated for the VSphereCPIConfig
+func (c *VSphereCPIConfig) SetSecretRef(name string) {
+	c.Status.SecretRef = name
+}
+
 func init() {
 	SchemeBuilder.Register(&VSphereCPIConfig{}, &VSphereCPIConfigList{})
 }
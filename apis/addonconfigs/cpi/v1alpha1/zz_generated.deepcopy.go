@@ -9,7 +9,8 @@
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -105,6 +106,13 @@ func (in *KubevipCPIConfigStatus) DeepCopyInto(out *KubevipCPIConfigStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubevipCPIConfigStatus.
@@ -132,7 +140,7 @@ func (in *NSXT) DeepCopyInto(out *NSXT) {
 	}
 	if in.CredentialLocalObjRef != nil {
 		in, out := &in.CredentialLocalObjRef, &out.CredentialLocalObjRef
-		*out = new(v1.TypedLocalObjectReference)
+		*out = new(corev1.TypedLocalObjectReference)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.APIHost != nil {
@@ -222,7 +230,7 @@ func (in *NonParavirtualConfig) DeepCopyInto(out *NonParavirtualConfig) {
 	}
 	if in.VSphereCredentialLocalObjRef != nil {
 		in, out := &in.VSphereCredentialLocalObjRef, &out.VSphereCredentialLocalObjRef
-		*out = new(v1.TypedLocalObjectReference)
+		*out = new(corev1.TypedLocalObjectReference)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.TLSThumbprint != nil {
@@ -288,7 +296,7 @@ func (in *OracleCPIConfig) DeepCopyInto(out *OracleCPIConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleCPIConfig.
@@ -361,6 +369,13 @@ func (in *OracleCPIConfigSpec) DeepCopy() *OracleCPIConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OracleCPIConfigStatus) DeepCopyInto(out *OracleCPIConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleCPIConfigStatus.
@@ -529,7 +544,7 @@ func (in *VSphereCPIConfig) DeepCopyInto(out *VSphereCPIConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereCPIConfig.
@@ -601,6 +616,13 @@ func (in *VSphereCPIConfigSpec) DeepCopy() *VSphereCPIConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereCPIConfigStatus) DeepCopyInto(out *VSphereCPIConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereCPIConfigStatus.
@@ -20,6 +20,10 @@ type AzureDiskCSIConfigStatus struct {
 	// Name of the secret created by csi controller
 	//+ kubebuilder:validation:Optional
 	SecretRef *string `json:"secretRef,omitempty"`
+
+	// Conditions describe the reconciliation of the data values secret
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -62,6 +66,21 @@ type AzureDiskCSI struct {
 	DeploymentReplicas *int32 `json:"deploymentReplicas,omitempty"`
 }
 
+// GetConditions returns the conditions of the AzureDiskCSIConfig
+func (c *AzureDiskCSIConfig) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the AzureDiskCSIConfig
+func (c *AzureDiskCSIConfig) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// SetSecretRef records the name of the data values secret generated for the AzureDiskCSIConfig
+func (c *AzureDiskCSIConfig) SetSecretRef(name string) {
+	c.Status.SecretRef = &name
+}
+
 func init() {
 	SchemeBuilder.Register(&AzureDiskCSIConfig{}, &AzureDiskCSIConfigList{})
 }
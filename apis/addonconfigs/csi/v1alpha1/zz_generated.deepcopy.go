@@ -9,7 +9,8 @@
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -116,6 +117,13 @@ func (in *AwsEbsCSIConfigStatus) DeepCopyInto(out *AwsEbsCSIConfigStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsEbsCSIConfigStatus.
@@ -231,6 +239,13 @@ func (in *AzureDiskCSIConfigStatus) DeepCopyInto(out *AzureDiskCSIConfigStatus)
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDiskCSIConfigStatus.
@@ -346,6 +361,13 @@ func (in *AzureFileCSIConfigStatus) DeepCopyInto(out *AzureFileCSIConfigStatus)
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureFileCSIConfigStatus.
@@ -363,7 +385,7 @@ func (in *NonParavirtualConfig) DeepCopyInto(out *NonParavirtualConfig) {
 	*out = *in
 	if in.VSphereCredentialLocalObjRef != nil {
 		in, out := &in.VSphereCredentialLocalObjRef, &out.VSphereCredentialLocalObjRef
-		*out = new(v1.TypedLocalObjectReference)
+		*out = new(corev1.TypedLocalObjectReference)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.InsecureFlag != nil {
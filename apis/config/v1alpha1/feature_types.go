@@ -28,6 +28,12 @@ type FeatureSpec struct {
 	// - ga: intended to be part of the mainline codebase, non-optional
 	// - deprecated: destined for future removal
 	Maturity string `json:"maturity"`
+	// DependsOn lists the Features which must be activated for this feature to be activated.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// ConflictsWith lists the Features which cannot be activated together with this feature.
+	// +optional
+	ConflictsWith []string `json:"conflictsWith,omitempty"`
 }
 
 // FeatureStatus defines the observed state of Feature
@@ -4,9 +4,22 @@
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// MaxFeatureGateHistory is the maximum number of activation changes kept in the history of a FeatureGate.
+	MaxFeatureGateHistory = 50
+	// PendingFeatureChangesAnnotation holds the activation changes recorded by the FeatureGate webhook, until the
+	// FeatureGate controller moves them to the history in status. The webhook ignores the values set by clients.
+	PendingFeatureChangesAnnotation = "config.tanzu.vmware.com/pending-feature-changes"
+	// FeatureGateControllerUser is the user of the activation changes made by the FeatureGate controller when
+	// activateAfter or expiresAt is reached.
+	FeatureGateControllerUser = "featuregate-controller"
+)
+
 // FeatureReference refers to a Feature resource and specifies its intended activation state.
 type FeatureReference struct {
 	// Name is the name of the Feature resource, which represents a feature the system offers.
@@ -14,6 +27,33 @@ type FeatureReference struct {
 	Name string `json:"name"`
 	// Activate indicates the activation intent for the feature.
 	Activate bool `json:"activate,omitempty"`
+	// ActivateAfter is the time after which the feature is activated. The feature is deactivated before this time.
+	// It only applies when Activate is true, must be before ExpiresAt and cannot be set for immutable features.
+	// +optional
+	ActivateAfter *metav1.Time `json:"activateAfter,omitempty"`
+	// ExpiresAt is the time at which the feature is deactivated. It only applies when Activate is true, must be after
+	// ActivateAfter and cannot be set for immutable features.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// ActivateAt returns the activation intent for the feature at a time, taking ActivateAfter and ExpiresAt into account.
+func (r *FeatureReference) ActivateAt(t time.Time) bool {
+	if !r.Activate {
+		return false
+	}
+	if r.ActivateAfter != nil && t.Before(r.ActivateAfter.Time) {
+		return false
+	}
+	if r.ExpiresAt != nil && !t.Before(r.ExpiresAt.Time) {
+		return false
+	}
+	return true
+}
+
+// Scheduled returns true if the activation intent for the feature depends on time.
+func (r *FeatureReference) Scheduled() bool {
+	return r.Activate && (r.ActivateAfter != nil || r.ExpiresAt != nil)
 }
 
 // FeatureGateSpec defines the desired state of FeatureGate
@@ -31,6 +71,35 @@ type FeatureGateSpec struct {
 	Features []FeatureReference `json:"features,omitempty"`
 }
 
+// SpecAt returns the spec with the activation intents at a time, with ActivateAfter and ExpiresAt applied.
+func (s *FeatureGateSpec) SpecAt(t time.Time) FeatureGateSpec {
+	spec := *s.DeepCopy()
+	for i := range spec.Features {
+		spec.Features[i].Activate = spec.Features[i].ActivateAt(t)
+		spec.Features[i].ActivateAfter = nil
+		spec.Features[i].ExpiresAt = nil
+	}
+	return spec
+}
+
+// NextTransitionAfter returns the earliest ActivateAfter or ExpiresAt time of the activated features after a time,
+// or nil if the activation intents do not change after it.
+func (s *FeatureGateSpec) NextTransitionAfter(t time.Time) *time.Time {
+	var next *time.Time
+	for i := range s.Features {
+		ref := &s.Features[i]
+		if !ref.Activate {
+			continue
+		}
+		for _, transition := range []*metav1.Time{ref.ActivateAfter, ref.ExpiresAt} {
+			if transition != nil && transition.Time.After(t) && (next == nil || transition.Time.Before(*next)) {
+				next = &transition.Time
+			}
+		}
+	}
+	return next
+}
+
 // FeatureGateStatus defines the observed state of FeatureGate
 type FeatureGateStatus struct {
 	// Namespaces lists the existing namespaces for which this feature gate applies. This is obtained from listing all
@@ -47,6 +116,27 @@ type FeatureGateStatus struct {
 	// UnavailableFeatures lists the features that are gated in the spec, but are not available in the system as
 	// Feature resources.
 	UnavailableFeatures []string `json:"unavailableFeatures,omitempty"`
+	// History lists the latest activation changes of the features in the spec, from the oldest to the newest.
+	// At most 50 changes are kept.
+	// +optional
+	History []FeatureChange `json:"history,omitempty"`
+}
+
+// FeatureChange is a change of the activation intent for a feature.
+type FeatureChange struct {
+	// Feature is the name of the Feature resource.
+	Feature string `json:"feature"`
+	// User is the user who made the change, or featuregate-controller when the change was scheduled.
+	// +optional
+	User string `json:"user,omitempty"`
+	// Time is the time of the change.
+	Time metav1.Time `json:"time"`
+	// OldActivate is the activation intent before the change, it is not set if the feature was not in the spec.
+	// +optional
+	OldActivate *bool `json:"oldActivate,omitempty"`
+	// NewActivate is the activation intent after the change, it is not set if the feature was removed from the spec.
+	// +optional
+	NewActivate *bool `json:"newActivate,omitempty"`
 }
 
 //+kubebuilder:object:root=true
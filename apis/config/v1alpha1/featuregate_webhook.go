@@ -5,8 +5,10 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -20,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // log is for logging in this package.
@@ -60,9 +63,143 @@ func (r *FeatureGate) getClient() (client.Client, error) {
 func (r *FeatureGate) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(&featureGateRecorder{}).
 		Complete()
 }
 
+//+kubebuilder:webhook:verbs=create;update,path=/mutate-config-tanzu-vmware-com-v1alpha1-featuregate,mutating=true,failurePolicy=fail,groups=config.tanzu.vmware.com,resources=featuregates,versions=v1alpha1,name=mfeaturegate.kb.io
+
+// featureGateRecorder records the changes of the activation intents of a FeatureGate, with the user who made them,
+// in the pending feature changes annotation. The FeatureGate controller moves them to the history in status.
+type featureGateRecorder struct{}
+
+var _ webhook.CustomDefaulter = &featureGateRecorder{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (d *featureGateRecorder) Default(ctx context.Context, obj runtime.Object) error {
+	gate, ok := obj.(*FeatureGate)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureGate object, but got object of type %T", obj))
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+
+	oldObj := &FeatureGate{}
+	if len(req.OldObject.Raw) != 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, oldObj); err != nil {
+			return apierrors.NewBadRequest(fmt.Sprintf("couldn't decode old FeatureGate object: %v", err))
+		}
+	}
+
+	// The pending changes are only taken from the old object, so that users can neither forge nor drop them. The
+	// changes the controller already moved to the history are dropped, which lets the controller clear the annotation.
+	pending, err := oldObj.PendingFeatureChanges()
+	if err != nil {
+		featuregatelog.Error(err, "dropping invalid pending feature changes", "name", gate.Name)
+	}
+	changes := computeFeatureChanges(oldObj.Spec, gate.Spec, req.UserInfo.Username, metav1.Now())
+	if len(changes) != 0 {
+		featuregatelog.Info("record feature changes", "name", gate.Name, "user", req.UserInfo.Username, "changes", len(changes))
+	}
+	return gate.SetPendingFeatureChanges(append(pending, changes...))
+}
+
+// computeFeatureChanges returns the changes of the activation intents between two specs.
+// This is a separate function for easier unit testing.
+func computeFeatureChanges(oldSpec, newSpec FeatureGateSpec, user string, now metav1.Time) []FeatureChange {
+	oldIntents := make(map[string]bool)
+	for _, ref := range oldSpec.Features {
+		oldIntents[ref.Name] = ref.Activate
+	}
+	newIntents := make(map[string]bool)
+	for _, ref := range newSpec.Features {
+		newIntents[ref.Name] = ref.Activate
+	}
+
+	var changes []FeatureChange
+	for _, ref := range newSpec.Features {
+		newActivate := ref.Activate
+		oldActivate, found := oldIntents[ref.Name]
+		if found && oldActivate == newActivate {
+			continue
+		}
+		change := FeatureChange{Feature: ref.Name, User: user, Time: now, NewActivate: &newActivate}
+		if found {
+			change.OldActivate = &oldActivate
+		}
+		changes = append(changes, change)
+	}
+	for _, ref := range oldSpec.Features {
+		if _, found := newIntents[ref.Name]; found {
+			continue
+		}
+		oldActivate := ref.Activate
+		changes = append(changes, FeatureChange{Feature: ref.Name, User: user, Time: now, OldActivate: &oldActivate})
+	}
+	return changes
+}
+
+// PendingFeatureChanges returns the feature changes recorded by the webhook which are not in the history yet.
+func (r *FeatureGate) PendingFeatureChanges() ([]FeatureChange, error) {
+	value, ok := r.Annotations[PendingFeatureChangesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var changes []FeatureChange
+	if err := json.Unmarshal([]byte(value), &changes); err != nil {
+		return nil, fmt.Errorf("couldn't decode annotation %s: %w", PendingFeatureChangesAnnotation, err)
+	}
+	var pending []FeatureChange
+	for i := range changes {
+		if !r.Status.hasFeatureChange(&changes[i]) {
+			pending = append(pending, changes[i])
+		}
+	}
+	return pending, nil
+}
+
+// hasFeatureChange returns true if a feature change is in the history.
+func (s *FeatureGateStatus) hasFeatureChange(change *FeatureChange) bool {
+	for i := range s.History {
+		if s.History[i].equal(change) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal returns true if two feature changes are the same.
+func (c *FeatureChange) equal(other *FeatureChange) bool {
+	return c.Feature == other.Feature && c.User == other.User && c.Time.Equal(&other.Time) &&
+		boolPtrEqual(c.OldActivate, other.OldActivate) && boolPtrEqual(c.NewActivate, other.NewActivate)
+}
+
+func boolPtrEqual(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// SetPendingFeatureChanges sets the feature changes which are not in the history yet, no changes remove the annotation.
+func (r *FeatureGate) SetPendingFeatureChanges(changes []FeatureChange) error {
+	if len(changes) == 0 {
+		delete(r.Annotations, PendingFeatureChangesAnnotation)
+		return nil
+	}
+	value, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	if r.Annotations == nil {
+		r.Annotations = make(map[string]string)
+	}
+	r.Annotations[PendingFeatureChangesAnnotation] = string(value)
+	return nil
+}
+
 //+kubebuilder:webhook:verbs=create;update,path=/validate-config-tanzu-vmware-com-v1alpha1-featuregate,mutating=false,failurePolicy=fail,groups=config.tanzu.vmware.com,resources=featuregates,versions=v1alpha1,name=vfeaturegate.kb.io
 
 var _ webhook.Validator = &FeatureGate{}
@@ -79,6 +216,9 @@ func (r *FeatureGate) ValidateCreate() error {
 	var allErrors field.ErrorList
 
 	allErrors = append(allErrors, r.validateNamespaceConflicts(ctx, c, field.NewPath("spec"))...)
+	allErrors = append(allErrors, r.validateFeatureSchedules(field.NewPath("spec").Child("features"))...)
+	allErrors = append(allErrors, r.validateFeatureImmutability(ctx, c, nil, field.NewPath("spec").Child("features"))...)
+	allErrors = append(allErrors, r.validateFeatureDependencies(ctx, c, nil, field.NewPath("spec").Child("features"))...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -107,7 +247,9 @@ func (r *FeatureGate) ValidateUpdate(old runtime.Object) error {
 	var allErrors field.ErrorList
 
 	allErrors = append(allErrors, r.validateNamespaceConflicts(ctx, c, field.NewPath("spec"))...)
+	allErrors = append(allErrors, r.validateFeatureSchedules(field.NewPath("spec").Child("features"))...)
 	allErrors = append(allErrors, r.validateFeatureImmutability(ctx, c, oldObj, field.NewPath("spec").Child("features"))...)
+	allErrors = append(allErrors, r.validateFeatureDependencies(ctx, c, oldObj, field.NewPath("spec").Child("features"))...)
 
 	if len(allErrors) == 0 {
 		return nil
@@ -187,7 +329,8 @@ func (r *FeatureGate) validateFeatureImmutability(ctx context.Context, c client.
 	return allErrors
 }
 
-// computeChangedImmutableFeatures returns immutable features which are changed in the current spec.
+// computeChangedImmutableFeatures returns immutable features which are changed in the current spec, or whose activation
+// is scheduled with activateAfter or expiresAt, which would change them later. The old object is nil on creation.
 // This is a separate function for easier unit testing.
 func computeChangedImmutableFeatures(spec FeatureGateSpec, currentFeatures []Feature, oldObject *FeatureGate) []string {
 	immutable := sets.String{}
@@ -198,8 +341,12 @@ func computeChangedImmutableFeatures(spec FeatureGateSpec, currentFeatures []Fea
 		}
 	}
 
-	oldActivated := sets.NewString(oldObject.Status.ActivatedFeatures...)
-	oldDeactivated := sets.NewString(oldObject.Status.DeactivatedFeatures...)
+	oldStatus := FeatureGateStatus{}
+	if oldObject != nil {
+		oldStatus = oldObject.Status
+	}
+	oldActivated := sets.NewString(oldStatus.ActivatedFeatures...)
+	oldDeactivated := sets.NewString(oldStatus.DeactivatedFeatures...)
 
 	changedFeatures := sets.String{}
 	for _, featureRef := range spec.Features {
@@ -207,6 +354,10 @@ func computeChangedImmutableFeatures(spec FeatureGateSpec, currentFeatures []Fea
 		if !immutable.Has(name) {
 			continue
 		}
+		if featureRef.ActivateAfter != nil || featureRef.ExpiresAt != nil {
+			changedFeatures.Insert(name)
+			continue
+		}
 		// Features that changed states from activated to deactivated or vice versa in the current update.
 		if (featureRef.Activate && oldDeactivated.Has(name)) || !featureRef.Activate && oldActivated.Has(name) {
 			changedFeatures.Insert(name)
@@ -215,6 +366,129 @@ func computeChangedImmutableFeatures(spec FeatureGateSpec, currentFeatures []Fea
 	return immutable.Intersection(changedFeatures).List()
 }
 
+// validateFeatureSchedules validates that the features are activated after activateAfter before they expire.
+func (r *FeatureGate) validateFeatureSchedules(fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+	for i := range r.Spec.Features {
+		ref := &r.Spec.Features[i]
+		if ref.ActivateAfter != nil && ref.ExpiresAt != nil && !ref.ActivateAfter.Before(ref.ExpiresAt) {
+			allErrors = append(allErrors, field.Invalid(fldPath.Index(i).Child("expiresAt"), ref.ExpiresAt,
+				fmt.Sprintf("feature %q must expire after activateAfter %s", ref.Name, ref.ActivateAfter.UTC().Format(time.RFC3339))))
+		}
+	}
+	return allErrors
+}
+
+// validateFeatureDependencies validates that the features activated by the spec have their dependencies activated
+// and do not conflict with other activated features.
+func (r *FeatureGate) validateFeatureDependencies(ctx context.Context, c client.Client, oldObject *FeatureGate, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	features := &FeatureList{}
+	if err := c.List(ctx, features); err != nil {
+		allErrors = append(allErrors, field.InternalError(fldPath, err))
+		return allErrors
+	}
+
+	var oldSpec *FeatureGateSpec
+	if oldObject != nil {
+		oldSpec = &oldObject.Spec
+	}
+	for _, violation := range computeScheduledFeatureDependencyViolations(r.Spec, features.Items, oldSpec, time.Now()) {
+		allErrors = append(allErrors, field.Invalid(fldPath, r.Spec.Features, violation))
+	}
+	return allErrors
+}
+
+// computeScheduledFeatureDependencyViolations returns the dependency violations of the scheduled activation intents
+// at the current time and at each activateAfter and expiresAt time after it, so that a scheduled activation or
+// expiry cannot leave the dependency of an activated feature deactivated. This is a separate function for easier
+// unit testing.
+func computeScheduledFeatureDependencyViolations(spec FeatureGateSpec, features []Feature, oldSpec *FeatureGateSpec, now time.Time) []string {
+	times := []time.Time{now}
+	for next := spec.NextTransitionAfter(now); next != nil; next = spec.NextTransitionAfter(*next) {
+		times = append(times, *next)
+	}
+
+	reported := sets.String{}
+	var violations []string
+	for i, t := range times {
+		var oldSpecAt *FeatureGateSpec
+		if oldSpec != nil {
+			s := oldSpec.SpecAt(t)
+			oldSpecAt = &s
+		}
+		for _, violation := range computeFeatureDependencyViolations(spec.SpecAt(t), features, oldSpecAt) {
+			if reported.Has(violation) {
+				continue
+			}
+			reported.Insert(violation)
+			if i > 0 {
+				violation = fmt.Sprintf("%s from %s", violation, t.UTC().Format(time.RFC3339))
+			}
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// computeFeatureDependencyViolations returns the unsatisfied dependencies and the conflicts of the features activated by
+// the spec. Violations which already exist with the old spec are not returned, so that a gate can still be updated
+// while the features in the system change. This is a separate function for easier unit testing.
+func computeFeatureDependencyViolations(spec FeatureGateSpec, features []Feature, oldSpec *FeatureGateSpec) []string {
+	violations := featureDependencyViolations(spec, features)
+	if oldSpec != nil {
+		violations = violations.Difference(featureDependencyViolations(*oldSpec, features))
+	}
+	return violations.List()
+}
+
+// featureDependencyViolations returns the unsatisfied dependencies and the conflicts of the activated features.
+func featureDependencyViolations(spec FeatureGateSpec, features []Feature) sets.String {
+	activated := activatedFeatures(spec, features)
+	violations := sets.String{}
+	for i := range features {
+		f := features[i]
+		if !activated.Has(f.Name) {
+			continue
+		}
+		for _, dependency := range f.Spec.DependsOn {
+			if !activated.Has(dependency) {
+				violations.Insert(fmt.Sprintf("feature %q depends on feature %q, which is not activated", f.Name, dependency))
+			}
+		}
+		for _, conflict := range f.Spec.ConflictsWith {
+			if activated.Has(conflict) {
+				violations.Insert(fmt.Sprintf("feature %q conflicts with feature %q, which is activated", f.Name, conflict))
+			}
+		}
+	}
+	return violations
+}
+
+// activatedFeatures returns the discoverable features activated by the spec or by default.
+func activatedFeatures(spec FeatureGateSpec, features []Feature) sets.String {
+	intent := make(map[string]bool)
+	for _, ref := range spec.Features {
+		intent[ref.Name] = ref.Activate
+	}
+	activated := sets.String{}
+	for i := range features {
+		f := features[i]
+		if !f.Spec.Discoverable {
+			continue
+		}
+		activate, ok := intent[f.Name]
+		if !ok {
+			activate = f.Spec.Activated
+		}
+		if activate {
+			activated.Insert(f.Name)
+		}
+	}
+	return activated
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *FeatureGate) ValidateDelete() error {
 	featuregatelog.Info("validate delete", "name", r.Name)
@@ -5,16 +5,22 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func TestValidateFeatureImmutability(t *testing.T) {
@@ -75,6 +81,43 @@ func TestValidateFeatureImmutability(t *testing.T) {
 			}},
 			want: []string{"one", "two", "eleven"},
 		},
+		{
+			description: "Immutable features scheduled without state change",
+			currentFeatures: []Feature{
+				{ObjectMeta: metav1.ObjectMeta{Name: "one"}, Spec: FeatureSpec{Activated: true, Discoverable: true, Immutable: true}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "two"}, Spec: FeatureSpec{Activated: false, Discoverable: true, Immutable: true}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "three"}, Spec: FeatureSpec{Activated: false, Discoverable: true, Immutable: false}},
+			},
+			featureGateSpec: FeatureGateSpec{
+				Features: []FeatureReference{
+					// Immutable, expires later (disallowed).
+					{Name: "one", Activate: true, ExpiresAt: &metav1.Time{Time: time.Now().Add(time.Hour)}},
+					// Immutable, activated later (disallowed).
+					{Name: "two", Activate: false, ActivateAfter: &metav1.Time{Time: time.Now().Add(time.Hour)}},
+					// Non-immutable, activated later (allowed).
+					{Name: "three", Activate: true, ActivateAfter: &metav1.Time{Time: time.Now().Add(time.Hour)}},
+				},
+			},
+			oldObj: &FeatureGate{Status: FeatureGateStatus{
+				ActivatedFeatures:   []string{"one"},
+				DeactivatedFeatures: []string{"two", "three"},
+			}},
+			want: []string{"one", "two"},
+		},
+		{
+			description: "Immutable feature scheduled on creation",
+			currentFeatures: []Feature{
+				{ObjectMeta: metav1.ObjectMeta{Name: "one"}, Spec: FeatureSpec{Activated: true, Discoverable: true, Immutable: true}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "two"}, Spec: FeatureSpec{Activated: false, Discoverable: true, Immutable: true}},
+			},
+			featureGateSpec: FeatureGateSpec{
+				Features: []FeatureReference{
+					{Name: "one", Activate: true, ExpiresAt: &metav1.Time{Time: time.Now().Add(time.Hour)}},
+					{Name: "two", Activate: true},
+				},
+			},
+			want: []string{"one"},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
@@ -86,6 +129,357 @@ func TestValidateFeatureImmutability(t *testing.T) {
 	}
 }
 
+func TestValidateFeatureSchedules(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	before := metav1.NewTime(now.Add(-time.Hour))
+	after := metav1.NewTime(now.Add(time.Hour))
+	gate := &FeatureGate{Spec: FeatureGateSpec{Features: []FeatureReference{
+		{Name: "scheduled", Activate: true, ActivateAfter: &before, ExpiresAt: &after},
+		{Name: "activated", Activate: true, ActivateAfter: &before},
+		{Name: "reversed", Activate: true, ActivateAfter: &after, ExpiresAt: &before},
+		{Name: "empty", Activate: true, ActivateAfter: &after, ExpiresAt: &after},
+	}}}
+
+	errs := gate.validateFeatureSchedules(field.NewPath("spec").Child("features"))
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Field)
+	}
+	want := []string{"spec.features[2].expiresAt", "spec.features[3].expiresAt"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("got invalid fields %v, want %v, diff: %s", got, want, diff)
+	}
+}
+
+func TestComputeFeatureDependencyViolations(t *testing.T) {
+	currentFeatures := []Feature{
+		{ObjectMeta: metav1.ObjectMeta{Name: "base"}, Spec: FeatureSpec{Activated: false, Discoverable: true}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "addon"}, Spec: FeatureSpec{Activated: false, Discoverable: true, DependsOn: []string{"base"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}, Spec: FeatureSpec{Activated: true, Discoverable: true, ConflictsWith: []string{"base"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "hidden"}, Spec: FeatureSpec{Activated: true, Discoverable: false}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "needs-hidden"}, Spec: FeatureSpec{Activated: false, Discoverable: true, DependsOn: []string{"hidden"}}},
+	}
+	testCases := []struct {
+		description     string
+		featureGateSpec FeatureGateSpec
+		oldSpec         *FeatureGateSpec
+		want            []string
+	}{
+		{
+			description:     "Default activation states satisfy the dependencies",
+			featureGateSpec: FeatureGateSpec{},
+		},
+		{
+			description:     "Activating a feature without its dependency",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{{Name: "addon", Activate: true}}},
+			want:            []string{`feature "addon" depends on feature "base", which is not activated`},
+		},
+		{
+			description: "Activating a feature with its dependency and a conflicting feature",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true},
+				{Name: "base", Activate: true},
+			}},
+			want: []string{`feature "legacy" conflicts with feature "base", which is activated`},
+		},
+		{
+			description: "Activating a feature with its dependency and deactivating a conflicting feature",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true},
+				{Name: "base", Activate: true},
+				{Name: "legacy", Activate: false},
+			}},
+		},
+		{
+			description: "Deactivating the dependency of an activated feature",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true},
+				{Name: "base", Activate: false},
+				{Name: "legacy", Activate: false},
+			}},
+			oldSpec: &FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true},
+				{Name: "base", Activate: true},
+				{Name: "legacy", Activate: false},
+			}},
+			want: []string{`feature "addon" depends on feature "base", which is not activated`},
+		},
+		{
+			description:     "Depending on a feature which is not discoverable",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{{Name: "needs-hidden", Activate: true}}},
+			want:            []string{`feature "needs-hidden" depends on feature "hidden", which is not activated`},
+		},
+		{
+			description:     "Violations which already exist are allowed",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{{Name: "addon", Activate: true}, {Name: "unrelated", Activate: true}}},
+			oldSpec:         &FeatureGateSpec{Features: []FeatureReference{{Name: "addon", Activate: true}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := computeFeatureDependencyViolations(tc.featureGateSpec, currentFeatures, tc.oldSpec)
+			if diff := sliceDiffIgnoreOrder(got, tc.want); diff != "" {
+				t.Errorf("got violations %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestComputeFeatureChanges(t *testing.T) {
+	now := metav1.NewTime(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	yes, no := true, false
+	testCases := []struct {
+		description string
+		oldSpec     FeatureGateSpec
+		newSpec     FeatureGateSpec
+		want        []FeatureChange
+	}{
+		{
+			description: "Feature added, changed, unchanged and removed",
+			oldSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "changed", Activate: false},
+				{Name: "unchanged", Activate: true},
+				{Name: "removed", Activate: true},
+			}},
+			newSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "changed", Activate: true},
+				{Name: "unchanged", Activate: true},
+				{Name: "added", Activate: false},
+			}},
+			want: []FeatureChange{
+				{Feature: "changed", User: "admin", Time: now, OldActivate: &no, NewActivate: &yes},
+				{Feature: "added", User: "admin", Time: now, NewActivate: &no},
+				{Feature: "removed", User: "admin", Time: now, OldActivate: &yes},
+			},
+		},
+		{
+			description: "Only the schedule changed",
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true}}},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true, ExpiresAt: &now}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := computeFeatureChanges(tc.oldSpec, tc.newSpec, "admin", now)
+			if diff := cmp.Diff(got, tc.want, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("got changes %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestComputeScheduledFeatureDependencyViolations(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	expiry := metav1.NewTime(now.Add(time.Hour))
+	start := metav1.NewTime(now.Add(2 * time.Hour))
+	currentFeatures := []Feature{
+		{ObjectMeta: metav1.ObjectMeta{Name: "base"}, Spec: FeatureSpec{Activated: false, Discoverable: true}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "addon"}, Spec: FeatureSpec{Activated: false, Discoverable: true, DependsOn: []string{"base"}}},
+	}
+	testCases := []struct {
+		description     string
+		featureGateSpec FeatureGateSpec
+		oldSpec         *FeatureGateSpec
+		want            []string
+	}{
+		{
+			description: "Dependency expiring before the feature",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true},
+				{Name: "base", Activate: true, ExpiresAt: &expiry},
+			}},
+			want: []string{`feature "addon" depends on feature "base", which is not activated from 2022-10-01T13:00:00Z`},
+		},
+		{
+			description: "Dependency and feature expiring together",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true, ExpiresAt: &expiry},
+				{Name: "base", Activate: true, ExpiresAt: &expiry},
+			}},
+		},
+		{
+			description: "Feature activated before its dependency",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true, ActivateAfter: &expiry},
+				{Name: "base", Activate: true, ActivateAfter: &start},
+			}},
+			want: []string{`feature "addon" depends on feature "base", which is not activated from 2022-10-01T13:00:00Z`},
+		},
+		{
+			description: "Violation reported once",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true},
+				{Name: "base", Activate: true, ActivateAfter: &start, ExpiresAt: &metav1.Time{Time: start.Add(time.Hour)}},
+			}},
+			want: []string{`feature "addon" depends on feature "base", which is not activated`},
+		},
+		{
+			description: "Scheduled violations which already exist are allowed",
+			featureGateSpec: FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true},
+				{Name: "base", Activate: true, ExpiresAt: &expiry},
+				{Name: "unrelated", Activate: true},
+			}},
+			oldSpec: &FeatureGateSpec{Features: []FeatureReference{
+				{Name: "addon", Activate: true},
+				{Name: "base", Activate: true, ExpiresAt: &expiry},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := computeScheduledFeatureDependencyViolations(tc.featureGateSpec, currentFeatures, tc.oldSpec, now)
+			if diff := cmp.Diff(got, tc.want, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("got violations %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestFeatureGateRecorder(t *testing.T) {
+	recorded := metav1.NewTime(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	yes, no := true, false
+	inHistory := FeatureChange{Feature: "foo", User: "admin", Time: recorded, OldActivate: &no, NewActivate: &yes}
+	pending := FeatureChange{Feature: "bar", User: "admin", Time: recorded, NewActivate: &yes}
+	forged := FeatureChange{Feature: "foo", User: "someone-else", Time: recorded, OldActivate: &yes, NewActivate: &no}
+
+	oldGate := &FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "gate"},
+		Spec: FeatureGateSpec{Features: []FeatureReference{
+			{Name: "foo", Activate: true},
+			{Name: "bar", Activate: true},
+		}},
+		Status: FeatureGateStatus{History: []FeatureChange{inHistory}},
+	}
+	if err := oldGate.SetPendingFeatureChanges([]FeatureChange{inHistory, pending}); err != nil {
+		t.Fatal(err)
+	}
+	oldRaw, err := json.Marshal(oldGate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		description string
+		newGate     func() *FeatureGate
+		want        []string
+	}{
+		{
+			description: "Client supplied changes are dropped",
+			newGate: func() *FeatureGate {
+				gate := oldGate.DeepCopy()
+				_ = gate.SetPendingFeatureChanges([]FeatureChange{forged})
+				return gate
+			},
+			want: []string{"bar"},
+		},
+		{
+			description: "Removing the annotation only drops the changes in the history",
+			newGate: func() *FeatureGate {
+				gate := oldGate.DeepCopy()
+				_ = gate.SetPendingFeatureChanges(nil)
+				return gate
+			},
+			want: []string{"bar"},
+		},
+		{
+			description: "Spec changes are recorded after the pending changes",
+			newGate: func() *FeatureGate {
+				gate := oldGate.DeepCopy()
+				gate.Annotations = nil
+				gate.Spec.Features[0].Activate = false
+				return gate
+			},
+			want: []string{"bar", "foo"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			gate := tc.newGate()
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo:  authenticationv1.UserInfo{Username: "admin"},
+				OldObject: runtime.RawExtension{Raw: oldRaw},
+			}})
+			if err := (&featureGateRecorder{}).Default(ctx, gate); err != nil {
+				t.Fatal(err)
+			}
+			changes, err := gate.PendingFeatureChanges()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, change := range changes {
+				if change.User != "admin" {
+					t.Errorf("got change %v by %s, want admin", change.Feature, change.User)
+				}
+				got = append(got, change.Feature)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("got pending changes of %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+
+	// Once the controller moved the pending changes to the history, it can remove the annotation.
+	recordedGate := oldGate.DeepCopy()
+	recordedGate.Status.History = append(recordedGate.Status.History, pending)
+	recordedRaw, err := json.Marshal(recordedGate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gate := recordedGate.DeepCopy()
+	_ = gate.SetPendingFeatureChanges(nil)
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UserInfo:  authenticationv1.UserInfo{Username: FeatureGateControllerUser},
+		OldObject: runtime.RawExtension{Raw: recordedRaw},
+	}})
+	if err := (&featureGateRecorder{}).Default(ctx, gate); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := gate.Annotations[PendingFeatureChangesAnnotation]; ok {
+		t.Errorf("got annotation %s, want it removed", gate.Annotations[PendingFeatureChangesAnnotation])
+	}
+}
+
+func TestFeatureGateSpecAt(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	before := metav1.NewTime(now.Add(-time.Hour))
+	after := metav1.NewTime(now.Add(time.Hour))
+	later := metav1.NewTime(now.Add(2 * time.Hour))
+	spec := FeatureGateSpec{Features: []FeatureReference{
+		{Name: "deactivated", Activate: false, ActivateAfter: &before},
+		{Name: "activated", Activate: true},
+		{Name: "started", Activate: true, ActivateAfter: &before, ExpiresAt: &later},
+		{Name: "pending", Activate: true, ActivateAfter: &later},
+		{Name: "expired", Activate: true, ExpiresAt: &before},
+		{Name: "expiring", Activate: true, ExpiresAt: &after},
+	}}
+
+	got := spec.SpecAt(now)
+	want := FeatureGateSpec{Features: []FeatureReference{
+		{Name: "deactivated", Activate: false},
+		{Name: "activated", Activate: true},
+		{Name: "started", Activate: true},
+		{Name: "pending", Activate: false},
+		{Name: "expired", Activate: false},
+		{Name: "expiring", Activate: true},
+	}}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("got spec %v, want %v, diff: %s", got, want, diff)
+	}
+	if spec.Features[2].ActivateAfter == nil {
+		t.Errorf("SpecAt modified the spec")
+	}
+
+	if next := spec.NextTransitionAfter(now); next == nil || !next.Equal(after.Time) {
+		t.Errorf("got next transition %v, want %v", next, after.Time)
+	}
+	if next := spec.NextTransitionAfter(later.Time); next != nil {
+		t.Errorf("got next transition %v, want none", next)
+	}
+}
+
 func TestNamespaceConflicts(t *testing.T) {
 	scheme, err := getScheme()
 	if err != nil {
@@ -17,7 +17,7 @@ func (in *Feature) DeepCopyInto(out *Feature) {
 	*out = *in
 	out.Status = in.Status
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.TypeMeta = in.TypeMeta
 }
 
@@ -39,6 +39,32 @@ func (in *Feature) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureChange) DeepCopyInto(out *FeatureChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.OldActivate != nil {
+		in, out := &in.OldActivate, &out.OldActivate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NewActivate != nil {
+		in, out := &in.NewActivate, &out.NewActivate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureChange.
+func (in *FeatureChange) DeepCopy() *FeatureChange {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGate) DeepCopyInto(out *FeatureGate) {
 	*out = *in
@@ -105,7 +131,9 @@ func (in *FeatureGateSpec) DeepCopyInto(out *FeatureGateSpec) {
 	if in.Features != nil {
 		in, out := &in.Features, &out.Features
 		*out = make([]FeatureReference, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -142,6 +170,13 @@ func (in *FeatureGateStatus) DeepCopyInto(out *FeatureGateStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]FeatureChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGateStatus.
@@ -189,6 +224,14 @@ func (in *FeatureList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureReference) DeepCopyInto(out *FeatureReference) {
 	*out = *in
+	if in.ActivateAfter != nil {
+		in, out := &in.ActivateAfter, &out.ActivateAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureReference.
@@ -204,6 +247,16 @@ func (in *FeatureReference) DeepCopy() *FeatureReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
 	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConflictsWith != nil {
+		in, out := &in.ConflictsWith, &out.ConflictsWith
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
@@ -21,6 +21,20 @@ type CapabilitySpec struct {
 	// +listType=map
 	// +listMapKey=name
 	Queries []Query `json:"queries"`
+	// ResyncInterval is the interval at which the queries are evaluated again, in addition to
+	// the evaluations triggered by changes of the CRDs and objects the queries depend on.
+	// When this field is not specified, the queries are only evaluated on changes, unless they
+	// depend on changes which are not watched: the queried objects of the kinds listed in
+	// status.unwatchedKinds, or the clusters selected by the clusterSelector. Such queries are
+	// evaluated again every 10 minutes.
+	// +optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+	// ClusterSelector selects the Cluster API clusters in the namespace of this resource the queries
+	// are evaluated on, instead of the cluster this resource is in. The queries are evaluated with the
+	// kubeconfig secret of each cluster, which is read with the service account. An empty selector
+	// selects all the clusters of the namespace.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
 }
 
 // Query is a logical grouping of GVR, Object and PartialSchema queries.
@@ -63,6 +77,31 @@ type QueryObject struct {
 	// The query succeeds only if all the annotations specified do not exist.
 	// +optional
 	WithoutAnnotations map[string]string `json:"withoutAnnotations,omitempty"`
+	// FieldPredicates are comparisons of the values of fields of the object.
+	// The query succeeds only if all the predicates hold.
+	// +optional
+	FieldPredicates []FieldPredicate `json:"fieldPredicates,omitempty"`
+}
+
+// FieldPredicateOperator is the comparison a FieldPredicate applies to the values of a field.
+// +kubebuilder:validation:Enum=Exists;DoesNotExist;Equals;NotEquals;GreaterThan;GreaterThanOrEqual;LessThan;LessThanOrEqual
+type FieldPredicateOperator string
+
+// FieldPredicate compares the values a JSONPath expression selects in an object, for example
+// {.status.readyReplicas} GreaterThanOrEqual 1, or {.spec.versions[?(@.served==true)].name} Equals v1beta1.
+// Equals and the numeric operators hold if one of the selected values matches, NotEquals holds if none does.
+type FieldPredicate struct {
+	// Path is a JSONPath expression selecting the values to compare, the enclosing braces are optional.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	Path string `json:"path"`
+	// Operator is the comparison to apply.
+	// +kubebuilder:validation:Required
+	Operator FieldPredicateOperator `json:"operator"`
+	// Value is compared to the selected values. It must be a number for the GreaterThan, GreaterThanOrEqual,
+	// LessThan and LessThanOrEqual operators and is ignored by the Exists and DoesNotExist operators.
+	// +optional
+	Value string `json:"value,omitempty"`
 }
 
 // QueryGVR queries for an API group with the optional ability to check for API versions and resource.
@@ -101,6 +140,54 @@ type CapabilityStatus struct {
 	// +listType=map
 	// +listMapKey=name
 	Results []Result `json:"results"`
+	// ObservedGeneration is the generation of the spec the results were evaluated for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastEvaluatedTime is the time the queries were last evaluated.
+	// +optional
+	LastEvaluatedTime *metav1.Time `json:"lastEvaluatedTime,omitempty"`
+	// UnwatchedKinds lists the kinds of the queried objects whose changes are not watched, because
+	// they are not served or the controller is not allowed to list and watch them, formatted as
+	// kind.version.group. The queries are evaluated again periodically instead, see spec.resyncInterval.
+	// +optional
+	UnwatchedKinds []string `json:"unwatchedKinds,omitempty"`
+	// ClusterResults represents the results of the queries on each cluster selected by the clusterSelector.
+	// +listType=map
+	// +listMapKey=clusterName
+	// +optional
+	ClusterResults []ClusterResult `json:"clusterResults,omitempty"`
+	// FleetResults lists, for each query in spec, the selected clusters on which the query succeeded.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	FleetResults []FleetResult `json:"fleetResults,omitempty"`
+}
+
+// ClusterResult represents the results of the queries on a cluster selected by the clusterSelector.
+type ClusterResult struct {
+	// ClusterName is the name of the cluster.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	ClusterName string `json:"clusterName"`
+	// Results represents the results of all the queries specified in the spec on the cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Results []Result `json:"results,omitempty"`
+	// Error is the reason the queries could not be evaluated on the cluster.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// FleetResult lists the clusters on which a query succeeded.
+type FleetResult struct {
+	// Name is the name of the query in spec.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+	// Clusters are the names of the clusters on which all the GVR, Object and PartialSchema queries of the query were found.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
 }
 
 // QueryResult represents the result of a single query.
@@ -9,6 +9,7 @@
 package v1alpha2
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -81,6 +82,16 @@ func (in *CapabilitySpec) DeepCopyInto(out *CapabilitySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapabilitySpec.
@@ -103,6 +114,29 @@ func (in *CapabilityStatus) DeepCopyInto(out *CapabilityStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastEvaluatedTime != nil {
+		in, out := &in.LastEvaluatedTime, &out.LastEvaluatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UnwatchedKinds != nil {
+		in, out := &in.UnwatchedKinds, &out.UnwatchedKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterResults != nil {
+		in, out := &in.ClusterResults, &out.ClusterResults
+		*out = make([]ClusterResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FleetResults != nil {
+		in, out := &in.FleetResults, &out.FleetResults
+		*out = make([]FleetResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapabilityStatus.
@@ -115,6 +149,63 @@ func (in *CapabilityStatus) DeepCopy() *CapabilityStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResult) DeepCopyInto(out *ClusterResult) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]Result, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResult.
+func (in *ClusterResult) DeepCopy() *ClusterResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldPredicate) DeepCopyInto(out *FieldPredicate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldPredicate.
+func (in *FieldPredicate) DeepCopy() *FieldPredicate {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldPredicate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetResult) DeepCopyInto(out *FleetResult) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetResult.
+func (in *FleetResult) DeepCopy() *FleetResult {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Query) DeepCopyInto(out *Query) {
 	*out = *in
@@ -187,6 +278,11 @@ func (in *QueryObject) DeepCopyInto(out *QueryObject) {
 			(*out)[key] = val
 		}
 	}
+	if in.FieldPredicates != nil {
+		in, out := &in.FieldPredicates, &out.FieldPredicates
+		*out = make([]FieldPredicate, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryObject.
@@ -0,0 +1,94 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package certificates provides helper functions to read the expiry of the certificates of Cluster API clusters.
+package certificates
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// KubeadmCertificatesValidity is the validity of the certificates kubeadm issues to the control plane nodes
+	KubeadmCertificatesValidity = 365 * 24 * time.Hour
+
+	// MachineCertificatesExpiryAnnotation records the expiry date, in RFC3339 format, of the kubeadm certificates of
+	// a control plane machine. It is set by users renewing the certificates on the nodes.
+	MachineCertificatesExpiryAnnotation = "machine.cluster.x-k8s.io/certificates-expiry"
+)
+
+// CertificateAuthority is a certificate authority of a cluster, stored in the <cluster name>-<suffix> secret
+type CertificateAuthority struct {
+	SecretSuffix string
+	Description  string
+}
+
+// SecretName returns the name of the secret of the certificate authority of a cluster
+func (ca CertificateAuthority) SecretName(clusterName string) string {
+	return clusterName + "-" + ca.SecretSuffix
+}
+
+// CertificateAuthorities are the certificate authorities of a cluster. Rolling out the control plane does not
+// renew them.
+var CertificateAuthorities = []CertificateAuthority{
+	{SecretSuffix: "ca", Description: "Kubernetes CA"},
+	{SecretSuffix: "etcd", Description: "etcd CA"},
+	{SecretSuffix: "proxy", Description: "front-proxy CA"},
+}
+
+// CertificateExpiry returns the expiry date of a PEM encoded certificate
+func CertificateExpiry(data []byte) (time.Time, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}, errors.New("no PEM encoded certificate")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return certificate.NotAfter, nil
+}
+
+// AnnotatedMachineCertificatesExpiry returns the expiry date of the kubeadm certificates of a control plane machine
+// read from its MachineCertificatesExpiryAnnotation, and false if the machine does not have the annotation.
+func AnnotatedMachineCertificatesExpiry(machine *clusterv1.Machine) (time.Time, bool, error) {
+	value, ok := machine.Annotations[MachineCertificatesExpiryAnnotation]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, errors.Wrapf(err, "invalid %s annotation of machine %s", MachineCertificatesExpiryAnnotation, machine.Name)
+	}
+	return expiresAt, true, nil
+}
+
+// EstimatedMachineCertificatesExpiry estimates the expiry date of the kubeadm certificates of a control plane machine
+// from the creation of its bootstrap config, as kubeadm issues the certificates when the node is bootstrapped, or from
+// the creation of the machine if it has no bootstrap config. The bootstrap config, of any kind, is read with
+// getBootstrapConfig, which is passed an object with the API version, kind, namespace and name of the config.
+func EstimatedMachineCertificatesExpiry(machine *clusterv1.Machine, getBootstrapConfig func(config *unstructured.Unstructured) error) (time.Time, error) {
+	bootstrapTime := machine.CreationTimestamp.Time
+	if configRef := machine.Spec.Bootstrap.ConfigRef; configRef != nil {
+		config := &unstructured.Unstructured{}
+		config.SetAPIVersion(configRef.APIVersion)
+		config.SetKind(configRef.Kind)
+		config.SetNamespace(machine.Namespace)
+		config.SetName(configRef.Name)
+		err := getBootstrapConfig(config)
+		switch {
+		case err == nil:
+			bootstrapTime = config.GetCreationTimestamp().Time
+		case !apierrors.IsNotFound(err):
+			return time.Time{}, errors.Wrapf(err, "unable to get the bootstrap config of machine %s", machine.Name)
+		}
+	}
+	return bootstrapTime.Add(KubeadmCertificatesValidity), nil
+}
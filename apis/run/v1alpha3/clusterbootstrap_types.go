@@ -18,6 +18,9 @@ import (
 // +kubebuilder:printcolumn:name="Kapp",type="string",JSONPath=".spec.kapp.refName",description="Kapp package name and version"
 // +kubebuilder:printcolumn:name="Additional Packages",type="string",JSONPath=".spec.additionalPackages[*].refName",description="Additional packages",priority=10
 // +kubebuilder:printcolumn:name="Resolved_TKR",type="string",JSONPath=".status.resolvedTKR",description="Resolved TKR name"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether all packages are reconciled successfully"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="Reason for the ClusterBootstrap not being ready"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].message",description="Packages blocking the ClusterBootstrap",priority=10
 
 // ClusterBootstrap is the Schema for the ClusterBootstraps API
 type ClusterBootstrap struct {
@@ -33,6 +36,40 @@ type ClusterBootstrapStatus struct {
 	ResolvedTKR string `json:"resolvedTKR,omitempty"`
 
 	Conditions clusterapiv1beta1.Conditions `json:"conditions,omitempty"`
+
+	// Packages is the reconciliation status of each package installed by the ClusterBootstrap
+	// +optional
+	Packages []ClusterBootstrapPackageStatus `json:"packages,omitempty"`
+}
+
+// ClusterBootstrapPackageStatus defines the observed state of a package installed by the ClusterBootstrap
+type ClusterBootstrapPackageStatus struct {
+	// RefName is the name of the Package referenced by the ClusterBootstrap
+	RefName string `json:"refName"`
+
+	// Version is the resolved version of the Package
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Phase is the summarized condition type of the PackageInstall, e.g. Reconciling, ReconcileSucceeded or ReconcileFailed
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// LastTransitionTime is the last time the phase changed
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// ReconcileDuration is the time the last reconciliation of the PackageInstall took to complete
+	// +optional
+	ReconcileDuration *metav1.Duration `json:"reconcileDuration,omitempty"`
+
+	// Message is an excerpt of the PackageInstall error message, if any
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ValuesSecretHash is the hash of the data values secret of the PackageInstall
+	// +optional
+	ValuesSecretHash string `json:"valuesSecretHash,omitempty"`
 }
 
 // GetConditions returns the set of conditions for this object. implements Setter interface
@@ -0,0 +1,150 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterBootstrapRolloutPromoteAnnotation is the annotation used to promote a paused ClusterBootstrapRollout.
+	// Any new value of the annotation resumes the rollout past the canary clusters and the failures observed so far.
+	ClusterBootstrapRolloutPromoteAnnotation = "run.tanzu.vmware.com/rollout-promote"
+
+	// ClusterBootstrapRolloutAbortAnnotation is the annotation used to abort a ClusterBootstrapRollout.
+	// No more clusters are admitted once the rollout is aborted.
+	ClusterBootstrapRolloutAbortAnnotation = "run.tanzu.vmware.com/rollout-abort"
+)
+
+// ClusterBootstrapRolloutPhase is the phase of a ClusterBootstrapRollout
+type ClusterBootstrapRolloutPhase string
+
+const (
+	// RolloutPhaseProgressing means clusters are being admitted to the new ClusterBootstrapTemplate
+	RolloutPhaseProgressing ClusterBootstrapRolloutPhase = "Progressing"
+
+	// RolloutPhasePaused means the rollout waits to be promoted, either after the canary clusters or after a failure
+	RolloutPhasePaused ClusterBootstrapRolloutPhase = "Paused"
+
+	// RolloutPhaseCompleted means all the selected clusters have been updated successfully
+	RolloutPhaseCompleted ClusterBootstrapRolloutPhase = "Completed"
+
+	// RolloutPhaseAborted means the rollout has been aborted and no more clusters are admitted
+	RolloutPhaseAborted ClusterBootstrapRolloutPhase = "Aborted"
+)
+
+// ClusterBootstrapRolloutSpec defines the desired state of ClusterBootstrapRollout
+type ClusterBootstrapRolloutSpec struct {
+	// TKR is the name of the TKR whose ClusterBootstrapTemplate is rolled out
+	TKR string `json:"tkr"`
+
+	// ClusterSelector selects the clusters governed by the rollout. All clusters upgrading to the TKR are selected when empty.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// BatchSize is the maximum number of clusters whose ClusterBootstrap is being updated at the same time
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	// OrderByLabel is the key of a cluster label whose value orders the clusters; clusters are admitted in ascending
+	// order of the label value. Clusters without the label are admitted last.
+	// +optional
+	OrderByLabel string `json:"orderByLabel,omitempty"`
+
+	// Canary is the number of clusters updated first. The rollout pauses once the canary clusters are ready, until it is promoted.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Canary int32 `json:"canary,omitempty"`
+
+	// PauseOnFailure pauses the rollout when the PackageInstalls of an updated cluster fail to reconcile. Defaults to true.
+	// +optional
+	// +kubebuilder:default:=true
+	PauseOnFailure *bool `json:"pauseOnFailure,omitempty"`
+}
+
+// ClusterBootstrapRolloutStatus defines the observed state of ClusterBootstrapRollout
+type ClusterBootstrapRolloutStatus struct {
+	// Phase is the phase of the rollout
+	// +optional
+	Phase ClusterBootstrapRolloutPhase `json:"phase,omitempty"`
+
+	// Message gives details about the phase of the rollout
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// AdmittedClusters are the clusters, in <namespace>/<name> form, allowed to update their ClusterBootstrap to the TKR
+	// +optional
+	AdmittedClusters []string `json:"admittedClusters,omitempty"`
+
+	// ReadyClusters are the admitted clusters whose packages have been reconciled successfully
+	// +optional
+	ReadyClusters []string `json:"readyClusters,omitempty"`
+
+	// FailedClusters are the admitted clusters whose packages failed to reconcile
+	// +optional
+	FailedClusters []string `json:"failedClusters,omitempty"`
+
+	// CanaryPromoted tells whether the rollout has been promoted past the canary clusters
+	// +optional
+	CanaryPromoted bool `json:"canaryPromoted,omitempty"`
+
+	// AcceptedFailures is the number of failed clusters accepted by the last promotion
+	// +optional
+	AcceptedFailures int32 `json:"acceptedFailures,omitempty"`
+
+	// ObservedPromotion is the value of the promote annotation last acted upon
+	// +optional
+	ObservedPromotion string `json:"observedPromotion,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clusterbootstraprollouts,shortName=cbr,scope=Namespaced
+// +kubebuilder:printcolumn:name="TKR",type="string",JSONPath=".spec.tkr",description="TKR being rolled out"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Rollout phase"
+// +kubebuilder:printcolumn:name="Batch",type="integer",JSONPath=".spec.batchSize",description="Batch size"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Rollout details",priority=10
+
+// ClusterBootstrapRollout is the Schema for the ClusterBootstrapRollouts API.
+// It controls the order in which clusters update their ClusterBootstrap to the ClusterBootstrapTemplate of a new TKR.
+// A cluster upgraded to the TKR is paused by the cluster pause webhook until its ClusterBootstrap has been updated, so
+// clusters waiting for admission also stay paused for Cluster API: their Kubernetes upgrade is staged by the rollout too.
+type ClusterBootstrapRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterBootstrapRolloutSpec   `json:"spec"`
+	Status ClusterBootstrapRolloutStatus `json:"status,omitempty"`
+}
+
+// IsAdmitted tells whether the cluster identified by namespace and name is admitted by the rollout
+func (r *ClusterBootstrapRollout) IsAdmitted(namespace, name string) bool {
+	key := namespace + "/" + name
+	for _, admitted := range r.Status.AdmittedClusters {
+		if admitted == key {
+			return true
+		}
+	}
+	return false
+}
+
+// PausesOnFailure tells whether the rollout pauses when an admitted cluster fails, which is the default
+func (r *ClusterBootstrapRollout) PausesOnFailure() bool {
+	return r.Spec.PauseOnFailure == nil || *r.Spec.PauseOnFailure
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterBootstrapRolloutList contains a list of ClusterBootstrapRollout
+type ClusterBootstrapRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBootstrapRollout `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterBootstrapRollout{}, &ClusterBootstrapRolloutList{})
+}
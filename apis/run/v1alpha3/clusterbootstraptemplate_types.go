@@ -49,6 +49,10 @@ type ClusterBootstrapPackage struct {
 	RefName string `json:"refName"`
 	// +optional
 	ValuesFrom *ValuesFrom `json:"valuesFrom,omitempty"`
+	// DependsOn lists the packages that must be reconciled successfully before this package is installed on the cluster.
+	// A package is named after its refName in the Package CR, e.g. cert-manager.tanzu.vmware.com, and must be part of the same ClusterBootstrap.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 // ValuesFrom specifies how values for package install are retrieved from
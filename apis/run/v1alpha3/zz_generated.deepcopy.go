@@ -9,7 +9,8 @@
 package v1alpha3
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -84,6 +85,11 @@ func (in *ClusterBootstrapPackage) DeepCopyInto(out *ClusterBootstrapPackage) {
 		in, out := &in.ValuesFrom, &out.ValuesFrom
 		*out = (*in).DeepCopy()
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapPackage.
@@ -96,6 +102,141 @@ func (in *ClusterBootstrapPackage) DeepCopy() *ClusterBootstrapPackage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapPackageStatus) DeepCopyInto(out *ClusterBootstrapPackageStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.ReconcileDuration != nil {
+		in, out := &in.ReconcileDuration, &out.ReconcileDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapPackageStatus.
+func (in *ClusterBootstrapPackageStatus) DeepCopy() *ClusterBootstrapPackageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapPackageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRollout) DeepCopyInto(out *ClusterBootstrapRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRollout.
+func (in *ClusterBootstrapRollout) DeepCopy() *ClusterBootstrapRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBootstrapRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutList) DeepCopyInto(out *ClusterBootstrapRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterBootstrapRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutList.
+func (in *ClusterBootstrapRolloutList) DeepCopy() *ClusterBootstrapRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBootstrapRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutSpec) DeepCopyInto(out *ClusterBootstrapRolloutSpec) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PauseOnFailure != nil {
+		in, out := &in.PauseOnFailure, &out.PauseOnFailure
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutSpec.
+func (in *ClusterBootstrapRolloutSpec) DeepCopy() *ClusterBootstrapRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrapRolloutStatus) DeepCopyInto(out *ClusterBootstrapRolloutStatus) {
+	*out = *in
+	if in.AdmittedClusters != nil {
+		in, out := &in.AdmittedClusters, &out.AdmittedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadyClusters != nil {
+		in, out := &in.ReadyClusters, &out.ReadyClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedClusters != nil {
+		in, out := &in.FailedClusters, &out.FailedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapRolloutStatus.
+func (in *ClusterBootstrapRolloutStatus) DeepCopy() *ClusterBootstrapRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrapRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBootstrapStatus) DeepCopyInto(out *ClusterBootstrapStatus) {
 	*out = *in
@@ -106,6 +247,13 @@ func (in *ClusterBootstrapStatus) DeepCopyInto(out *ClusterBootstrapStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]ClusterBootstrapPackageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrapStatus.
@@ -604,12 +752,12 @@ func (in *TanzuKubernetesReleaseSpec) DeepCopyInto(out *TanzuKubernetesReleaseSp
 	in.Kubernetes.DeepCopyInto(&out.Kubernetes)
 	if in.OSImages != nil {
 		in, out := &in.OSImages, &out.OSImages
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.BootstrapPackages != nil {
 		in, out := &in.BootstrapPackages, &out.BootstrapPackages
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -19,6 +19,7 @@ import (
 
 // Object represents any runtime.Object that could exist on a cluster, with ability to specify:
 // WithAnnotations()
+// WithFieldPredicates()
 // WithLabels()
 // WithConditions()
 func Object(queryName string, obj *corev1.ObjectReference) *QueryObject {
@@ -34,7 +35,10 @@ type QueryObject struct {
 	name        string
 	object      *corev1.ObjectReference
 	annotations []resourceAnnotation
+	predicates  []FieldPredicate
 	presence    bool
+	// reason explains why the last run did not match.
+	reason string
 	//	conditions []resourceCondition
 }
 
@@ -69,8 +73,15 @@ func (q *QueryObject) WithAnnotations(a map[string]string) *QueryObject {
 	return q
 }
 
+// WithFieldPredicates matches the values of fields of a resource
+func (q *QueryObject) WithFieldPredicates(predicates ...FieldPredicate) *QueryObject {
+	q.predicates = append(q.predicates, predicates...)
+	return q
+}
+
 // Run the object discovery
 func (q *QueryObject) Run(config *clusterQueryClientConfig) (bool, error) {
+	q.reason = ""
 	groupResources, err := restmapper.GetAPIGroupResources(config.discoveryClientset)
 	if err != nil {
 		return false, err
@@ -100,9 +111,21 @@ func (q *QueryObject) QueryObjectExists(resources []*restmapper.APIGroupResource
 	}
 
 	if !q.checkAnnotations(u) {
+		q.reason = "annotations=unmatched"
 		return false, nil
 	}
 
+	for _, p := range q.predicates {
+		ok, reason, err := p.Evaluate(u.Object)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			q.reason = reason
+			return false, nil
+		}
+	}
+
 	return true, nil
 }
 
@@ -153,7 +176,11 @@ func (q *QueryObject) checkAnnotations(u *unstructured.Unstructured) bool {
 
 // Reason for failures, in a standard structure
 func (q *QueryObject) Reason() string {
-	return fmt.Sprintf("kind=%s status=unmatched presence=%t", q.object.Kind, q.presence)
+	reason := fmt.Sprintf("kind=%s status=unmatched presence=%t", q.object.Kind, q.presence)
+	if q.reason != "" {
+		reason += " " + q.reason
+	}
+	return reason
 }
 
 func (q *QueryObject) annotationsMap(presence bool) map[string]string {
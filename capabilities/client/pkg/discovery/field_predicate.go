@@ -0,0 +1,164 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// FieldPredicateOperator is the comparison a FieldPredicate applies to the selected values.
+type FieldPredicateOperator string
+
+const (
+	// FieldExists succeeds if the path selects at least one value.
+	FieldExists FieldPredicateOperator = "Exists"
+	// FieldDoesNotExist succeeds if the path selects no value.
+	FieldDoesNotExist FieldPredicateOperator = "DoesNotExist"
+	// FieldEquals succeeds if one of the selected values is equal to the value.
+	FieldEquals FieldPredicateOperator = "Equals"
+	// FieldNotEquals succeeds if none of the selected values is equal to the value.
+	FieldNotEquals FieldPredicateOperator = "NotEquals"
+	// FieldGreaterThan succeeds if one of the selected values is a number greater than the value.
+	FieldGreaterThan FieldPredicateOperator = "GreaterThan"
+	// FieldGreaterThanOrEqual succeeds if one of the selected values is a number greater than or equal to the value.
+	FieldGreaterThanOrEqual FieldPredicateOperator = "GreaterThanOrEqual"
+	// FieldLessThan succeeds if one of the selected values is a number less than the value.
+	FieldLessThan FieldPredicateOperator = "LessThan"
+	// FieldLessThanOrEqual succeeds if one of the selected values is a number less than or equal to the value.
+	FieldLessThanOrEqual FieldPredicateOperator = "LessThanOrEqual"
+)
+
+// FieldPredicate compares the values a JSONPath expression selects in an object, e.g.
+// {.status.readyReplicas} GreaterThanOrEqual 1, or {.spec.versions[?(@.served==true)].name} Equals v1beta1.
+type FieldPredicate struct {
+	// Path is a JSONPath expression, the enclosing braces are optional.
+	Path     string
+	Operator FieldPredicateOperator
+	// Value is compared to the selected values, it is ignored by the Exists and DoesNotExist operators.
+	Value string
+}
+
+// String returns a readable form of the predicate.
+func (p FieldPredicate) String() string {
+	switch p.Operator {
+	case FieldExists, FieldDoesNotExist:
+		return fmt.Sprintf("%s %s", p.Path, p.Operator)
+	default:
+		return fmt.Sprintf("%s %s %q", p.Path, p.Operator, p.Value)
+	}
+}
+
+// Validate returns an error if the path is not a valid JSONPath expression, the operator is unknown
+// or the value is missing or is not a number for a numeric comparison.
+func (p FieldPredicate) Validate() error {
+	if _, err := p.parse(); err != nil {
+		return err
+	}
+	switch p.Operator {
+	case FieldExists, FieldDoesNotExist:
+		return nil
+	case FieldEquals, FieldNotEquals:
+		return nil
+	case FieldGreaterThan, FieldGreaterThanOrEqual, FieldLessThan, FieldLessThanOrEqual:
+		if _, err := strconv.ParseFloat(p.Value, 64); err != nil {
+			return fmt.Errorf("operator %s requires a numeric value, got %q", p.Operator, p.Value)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown operator %q", p.Operator)
+	}
+}
+
+func (p FieldPredicate) parse() (*jsonpath.JSONPath, error) {
+	path := strings.TrimSpace(p.Path)
+	if path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	j := jsonpath.New("predicate").AllowMissingKeys(true)
+	if err := j.Parse(path); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath expression %q: %w", p.Path, err)
+	}
+	return j, nil
+}
+
+// Evaluate evaluates the predicate against an object. When the predicate does not hold, it returns
+// false and a reason describing the selected values.
+func (p FieldPredicate) Evaluate(obj map[string]interface{}) (ok bool, reason string, err error) {
+	if err := p.Validate(); err != nil {
+		return false, "", err
+	}
+	j, _ := p.parse()
+	results, err := j.FindResults(obj)
+	if err != nil {
+		return false, "", fmt.Errorf("unable to evaluate JSONPath expression %q: %w", p.Path, err)
+	}
+	var values []string
+	for _, result := range results {
+		for _, v := range result {
+			if v.IsValid() && v.CanInterface() && v.Interface() != nil {
+				values = append(values, fmt.Sprint(v.Interface()))
+			}
+		}
+	}
+
+	if p.matches(values) {
+		return true, "", nil
+	}
+	if len(values) == 0 {
+		return false, fmt.Sprintf("field %s: no value selected", p), nil
+	}
+	return false, fmt.Sprintf("field %s: selected values %v", p, values), nil
+}
+
+func (p FieldPredicate) matches(values []string) bool {
+	switch p.Operator {
+	case FieldExists:
+		return len(values) > 0
+	case FieldDoesNotExist:
+		return len(values) == 0
+	case FieldNotEquals:
+		for _, v := range values {
+			if v == p.Value {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if p.compare(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare compares a single value, non-numeric values never satisfy numeric comparisons.
+func (p FieldPredicate) compare(v string) bool {
+	if p.Operator == FieldEquals {
+		return v == p.Value
+	}
+	got, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return false
+	}
+	want, _ := strconv.ParseFloat(p.Value, 64)
+	switch p.Operator {
+	case FieldGreaterThan:
+		return got > want
+	case FieldGreaterThanOrEqual:
+		return got >= want
+	case FieldLessThan:
+		return got < want
+	case FieldLessThanOrEqual:
+		return got <= want
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"strings"
+	"testing"
+)
+
+var deployment = map[string]interface{}{
+	"metadata": map[string]interface{}{"name": "foo"},
+	"spec": map[string]interface{}{
+		"versions": []interface{}{
+			map[string]interface{}{"name": "v1alpha4", "served": false},
+			map[string]interface{}{"name": "v1beta1", "served": true},
+		},
+	},
+	"status": map[string]interface{}{"readyReplicas": int64(2)},
+	"data":   map[string]interface{}{"provider": "vsphere"},
+}
+
+func TestFieldPredicateEvaluate(t *testing.T) {
+	testCases := []struct {
+		description string
+		predicate   FieldPredicate
+		want        bool
+		reason      string
+		err         string
+	}{
+		{
+			description: "numeric comparison holds",
+			predicate:   FieldPredicate{Path: "{.status.readyReplicas}", Operator: FieldGreaterThanOrEqual, Value: "1"},
+			want:        true,
+		},
+		{
+			description: "numeric comparison fails",
+			predicate:   FieldPredicate{Path: ".status.readyReplicas", Operator: FieldGreaterThan, Value: "2"},
+			want:        false,
+			reason:      "selected values [2]",
+		},
+		{
+			description: "string equality",
+			predicate:   FieldPredicate{Path: ".data.provider", Operator: FieldEquals, Value: "vsphere"},
+			want:        true,
+		},
+		{
+			description: "one of the filtered values is equal",
+			predicate:   FieldPredicate{Path: "{.spec.versions[?(@.served==true)].name}", Operator: FieldEquals, Value: "v1beta1"},
+			want:        true,
+		},
+		{
+			description: "none of the filtered values is equal",
+			predicate:   FieldPredicate{Path: "{.spec.versions[?(@.served==true)].name}", Operator: FieldNotEquals, Value: "v1beta1"},
+			want:        false,
+		},
+		{
+			description: "missing field",
+			predicate:   FieldPredicate{Path: ".status.phase", Operator: FieldExists},
+			want:        false,
+			reason:      "no value selected",
+		},
+		{
+			description: "absent field",
+			predicate:   FieldPredicate{Path: ".status.phase", Operator: FieldDoesNotExist},
+			want:        true,
+		},
+		{
+			description: "non-numeric value of a numeric comparison",
+			predicate:   FieldPredicate{Path: ".data.provider", Operator: FieldLessThan, Value: "1"},
+			want:        false,
+		},
+		{
+			description: "invalid path",
+			predicate:   FieldPredicate{Path: "{.status[", Operator: FieldExists},
+			err:         "invalid JSONPath expression",
+		},
+		{
+			description: "numeric comparison with a non-numeric value",
+			predicate:   FieldPredicate{Path: ".status.readyReplicas", Operator: FieldGreaterThan, Value: "one"},
+			err:         "requires a numeric value",
+		},
+		{
+			description: "unknown operator",
+			predicate:   FieldPredicate{Path: ".status.readyReplicas", Operator: "Matches", Value: "1"},
+			err:         "unknown operator",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, reason, err := tc.predicate.Evaluate(deployment)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("error=%v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got=%t, want=%t", got, tc.want)
+			}
+			if !strings.Contains(reason, tc.reason) {
+				t.Errorf("reason=%q, want %q", reason, tc.reason)
+			}
+		})
+	}
+}
+
+func TestObjectQueryWithFieldPredicates(t *testing.T) {
+	c, err := queryClientWithResourcesAndObjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := Object("carpName", &carp).WithFieldPredicates(FieldPredicate{Path: ".metadata.name", Operator: FieldEquals, Value: "test14"})
+	phase := Object("carpPhase", &carp).WithFieldPredicates(FieldPredicate{Path: ".status.phase", Operator: FieldEquals, Value: "Running"})
+	query := c.Query(name, phase)
+	found, err := query.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Error("expected the phase predicate to fail")
+	}
+	if result := query.Results().ForQuery("carpName"); result == nil || !result.Found {
+		t.Errorf("expected the name predicate to succeed, got %v", result)
+	}
+	result := query.Results().ForQuery("carpPhase")
+	if result == nil || result.Found || !strings.Contains(result.NotFoundReason, `.status.phase Equals "Running": no value selected`) {
+		t.Errorf("unexpected result %v", result)
+	}
+}
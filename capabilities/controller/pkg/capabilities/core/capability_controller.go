@@ -6,11 +6,27 @@ package core
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
 	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
@@ -18,16 +34,34 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/capabilities/controller/pkg/constants"
 )
 
+// clusterListGVK is the kind of the Cluster API clusters a Capability with a clusterSelector is evaluated on.
+var clusterListGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "ClusterList"}
+
 // CapabilityReconciler reconciles a Capability object.
 type CapabilityReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
 	Host   string
+
+	controller   controller.Controller
+	restMapper   meta.RESTMapper
+	dependencies *dependencyTracker
+	// watchedKinds are the kinds of the queried objects the controller watches.
+	watchedKinds     map[schema.GroupVersionKind]bool
+	watchedKindsLock sync.Mutex
+	// clusterTimeout is the timeout of the evaluation of the queries on a cluster selected by a clusterSelector.
+	clusterTimeout time.Duration
+	// clustersTimeout is the timeout of the evaluation of the queries on all the clusters selected by a clusterSelector.
+	clustersTimeout time.Duration
+	// clusterConcurrency is the number of clusters selected by a clusterSelector evaluated in parallel.
+	clusterConcurrency int
 }
 
 //+kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=capabilities,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=run.tanzu.vmware.com,resources=capabilities/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=selfsubjectaccessreviews,verbs=create
 
 // Reconcile reconciles a Capability spec by executing specified queries.
 func (r *CapabilityReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -39,6 +73,9 @@ func (r *CapabilityReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	capability := &corev1alpha2.Capability{}
 	if err := r.Get(ctxCancel, req.NamespacedName, capability); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.dependencies.remove(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -55,27 +92,286 @@ func (r *CapabilityReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("unable to get config for ClusterQueryClient creation: %w", err)
 	}
-	clusterQueryClient, err := discovery.NewClusterQueryClientForConfig(cfg)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("unable to create ClusterQueryClient: %w", err)
+
+	if capability.Spec.ClusterSelector != nil {
+		c, err := client.New(cfg, client.Options{Scheme: r.Scheme})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to create client: %w", err)
+		}
+		// The clusters are evaluated with their own timeouts, instead of the timeout of the reconcile.
+		clusterResults, err := r.evaluateOnClusters(ctx, log, c, capability)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		capability.Status.Results = nil
+		capability.Status.ClusterResults = clusterResults
+		capability.Status.FleetResults = fleetResults(capability.Spec.Queries, clusterResults)
+		capability.Status.UnwatchedKinds = nil
+		// The queries depend on the CRDs and objects of the selected clusters, which are not watched.
+		r.dependencies.remove(req.NamespacedName)
+	} else {
+		clusterQueryClient, err := discovery.NewClusterQueryClientForConfig(cfg)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to create ClusterQueryClient: %w", err)
+		}
+		capability.Status.Results = r.evaluateQueries(log, clusterQueryClient, capability.Spec.Queries)
+		capability.Status.ClusterResults = nil
+		capability.Status.FleetResults = nil
+
+		deps := dependenciesOf(capability)
+		r.dependencies.set(req.NamespacedName, deps)
+		capability.Status.UnwatchedKinds = r.watchObjectKinds(ctxCancel, log, deps)
+	}
+
+	capability.Status.ObservedGeneration = capability.Generation
+	now := metav1.Now()
+	capability.Status.LastEvaluatedTime = &now
+
+	log.Info("Successfully reconciled")
+	// The evaluation on the selected clusters may have outlasted the reconcile timeout.
+	ctxUpdate, cancelUpdate := context.WithTimeout(ctx, constants.ContextTimeout)
+	defer cancelUpdate()
+	if err := r.Status().Update(ctxUpdate, capability); err != nil {
+		return ctrl.Result{}, err
 	}
+	return ctrl.Result{RequeueAfter: resyncInterval(capability)}, nil
+}
 
-	capability.Status.Results = make([]corev1alpha2.Result, len(capability.Spec.Queries))
+// resyncInterval returns the interval at which the queries of a Capability are evaluated again, or 0 if they are
+// only evaluated on changes. Capabilities depending on changes which are not watched are evaluated again
+// periodically, even when they do not specify a resync interval.
+func resyncInterval(capability *corev1alpha2.Capability) time.Duration {
+	if capability.Spec.ResyncInterval != nil && capability.Spec.ResyncInterval.Duration > 0 {
+		return capability.Spec.ResyncInterval.Duration
+	}
+	if capability.Spec.ClusterSelector != nil || len(capability.Status.UnwatchedKinds) > 0 {
+		return constants.DefaultResyncInterval
+	}
+	return 0
+}
 
-	for i, query := range capability.Spec.Queries {
+// evaluateQueries executes the queries of a Capability and returns their results.
+func (r *CapabilityReconciler) evaluateQueries(log logr.Logger, clusterQueryClient *discovery.ClusterQueryClient, queries []corev1alpha2.Query) []corev1alpha2.Result {
+	results := make([]corev1alpha2.Result, len(queries))
+	for i, query := range queries {
 		l := log.WithValues("query", query.Name)
 
-		capability.Status.Results[i].Name = query.Name
+		results[i].Name = query.Name
 		// Query GVRs.
-		capability.Status.Results[i].GroupVersionResources = r.queryGVRs(l, clusterQueryClient, query.GroupVersionResources)
+		results[i].GroupVersionResources = r.queryGVRs(l, clusterQueryClient, query.GroupVersionResources)
 		// Query Objects.
-		capability.Status.Results[i].Objects = r.queryObjects(l, clusterQueryClient, query.Objects)
+		results[i].Objects = r.queryObjects(l, clusterQueryClient, query.Objects)
 		// Query PartialSchemas.
-		capability.Status.Results[i].PartialSchemas = r.queryPartialSchemas(l, clusterQueryClient, query.PartialSchemas)
+		results[i].PartialSchemas = r.queryPartialSchemas(l, clusterQueryClient, query.PartialSchemas)
 	}
+	return results
+}
 
-	log.Info("Successfully reconciled")
-	return ctrl.Result{}, r.Status().Update(ctxCancel, capability)
+// evaluateOnClusters executes the queries of a Capability on the Cluster API clusters selected by its clusterSelector.
+// The clusters and their kubeconfig secrets are read with a client using the service account config, so that the
+// Capability can only query the clusters the service account has access to. The clusters are evaluated in parallel,
+// each with its own timeout, so that unreachable clusters do not prevent the evaluation on the other clusters, and
+// the evaluation on all the clusters is bounded by an overall timeout, so that it does not block the worker.
+func (r *CapabilityReconciler) evaluateOnClusters(ctx context.Context, log logr.Logger, c client.Client, capability *corev1alpha2.Capability) ([]corev1alpha2.ClusterResult, error) {
+	selector, err := metav1.LabelSelectorAsSelector(capability.Spec.ClusterSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clusterSelector: %w", err)
+	}
+	ctxList, cancel := context.WithTimeout(ctx, constants.ContextTimeout)
+	defer cancel()
+	clusters := &metav1.PartialObjectMetadataList{}
+	clusters.SetGroupVersionKind(clusterListGVK)
+	if err := c.List(ctxList, clusters, client.InNamespace(capability.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("unable to list clusters: %w", err)
+	}
+	sort.Slice(clusters.Items, func(i, j int) bool {
+		return clusters.Items[i].Name < clusters.Items[j].Name
+	})
+
+	ctxClusters, cancelClusters := context.WithTimeout(ctx, r.clustersTimeout)
+	defer cancelClusters()
+	results := make([]corev1alpha2.ClusterResult, len(clusters.Items))
+	sem := make(chan struct{}, r.clusterConcurrency)
+	var wg sync.WaitGroup
+	for i := range clusters.Items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctxClusters.Done():
+		}
+		if ctxClusters.Err() != nil {
+			// The clusters which could not be evaluated within the overall timeout are evaluated on the next resync.
+			results[i] = corev1alpha2.ClusterResult{ClusterName: clusters.Items[i].Name, Error: r.clustersTimeoutError()}
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = r.evaluateOnCluster(ctxClusters, log, c, capability, clusters.Items[i].Name)
+		}(i)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// evaluateOnCluster executes the queries of a Capability on a Cluster API cluster within the cluster timeout.
+func (r *CapabilityReconciler) evaluateOnCluster(ctx context.Context, log logr.Logger, c client.Client, capability *corev1alpha2.Capability, clusterName string) corev1alpha2.ClusterResult {
+	ctxCluster, cancel := context.WithTimeout(ctx, r.clusterTimeout)
+	defer cancel()
+	l := log.WithValues("cluster", clusterName)
+	result := corev1alpha2.ClusterResult{ClusterName: clusterName}
+
+	clusterCfg, err := config.GetConfigForCluster(ctxCluster, c, capability.Namespace, clusterName)
+	if err != nil {
+		l.Error(err, "Unable to get cluster config")
+		result.Error = err.Error()
+		return result
+	}
+	clusterQueryClient, err := discovery.NewClusterQueryClientForConfig(withContext(ctxCluster, clusterCfg))
+	if err != nil {
+		l.Error(err, "Unable to create ClusterQueryClient")
+		result.Error = err.Error()
+		return result
+	}
+	result.Results = r.evaluateQueries(l, clusterQueryClient, capability.Spec.Queries)
+	switch {
+	case ctx.Err() != nil:
+		l.Info("Timed out evaluating the queries on the clusters", "timeout", r.clustersTimeout.String())
+		result.Error = r.clustersTimeoutError()
+	case ctxCluster.Err() != nil:
+		l.Info("Timed out evaluating the queries", "timeout", r.clusterTimeout.String())
+		result.Error = fmt.Sprintf("timed out after %s evaluating the queries", r.clusterTimeout)
+	}
+	return result
+}
+
+// clustersTimeoutError is the error of the clusters which were not evaluated within the overall timeout.
+func (r *CapabilityReconciler) clustersTimeoutError() string {
+	return fmt.Sprintf("timed out after %s evaluating the queries on the selected clusters", r.clustersTimeout)
+}
+
+// withContext returns a copy of a config whose requests are cancelled when a context is done, as the
+// ClusterQueryClient does not take a context.
+func withContext(ctx context.Context, cfg *rest.Config) *rest.Config {
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return rt.RoundTrip(req.WithContext(ctx))
+		})
+	})
+	return cfg
+}
+
+// roundTripperFunc is a function implementing http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fleetResults lists, for each query, the clusters on which all of its GVR, Object and PartialSchema queries were found.
+func fleetResults(queries []corev1alpha2.Query, clusterResults []corev1alpha2.ClusterResult) []corev1alpha2.FleetResult {
+	results := make([]corev1alpha2.FleetResult, len(queries))
+	for i := range queries {
+		results[i].Name = queries[i].Name
+		for _, clusterResult := range clusterResults {
+			for j := range clusterResult.Results {
+				if clusterResult.Results[j].Name == queries[i].Name && resultFound(&clusterResult.Results[j]) {
+					results[i].Clusters = append(results[i].Clusters, clusterResult.ClusterName)
+				}
+			}
+		}
+	}
+	return results
+}
+
+// resultFound returns true if all the GVR, Object and PartialSchema queries of a result were found.
+func resultFound(result *corev1alpha2.Result) bool {
+	for _, queryResults := range [][]corev1alpha2.QueryResult{result.GroupVersionResources, result.Objects, result.PartialSchemas} {
+		for _, queryResult := range queryResults {
+			if !queryResult.Found || queryResult.Error {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// watchObjectKinds starts watching the metadata of the kinds of the queried objects, so that their
+// Capabilities are re-evaluated when they change, and returns the kinds which are not watched. Kinds which
+// are not served, or which the controller is not allowed to list and watch, are not watched. Watching them
+// is attempted again on the next evaluation, which is triggered by their CRD, the Capability or the resync.
+func (r *CapabilityReconciler) watchObjectKinds(ctx context.Context, log logr.Logger, deps capabilityDependencies) []string {
+	r.watchedKindsLock.Lock()
+	defer r.watchedKindsLock.Unlock()
+
+	unwatched := make(map[string]bool)
+	for _, dep := range deps.objects {
+		gvk := dep.gvk
+		if r.watchedKinds[gvk] || unwatched[kindName(gvk)] {
+			continue
+		}
+		if err := r.watchObjectKind(ctx, gvk); err != nil {
+			log.Info("Not watching objects, the queries will be evaluated again periodically", "gvk", gvk.String(), "reason", err.Error())
+			unwatched[kindName(gvk)] = true
+			continue
+		}
+		r.watchedKinds[gvk] = true
+		log.Info("Watching objects", "gvk", gvk.String())
+	}
+
+	kinds := make([]string, 0, len(unwatched))
+	for kind := range unwatched {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	if len(kinds) == 0 {
+		return nil
+	}
+	return kinds
+}
+
+// watchObjectKind starts watching the metadata of the objects of a kind.
+func (r *CapabilityReconciler) watchObjectKind(ctx context.Context, gvk schema.GroupVersionKind) error {
+	mapping, err := r.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("kind is not served: %w", err)
+	}
+	if !r.canListAndWatch(ctx, mapping.Resource) {
+		return fmt.Errorf("controller is not allowed to list and watch %s", mapping.Resource.GroupResource())
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gvk)
+	return r.controller.Watch(&source.Kind{Type: obj}, handler.EnqueueRequestsFromMapFunc(r.dependencies.objectToCapabilities(gvk)))
+}
+
+// kindName formats a kind as kind.version.group.
+func kindName(gvk schema.GroupVersionKind) string {
+	if gvk.Group == "" {
+		return gvk.Kind + "." + gvk.Version
+	}
+	return gvk.Kind + "." + gvk.Version + "." + gvk.Group
+}
+
+// canListAndWatch returns true if the controller is allowed to list and watch a resource in all namespaces.
+func (r *CapabilityReconciler) canListAndWatch(ctx context.Context, resource schema.GroupVersionResource) bool {
+	for _, verb := range []string{"list", "watch"} {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    resource.Group,
+					Version:  resource.Version,
+					Resource: resource.Resource,
+				},
+			},
+		}
+		if err := r.Create(ctx, review); err != nil || !review.Status.Allowed {
+			return false
+		}
+	}
+	return true
 }
 
 // queryGVRs executes GVR queries and returns results.
@@ -92,16 +388,35 @@ func (r *CapabilityReconciler) queryGVRs(log logr.Logger, clusterQueryClient *di
 }
 
 // queryObjects executes Object queries and returns results.
+// Queries with invalid field predicates are not executed, their results report the invalid predicates.
 func (r *CapabilityReconciler) queryObjects(log logr.Logger, clusterQueryClient *discovery.ClusterQueryClient, queries []corev1alpha2.QueryObject) []corev1alpha2.QueryResult {
-	return r.executeQueries(log.WithValues("queryType", "Object"), clusterQueryClient, func() map[string]discovery.QueryTarget {
+	var invalidResults []corev1alpha2.QueryResult
+	results := r.executeQueries(log.WithValues("queryType", "Object"), clusterQueryClient, func() map[string]discovery.QueryTarget {
 		queryTargets := make(map[string]discovery.QueryTarget)
 		for i := range queries {
 			q := queries[i]
-			query := discovery.Object(q.Name, &q.ObjectReference).WithAnnotations(q.WithAnnotations).WithoutAnnotations(q.WithoutAnnotations)
+			predicates := FieldPredicates(q.FieldPredicates)
+			if err := validateFieldPredicates(predicates); err != nil {
+				invalidResults = append(invalidResults, corev1alpha2.QueryResult{Name: q.Name, Error: true, ErrorDetail: err.Error()})
+				continue
+			}
+			query := discovery.Object(q.Name, &q.ObjectReference).WithAnnotations(q.WithAnnotations).WithoutAnnotations(q.WithoutAnnotations).
+				WithFieldPredicates(predicates...)
 			queryTargets[q.Name] = query
 		}
 		return queryTargets
 	})
+	return append(results, invalidResults...)
+}
+
+// validateFieldPredicates validates the JSONPath expressions, operators and values of the field predicates of an Object query.
+func validateFieldPredicates(predicates []discovery.FieldPredicate) error {
+	for i, predicate := range predicates {
+		if err := predicate.Validate(); err != nil {
+			return fmt.Errorf("invalid fieldPredicates[%d]: %w", i, err)
+		}
+	}
+	return nil
 }
 
 // queryPartialSchemas executes PartialSchema queries and returns results.
@@ -141,9 +456,34 @@ func (r *CapabilityReconciler) executeQueries(log logr.Logger, clusterQueryClien
 	return results
 }
 
+// FieldPredicates converts the field predicates of an Object query to discovery field predicates.
+func FieldPredicates(predicates []corev1alpha2.FieldPredicate) []discovery.FieldPredicate {
+	result := make([]discovery.FieldPredicate, len(predicates))
+	for i, p := range predicates {
+		result[i] = discovery.FieldPredicate{Path: p.Path, Operator: discovery.FieldPredicateOperator(p.Operator), Value: p.Value}
+	}
+	return result
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *CapabilityReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1alpha2.Capability{}).
-		Complete(r)
+	r.restMapper = mgr.GetRESTMapper()
+	r.dependencies = newDependencyTracker()
+	r.watchedKinds = make(map[schema.GroupVersionKind]bool)
+	r.clusterTimeout = constants.ClusterContextTimeout
+	r.clustersTimeout = constants.ClusterEvaluationTimeout
+	r.clusterConcurrency = constants.ClusterEvaluationConcurrency
+
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		// Status updates record the evaluation time, only re-evaluate on spec changes.
+		For(&corev1alpha2.Capability{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &apiextensionsv1.CustomResourceDefinition{}},
+			handler.EnqueueRequestsFromMapFunc(r.dependencies.crdToCapabilities),
+			builder.OnlyMetadata).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	r.controller = c
+	return nil
 }
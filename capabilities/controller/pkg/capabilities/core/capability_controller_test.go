@@ -0,0 +1,363 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/capabilities/controller/pkg/constants"
+)
+
+func TestFleetResults(t *testing.T) {
+	queries := []corev1alpha2.Query{{Name: "tkr"}, {Name: "nsx"}}
+	found := []corev1alpha2.QueryResult{{Name: "q", Found: true}}
+	notFound := []corev1alpha2.QueryResult{{Name: "q", Found: true}, {Name: "r", Found: false}}
+	clusterResults := []corev1alpha2.ClusterResult{
+		{
+			ClusterName: "wc-1",
+			Results: []corev1alpha2.Result{
+				{Name: "tkr", GroupVersionResources: found},
+				{Name: "nsx", Objects: notFound},
+			},
+		},
+		{
+			ClusterName: "wc-2",
+			Results: []corev1alpha2.Result{
+				{Name: "tkr", GroupVersionResources: found, PartialSchemas: found},
+				{Name: "nsx", Objects: found},
+			},
+		},
+		{
+			ClusterName: "wc-3",
+			Error:       "couldn't get cluster kubeconfig secret",
+		},
+	}
+
+	want := []corev1alpha2.FleetResult{
+		{Name: "tkr", Clusters: []string{"wc-1", "wc-2"}},
+		{Name: "nsx", Clusters: []string{"wc-2"}},
+	}
+	if got := fleetResults(queries, clusterResults); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestQueryObjectsInvalidFieldPredicates(t *testing.T) {
+	testCases := []struct {
+		description string
+		predicates  []corev1alpha2.FieldPredicate
+		err         string
+	}{
+		{
+			description: "valid predicates",
+			predicates: []corev1alpha2.FieldPredicate{
+				{Path: "{.data.metadata\\.yaml}", Operator: "Exists"},
+				{Path: ".status.readyReplicas", Operator: "GreaterThanOrEqual", Value: "1"},
+			},
+		},
+		{
+			description: "invalid JSONPath expression",
+			predicates:  []corev1alpha2.FieldPredicate{{Path: "{.data[", Operator: "Exists"}},
+			err:         "invalid fieldPredicates[0]",
+		},
+		{
+			description: "non-numeric value of a numeric comparison",
+			predicates: []corev1alpha2.FieldPredicate{
+				{Path: ".data.provider", Operator: "Equals", Value: "vsphere"},
+				{Path: ".status.readyReplicas", Operator: "LessThan", Value: "few"},
+			},
+			err: "invalid fieldPredicates[1]: operator LessThan requires a numeric value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := validateFieldPredicates(FieldPredicates(tc.predicates))
+			if tc.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("error=%v, want %q", err, tc.err)
+			}
+		})
+	}
+
+	// queries with invalid predicates are reported without being executed
+	r := &CapabilityReconciler{}
+	queries := []corev1alpha2.QueryObject{{
+		Name:            "tkg-metadata",
+		ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "tkg-system-public", Name: "tkg-metadata"},
+		FieldPredicates: []corev1alpha2.FieldPredicate{{Path: "{.data[", Operator: "Exists"}},
+	}}
+	results := r.queryObjects(logr.Discard(), nil, queries)
+	if len(results) != 1 || results[0].Name != "tkg-metadata" || !results[0].Error || results[0].Found ||
+		!strings.HasPrefix(results[0].ErrorDetail, "invalid fieldPredicates[0]") {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestWatchObjectKinds(t *testing.T) {
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	r := &CapabilityReconciler{
+		Client:       fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		Log:          logr.Discard(),
+		restMapper:   restMapper,
+		dependencies: newDependencyTracker(),
+		watchedKinds: make(map[schema.GroupVersionKind]bool),
+	}
+	capability := &corev1alpha2.Capability{
+		Spec: corev1alpha2.CapabilitySpec{Queries: []corev1alpha2.Query{{
+			Name: "objects",
+			Objects: []corev1alpha2.QueryObject{
+				{Name: "cluster", ObjectReference: corev1.ObjectReference{APIVersion: "cluster.x-k8s.io/v1beta1", Kind: "Cluster", Name: "wc-1"}},
+				{Name: "other-cluster", ObjectReference: corev1.ObjectReference{APIVersion: "cluster.x-k8s.io/v1beta1", Kind: "Cluster", Name: "wc-2"}},
+				{Name: "secret", ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "creds"}},
+			},
+		}}},
+	}
+
+	// The Cluster kind is not served and the controller is not allowed to watch secrets.
+	want := []string{"Cluster.v1beta1.cluster.x-k8s.io", "Secret.v1"}
+	got := r.watchObjectKinds(context.Background(), r.Log, dependenciesOf(capability))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if len(r.watchedKinds) != 0 {
+		t.Errorf("got watched kinds %v, want none", r.watchedKinds)
+	}
+}
+
+func TestResyncInterval(t *testing.T) {
+	tests := []struct {
+		name       string
+		capability corev1alpha2.Capability
+		want       time.Duration
+	}{
+		{
+			name: "watched queries",
+			want: 0,
+		},
+		{
+			name: "specified interval",
+			capability: corev1alpha2.Capability{
+				Spec:   corev1alpha2.CapabilitySpec{ResyncInterval: &metav1.Duration{Duration: time.Minute}},
+				Status: corev1alpha2.CapabilityStatus{UnwatchedKinds: []string{"Cluster.v1beta1.cluster.x-k8s.io"}},
+			},
+			want: time.Minute,
+		},
+		{
+			name: "unwatched kinds",
+			capability: corev1alpha2.Capability{
+				Status: corev1alpha2.CapabilityStatus{UnwatchedKinds: []string{"Cluster.v1beta1.cluster.x-k8s.io"}},
+			},
+			want: constants.DefaultResyncInterval,
+		},
+		{
+			name: "cluster selector",
+			capability: corev1alpha2.Capability{
+				Spec: corev1alpha2.CapabilitySpec{ClusterSelector: &metav1.LabelSelector{}},
+			},
+			want: constants.DefaultResyncInterval,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resyncInterval(&tc.capability); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// clusterListClient lists a fixed set of clusters, as the fake client does not support listing object metadata.
+type clusterListClient struct {
+	client.Client
+	clusters []metav1.PartialObjectMetadata
+}
+
+func (c *clusterListClient) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	list.(*metav1.PartialObjectMetadataList).Items = c.clusters
+	return nil
+}
+
+// newDiscoveryServer returns an API server which only serves the discovery of the crd.antrea.io group.
+func newDiscoveryServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch req.URL.Path {
+		case "/api":
+			body = &metav1.APIVersions{Versions: []string{"v1"}}
+		case "/api/v1":
+			body = &metav1.APIResourceList{GroupVersion: "v1"}
+		case "/apis":
+			body = &metav1.APIGroupList{Groups: []metav1.APIGroup{{
+				Name:             "crd.antrea.io",
+				Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "crd.antrea.io/v1alpha1", Version: "v1alpha1"}},
+				PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "crd.antrea.io/v1alpha1", Version: "v1alpha1"},
+			}}}
+		case "/apis/crd.antrea.io/v1alpha1":
+			body = &metav1.APIResourceList{GroupVersion: "crd.antrea.io/v1alpha1", APIResources: []metav1.APIResource{
+				{Name: "clusternetworkpolicies", Kind: "ClusterNetworkPolicy", Verbs: metav1.Verbs{"get", "list"}},
+			}}
+		default:
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+}
+
+func kubeconfigSecret(clusterName, server string) *corev1.Secret {
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: %[2]s
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s
+current-context: %[1]s
+users:
+- name: %[1]s
+  user:
+    token: token
+`, clusterName, server)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName + constants.ClusterKubeconfigSecretSuffix, Namespace: "default"},
+		Data:       map[string][]byte{constants.ClusterKubeconfigSecretKey: []byte(kubeconfig)},
+	}
+}
+
+func TestEvaluateOnClusters(t *testing.T) {
+	// The unreachable cluster accepts connections but never answers.
+	unreachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	}))
+	defer unreachable.Close()
+	reachable := newDiscoveryServer()
+	defer reachable.Close()
+
+	c := &clusterListClient{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			kubeconfigSecret("wc-1", unreachable.URL),
+			kubeconfigSecret("wc-2", reachable.URL),
+		).Build(),
+		clusters: []metav1.PartialObjectMetadata{
+			{ObjectMeta: metav1.ObjectMeta{Name: "wc-3", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "wc-2", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "wc-1", Namespace: "default"}},
+		},
+	}
+	r := &CapabilityReconciler{Log: logr.Discard(), clusterTimeout: time.Second, clustersTimeout: time.Minute, clusterConcurrency: 1}
+	capability := &corev1alpha2.Capability{
+		ObjectMeta: metav1.ObjectMeta{Name: "antrea", Namespace: "default"},
+		Spec: corev1alpha2.CapabilitySpec{
+			ClusterSelector: &metav1.LabelSelector{},
+			Queries: []corev1alpha2.Query{{
+				Name:                  "antrea",
+				GroupVersionResources: []corev1alpha2.QueryGVR{{Name: "antrea-resource", Group: "crd.antrea.io", Resource: "clusternetworkpolicies"}},
+			}},
+		},
+	}
+
+	// The evaluation on the unreachable cluster times out, without using up the time of the other clusters.
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	results, err := r.evaluateOnClusters(ctx, r.Log, c, capability)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d cluster results, want 3", len(results))
+	}
+	if results[0].ClusterName != "wc-1" || !strings.Contains(results[0].Error, "timed out") {
+		t.Errorf("got %+v, want a timeout of wc-1", results[0])
+	}
+	if results[1].ClusterName != "wc-2" || results[1].Error != "" || !resultFound(&results[1].Results[0]) {
+		t.Errorf("got %+v, want the queries found on wc-2", results[1])
+	}
+	if results[2].ClusterName != "wc-3" || !strings.Contains(results[2].Error, "kubeconfig secret") {
+		t.Errorf("got %+v, want a missing kubeconfig secret error for wc-3", results[2])
+	}
+	if want := []corev1alpha2.FleetResult{{Name: "antrea", Clusters: []string{"wc-2"}}}; !reflect.DeepEqual(fleetResults(capability.Spec.Queries, results), want) {
+		t.Errorf("got %v, want %v", fleetResults(capability.Spec.Queries, results), want)
+	}
+}
+
+func TestEvaluateOnClustersInParallel(t *testing.T) {
+	unreachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	}))
+	defer unreachable.Close()
+
+	var objects []client.Object
+	var clusters []metav1.PartialObjectMetadata
+	for i := 1; i <= 5; i++ {
+		name := fmt.Sprintf("wc-%d", i)
+		objects = append(objects, kubeconfigSecret(name, unreachable.URL))
+		clusters = append(clusters, metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+	}
+	c := &clusterListClient{
+		Client:   fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build(),
+		clusters: clusters,
+	}
+	capability := &corev1alpha2.Capability{
+		ObjectMeta: metav1.ObjectMeta{Name: "antrea", Namespace: "default"},
+		Spec: corev1alpha2.CapabilitySpec{
+			ClusterSelector: &metav1.LabelSelector{},
+			Queries: []corev1alpha2.Query{{
+				Name:                  "antrea",
+				GroupVersionResources: []corev1alpha2.QueryGVR{{Name: "antrea-resource", Group: "crd.antrea.io", Resource: "clusternetworkpolicies"}},
+			}},
+		},
+	}
+
+	// Two clusters are evaluated at a time, the fifth cluster is not evaluated before the overall timeout.
+	r := &CapabilityReconciler{Log: logr.Discard(), clusterTimeout: time.Second, clustersTimeout: 2500 * time.Millisecond, clusterConcurrency: 2}
+	start := time.Now()
+	results, err := r.evaluateOnClusters(context.Background(), r.Log, c, capability)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("evaluation took %s, want it bounded by the overall timeout", elapsed)
+	}
+	if len(results) != 5 {
+		t.Fatalf("got %d cluster results, want 5", len(results))
+	}
+	for i := 0; i < 4; i++ {
+		if want := fmt.Sprintf("timed out after %s evaluating the queries", r.clusterTimeout); results[i].Error != want {
+			t.Errorf("got %+v, want a timeout of %s", results[i], results[i].ClusterName)
+		}
+	}
+	if results[4].ClusterName != "wc-5" || results[4].Error != r.clustersTimeoutError() {
+		t.Errorf("got %+v, want wc-5 not evaluated within the overall timeout", results[4])
+	}
+}
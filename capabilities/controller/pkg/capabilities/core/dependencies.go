@@ -0,0 +1,115 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// capabilityDependencies are the API groups and objects the queries of a Capability depend on.
+type capabilityDependencies struct {
+	// groups are the API groups of the GVR and Object queries.
+	groups map[string]bool
+	// objects are the objects of the Object queries.
+	objects []objectDependency
+	// partialSchemas is true if the Capability has PartialSchema queries, which depend on any CRD.
+	partialSchemas bool
+}
+
+// objectDependency is an object a Capability queries.
+type objectDependency struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// dependenciesOf returns the dependencies of the queries of a Capability.
+func dependenciesOf(capability *corev1alpha2.Capability) capabilityDependencies {
+	deps := capabilityDependencies{groups: make(map[string]bool)}
+	for i := range capability.Spec.Queries {
+		query := &capability.Spec.Queries[i]
+		for j := range query.GroupVersionResources {
+			deps.groups[query.GroupVersionResources[j].Group] = true
+		}
+		for j := range query.Objects {
+			ref := &query.Objects[j].ObjectReference
+			gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+			deps.groups[gvk.Group] = true
+			deps.objects = append(deps.objects, objectDependency{gvk: gvk, namespace: ref.Namespace, name: ref.Name})
+		}
+		if len(query.PartialSchemas) > 0 {
+			deps.partialSchemas = true
+		}
+	}
+	return deps
+}
+
+// dependencyTracker indexes the dependencies of the Capabilities to find the ones affected by a change.
+type dependencyTracker struct {
+	sync.RWMutex
+	dependencies map[types.NamespacedName]capabilityDependencies
+}
+
+func newDependencyTracker() *dependencyTracker {
+	return &dependencyTracker{dependencies: make(map[types.NamespacedName]capabilityDependencies)}
+}
+
+// set records the dependencies of a Capability.
+func (t *dependencyTracker) set(key types.NamespacedName, deps capabilityDependencies) {
+	t.Lock()
+	defer t.Unlock()
+	t.dependencies[key] = deps
+}
+
+// remove forgets the dependencies of a deleted Capability.
+func (t *dependencyTracker) remove(key types.NamespacedName) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.dependencies, key)
+}
+
+// crdToCapabilities returns the Capabilities depending on the API group of a CRD.
+// The name of a CRD is <plural>.<group>, which allows to only watch the metadata of CRDs.
+func (t *dependencyTracker) crdToCapabilities(crd client.Object) []reconcile.Request {
+	group := ""
+	if i := strings.Index(crd.GetName(), "."); i >= 0 {
+		group = crd.GetName()[i+1:]
+	}
+
+	t.RLock()
+	defer t.RUnlock()
+	var requests []reconcile.Request
+	for key, deps := range t.dependencies {
+		if deps.partialSchemas || deps.groups[group] {
+			requests = append(requests, reconcile.Request{NamespacedName: key})
+		}
+	}
+	return requests
+}
+
+// objectToCapabilities returns a function mapping an object of the given kind to the Capabilities querying it.
+func (t *dependencyTracker) objectToCapabilities(gvk schema.GroupVersionKind) func(client.Object) []reconcile.Request {
+	return func(obj client.Object) []reconcile.Request {
+		t.RLock()
+		defer t.RUnlock()
+		var requests []reconcile.Request
+		for key, deps := range t.dependencies {
+			for _, dep := range deps.objects {
+				if dep.gvk.GroupKind() == gvk.GroupKind() && dep.name == obj.GetName() && dep.namespace == obj.GetNamespace() {
+					requests = append(requests, reconcile.Request{NamespacedName: key})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
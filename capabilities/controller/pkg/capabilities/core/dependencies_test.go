@@ -0,0 +1,101 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func TestDependencyTracker(t *testing.T) {
+	gvrCapability := types.NamespacedName{Namespace: "default", Name: "gvr"}
+	objectCapability := types.NamespacedName{Namespace: "default", Name: "object"}
+	schemaCapability := types.NamespacedName{Namespace: "default", Name: "schema"}
+
+	tracker := newDependencyTracker()
+	tracker.set(gvrCapability, dependenciesOf(&corev1alpha2.Capability{
+		Spec: corev1alpha2.CapabilitySpec{Queries: []corev1alpha2.Query{{
+			Name:                  "tkr",
+			GroupVersionResources: []corev1alpha2.QueryGVR{{Name: "tkr", Group: "run.tanzu.vmware.com"}},
+		}}},
+	}))
+	tracker.set(objectCapability, dependenciesOf(&corev1alpha2.Capability{
+		Spec: corev1alpha2.CapabilitySpec{Queries: []corev1alpha2.Query{{
+			Name: "cluster",
+			Objects: []corev1alpha2.QueryObject{{Name: "cluster", ObjectReference: corev1.ObjectReference{
+				APIVersion: "cluster.x-k8s.io/v1beta1", Kind: "Cluster", Namespace: "default", Name: "wc",
+			}}},
+		}}},
+	}))
+	tracker.set(schemaCapability, dependenciesOf(&corev1alpha2.Capability{
+		Spec: corev1alpha2.CapabilitySpec{Queries: []corev1alpha2.Query{{
+			Name:           "schema",
+			PartialSchemas: []corev1alpha2.QueryPartialSchema{{Name: "schema", PartialSchema: "type: object"}},
+		}}},
+	}))
+
+	crd := func(name string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	testCases := []struct {
+		description string
+		requests    []reconcile.Request
+		want        []types.NamespacedName
+	}{
+		{
+			description: "CRD of a queried group",
+			requests:    tracker.crdToCapabilities(crd("tanzukubernetesreleases.run.tanzu.vmware.com")),
+			want:        []types.NamespacedName{gvrCapability, schemaCapability},
+		},
+		{
+			description: "CRD of a queried object",
+			requests:    tracker.crdToCapabilities(crd("clusters.cluster.x-k8s.io")),
+			want:        []types.NamespacedName{objectCapability, schemaCapability},
+		},
+		{
+			description: "CRD of an unrelated group",
+			requests:    tracker.crdToCapabilities(crd("foos.example.com")),
+			want:        []types.NamespacedName{schemaCapability},
+		},
+		{
+			description: "queried object",
+			requests: tracker.objectToCapabilities(schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"})(
+				&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "wc"}}),
+			want: []types.NamespacedName{objectCapability},
+		},
+		{
+			description: "object of a queried kind with another name",
+			requests: tracker.objectToCapabilities(schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"})(
+				&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"}}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := map[types.NamespacedName]bool{}
+			for _, req := range tc.requests {
+				got[req.NamespacedName] = true
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got requests %v, want %v", tc.requests, tc.want)
+			}
+			for _, key := range tc.want {
+				if !got[key] {
+					t.Errorf("missing request for %v in %v", key, tc.requests)
+				}
+			}
+		})
+	}
+
+	tracker.remove(schemaCapability)
+	if requests := tracker.crdToCapabilities(crd("foos.example.com")); len(requests) != 0 {
+		t.Errorf("expected no requests after removing the Capability, got %v", requests)
+	}
+}
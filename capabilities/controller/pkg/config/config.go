@@ -9,7 +9,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/capabilities/controller/pkg/constants"
 )
 
 // GetConfigForServiceAccount returns a *rest.Config which uses the service account for talking to a Kubernetes API server.
@@ -41,6 +44,23 @@ func GetConfigForServiceAccount(ctx context.Context, coreClient client.Client, n
 	return nil, fmt.Errorf("expected to find one service account token secret, but found none")
 }
 
+// GetConfigForCluster returns a *rest.Config which uses the kubeconfig secret of a Cluster API cluster for talking to its API server.
+func GetConfigForCluster(ctx context.Context, c client.Client, nsName, clusterName string) (*rest.Config, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{
+		Namespace: nsName,
+		Name:      clusterName + constants.ClusterKubeconfigSecretSuffix,
+	}, secret); err != nil {
+		return nil, fmt.Errorf("couldn't get cluster kubeconfig secret: %w", err)
+	}
+
+	kubeconfig, found := secret.Data[constants.ClusterKubeconfigSecretKey]
+	if !found {
+		return nil, fmt.Errorf("couldn't find kubeconfig in cluster kubeconfig secret")
+	}
+	return clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+}
+
 // buildConfig builds a *rest.Config from the service account secret
 func buildConfig(secret *corev1.Secret, host string) (*rest.Config, error) {
 	caBytes, found := secret.Data[corev1.ServiceAccountRootCAKey]
@@ -139,3 +139,48 @@ func getTestObjects() ([]runtime.Object, map[string]*corev1.Secret) {
 	// Objects to track in the fake client.
 	return []runtime.Object{fooServiceAccount, fooSecret, barServiceAccount, barSecret}, secrets
 }
+
+func TestGetConfigForCluster(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: wc
+  cluster:
+    server: https://10.0.0.1:6443
+contexts:
+- name: wc-admin@wc
+  context:
+    cluster: wc
+    user: wc-admin
+current-context: wc-admin@wc
+users:
+- name: wc-admin
+  user:
+    token: secret-token
+`
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "wc-kubeconfig", Namespace: "default"},
+			Data:       map[string][]byte{"value": []byte(kubeconfig)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty-kubeconfig", Namespace: "default"},
+		},
+	).Build()
+	ctx, cancel := context.WithTimeout(context.Background(), constants.ContextTimeout)
+	defer cancel()
+
+	config, err := GetConfigForCluster(ctx, cl, "default", "wc")
+	if err != nil {
+		t.Fatalf("error not expected, but got error: %v", err)
+	}
+	if config.Host != "https://10.0.0.1:6443" || config.BearerToken != "secret-token" {
+		t.Errorf("config object is not constructed properly")
+	}
+
+	for _, clusterName := range []string{"empty", "missing"} {
+		if _, err := GetConfigForCluster(ctx, cl, "default", clusterName); err == nil {
+			t.Errorf("error expected for cluster %s, but got nothing", clusterName)
+		}
+	}
+}
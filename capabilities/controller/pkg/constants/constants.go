@@ -9,4 +9,20 @@ const (
 	ContextTimeout                       = 60 * time.Second
 	ServiceAccountWithDefaultPermissions = "tanzu-capabilities-manager-default-sa"
 	CapabilitiesControllerNamespace      = "tkg-system"
+	// ClusterKubeconfigSecretSuffix is the suffix of the name of the kubeconfig secret of a Cluster API cluster.
+	ClusterKubeconfigSecretSuffix = "-kubeconfig"
+	// ClusterKubeconfigSecretKey is the key of the kubeconfig in the kubeconfig secret of a Cluster API cluster.
+	ClusterKubeconfigSecretKey = "value"
+	// DefaultResyncInterval is the interval at which the queries of a Capability depending on changes which
+	// are not watched are evaluated again, when the Capability does not specify a resync interval.
+	DefaultResyncInterval = 10 * time.Minute
+	// ClusterContextTimeout is the timeout of the evaluation of the queries of a Capability on a cluster selected by
+	// its clusterSelector.
+	ClusterContextTimeout = 30 * time.Second
+	// ClusterEvaluationTimeout is the timeout of the evaluation of the queries of a Capability on all the clusters
+	// selected by its clusterSelector.
+	ClusterEvaluationTimeout = 2 * time.Minute
+	// ClusterEvaluationConcurrency is the number of clusters selected by the clusterSelector of a Capability on which
+	// its queries are evaluated in parallel.
+	ClusterEvaluationConcurrency = 10
 )
@@ -21,7 +21,7 @@ func GetCmd(p *cliapi.PluginDescriptor) *cobra.Command {
 		Use:   p.Name,
 		Short: p.Description,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			runner := NewRunner(p.Name, p.InstallationPath, args)
+			runner := NewRunner(p.Name, p.InstallationPath, args, WithPermissions(p.Permissions))
 			ctx := context.Background()
 			return runner.Run(ctx)
 		},
@@ -44,7 +44,7 @@ func GetCmd(p *cliapi.PluginDescriptor) *cobra.Command {
 			completion = append(completion, args...)
 			completion = append(completion, toComplete)
 
-			runner := NewRunner(p.Name, p.InstallationPath, completion)
+			runner := NewRunner(p.Name, p.InstallationPath, completion, WithPermissions(p.Permissions))
 			ctx := context.Background()
 			output, _, err := runner.RunOutput(ctx)
 			if err != nil {
@@ -77,7 +77,7 @@ func GetCmd(p *cliapi.PluginDescriptor) *cobra.Command {
 			completion = append(completion, args...)
 			completion = append(completion, toComplete)
 
-			runner := NewRunner(p.Name, p.InstallationPath, completion)
+			runner := NewRunner(p.Name, p.InstallationPath, completion, WithPermissions(p.Permissions))
 			ctx := context.Background()
 			output, stderr, err := runner.RunOutput(ctx)
 			if err != nil || stderr != "" {
@@ -102,7 +102,7 @@ func GetCmd(p *cliapi.PluginDescriptor) *cobra.Command {
 		helpArgs := getHelpArguments()
 
 		// Pass this new command in to our plugin to have it handle help output
-		runner := NewRunner(p.Name, p.InstallationPath, helpArgs)
+		runner := NewRunner(p.Name, p.InstallationPath, helpArgs, WithPermissions(p.Permissions))
 		ctx := context.Background()
 		err := runner.Run(ctx)
 		if err != nil {
@@ -140,7 +140,7 @@ func TestCmd(p *cliapi.PluginDescriptor) *cobra.Command {
 		Use:   p.Name,
 		Short: p.Description,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			runner := NewRunner(p.Name, p.InstallationPath, args)
+			runner := NewRunner(p.Name, p.InstallationPath, args, WithPermissions(p.Permissions))
 			ctx := context.Background()
 			return runner.RunTest(ctx)
 		},
@@ -30,6 +30,9 @@ type optionsConfig struct {
 
 	// VersionSelector is the means to find versions of plugins in a repository.
 	versionSelector VersionSelector
+
+	// permissions is the permission manifest enforced when running a plugin.
+	permissions *cliapi.PluginPermissions
 }
 
 var (
@@ -98,3 +101,10 @@ func WithVersionSelector(finder VersionSelector) Option {
 		o.versionSelector = finder
 	}
 }
+
+// WithPermissions sets the permission manifest the plugin runner enforces.
+func WithPermissions(permissions *cliapi.PluginPermissions) Option {
+	return func(o *optionsConfig) {
+		o.permissions = permissions
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+	"sigs.k8s.io/yaml"
+
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+)
+
+const (
+	// permissionsConsentFileName is the name of the file, under the plugin root, that
+	// records which plugin permission manifests were accepted by the user.
+	permissionsConsentFileName = "plugin-permissions.yaml"
+
+	// EnvAcceptPluginPermissions can be set to "true" to accept plugin permissions
+	// without prompting, e.g. in non-interactive environments.
+	EnvAcceptPluginPermissions = "TANZU_CLI_ACCEPT_PLUGIN_PERMISSIONS"
+
+	// EnvDisablePluginSandbox can be set to "true" to run plugins without enforcing their
+	// permissions, e.g. on hosts where the sandbox cannot be set up.
+	EnvDisablePluginSandbox = "TANZU_CLI_DISABLE_PLUGIN_SANDBOX"
+)
+
+// kubeconfigEnvVars are the environment variables withheld from plugins that do not
+// request access to the kubeconfig.
+var kubeconfigEnvVars = []string{"KUBECONFIG"}
+
+// permissionsConsent maps a plugin name to the digest of the permission manifest accepted by the user.
+type permissionsConsent map[string]string
+
+// PermissionsDigest returns the SHA256 digest of a plugin permission manifest.
+func PermissionsDigest(perms *cliapi.PluginPermissions) (string, error) {
+	normalized := perms.DeepCopy()
+	sort.Strings(normalized.Network)
+	sort.Strings(normalized.Filesystem)
+	b, err := json.Marshal(normalized)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode plugin permissions")
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// DescribePermissions returns a human readable summary of a plugin permission manifest.
+func DescribePermissions(perms *cliapi.PluginPermissions) string {
+	var b strings.Builder
+	describeList := func(title string, items []string) {
+		if len(items) == 0 {
+			fmt.Fprintf(&b, "  %s: none\n", title)
+			return
+		}
+		fmt.Fprintf(&b, "  %s:\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&b, "    - %s\n", item)
+		}
+	}
+	describeList("network endpoints", perms.Network)
+	describeList("filesystem paths", perms.Filesystem)
+	fmt.Fprintf(&b, "  kubeconfig access: %t\n", perms.Kubeconfig)
+	fmt.Fprintf(&b, "  tanzu config and token access: %t\n", perms.ConfigTokens)
+	return b.String()
+}
+
+// ensurePermissionsConsent verifies that the user has accepted the permissions requested by
+// the plugin. If they have not, the user is prompted when interactive is true, otherwise an
+// error is returned. Plugins that do not declare their permissions keep running without
+// restrictions, without asking for consent.
+func (r *Runner) ensurePermissionsConsent(interactive bool) error {
+	if r.permissions == nil {
+		return nil
+	}
+	digest, err := PermissionsDigest(r.permissions)
+	if err != nil {
+		return err
+	}
+
+	consentPath := filepath.Join(r.pluginRoot, permissionsConsentFileName)
+	consent, err := readPermissionsConsent(consentPath)
+	if err != nil {
+		return err
+	}
+	if consent[r.name] == digest {
+		return nil
+	}
+
+	if !strings.EqualFold(os.Getenv(EnvAcceptPluginPermissions), "true") {
+		if !interactive {
+			return fmt.Errorf("plugin %q has not been allowed to run, run the plugin interactively to review its permissions or set %s=true",
+				r.name, EnvAcceptPluginPermissions)
+		}
+		fmt.Fprintf(os.Stderr, "Plugin %q requests the following permissions:\n%s", r.name, DescribePermissions(r.permissions))
+		if err := component.AskForConfirmation("Allow the plugin to run with these permissions?"); err != nil {
+			return errors.Wrapf(err, "permissions for plugin %q were not accepted", r.name)
+		}
+	}
+
+	consent[r.name] = digest
+	return writePermissionsConsent(consentPath, consent)
+}
+
+// isInteractive tells whether the user can be prompted, that is whether the standard input and
+// the standard error are attached to a terminal.
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// sandboxDisabled tells whether the user opted out of enforcing the plugin permissions.
+func sandboxDisabled() bool {
+	return strings.EqualFold(os.Getenv(EnvDisablePluginSandbox), "true")
+}
+
+// sandboxError is returned when a plugin is not started because its permissions could not be enforced.
+func sandboxError(err error) error {
+	return errors.Wrapf(err, "could not restrict the plugin to its permissions, set %s=true to run plugins without restrictions",
+		EnvDisablePluginSandbox)
+}
+
+func readPermissionsConsent(path string) (permissionsConsent, error) {
+	consent := permissionsConsent{}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return consent, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read plugin permissions consent")
+	}
+	if err := yaml.Unmarshal(b, &consent); err != nil {
+		return nil, errors.Wrap(err, "could not decode plugin permissions consent")
+	}
+	return consent, nil
+}
+
+func writePermissionsConsent(path string, consent permissionsConsent) error {
+	b, err := yaml.Marshal(consent)
+	if err != nil {
+		return errors.Wrap(err, "could not encode plugin permissions consent")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "could not create plugin root")
+	}
+	return os.WriteFile(path, b, 0600)
+}
+
+// filterEnv removes the environment variables the plugin is not permitted to see.
+func filterEnv(env []string, perms *cliapi.PluginPermissions) []string {
+	if perms == nil || perms.Kubeconfig {
+		return env
+	}
+	filtered := make([]string, 0, len(env))
+	for _, kv := range env {
+		withheld := false
+		for _, key := range kubeconfigEnvVars {
+			if strings.HasPrefix(kv, key+"=") {
+				withheld = true
+				break
+			}
+		}
+		if !withheld {
+			filtered = append(filtered, kv)
+		}
+	}
+	return filtered
+}
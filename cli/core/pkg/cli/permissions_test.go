@@ -0,0 +1,56 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+)
+
+func TestPermissionsDigestIgnoresOrder(t *testing.T) {
+	d1, err := PermissionsDigest(&cliapi.PluginPermissions{Network: []string{"a.com", "b.com"}, Filesystem: []string{"/tmp/x"}})
+	require.NoError(t, err)
+	d2, err := PermissionsDigest(&cliapi.PluginPermissions{Network: []string{"b.com", "a.com"}, Filesystem: []string{"/tmp/x"}})
+	require.NoError(t, err)
+	require.Equal(t, d1, d2)
+
+	d3, err := PermissionsDigest(&cliapi.PluginPermissions{Network: []string{"a.com", "b.com"}, Kubeconfig: true})
+	require.NoError(t, err)
+	require.NotEqual(t, d1, d3)
+}
+
+func TestFilterEnv(t *testing.T) {
+	env := []string{"HOME=/home/user", "KUBECONFIG=/home/user/.kube/config", "KUBECONFIG_EXTRA=1"}
+
+	require.Equal(t, env, filterEnv(env, nil))
+	require.Equal(t, env, filterEnv(env, &cliapi.PluginPermissions{Kubeconfig: true}))
+	require.Equal(t, []string{"HOME=/home/user", "KUBECONFIG_EXTRA=1"}, filterEnv(env, &cliapi.PluginPermissions{}))
+}
+
+func TestEnsurePermissionsConsent(t *testing.T) {
+	perms := &cliapi.PluginPermissions{Network: []string{"example.com:443"}}
+	r := NewRunner("foo", "", nil, WithPluginRoot(t.TempDir()), WithPermissions(perms))
+
+	// Non-interactive runs must not prompt and fail until the permissions are accepted.
+	err := r.ensurePermissionsConsent(false)
+	require.Error(t, err)
+
+	t.Setenv(EnvAcceptPluginPermissions, "true")
+	require.NoError(t, r.ensurePermissionsConsent(false))
+
+	// Consent is persisted for the same manifest.
+	t.Setenv(EnvAcceptPluginPermissions, "")
+	require.NoError(t, r.ensurePermissionsConsent(false))
+
+	// A changed manifest requires consent again.
+	r.permissions = &cliapi.PluginPermissions{Network: []string{"example.com:443"}, Kubeconfig: true}
+	require.Error(t, r.ensurePermissionsConsent(false))
+
+	// Plugins without a manifest keep running without asking for consent.
+	r.permissions = nil
+	require.NoError(t, r.ensurePermissionsConsent(false))
+}
@@ -14,6 +14,8 @@ import (
 	"strings"
 
 	"github.com/aunum/log"
+
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
 )
 
 // Runner is a plugin runner.
@@ -22,6 +24,7 @@ type Runner struct {
 	args          []string
 	pluginRoot    string
 	pluginAbsPath string
+	permissions   *cliapi.PluginPermissions
 }
 
 // NewRunner creates an instance of Runner.
@@ -33,6 +36,7 @@ func NewRunner(name, pluginAbsPath string, args []string, options ...Option) *Ru
 		args:          args,
 		pluginRoot:    opts.pluginRoot,
 		pluginAbsPath: pluginAbsPath,
+		permissions:   opts.permissions,
 	}
 	return r
 }
@@ -82,6 +86,11 @@ func (r *Runner) run(ctx context.Context, pluginPath string, stdout, stderr *byt
 		return fmt.Errorf("%q is a directory", pluginPath)
 	}
 
+	// Only prompt for consent when the plugin output is not being captured and a user is attached.
+	if err := r.ensurePermissionsConsent(stdout == nil && isInteractive()); err != nil {
+		return err
+	}
+
 	stateFile, err := os.CreateTemp("", "tanzu-cli-state")
 	if err != nil {
 		return fmt.Errorf("create state file: %w", err)
@@ -105,7 +114,7 @@ func (r *Runner) run(ctx context.Context, pluginPath string, stdout, stderr *byt
 		return fmt.Errorf("close state file: %w", err)
 	}
 
-	env := append(os.Environ(), fmt.Sprintf("%s=%s", EnvPluginStateKey, stateFile.Name()))
+	env := append(filterEnv(os.Environ(), r.permissions), fmt.Sprintf("%s=%s", EnvPluginStateKey, stateFile.Name()))
 
 	log.Debugf("running command path %s args: %+v", pluginPath, r.args)
 	cmd := exec.CommandContext(ctx, pluginPath, r.args...) //nolint:gosec
@@ -124,8 +133,12 @@ func (r *Runner) run(ctx context.Context, pluginPath string, stdout, stderr *byt
 		cmd.Stdout = os.Stdout
 	}
 
-	err = cmd.Run()
-	return err
+	stop, err := startSandboxed(cmd, pluginPath, r.permissions)
+	if err != nil {
+		return err
+	}
+	defer stop()
+	return cmd.Wait()
 }
 
 func (r *Runner) pluginName() string {
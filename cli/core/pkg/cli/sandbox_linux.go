@@ -0,0 +1,255 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+)
+
+const (
+	// landlockFileAccess are the access rights that can be granted on a regular file.
+	landlockFileAccess = unix.LANDLOCK_ACCESS_FS_EXECUTE | unix.LANDLOCK_ACCESS_FS_WRITE_FILE | unix.LANDLOCK_ACCESS_FS_READ_FILE
+
+	// landlockReadAccess are the access rights required to read and execute files beneath a directory.
+	landlockReadAccess = unix.LANDLOCK_ACCESS_FS_EXECUTE | unix.LANDLOCK_ACCESS_FS_READ_FILE | unix.LANDLOCK_ACCESS_FS_READ_DIR
+
+	// landlockFullAccess are all the access rights handled by the first landlock ABI.
+	landlockFullAccess = landlockReadAccess | unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_DIR | unix.LANDLOCK_ACCESS_FS_REMOVE_FILE |
+		unix.LANDLOCK_ACCESS_FS_MAKE_CHAR | unix.LANDLOCK_ACCESS_FS_MAKE_DIR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_REG | unix.LANDLOCK_ACCESS_FS_MAKE_SOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_FIFO | unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SYM
+)
+
+// Network access rights handled by the fourth landlock ABI.
+const (
+	landlockAccessNetConnectTCP = 1 << 1
+	landlockRuleNetPort         = 2
+	landlockNetworkABI          = 4
+)
+
+// systemReadOnlyPaths are the paths every plugin needs to read in order to run.
+var systemReadOnlyPaths = []string{"/bin", "/sbin", "/usr", "/lib", "/lib64", "/etc", "/proc"}
+
+// landlockRulesetAttr is the landlock_ruleset_attr of the landlock ABI 4.
+type landlockRulesetAttr struct {
+	handledAccessFS  uint64
+	handledAccessNet uint64
+}
+
+// landlockNetPortAttr is the landlock_net_port_attr of the landlock ABI 4.
+type landlockNetPortAttr struct {
+	allowedAccess uint64
+	port          uint64
+}
+
+// sandboxProfile are the restrictions applied to a plugin process.
+type sandboxProfile struct {
+	// paths are the landlock access rights granted to the plugin indexed by path
+	paths map[string]uint64
+	// restrictNetwork tells whether outgoing TCP connections are restricted to connectPorts with landlock,
+	// landlock does not restrict the hosts connections are made to
+	restrictNetwork bool
+	connectPorts    []uint16
+}
+
+// startSandboxed starts the plugin command restricted to the declared permissions and returns a
+// function releasing the sandbox once the plugin exited:
+//   - network access is removed with a network namespace when no endpoint is declared, otherwise
+//     the plugin is configured to use an egress proxy only forwarding connections to the declared
+//     endpoints and to the servers of the kubeconfig. Landlock only scopes TCP connections by port:
+//     it restricts them to the port of the proxy and to the ports of the declared loopback endpoints,
+//     on any host, so a plugin bypassing the proxy can still reach other hosts on these ports.
+//   - filesystem access is restricted with landlock
+//   - datagram and raw sockets and the system calls plugins never need are denied with seccomp
+//
+// The plugin is not started if any of the restrictions cannot be applied, unless the user opted
+// out of the sandbox. Plugins that do not declare permissions run without restrictions.
+func startSandboxed(cmd *exec.Cmd, pluginPath string, perms *cliapi.PluginPermissions) (func(), error) {
+	stop := func() {}
+	if perms == nil {
+		return stop, cmd.Start()
+	}
+	if sandboxDisabled() {
+		log.Warningf("%s is set, plugin permissions are not enforced", EnvDisablePluginSandbox)
+		return stop, cmd.Start()
+	}
+
+	profile := &sandboxProfile{paths: landlockRules(pluginPath, perms)}
+	endpoints := allowedEndpoints(perms)
+	switch {
+	case len(endpoints) != 0:
+		proxy, err := startEgressProxy(endpoints)
+		if err != nil {
+			return nil, sandboxError(err)
+		}
+		stop = proxy.Close
+		cmd.Env = append(withoutProxyEnv(cmd.Env), proxyEnv(proxy.URL())...)
+		profile.restrictNetwork = true
+		// Go HTTP clients never use a proxy for loopback addresses
+		profile.connectPorts = append([]uint16{proxy.Port()}, loopbackPorts(endpoints)...)
+	case userNamespacesEnabled():
+		// No ID mappings are written since the /proc of the restricted thread is read-only.
+		// The plugin keeps the credentials of the user on the host for file access checks.
+		cmd.SysProcAttr = &syscall.SysProcAttr{
+			Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET,
+		}
+	default:
+		profile.restrictNetwork = true
+	}
+
+	// Landlock and seccomp restrictions apply to the calling thread and are inherited by the
+	// processes it creates, so the plugin is started from a dedicated OS thread. The thread is
+	// discarded when the goroutine exits since it is never unlocked.
+	errCh := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+		if err := profile.restrictThread(); err != nil {
+			errCh <- sandboxError(err)
+			return
+		}
+		errCh <- cmd.Start()
+	}()
+	if err := <-errCh; err != nil {
+		stop()
+		return nil, err
+	}
+	return stop, nil
+}
+
+// userNamespacesEnabled tells whether unprivileged processes can create user namespaces.
+func userNamespacesEnabled() bool {
+	for _, p := range []string{"/proc/sys/kernel/unprivileged_userns_clone", "/proc/sys/user/max_user_namespaces"} {
+		b, err := os.ReadFile(p)
+		if err == nil && strings.TrimSpace(string(b)) == "0" {
+			return false
+		}
+	}
+	return true
+}
+
+// landlockRules returns the access rights granted to the plugin indexed by path.
+func landlockRules(pluginPath string, perms *cliapi.PluginPermissions) map[string]uint64 {
+	rules := map[string]uint64{}
+	grant := func(path string, access uint64) {
+		rules[path] |= access
+	}
+
+	for _, p := range systemReadOnlyPaths {
+		grant(p, landlockReadAccess)
+	}
+	grant("/dev", landlockReadAccess|unix.LANDLOCK_ACCESS_FS_WRITE_FILE)
+	grant(os.TempDir(), landlockReadAccess)
+	grant(pluginPath, landlockReadAccess)
+
+	for _, p := range perms.Filesystem {
+		grant(p, landlockFullAccess)
+	}
+	if perms.Kubeconfig {
+		for _, p := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+			grant(p, landlockFullAccess)
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			grant(filepath.Join(home, ".kube"), landlockFullAccess)
+		}
+	}
+	if perms.ConfigTokens {
+		if dir, err := config.LocalDir(); err == nil {
+			grant(dir, landlockFullAccess)
+		}
+	}
+	return rules
+}
+
+// landlockABI returns the landlock ABI version supported by the kernel, 0 if landlock is not available.
+func landlockABI() int {
+	abi, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errno != 0 {
+		return 0
+	}
+	return int(abi)
+}
+
+// restrictThread applies the landlock rules and the seccomp filter of the profile to the current OS thread.
+func (p *sandboxProfile) restrictThread() error {
+	abi := landlockABI()
+	if abi < 1 {
+		return errors.New("landlock is not available")
+	}
+	attr := landlockRulesetAttr{handledAccessFS: landlockFullAccess}
+	if p.restrictNetwork {
+		if abi < landlockNetworkABI {
+			return errors.Errorf("landlock ABI %d does not support network restrictions", abi)
+		}
+		attr.handledAccessNet = landlockAccessNetConnectTCP
+	}
+
+	fd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr), 0)
+	if errno != 0 {
+		return errors.Wrap(errno, "could not create landlock ruleset")
+	}
+	defer unix.Close(int(fd))
+
+	for path, access := range p.paths {
+		if err := addLandlockRule(int(fd), path, access); err != nil {
+			return errors.Wrapf(err, "could not allow access to %q", path)
+		}
+	}
+	for _, port := range p.connectPorts {
+		portAttr := landlockNetPortAttr{allowedAccess: landlockAccessNetConnectTCP, port: uint64(port)}
+		if _, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, fd, landlockRuleNetPort,
+			uintptr(unsafe.Pointer(&portAttr)), 0, 0, 0); errno != 0 {
+			return errors.Wrapf(errno, "could not allow connections to port %d", port)
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return err
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_RESTRICT_SELF, fd, 0, 0); errno != 0 {
+		return errors.Wrap(errno, "could not apply landlock ruleset")
+	}
+	return installSeccompFilter()
+}
+
+func addLandlockRule(rulesetFd int, path string, access uint64) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		access &= landlockFileAccess
+	}
+
+	pathFd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(pathFd)
+
+	attr := unix.LandlockPathBeneathAttr{Allowed_access: access, Parent_fd: int32(pathFd)}
+	_, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(rulesetFd), unix.LANDLOCK_RULE_PATH_BENEATH,
+		uintptr(unsafe.Pointer(&attr)), 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+)
+
+// sandboxHelperEnv is set when the test binary is run as a sandboxed plugin, to the action it performs.
+const sandboxHelperEnv = "TANZU_CLI_SANDBOX_TEST_ACTION"
+
+func TestMain(m *testing.M) {
+	if action := os.Getenv(sandboxHelperEnv); action != "" {
+		os.Exit(runSandboxHelper(action))
+	}
+	os.Exit(m.Run())
+}
+
+// runSandboxHelper performs "read PATH", "dial HOST:PORT" or "udp" and returns a non zero exit code if it fails.
+func runSandboxHelper(action string) int {
+	verb, arg, _ := strings.Cut(action, " ")
+	var err error
+	switch verb {
+	case "read":
+		_, err = os.ReadFile(arg)
+	case "dial":
+		var conn net.Conn
+		if conn, err = net.DialTimeout("tcp", arg, 5*time.Second); err == nil {
+			conn.Close()
+		}
+	case "udp":
+		var conn net.PacketConn
+		if conn, err = net.ListenPacket("udp4", "127.0.0.1:0"); err == nil {
+			conn.Close()
+		}
+	default:
+		err = fmt.Errorf("unknown action %q", action)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// runSandboxed runs the test binary as a plugin with perms performing action. The test binary
+// initializes the tanzu config, so it is given access to it.
+func runSandboxed(t *testing.T, perms *cliapi.PluginPermissions, action string) error {
+	if perms != nil {
+		perms = perms.DeepCopy()
+		perms.ConfigTokens = true
+	}
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), sandboxHelperEnv+"="+action)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stop, err := startSandboxed(cmd, os.Args[0], perms)
+	if err != nil {
+		return err
+	}
+	defer stop()
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func requireSandbox(t *testing.T) {
+	if abi := landlockABI(); abi < landlockNetworkABI {
+		t.Skipf("landlock ABI %d does not support network restrictions", abi)
+	}
+}
+
+func TestSandboxRestrictsFilesystem(t *testing.T) {
+	requireSandbox(t)
+	// the temporary directory is readable by plugins
+	dir, err := os.MkdirTemp(".", "sandbox-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	dir, err = filepath.Abs(dir)
+	require.NoError(t, err)
+	secret := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secret, []byte("secret"), 0600))
+
+	err = runSandboxed(t, &cliapi.PluginPermissions{}, "read "+secret)
+	require.ErrorContains(t, err, "permission denied")
+
+	require.NoError(t, runSandboxed(t, &cliapi.PluginPermissions{Filesystem: []string{dir}}, "read "+secret))
+}
+
+func TestSandboxRestrictsNetwork(t *testing.T) {
+	requireSandbox(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	addr := listener.Addr().String()
+
+	// without declared endpoints the plugin runs without network
+	require.Error(t, runSandboxed(t, &cliapi.PluginPermissions{}, "dial "+addr))
+
+	// endpoints that are not declared cannot be reached
+	err = runSandboxed(t, &cliapi.PluginPermissions{Network: []string{"example.com:443"}}, "dial "+addr)
+	require.ErrorContains(t, err, "permission denied")
+
+	require.NoError(t, runSandboxed(t, &cliapi.PluginPermissions{Network: []string{addr}}, "dial "+addr))
+}
+
+func TestSandboxDeniesDatagramSockets(t *testing.T) {
+	requireSandbox(t)
+	require.NoError(t, runSandboxed(t, nil, "udp"))
+
+	err := runSandboxed(t, &cliapi.PluginPermissions{Network: []string{"example.com:443"}}, "udp")
+	require.ErrorContains(t, err, "operation not permitted")
+}
+
+func TestSandboxFailsClosed(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	// the rule of a path beneath a file cannot be added
+	perms := &cliapi.PluginPermissions{Filesystem: []string{filepath.Join(file, "dir")}}
+
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), sandboxHelperEnv+"=read "+file)
+	_, err := startSandboxed(cmd, os.Args[0], perms)
+	require.ErrorContains(t, err, EnvDisablePluginSandbox)
+	require.Nil(t, cmd.Process)
+
+	t.Setenv(EnvDisablePluginSandbox, "true")
+	require.NoError(t, runSandboxed(t, perms, "read "+file))
+}
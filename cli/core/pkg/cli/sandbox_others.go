@@ -0,0 +1,28 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package cli
+
+import (
+	"os/exec"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+)
+
+// startSandboxed starts the plugin command. Permission enforcement is only supported on
+// Linux, on other platforms plugins declaring permissions only run once the user opted out
+// of the sandbox with EnvDisablePluginSandbox.
+func startSandboxed(cmd *exec.Cmd, _ string, perms *cliapi.PluginPermissions) (func(), error) {
+	if perms != nil {
+		if !sandboxDisabled() {
+			return nil, sandboxError(errors.New("plugin permissions are not enforced on this platform"))
+		}
+		log.Warningf("%s is set, plugin permissions are not enforced", EnvDisablePluginSandbox)
+	}
+	return func() {}, cmd.Start()
+}
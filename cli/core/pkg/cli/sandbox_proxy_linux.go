@@ -0,0 +1,234 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+
+	cliapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/cli/v1alpha1"
+)
+
+const egressProxyDialTimeout = 30 * time.Second
+
+// proxyEnvVars are the environment variables configuring the proxy of plugins.
+var proxyEnvVars = []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy"}
+
+// egressProxy is an HTTP proxy only forwarding the connections of a plugin to the endpoints it is allowed to reach.
+type egressProxy struct {
+	endpoints []string
+	listener  net.Listener
+	server    *http.Server
+	transport *http.Transport
+}
+
+// startEgressProxy starts a proxy forwarding the connections to endpoints on a loopback address.
+func startEgressProxy(endpoints []string) (*egressProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not start the plugin egress proxy")
+	}
+	p := &egressProxy{
+		endpoints: endpoints,
+		listener:  listener,
+		transport: &http.Transport{DialContext: (&net.Dialer{Timeout: egressProxyDialTimeout}).DialContext},
+	}
+	p.server = &http.Server{Handler: p, ReadHeaderTimeout: egressProxyDialTimeout}
+	go p.server.Serve(listener) //nolint:errcheck
+	return p, nil
+}
+
+// URL returns the URL of the proxy.
+func (p *egressProxy) URL() string {
+	return "http://" + p.listener.Addr().String()
+}
+
+// Port returns the port the proxy listens on.
+func (p *egressProxy) Port() uint16 {
+	return uint16(p.listener.Addr().(*net.TCPAddr).Port)
+}
+
+// Close stops the proxy and closes the connections it forwards.
+func (p *egressProxy) Close() {
+	_ = p.server.Close()
+	p.transport.CloseIdleConnections()
+}
+
+func (p *egressProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	p.forward(w, r)
+}
+
+// tunnel forwards a CONNECT request, typically for HTTPS.
+func (p *egressProxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	if !p.allowed(w, r.Host, "443") {
+		return
+	}
+	upstream, err := net.DialTimeout("tcp", r.Host, egressProxyDialTimeout)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		http.Error(w, "connection hijacking is not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+		upstream.Close()
+		return
+	}
+	go func() {
+		defer upstream.Close()
+		_, _ = io.Copy(upstream, buf)
+	}()
+	_, _ = io.Copy(conn, upstream)
+}
+
+// forward forwards a plain HTTP request.
+func (p *egressProxy) forward(w http.ResponseWriter, r *http.Request) {
+	if !r.URL.IsAbs() {
+		http.Error(w, "the plugin egress proxy only serves proxy requests", http.StatusBadRequest)
+		return
+	}
+	if !p.allowed(w, r.URL.Host, "80") {
+		return
+	}
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Header.Del("Proxy-Connection")
+	out.Header.Del("Proxy-Authorization")
+	resp, err := p.transport.RoundTrip(out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+// allowed tells whether the plugin is allowed to reach hostport, and denies the request otherwise.
+func (p *egressProxy) allowed(w http.ResponseWriter, hostport, defaultPort string) bool {
+	if endpointAllowed(p.endpoints, hostport, defaultPort) {
+		return true
+	}
+	log.Warningf("plugin connection to %s denied, the endpoint is not declared in the plugin permissions", hostport)
+	http.Error(w, fmt.Sprintf("the plugin is not permitted to connect to %s", hostport), http.StatusForbidden)
+	return false
+}
+
+// endpointAllowed tells whether hostport matches one of the endpoints, a host or host:port.
+// An endpoint without port matches every port of the host.
+func endpointAllowed(endpoints []string, hostport, defaultPort string) bool {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host, port = hostport, defaultPort
+	}
+	for _, endpoint := range endpoints {
+		endpointHost, endpointPort, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			endpointHost, endpointPort = endpoint, ""
+		}
+		if strings.EqualFold(endpointHost, host) && (endpointPort == "" || endpointPort == port) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedEndpoints returns the endpoints a plugin is allowed to reach: the declared endpoints and,
+// if the plugin has access to the kubeconfig, the servers of its clusters.
+func allowedEndpoints(perms *cliapi.PluginPermissions) []string {
+	endpoints := append([]string{}, perms.Network...)
+	if !perms.Kubeconfig {
+		return endpoints
+	}
+	config, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil {
+		log.Debugf("could not load the kubeconfig, the plugin cannot reach its clusters: %v", err)
+		return endpoints
+	}
+	for _, cluster := range config.Clusters {
+		server, err := url.Parse(cluster.Server)
+		if err != nil || server.Host == "" {
+			continue
+		}
+		port := server.Port()
+		if port == "" {
+			port = "443"
+			if server.Scheme == "http" {
+				port = "80"
+			}
+		}
+		endpoints = append(endpoints, net.JoinHostPort(server.Hostname(), port))
+	}
+	return endpoints
+}
+
+// loopbackPorts returns the ports of the loopback endpoints, which plugins connect to directly.
+func loopbackPorts(endpoints []string) []uint16 {
+	var ports []uint16
+	for _, endpoint := range endpoints {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			continue
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			continue
+		}
+		if n, err := strconv.ParseUint(port, 10, 16); err == nil {
+			ports = append(ports, uint16(n))
+		}
+	}
+	return ports
+}
+
+// proxyEnv returns the environment variables making plugins use the proxy for every non loopback endpoint.
+func proxyEnv(proxyURL string) []string {
+	return []string{"HTTP_PROXY=" + proxyURL, "HTTPS_PROXY=" + proxyURL, "http_proxy=" + proxyURL, "https_proxy=" + proxyURL}
+}
+
+// withoutProxyEnv removes the proxy configuration of the user from env.
+func withoutProxyEnv(env []string) []string {
+	filtered := make([]string, 0, len(env))
+	for _, kv := range env {
+		withheld := false
+		for _, key := range proxyEnvVars {
+			if strings.HasPrefix(kv, key+"=") {
+				withheld = true
+				break
+			}
+		}
+		if !withheld {
+			filtered = append(filtered, kv)
+		}
+	}
+	return filtered
+}
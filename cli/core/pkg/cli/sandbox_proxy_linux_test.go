@@ -0,0 +1,63 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointAllowed(t *testing.T) {
+	endpoints := []string{"example.com:8443", "Registry.example.com", "[::1]:6443"}
+
+	require.True(t, endpointAllowed(endpoints, "example.com:8443", "443"))
+	require.False(t, endpointAllowed(endpoints, "example.com:443", "443"))
+	require.False(t, endpointAllowed(endpoints, "example.com", "443"))
+	require.True(t, endpointAllowed(endpoints, "registry.example.com:5000", "443"))
+	require.True(t, endpointAllowed(endpoints, "registry.example.com", "80"))
+	require.True(t, endpointAllowed(endpoints, "[::1]:6443", "443"))
+	require.False(t, endpointAllowed(endpoints, "evil.example.com:443", "443"))
+}
+
+func TestLoopbackPorts(t *testing.T) {
+	require.Equal(t, []uint16{6443, 8080}, loopbackPorts([]string{"127.0.0.1:6443", "example.com:443", "localhost:8080", "localhost"}))
+}
+
+func TestEgressProxy(t *testing.T) {
+	for _, newServer := range []func(http.Handler) *httptest.Server{httptest.NewServer, httptest.NewTLSServer} {
+		server := newServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+		serverURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		get := func(endpoints []string) *http.Response {
+			proxy, err := startEgressProxy(endpoints)
+			require.NoError(t, err)
+			defer proxy.Close()
+			proxyURL, err := url.Parse(proxy.URL())
+			require.NoError(t, err)
+
+			transport := server.Client().Transport.(*http.Transport).Clone()
+			transport.Proxy = http.ProxyURL(proxyURL)
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if err != nil {
+				// the CONNECT request of HTTPS requests fails with the status of the proxy
+				require.True(t, strings.Contains(err.Error(), "Forbidden"), err.Error())
+				return &http.Response{StatusCode: http.StatusForbidden}
+			}
+			resp.Body.Close()
+			return resp
+		}
+
+		require.Equal(t, http.StatusOK, get([]string{serverURL.Host}).StatusCode)
+		require.Equal(t, http.StatusForbidden, get([]string{"example.com"}).StatusCode)
+	}
+}
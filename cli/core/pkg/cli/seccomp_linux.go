@@ -0,0 +1,111 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// seccomp filter return values
+const (
+	seccompRetKillProcess = 0x80000000
+	seccompRetErrno       = 0x00050000
+	seccompRetAllow       = 0x7fff0000
+)
+
+// offsets of the fields of struct seccomp_data, the arguments are read as their low 32 bits on
+// little endian architectures
+const (
+	seccompDataNr    = 0
+	seccompDataArch  = 4
+	seccompDataArgs0 = 16
+	seccompDataArgs1 = 24
+)
+
+// seccompAuditArchs are the architectures the seccomp filter supports.
+var seccompAuditArchs = map[string]uint32{
+	"amd64": unix.AUDIT_ARCH_X86_64,
+	"arm64": unix.AUDIT_ARCH_AARCH64,
+}
+
+// seccompDeniedSyscalls are the system calls plugins are not allowed to make: debugging other
+// processes, administering the host and kernel interfaces bypassing the other restrictions.
+var seccompDeniedSyscalls = []uint32{
+	unix.SYS_PTRACE,
+	unix.SYS_PROCESS_VM_READV,
+	unix.SYS_PROCESS_VM_WRITEV,
+	unix.SYS_MOUNT,
+	unix.SYS_UMOUNT2,
+	unix.SYS_PIVOT_ROOT,
+	unix.SYS_SETNS,
+	unix.SYS_KEXEC_LOAD,
+	unix.SYS_INIT_MODULE,
+	unix.SYS_FINIT_MODULE,
+	unix.SYS_DELETE_MODULE,
+	unix.SYS_REBOOT,
+	unix.SYS_SWAPON,
+	unix.SYS_SWAPOFF,
+	unix.SYS_BPF,
+	unix.SYS_PERF_EVENT_OPEN,
+	unix.SYS_KEYCTL,
+	unix.SYS_ADD_KEY,
+	unix.SYS_REQUEST_KEY,
+	unix.SYS_USERFAULTFD,
+	unix.SYS_OPEN_BY_HANDLE_AT,
+	unix.SYS_IO_URING_SETUP,
+}
+
+// seccompFilter returns the seccomp program denying seccompDeniedSyscalls, and packet, datagram
+// and raw internet sockets since the network access of plugins is only enforced for TCP.
+func seccompFilter(auditArch uint32) []unix.SockFilter {
+	stmt := func(code uint16, k uint32) unix.SockFilter {
+		return unix.SockFilter{Code: code, K: k}
+	}
+	jeq := func(k uint32, jt, jf uint8) unix.SockFilter {
+		return unix.SockFilter{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: jt, Jf: jf, K: k}
+	}
+	deny := stmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.EPERM))
+	allow := stmt(unix.BPF_RET|unix.BPF_K, seccompRetAllow)
+
+	filter := []unix.SockFilter{
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataArch),
+		jeq(auditArch, 1, 0),
+		stmt(unix.BPF_RET|unix.BPF_K, seccompRetKillProcess),
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataNr),
+	}
+	for _, nr := range seccompDeniedSyscalls {
+		filter = append(filter, jeq(nr, 0, 1), deny)
+	}
+	return append(filter,
+		jeq(unix.SYS_SOCKET, 0, 7),
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataArgs0),
+		jeq(unix.AF_PACKET, 6, 0),
+		jeq(unix.AF_INET, 1, 0),
+		jeq(unix.AF_INET6, 0, 3),
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataArgs1),
+		stmt(unix.BPF_ALU|unix.BPF_AND|unix.BPF_K, 0xf),
+		jeq(unix.SOCK_STREAM, 0, 1),
+		allow,
+		deny,
+	)
+}
+
+// installSeccompFilter applies the seccomp filter to the current OS thread, which must not be
+// able to gain privileges.
+func installSeccompFilter() error {
+	auditArch, ok := seccompAuditArchs[runtime.GOARCH]
+	if !ok {
+		return errors.Errorf("seccomp filters are not supported on %s", runtime.GOARCH)
+	}
+	filter := seccompFilter(auditArch)
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	if err := unix.Prctl(unix.PR_SET_SECCOMP, unix.SECCOMP_MODE_FILTER, uintptr(unsafe.Pointer(&prog)), 0, 0); err != nil {
+		return errors.Wrap(err, "could not apply seccomp filter")
+	}
+	return nil
+}
@@ -107,7 +107,13 @@ func genREADME(plugins []*cliapi.PluginDescriptor) error {
 func genMarkdownTreePlugins(plugins []*cliapi.PluginDescriptor) error {
 	args := []string{"generate-docs", "--docs-dir", docsDir}
 	for _, p := range plugins {
-		runner := cli.NewRunner(p.Name, p.InstallationPath, args)
+		// the plugin writes its docs to the docs directory
+		perms := p.Permissions
+		if perms != nil {
+			perms = perms.DeepCopy()
+			perms.Filesystem = append(perms.Filesystem, docsDir)
+		}
+		runner := cli.NewRunner(p.Name, p.InstallationPath, args, cli.WithPermissions(perms))
 		ctx := context.Background()
 		if err := runner.Run(ctx); err != nil {
 			return err
@@ -159,6 +159,27 @@ type PluginDescriptor struct {
 
 	// DefaultFeatureFlags is default featureflags to be configured if missing when invoking plugin
 	DefaultFeatureFlags map[string]bool `json:"defaultFeatureFlags"`
+
+	// Permissions declares the capabilities the plugin requires at runtime.
+	// Plugins that do not declare permissions run without restrictions.
+	Permissions *PluginPermissions `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+}
+
+// PluginPermissions is the permission manifest of a plugin.
+type PluginPermissions struct {
+	// Network is the list of network endpoints (host or host:port) the plugin connects to.
+	// When empty, the plugin is not given network access. Otherwise the plugin is given an HTTP proxy
+	// only forwarding connections to these endpoints; direct connections are only restricted by port.
+	Network []string `json:"network,omitempty" yaml:"network,omitempty"`
+
+	// Filesystem is the list of filesystem paths the plugin reads or writes.
+	Filesystem []string `json:"filesystem,omitempty" yaml:"filesystem,omitempty"`
+
+	// Kubeconfig tells whether the plugin requires access to the kubeconfig.
+	Kubeconfig bool `json:"kubeconfig,omitempty" yaml:"kubeconfig,omitempty"`
+
+	// ConfigTokens tells whether the plugin requires access to the tanzu config and the tokens stored in it.
+	ConfigTokens bool `json:"configTokens,omitempty" yaml:"configTokens,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -206,6 +206,11 @@ func (in *PluginDescriptor) DeepCopyInto(out *PluginDescriptor) {
 			(*out)[key] = val
 		}
 	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = new(PluginPermissions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginDescriptor.
@@ -217,3 +222,28 @@ func (in *PluginDescriptor) DeepCopy() *PluginDescriptor {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginPermissions) DeepCopyInto(out *PluginPermissions) {
+	*out = *in
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginPermissions.
+func (in *PluginPermissions) DeepCopy() *PluginPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginPermissions)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+// bulkClusterOptions are the flags of the commands operating on the clusters selected by a label selector
+type bulkClusterOptions struct {
+	labelSelector string
+	maxConcurrent int
+	maxFailures   int
+}
+
+func addBulkClusterFlags(cmd *cobra.Command, options *bulkClusterOptions) {
+	cmd.Flags().StringVarP(&options.labelSelector, "selector", "l", "", "Operate on the workload clusters matching the label selector (e.g. env=dev) instead of CLUSTER_NAME, in all namespaces unless --namespace is specified")
+	cmd.Flags().IntVar(&options.maxConcurrent, "max-concurrent", 1, "With --selector, the number of clusters operated on at the same time")
+	cmd.Flags().IntVar(&options.maxFailures, "max-failures", 0, "With --selector, the number of failed clusters tolerated before the remaining clusters are skipped")
+}
+
+// clusterNameOrSelectorArgs accepts either CLUSTER_NAME or the --selector flag
+func clusterNameOrSelectorArgs(options *bulkClusterOptions) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if options.labelSelector != "" {
+			if len(args) != 0 {
+				return errors.New("CLUSTER_NAME cannot be used with --selector")
+			}
+			return nil
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+}
+
+func (o *bulkClusterOptions) tkgctlOptions(namespace string, skipPrompt bool) tkgctl.BulkClusterOptions {
+	return tkgctl.BulkClusterOptions{
+		LabelSelector: o.labelSelector,
+		Namespace:     namespace,
+		MaxConcurrent: o.maxConcurrent,
+		MaxFailures:   o.maxFailures,
+		SkipPrompt:    skipPrompt,
+	}
+}
+
+// printBulkClusterResult prints the summary of a bulk cluster operation, if it was started
+func printBulkClusterResult(cmd *cobra.Command, result *client.BulkClusterOperationResult) {
+	if result == nil {
+		return
+	}
+	t := component.NewOutputWriter(cmd.OutOrStdout(), "table", "NAME", "NAMESPACE", "STATUS", "DURATION", "ERROR")
+	for i := range result.Clusters {
+		cluster := &result.Clusters[i]
+		duration := ""
+		if cluster.Duration > 0 {
+			duration = cluster.Duration.Round(time.Second).String()
+		}
+		t.AddRow(cluster.Name, cluster.Namespace, cluster.Status, duration, cluster.Error)
+	}
+	t.Render()
+}
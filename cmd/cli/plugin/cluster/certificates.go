@@ -0,0 +1,12 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "github.com/spf13/cobra"
+
+var clusterCertificatesCmd = &cobra.Command{
+	Use:          "certificates",
+	Short:        "Cluster control plane certificates operations",
+	SilenceUsage: true,
+}
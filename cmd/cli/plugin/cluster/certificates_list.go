@@ -0,0 +1,93 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type listCertificatesOptions struct {
+	namespace    string
+	outputFormat string
+}
+
+var lcc = &listCertificatesOptions{}
+
+var listCertificatesCmd = &cobra.Command{
+	Use:   "list CLUSTER_NAME",
+	Short: "List the expiry of the control plane certificates of a cluster",
+	Long: `List the expiry of the kubeadm certificates of the control plane machines and of the certificate authorities
+of a cluster. The expiry of the kubeadm certificates is read from the machine.cluster.x-k8s.io/certificates-expiry
+annotation of a machine, or estimated as one year after the machine was bootstrapped.`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         listCertificates,
+	SilenceUsage: true,
+}
+
+func init() {
+	listCertificatesCmd.Flags().StringVarP(&lcc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	listCertificatesCmd.Flags().StringVarP(&lcc.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	clusterCertificatesCmd.AddCommand(listCertificatesCmd)
+}
+
+func listCertificates(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("listing cluster certificates with a global server is not implemented yet")
+	}
+	return listCertificatesInternal(cmd, server, args[0])
+}
+
+//nolint:gocritic
+func listCertificatesInternal(cmd *cobra.Command, server *configapi.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	certificates, err := tkgctlClient.GetClusterCertificates(tkgctl.GetClusterCertificatesOptions{
+		ClusterName: clusterName,
+		Namespace:   lcc.namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	var t component.OutputWriter
+	if lcc.outputFormat == string(component.JSONOutputType) || lcc.outputFormat == string(component.YAMLOutputType) {
+		t = component.NewObjectWriter(cmd.OutOrStdout(), lcc.outputFormat, certificates)
+	} else {
+		t = component.NewOutputWriter(cmd.OutOrStdout(), lcc.outputFormat, "NAME", "KIND", "DESCRIPTION", "EXPIRES", "RESIDUAL", "SOURCE")
+		for _, certificate := range certificates {
+			t.AddRow(certificate.Name, certificate.Kind, certificate.Description, certificate.ExpiresAt.Format(time.RFC3339),
+				residualTime(certificate.ExpiresAt), certificate.Source)
+		}
+	}
+	t.Render()
+
+	return nil
+}
+
+// residualTime returns the time left before an expiry, or expired
+func residualTime(expiresAt time.Time) string {
+	residual := time.Until(expiresAt)
+	if residual <= 0 {
+		return "expired"
+	}
+	return duration.HumanDuration(residual)
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type rotateCertificatesOptions struct {
+	namespace  string
+	noWait     bool
+	unattended bool
+}
+
+var rcc = &rotateCertificatesOptions{}
+
+var rotateCertificatesCmd = &cobra.Command{
+	Use:   "rotate CLUSTER_NAME",
+	Short: "Renew the control plane certificates of a cluster",
+	Long: `Renew the kubeadm certificates of the control plane nodes of a cluster by rolling out its KubeadmControlPlane:
+every control plane machine is replaced by a machine bootstrapped with new certificates. The certificate authorities
+are not renewed.`,
+	Example: `
+    # Renew the control plane certificates of a cluster and wait for the rollout to complete
+    tanzu cluster certificates rotate my-cluster
+    # Start the renewal without confirmation and without waiting for the rollout
+    tanzu cluster certificates rotate my-cluster --namespace my-namespace --yes --no-wait`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         rotateCertificates,
+	SilenceUsage: true,
+}
+
+func init() {
+	rotateCertificatesCmd.Flags().StringVarP(&rcc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	rotateCertificatesCmd.Flags().BoolVar(&rcc.noWait, "no-wait", false, "Return once the rollout of the control plane is started")
+	rotateCertificatesCmd.Flags().BoolVarP(&rcc.unattended, "yes", "y", false, "Rotate the certificates without asking for confirmation")
+	clusterCertificatesCmd.AddCommand(rotateCertificatesCmd)
+}
+
+func rotateCertificates(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("rotating cluster certificates with a global server is not implemented yet")
+	}
+	return rotateCertificatesInternal(server, args[0])
+}
+
+func rotateCertificatesInternal(server *configapi.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	return tkgctlClient.RotateClusterCertificates(tkgctl.RotateClusterCertificatesOptions{
+		ClusterName: clusterName,
+		Namespace:   rcc.namespace,
+		SkipWait:    rcc.noWait,
+		SkipPrompt:  rcc.unattended,
+	})
+}
@@ -0,0 +1,14 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var clusterConfigCmd = &cobra.Command{
+	Use:          "config",
+	Short:        "Cluster configuration operations",
+	SilenceUsage: true,
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type configShowOptions struct {
+	clusterConfigFile string
+	resolved          bool
+	outputFormat      string
+}
+
+var configShowOpts = &configShowOptions{}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the values of a cluster configuration file",
+	Long: "Show the values of a cluster configuration file. A configuration file can extend a configuration profile, stored as " +
+		"<name>.yaml in the profiles directory of the TKG configuration directory, with the 'extends: <name>' key. " +
+		"Profiles can extend other profiles, and the values of a file replace the ones of the profiles it extends",
+	Example: `
+	# Show the values set by a cluster configuration file
+	tanzu cluster config show -f cluster-config.yaml
+	# Show the values of a cluster configuration file merged with the profiles it extends,
+	# along with the file each value comes from
+	tanzu cluster config show -f cluster-config.yaml --resolved`,
+	Args:         cobra.NoArgs,
+	RunE:         showClusterConfig,
+	SilenceUsage: true,
+}
+
+func init() {
+	configShowCmd.Flags().StringVarP(&configShowOpts.clusterConfigFile, "file", "f", "", "Cluster configuration file to show")
+	_ = configShowCmd.MarkFlagRequired("file")
+	configShowCmd.Flags().BoolVarP(&configShowOpts.resolved, "resolved", "", false, "Show the values merged with the configuration profiles the file extends, and the source of each value")
+	configShowCmd.Flags().StringVarP(&configShowOpts.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	clusterConfigCmd.AddCommand(configShowCmd)
+}
+
+func showClusterConfig(cmd *cobra.Command, args []string) error {
+	// resolving the configuration does not need a management cluster
+	tkgctlClient, err := createTKGClient("", "")
+	if err != nil {
+		return err
+	}
+
+	resolved, err := tkgctlClient.ShowClusterConfig(tkgctl.ShowClusterConfigOptions{
+		ClusterConfigFile: configShowOpts.clusterConfigFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !configShowOpts.resolved {
+		t := component.NewOutputWriter(cmd.OutOrStdout(), configShowOpts.outputFormat, "KEY", "VALUE")
+		for _, key := range resolved.Keys() {
+			if resolved.Sources[key] == configShowOpts.clusterConfigFile {
+				t.AddRow(key, fmt.Sprint(resolved.Values[key]))
+			}
+		}
+		t.Render()
+		return nil
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), configShowOpts.outputFormat, "KEY", "VALUE", "SOURCE")
+	for _, key := range resolved.Keys() {
+		t.AddRow(key, fmt.Sprint(resolved.Values[key]), resolved.Sources[key])
+	}
+	t.Render()
+	return nil
+}
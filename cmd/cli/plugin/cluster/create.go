@@ -5,8 +5,10 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,8 +17,10 @@ import (
 
 	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
 	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
 	tkr "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/controllers/source"
 	tkrutils "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/utils"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
@@ -43,7 +47,10 @@ type createClusterOptions struct {
 	controlPlaneMachineCount    int
 	workerMachineCount          int
 	timeout                     time.Duration
+	outputFormat                string
 	generateOnly                bool
+	convertOnly                 bool
+	validateOnly                bool
 	unattended                  bool
 }
 
@@ -64,6 +71,9 @@ func init() {
 	createClusterCmd.Flags().IntVarP(&cc.controlPlaneMachineCount, "controlplane-machine-count", "c", 0, "The number of control plane machines to be added to the workload cluster (default 1 or 3 depending on dev or prod plan)")
 	createClusterCmd.Flags().IntVarP(&cc.workerMachineCount, "worker-machine-count", "w", 0, "The number of worker machines to be added to the workload cluster (default 1 or 3 depending on dev or prod plan)")
 	createClusterCmd.Flags().BoolVarP(&cc.generateOnly, "dry-run", "d", false, "Does not create cluster, but show the deployment YAML instead")
+	createClusterCmd.Flags().BoolVarP(&cc.convertOnly, "convert-only", "", false, "Does not create cluster, but convert the legacy configuration file into a ClusterClass based Cluster configuration instead")
+	createClusterCmd.Flags().BoolVarP(&cc.validateOnly, "validate-only", "", false, "Does not create cluster, but run all the configuration checks and report every problem found")
+	createClusterCmd.Flags().StringVarP(&cc.outputFormat, "output", "o", "", "Output format of the configuration validation report (yaml|json|table), used with --validate-only")
 	createClusterCmd.Flags().StringVarP(&cc.namespace, "namespace", "n", "", "The namespace where the cluster should be deployed. Assumes 'default' if not specified")
 	createClusterCmd.Flags().StringVarP(&cc.vsphereControlPlaneEndpoint, "vsphere-controlplane-endpoint", "", "", "Virtual IP address or FQDN for the cluster's control plane nodes")
 	createClusterCmd.Flags().DurationVarP(&cc.timeout, "timeout", "t", constants.DefaultLongRunningOperationTimeout, "Time duration to wait for an operation before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s)")
@@ -108,7 +118,8 @@ func create(cmd *cobra.Command, args []string) error {
 		// information.
 		// Note: This is only used for testing purpose when management cluster
 		// does not exist and we want to test cluster template generation
-		if cc.generateOnly {
+		// or convert a cluster configuration file
+		if cc.generateOnly || cc.convertOnly {
 			server = &configapi.Server{
 				Type:                  configapi.ManagementClusterServerType,
 				ManagementClusterOpts: &configapi.ManagementClusterServer{},
@@ -125,10 +136,19 @@ func create(cmd *cobra.Command, args []string) error {
 	if server.IsGlobal() {
 		return errors.New("creating cluster with a global server is not implemented yet")
 	}
-	return createCluster(clusterName, server)
+	if cc.validateOnly && cc.generateOnly {
+		return errors.New("--validate-only and --dry-run cannot be used together")
+	}
+	if cc.convertOnly && (cc.generateOnly || cc.validateOnly) {
+		return errors.New("--convert-only cannot be used together with --dry-run or --validate-only")
+	}
+	if cc.convertOnly {
+		return convertClusterConfig(cmd.OutOrStdout(), clusterName, server)
+	}
+	return createCluster(cmd.OutOrStdout(), clusterName, server)
 }
 
-func createCluster(clusterName string, server *configapi.Server) error {
+func createCluster(out io.Writer, clusterName string, server *configapi.Server) error {
 	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
 	if err != nil {
 		return err
@@ -174,9 +194,68 @@ func createCluster(clusterName string, server *configapi.Server) error {
 		Edition:                     edition,
 	}
 
+	if cc.validateOnly {
+		report, err := tkgctlClient.ValidateCluster(ccOptions)
+		if err != nil {
+			return err
+		}
+		return renderValidationReport(out, cc.outputFormat, report)
+	}
+
 	return tkgctlClient.CreateCluster(ccOptions)
 }
 
+// convertClusterConfig writes the ClusterClass based Cluster configuration converted from the legacy configuration file
+// and reports the configuration variables which could not be converted
+func convertClusterConfig(out io.Writer, clusterName string, server *configapi.Server) error {
+	if cc.clusterConfigFile == "" {
+		return errors.New("--convert-only requires the cluster configuration file to be specified with --file")
+	}
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	result, err := tkgctlClient.ConvertClusterConfig(tkgctl.ConvertClusterConfigOptions{
+		ClusterConfigFile: cc.clusterConfigFile,
+		ClusterName:       clusterName,
+		Namespace:         cc.namespace,
+	})
+	if err != nil {
+		return err
+	}
+	content, err := result.YAML()
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write(content); err != nil {
+		return err
+	}
+	if len(result.UnmappedVariables) != 0 {
+		log.Warningf("The following configuration variables have no equivalent in the Cluster configuration and were not converted: %s", strings.Join(result.UnmappedVariables, ", "))
+	}
+	return nil
+}
+
+// renderValidationReport writes the configuration validation report and fails if it contains errors
+func renderValidationReport(out io.Writer, outputFormat string, report *client.ValidationReport) error {
+	var t component.OutputWriter
+	if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+		t = component.NewObjectWriter(out, outputFormat, report)
+	} else {
+		t = component.NewOutputWriter(out, outputFormat, "SEVERITY", "CHECK", "CONFIG KEY", "MESSAGE", "REMEDIATION")
+		for _, finding := range report.Findings {
+			t.AddRow(finding.Severity, finding.Check, finding.ConfigKey, finding.Message, finding.Remediation)
+		}
+	}
+	t.Render()
+
+	if report.HasErrors() {
+		return errors.Errorf("configuration validation failed: %s", report)
+	}
+	return nil
+}
+
 func getTkrVersionForMatchingTkr(clusterClient clusterclient.Client, tkrName string) (string, error) {
 	// get all the TKRs with tkrName prefix matching
 	tkrs, err := clusterClient.GetTanzuKubernetesReleases(tkrName)
@@ -0,0 +1,74 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type diagnoseClusterOptions struct {
+	namespace    string
+	outputFile   string
+	logTailLines int64
+}
+
+var dgc = &diagnoseClusterOptions{}
+
+var diagnoseClusterCmd = &cobra.Command{
+	Use:   "diagnose CLUSTER_NAME",
+	Short: "Collect a diagnostics bundle of a cluster",
+	Long: `Collect the Cluster API objects, the ClusterBootstrap, the PackageInstalls, the Tanzu Kubernetes releases,
+the nodes and the pod logs of a cluster, together with a summary of the failing conditions, into a redacted tarball`,
+	Example: `
+    # Collect a diagnostics bundle of a cluster
+    tanzu cluster diagnose my-cluster
+    # Collect a diagnostics bundle of a cluster with the complete pod logs
+    tanzu cluster diagnose my-cluster --namespace my-namespace --output my-cluster.tar.gz --log-lines 0`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         diagnose,
+	SilenceUsage: true,
+}
+
+func init() {
+	diagnoseClusterCmd.Flags().StringVarP(&dgc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	diagnoseClusterCmd.Flags().StringVarP(&dgc.outputFile, "output", "o", "", "Path of the diagnostics bundle. Assumes CLUSTER_NAME-diagnostics-TIME.tar.gz if not specified.")
+	diagnoseClusterCmd.Flags().Int64Var(&dgc.logTailLines, "log-lines", client.DefaultDiagnoseLogTailLines, "Number of lines collected from the end of each container log, 0 collects the complete logs")
+}
+
+func diagnose(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("diagnosing cluster with a global server is not implemented yet")
+	}
+	return diagnoseCluster(server, args[0])
+}
+
+func diagnoseCluster(server *configapi.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	diagnoseClusterOptions := tkgctl.DiagnoseClusterOptions{
+		ClusterName:  clusterName,
+		Namespace:    dgc.namespace,
+		OutputFile:   dgc.outputFile,
+		LogTailLines: dgc.logTailLines,
+	}
+
+	_, err = tkgctlClient.DiagnoseCluster(diagnoseClusterOptions)
+	return err
+}
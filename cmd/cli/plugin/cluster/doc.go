@@ -12,10 +12,13 @@ Usage:
 
 Flags:
 
-	-d, --dry-run       Does not create cluster but show the deployment YAML instead
-	-f, --file string   Cluster configuration file from which to create a Cluster
-	-h, --help          help for create
-	    --tkr string    TanzuKubernetesRelease(TKr) to be used for creating the workload cluster
+	    --convert-only    Does not create cluster but convert the legacy configuration file into a ClusterClass based Cluster configuration instead
+	-d, --dry-run         Does not create cluster but show the deployment YAML instead
+	-f, --file string     Cluster configuration file from which to create a Cluster
+	-h, --help            help for create
+	-o, --output string   Output format of the configuration validation report (yaml|json|table), used with --validate-only
+	    --tkr string      TanzuKubernetesRelease(TKr) to be used for creating the workload cluster
+	    --validate-only   Does not create cluster but run all the configuration checks and report every problem found
 
 # List clusters
 
@@ -54,6 +57,10 @@ Flags:
 	-h, --help                               help for scale
 	-n, --namespace string                   The namespace where the workload cluster was created. Assumes 'default' if not specified.
 	-w, --worker-machine-count int32         The number of worker nodes to scale to. Assumes unchanged if not specified
+	-l, --selector string                    Operate on the workload clusters matching the label selector (e.g. env=dev) instead of CLUSTER_NAME, in all namespaces unless --namespace is specified
+	    --max-concurrent int                 With --selector, the number of clusters operated on at the same time (default 1)
+	    --max-failures int                   With --selector, the number of failed clusters tolerated before the remaining clusters are skipped
+	-y, --yes                                With --selector, scale the clusters without asking for confirmation
 
 # Upgrade a cluster
 
@@ -68,6 +75,9 @@ Flags:
 	-t, --timeout duration            Time duration to wait for an operation before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s) (default 30m0s)
 	    --tkr string                  TanzuKubernetesRelease(TKr) to upgrade to
 	-y, --yes                         Upgrade workload cluster without asking for confirmation
+	-l, --selector string             Operate on the workload clusters matching the label selector (e.g. env=dev) instead of CLUSTER_NAME, in all namespaces unless --namespace is specified
+	    --max-concurrent int          With --selector, the number of clusters operated on at the same time (default 1)
+	    --max-failures int            With --selector, the number of failed clusters tolerated before the remaining clusters are skipped
 
 # Get,set, or delete a MachineHealthCheck object for a Tanzu Kubernetes cluster
 
@@ -172,6 +182,7 @@ Flags:
 	    --show-all-conditions string   List of comma separated kind or kind/name for which we should show all the object's conditions (all to show conditions for all the objects)
 	    --show-details                 Show details of MachineInfrastructure and BootstrapConfig when ready condition is true or it has the Status, Severity and Reason of the machine's object
 	    --show-group-members           Expand machine groups whose ready condition has the same Status, Severity and Reason
+	-w, --watch                        After the details, stream the condition transitions and events of the cluster until interrupted
 
 # Get kubeconfig of a cluster and merge the context into the default kubeconfig file
 
@@ -193,5 +204,224 @@ Flags:
 	    --export-file string   File path to export a standalone kubeconfig for workload cluster
 	-h, --help                 help for get
 	-n, --namespace string     The namespace where the workload cluster was created. Assumes 'default' if not specified.
+
+# Manage the ytt overlay packs applied to the cluster templates of the current management cluster
+
+The overlay packs are pinned by checksum in the tkg-overlay-packs ConfigMap of the tkg-system namespace of the
+management cluster, so that every user of the management cluster applies the same overlay packs. Each machine
+caches the overlay packs and fetches them again from their pinned image when they do not match their checksum.
+
+Usage:
+
+	tanzu cluster template overlays [command]
+
+Available Commands:
+
+	add         Fetch an overlay pack and pin it to the current management cluster
+	delete      Remove an overlay pack from the current management cluster
+	list        List the overlay packs pinned to the current management cluster
+
+Examples:
+
+	# Pin version v1.0.0 of an overlay pack
+	tanzu cluster template overlays add custom-labels --image registry.example.com/overlays/custom-labels:v1.0.0
+
+Flags:
+
+	-i, --image string    OCI image with tag of the overlay pack (add)
+	-o, --output string   Output format (yaml|json|table) (list)
+
+# Compare the cluster templates rendered by two providers bundles
+
+Usage:
+
+	tanzu cluster template diff [flags]
+
+Examples:
+
+	# Compare the current providers with the ones of a new release
+	tanzu cluster template diff -f cluster-config.yaml --from ~/.config/tanzu/tkg/providers --to ./providers
+
+Flags:
+
+	-f, --file string             Cluster configuration file to render
+	    --from string             Providers directory of the original templates
+	    --from-bom string         TKG BOM file name used to render the original templates
+	-h, --help                    help for diff
+	-i, --infrastructure string   The infrastructure provider of the templates, defaults to INFRASTRUCTURE_PROVIDER of the cluster configuration
+	-o, --output string           Output format (yaml|json), the diff is printed as text by default
+	-p, --plan string             The plan of the templates, defaults to CLUSTER_PLAN of the cluster configuration
+	    --to string               Providers directory of the new templates
+	    --to-bom string           TKG BOM file name used to render the new templates
+
+# Show the values of a cluster configuration file
+
+A configuration file can extend a configuration profile, stored as <name>.yaml in the profiles
+directory of the TKG configuration directory, with the 'extends: <name>' key. Profiles can extend
+other profiles, and the values of a file replace the ones of the profiles it extends.
+
+Usage:
+
+	tanzu cluster config show [flags]
+
+Examples:
+
+	# Show the values set by a cluster configuration file
+	tanzu cluster config show -f cluster-config.yaml
+	# Show the values of a cluster configuration file merged with the profiles it extends,
+	# along with the file each value comes from
+	tanzu cluster config show -f cluster-config.yaml --resolved
+
+Flags:
+
+	-f, --file string     Cluster configuration file to show
+	-h, --help            help for show
+	-o, --output string   Output format (yaml|json|table)
+	    --resolved        Show the values merged with the configuration profiles the file extends, and the source of each value
+
+# Collect a diagnostics bundle of a cluster
+
+Collect the Cluster API objects, the ClusterBootstrap, the PackageInstalls, the Tanzu Kubernetes releases,
+the nodes and the pod logs of a cluster, together with a summary of the failing conditions, into a redacted tarball
+
+Usage:
+
+	tanzu cluster diagnose CLUSTER_NAME [flags]
+
+Examples:
+
+	# Collect a diagnostics bundle of a cluster
+	tanzu cluster diagnose my-cluster
+	# Collect a diagnostics bundle of a cluster with the complete pod logs
+	tanzu cluster diagnose my-cluster --namespace my-namespace --output my-cluster.tar.gz --log-lines 0
+
+Flags:
+
+	-h, --help               help for diagnose
+	    --log-lines int      Number of lines collected from the end of each container log, 0 collects the complete logs (default 2000)
+	-n, --namespace string   The namespace where the workload cluster was created. Assumes 'default' if not specified.
+	-o, --output string      Path of the diagnostics bundle. Assumes CLUSTER_NAME-diagnostics-TIME.tar.gz if not specified.
+
+# Get the timeline of the condition transitions and events of a cluster
+
+Get the timeline of the condition transitions of the Cluster, KubeadmControlPlane, MachineDeployments,
+Machines and ClusterBootstrap of a cluster, merged with the Kubernetes Events of these objects
+
+Usage:
+
+	tanzu cluster events CLUSTER_NAME [flags]
+
+Examples:
+
+	# Get the timeline of a cluster
+	tanzu cluster events my-cluster
+	# Stream the timeline of a cluster as JSON lines until interrupted
+	tanzu cluster events my-cluster --namespace my-namespace --watch --output json
+
+Flags:
+
+	-h, --help               help for events
+	-n, --namespace string   The namespace where the workload cluster was created. Assumes 'default' if not specified.
+	-o, --output string      Output format (table|json), json prints one entry per line
+	-w, --watch              Keep streaming the new entries of the timeline until interrupted
+
+# List the expiry of the control plane certificates of a cluster
+
+List the expiry of the kubeadm certificates of the control plane machines and of the certificate authorities
+of a cluster. The expiry of the kubeadm certificates is read from the machine.cluster.x-k8s.io/certificates-expiry
+annotation of a machine, or estimated as one year after the machine was bootstrapped.
+
+Usage:
+
+	tanzu cluster certificates list CLUSTER_NAME [flags]
+
+Flags:
+
+	-h, --help               help for list
+	-n, --namespace string   The namespace where the workload cluster was created. Assumes 'default' if not specified.
+	-o, --output string      Output format (yaml|json|table)
+
+# Renew the control plane certificates of a cluster
+
+Renew the kubeadm certificates of the control plane nodes of a cluster by rolling out its KubeadmControlPlane:
+every control plane machine is replaced by a machine bootstrapped with new certificates. The certificate authorities
+are not renewed.
+
+Usage:
+
+	tanzu cluster certificates rotate CLUSTER_NAME [flags]
+
+Examples:
+
+	# Renew the control plane certificates of a cluster and wait for the rollout to complete
+	tanzu cluster certificates rotate my-cluster
+	# Start the renewal without confirmation and without waiting for the rollout
+	tanzu cluster certificates rotate my-cluster --namespace my-namespace --yes --no-wait
+
+Flags:
+
+	-h, --help               help for rotate
+	-n, --namespace string   The namespace where the workload cluster was created. Assumes 'default' if not specified.
+	    --no-wait            Return once the rollout of the control plane is started
+	-y, --yes                Rotate the certificates without asking for confirmation
+
+# Operate on the clusters matching a label selector
+
+tanzu cluster upgrade, tanzu cluster scale and tanzu cluster machinehealthcheck node set accept --selector instead of
+CLUSTER_NAME. The operation runs on the matching workload clusters, --max-concurrent at a time, and the remaining clusters
+are skipped once more than --max-failures clusters failed. A summary with the status of each cluster is printed at the end.
+
+Examples:
+
+	# Upgrade the workload clusters labeled env=dev with tkr prefix v1.24, three at a time
+	tanzu cluster upgrade -l env=dev --tkr v1.24 --max-concurrent 3
+	# Scale the workers of the workload clusters labeled env=dev, stopping at the second failure
+	tanzu cluster scale -l env=dev --worker-machine-count 3 --max-failures 1
+	# Set the MachineHealthCheck of the workload clusters labeled env=dev in a namespace
+	tanzu cluster machinehealthcheck node set -l env=dev -n my-namespace --unhealthy-conditions "Ready:False:5m"
+
+# Pause the reconciliation of a cluster
+
+Pause the Cluster API reconciliation of a cluster, the reconciliation of its ClusterBootstrap and the
+PackageInstalls of its add-ons, so that the cluster can be maintained without the controllers reverting the changes.
+The user pausing the cluster and the reason are recorded in annotations of the Cluster. The PackageInstalls of the
+workload cluster are left unchanged if the cluster is unreachable.
+
+Usage:
+
+	tanzu cluster pause CLUSTER_NAME [flags]
+
+Examples:
+
+	# Pause a cluster
+	tanzu cluster pause my-cluster --reason "storage maintenance"
+	# Resume the cluster once the maintenance is done
+	tanzu cluster resume my-cluster
+
+Flags:
+
+	-h, --help               help for pause
+	-n, --namespace string   The namespace where the workload cluster was created. Assumes 'default' if not specified.
+	    --reason string      The reason for pausing the cluster, recorded in the annotations of the cluster
+
+# Resume the reconciliation of a paused cluster
+
+Resume the reconciliation of a cluster paused with 'tanzu cluster pause': the Cluster, its ClusterBootstrap and
+the PackageInstalls paused by the command are unpaused, and the pause annotations are removed. A cluster being upgraded
+stays paused until its add-ons are reconciled.
+
+Usage:
+
+	tanzu cluster resume CLUSTER_NAME [flags]
+
+Examples:
+
+	# Resume a paused cluster
+	tanzu cluster resume my-cluster --namespace my-namespace
+
+Flags:
+
+	-h, --help               help for resume
+	-n, --namespace string   The namespace where the workload cluster was created. Assumes 'default' if not specified.
 */
 package main
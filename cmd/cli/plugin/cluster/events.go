@@ -0,0 +1,132 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/timeline"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type clusterEventsOptions struct {
+	namespace string
+	watch     bool
+	output    string
+}
+
+var ce = &clusterEventsOptions{}
+
+var clusterEventsCmd = &cobra.Command{
+	Use:   "events CLUSTER_NAME",
+	Short: "Get the timeline of the condition transitions and events of a cluster",
+	Long: `Get the timeline of the condition transitions of the Cluster, KubeadmControlPlane, MachineDeployments,
+Machines and ClusterBootstrap of a cluster, merged with the Kubernetes Events of these objects`,
+	Example: `
+    # Get the timeline of a cluster
+    tanzu cluster events my-cluster
+    # Stream the timeline of a cluster as JSON lines until interrupted
+    tanzu cluster events my-cluster --namespace my-namespace --watch --output json`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         events,
+	SilenceUsage: true,
+}
+
+func init() {
+	clusterEventsCmd.Flags().StringVarP(&ce.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	clusterEventsCmd.Flags().BoolVarP(&ce.watch, "watch", "w", false, "Keep streaming the new entries of the timeline until interrupted")
+	clusterEventsCmd.Flags().StringVarP(&ce.output, "output", "o", "", "Output format (table|json), json prints one entry per line")
+}
+
+func events(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("getting cluster events with a global server is not implemented yet")
+	}
+	return clusterEvents(server, args[0])
+}
+
+func clusterEvents(server *configapi.Server, clusterName string) error {
+	printer, err := newTimelinePrinter(cmdOutput, ce.output)
+	if err != nil {
+		return err
+	}
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+	return watchClusterTimeline(tkgctlClient, tkgctl.WatchClusterTimelineOptions{
+		ClusterName: clusterName,
+		Namespace:   ce.namespace,
+		Follow:      ce.watch,
+		Handler:     printer.print,
+	})
+}
+
+// watchClusterTimeline watches the timeline of a cluster until it is handled or, when following it, until interrupted
+func watchClusterTimeline(tkgctlClient tkgctl.TKGClient, options tkgctl.WatchClusterTimelineOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return tkgctlClient.WatchClusterTimeline(ctx, options)
+}
+
+const timelineRowFormat = "%-20s  %-50s  %-9s  %-24s  %-7s  %-30s  %s\n"
+
+// timelinePrinter prints the entries of a timeline as they are handled, as table rows or JSON lines
+type timelinePrinter struct {
+	out           io.Writer
+	json          bool
+	headerPrinted bool
+}
+
+func newTimelinePrinter(out io.Writer, output string) (*timelinePrinter, error) {
+	switch output {
+	case "", "table":
+		return &timelinePrinter{out: out}, nil
+	case "json":
+		return &timelinePrinter{out: out, json: true}, nil
+	default:
+		return nil, errors.Errorf("unsupported output format %q, supported formats are table and json", output)
+	}
+}
+
+func (p *timelinePrinter) print(entry timeline.Entry) {
+	if p.json {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(p.out, string(line))
+		return
+	}
+	if !p.headerPrinted {
+		fmt.Fprintf(p.out, timelineRowFormat, "TIME", "OBJECT", "SOURCE", "TYPE", "STATUS", "REASON", "MESSAGE")
+		p.headerPrinted = true
+	}
+	fmt.Fprintf(p.out, timelineRowFormat, entry.Time.Local().Format(time.RFC3339), entry.Object(), entry.Source, entry.Type,
+		valueOrDash(entry.Status), valueOrDash(entry.Reason), entry.Message)
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -39,6 +39,7 @@ type getClustersOptions struct {
 	disableGroupObjects bool
 	showDetails         bool
 	showGroupMembers    bool
+	watch               bool
 }
 
 var cd = &getClustersOptions{}
@@ -66,6 +67,8 @@ func init() {
 	getClustersCmd.Flags().BoolVar(&cd.disableGroupObjects, "disable-grouping", false, "Disable grouping machines when ready condition has the same Status, Severity and Reason")
 	command.DeprecateFlagWithAlternative(getClustersCmd, "disable-grouping", "1.6.0", "--show-group-members")
 	getClustersCmd.Flags().BoolVar(&cd.showGroupMembers, "show-group-members", false, "Expand machine groups whose ready condition has the same Status, Severity and Reason")
+
+	getClustersCmd.Flags().BoolVarP(&cd.watch, "watch", "w", false, "After the details, stream the condition transitions and events of the cluster until interrupted")
 }
 
 func get(cmd *cobra.Command, args []string) error {
@@ -162,6 +165,20 @@ func getCluster(server *configapi.Server, clusterName string) error {
 		p.Render()
 	}
 
+	if cd.watch {
+		log.Infof("\n\nTimeline:\n\n")
+		printer, err := newTimelinePrinter(cmdOutput, "table")
+		if err != nil {
+			return err
+		}
+		return watchClusterTimeline(tkgctlClient, tkgctl.WatchClusterTimelineOptions{
+			ClusterName: clusterName,
+			Namespace:   cd.namespace,
+			Follow:      true,
+			Handler:     printer.print,
+		})
+	}
+
 	return nil
 
 }
@@ -80,7 +80,11 @@ func listClusters(cmd *cobra.Command, server *configapi.Server) error {
 			if len(cl.Roles) != 0 {
 				clusterRoles = strings.Join(cl.Roles, ",")
 			}
-			t.AddRow(cl.Name, cl.Namespace, cl.Status, cl.ControlPlaneCount, cl.WorkerCount, cl.K8sVersion, clusterRoles, cl.Plan, cl.TKR)
+			status := cl.Status
+			if cl.Paused {
+				status += " (paused)"
+			}
+			t.AddRow(cl.Name, cl.Namespace, status, cl.ControlPlaneCount, cl.WorkerCount, cl.K8sVersion, clusterRoles, cl.Plan, cl.TKR)
 		}
 	}
 	t.Render()
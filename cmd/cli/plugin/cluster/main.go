@@ -51,6 +51,13 @@ func main() {
 		getClustersCmd,
 		availableUpgradesCmd,
 		clusterNodePoolCmd,
+		clusterTemplateCmd,
+		clusterConfigCmd,
+		diagnoseClusterCmd,
+		clusterEventsCmd,
+		clusterCertificatesCmd,
+		pauseClusterCmd,
+		resumeClusterCmd,
 	)
 	if err := p.Execute(); err != nil {
 		os.Exit(1)
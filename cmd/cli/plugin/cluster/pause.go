@@ -0,0 +1,68 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type pauseClusterOptions struct {
+	namespace string
+	reason    string
+}
+
+var pco = &pauseClusterOptions{}
+
+var pauseClusterCmd = &cobra.Command{
+	Use:   "pause CLUSTER_NAME",
+	Short: "Pause the reconciliation of a cluster",
+	Long: `Pause the Cluster API reconciliation of a cluster, the reconciliation of its ClusterBootstrap and the
+PackageInstalls of its add-ons, so that the cluster can be maintained without the controllers reverting the changes.
+The user pausing the cluster and the reason are recorded in annotations of the Cluster. The PackageInstalls of the
+workload cluster are left unchanged if the cluster is unreachable.`,
+	Example: `
+    # Pause a cluster
+    tanzu cluster pause my-cluster --reason "storage maintenance"
+    # Resume the cluster once the maintenance is done
+    tanzu cluster resume my-cluster`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         pause,
+	SilenceUsage: true,
+}
+
+func init() {
+	pauseClusterCmd.Flags().StringVarP(&pco.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	pauseClusterCmd.Flags().StringVar(&pco.reason, "reason", "", "The reason for pausing the cluster, recorded in the annotations of the cluster")
+}
+
+func pause(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("pausing cluster with a global server is not implemented yet")
+	}
+	return pauseCluster(server, args[0])
+}
+
+func pauseCluster(server *configapi.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	return tkgctlClient.PauseCluster(tkgctl.PauseClusterOptions{
+		ClusterName: clusterName,
+		Namespace:   pco.namespace,
+		Reason:      pco.reason,
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type resumeClusterOptions struct {
+	namespace string
+}
+
+var rco = &resumeClusterOptions{}
+
+var resumeClusterCmd = &cobra.Command{
+	Use:   "resume CLUSTER_NAME",
+	Short: "Resume the reconciliation of a paused cluster",
+	Long: `Resume the reconciliation of a cluster paused with 'tanzu cluster pause': the Cluster, its ClusterBootstrap and
+the PackageInstalls paused by the command are unpaused, and the pause annotations are removed. A cluster being upgraded
+stays paused until its add-ons are reconciled.`,
+	Example: `
+    # Resume a paused cluster
+    tanzu cluster resume my-cluster --namespace my-namespace`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         resume,
+	SilenceUsage: true,
+}
+
+func init() {
+	resumeClusterCmd.Flags().StringVarP(&rco.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+}
+
+func resume(cmd *cobra.Command, args []string) error {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("resuming cluster with a global server is not implemented yet")
+	}
+	return resumeCluster(server, args[0])
+}
+
+func resumeCluster(server *configapi.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	return tkgctlClient.ResumeCluster(tkgctl.ResumeClusterOptions{
+		ClusterName: clusterName,
+		Namespace:   rco.namespace,
+	})
+}
@@ -19,14 +19,22 @@ type scaleClustersOptions struct {
 	nodePoolName      string
 	workerCount       int32
 	controlPlaneCount int32
+	unattended        bool
+	bulk              bulkClusterOptions
 }
 
 var sc = &scaleClustersOptions{}
 
 var scaleClusterCmd = &cobra.Command{
-	Use:          "scale CLUSTER_NAME",
-	Short:        "Scale a cluster",
-	Args:         cobra.ExactArgs(1),
+	Use:   "scale CLUSTER_NAME",
+	Short: "Scale a cluster",
+	Args:  clusterNameOrSelectorArgs(&sc.bulk),
+	Example: `
+  # Scale the workers of a workload cluster
+  tanzu cluster scale wc-1 --worker-machine-count 3
+
+  # Scale the workers of the workload clusters labeled env=dev, stopping at the second failure
+  tanzu cluster scale -l env=dev --worker-machine-count 3 --max-failures 1`,
 	RunE:         scale,
 	SilenceUsage: true,
 }
@@ -36,6 +44,9 @@ func init() {
 	scaleClusterCmd.Flags().Int32VarP(&sc.controlPlaneCount, "controlplane-machine-count", "c", 0, "The number of control plane nodes to scale to. Assumes unchanged if not specified")
 	scaleClusterCmd.Flags().StringVarP(&sc.nodePoolName, "node-pool-name", "p", "", "The name of the node-pool to scale")
 	scaleClusterCmd.Flags().StringVarP(&sc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	scaleClusterCmd.Flags().BoolVarP(&sc.unattended, "yes", "y", false, "With --selector, scale the clusters without asking for confirmation")
+
+	addBulkClusterFlags(scaleClusterCmd, &sc.bulk)
 }
 
 func scale(cmd *cobra.Command, args []string) error {
@@ -47,6 +58,9 @@ func scale(cmd *cobra.Command, args []string) error {
 	if server.IsGlobal() {
 		return errors.New("scaling cluster with a global server is not implemented yet")
 	}
+	if sc.bulk.labelSelector != "" {
+		return scaleClusters(cmd, server)
+	}
 	return scaleCluster(server, args[0])
 }
 
@@ -66,3 +80,19 @@ func scaleCluster(server *configapi.Server, clusterName string) error {
 
 	return tkgctlClient.ScaleCluster(scaleClusterOptions)
 }
+
+func scaleClusters(cmd *cobra.Command, server *configapi.Server) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	result, err := tkgctlClient.ScaleClusters(tkgctl.ScaleClustersOptions{
+		BulkClusterOptions: sc.bulk.tkgctlOptions(sc.namespace, sc.unattended),
+		ControlPlaneCount:  sc.controlPlaneCount,
+		WorkerCount:        sc.workerCount,
+		NodePoolName:       sc.nodePoolName,
+	})
+	printBulkClusterResult(cmd, result)
+	return err
+}
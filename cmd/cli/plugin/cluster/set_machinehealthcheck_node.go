@@ -20,6 +20,8 @@ type setMachineHealthCheckNodeOptions struct {
 	matchLabels            string
 	unhealthyConditions    string
 	nodeStartupTimeout     string
+	unattended             bool
+	bulk                   bulkClusterOptions
 }
 
 var setMHCNode = &setMachineHealthCheckNodeOptions{}
@@ -27,8 +29,8 @@ var setMHCNode = &setMachineHealthCheckNodeOptions{}
 var setMachineHealthCheckNodeCmd = &cobra.Command{
 	Use:          "set CLUSTER_NAME",
 	Short:        "Create or update a MachineHealthCheck for a cluster",
-	Long:         "Create or update a MachineHealthCheck for a cluster",
-	Args:         cobra.ExactArgs(1),
+	Long:         "Create or update a MachineHealthCheck for a cluster, or for the clusters matching a label selector",
+	Args:         clusterNameOrSelectorArgs(&setMHCNode.bulk),
 	RunE:         setMachineHealthCheckNode,
 	SilenceUsage: true,
 }
@@ -39,6 +41,8 @@ func init() {
 	setMachineHealthCheckNodeCmd.Flags().StringVar(&setMHCNode.nodeStartupTimeout, "node-startup-timeout", "", "Any machine being created that takes longer than this duration to join the cluster is considered to have failed and will be remediated")
 	setMachineHealthCheckNodeCmd.Flags().StringVar(&setMHCNode.matchLabels, "match-labels", "", "Label selector to match machines whose health will be exercised")
 	setMachineHealthCheckNodeCmd.Flags().StringVar(&setMHCNode.unhealthyConditions, "unhealthy-conditions", "", "A list of the conditions that determine whether a node is considered unhealthy. Available condition types: [Ready, MemoryPressure,DiskPressure,PIDPressure, NetworkUnavailable], Available condition status: [True, False, Unknown]")
+	setMachineHealthCheckNodeCmd.Flags().BoolVarP(&setMHCNode.unattended, "yes", "y", false, "With --selector, set the MachineHealthChecks without asking for confirmation")
+	addBulkClusterFlags(setMachineHealthCheckNodeCmd, &setMHCNode.bulk)
 	machineHealthCheckNodeCmd.AddCommand(setMachineHealthCheckNodeCmd)
 }
 
@@ -51,6 +55,9 @@ func setMachineHealthCheckNode(cmd *cobra.Command, args []string) error {
 	if server.IsGlobal() {
 		return errors.New("setting machine healthcheck with a global server is not implemented yet")
 	}
+	if setMHCNode.bulk.labelSelector != "" {
+		return runCreateMachineHealthChecksNode(cmd, server)
+	}
 	return runCreateMachineHealthCheckNode(server, args[0])
 }
 
@@ -70,3 +77,24 @@ func runCreateMachineHealthCheckNode(server *configapi.Server, clusterName strin
 	}
 	return tkgctlClient.SetMachineHealthCheck(options)
 }
+
+func runCreateMachineHealthChecksNode(cmd *cobra.Command, server *configapi.Server) error {
+	// the MachineHealthChecks are named after their clusters, which may share a namespace
+	if setMHCNode.machineHealthCheckName != "" {
+		return errors.New("--mhc-name cannot be used with --selector")
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	result, err := tkgctlClient.SetMachineHealthChecks(tkgctl.SetMachineHealthChecksOptions{
+		BulkClusterOptions:  setMHCNode.bulk.tkgctlOptions(setMHCNode.namespace, setMHCNode.unattended),
+		MatchLabels:         setMHCNode.matchLabels,
+		UnhealthyConditions: setMHCNode.unhealthyConditions,
+		NodeStartupTimeout:  setMHCNode.nodeStartupTimeout,
+	})
+	printBulkClusterResult(cmd, result)
+	return err
+}
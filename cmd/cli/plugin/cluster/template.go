@@ -0,0 +1,14 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var clusterTemplateCmd = &cobra.Command{
+	Use:          "template",
+	Short:        "Cluster template operations",
+	SilenceUsage: true,
+}
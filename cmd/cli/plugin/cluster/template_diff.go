@@ -0,0 +1,91 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type templateDiffOptions struct {
+	clusterConfigFile      string
+	infrastructureProvider string
+	plan                   string
+	fromProvidersDir       string
+	fromBOM                string
+	toProvidersDir         string
+	toBOM                  string
+	outputFormat           string
+}
+
+var templateDiffOpts = &templateDiffOptions{}
+
+var templateDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the cluster templates rendered by two providers bundles",
+	Long: "Render the same cluster configuration through the templates of two providers bundles, and show the changes " +
+		"of the resulting Kubernetes resources field by field",
+	Example: `
+	# Compare the current providers with the ones of a new release
+	tanzu cluster template diff -f cluster-config.yaml --from ~/.config/tanzu/tkg/providers --to ./providers`,
+	Args:         cobra.NoArgs,
+	RunE:         diffClusterTemplate,
+	SilenceUsage: true,
+}
+
+func init() {
+	templateDiffCmd.Flags().StringVarP(&templateDiffOpts.clusterConfigFile, "file", "f", "", "Cluster configuration file to render")
+	_ = templateDiffCmd.MarkFlagRequired("file")
+	templateDiffCmd.Flags().StringVarP(&templateDiffOpts.fromProvidersDir, "from", "", "", "Providers directory of the original templates")
+	_ = templateDiffCmd.MarkFlagRequired("from")
+	templateDiffCmd.Flags().StringVarP(&templateDiffOpts.toProvidersDir, "to", "", "", "Providers directory of the new templates")
+	_ = templateDiffCmd.MarkFlagRequired("to")
+	templateDiffCmd.Flags().StringVarP(&templateDiffOpts.fromBOM, "from-bom", "", "", "TKG BOM file name used to render the original templates")
+	templateDiffCmd.Flags().StringVarP(&templateDiffOpts.toBOM, "to-bom", "", "", "TKG BOM file name used to render the new templates")
+	templateDiffCmd.Flags().StringVarP(&templateDiffOpts.plan, "plan", "p", "", "The plan of the templates, defaults to CLUSTER_PLAN of the cluster configuration")
+	templateDiffCmd.Flags().StringVarP(&templateDiffOpts.infrastructureProvider, "infrastructure", "i", "", "The infrastructure provider of the templates, defaults to INFRASTRUCTURE_PROVIDER of the cluster configuration")
+	templateDiffCmd.Flags().StringVarP(&templateDiffOpts.outputFormat, "output", "o", "", "Output format (yaml|json), the diff is printed as text by default")
+
+	clusterTemplateCmd.AddCommand(templateDiffCmd)
+}
+
+func diffClusterTemplate(cmd *cobra.Command, args []string) error {
+	// rendering the templates does not need a management cluster
+	kubeconfig, kubecontext := "", ""
+	if server, err := config.GetCurrentServer(); err == nil && server.ManagementClusterOpts != nil {
+		kubeconfig, kubecontext = server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context
+	}
+	tkgctlClient, err := createTKGClient(kubeconfig, kubecontext)
+	if err != nil {
+		return err
+	}
+
+	diff, err := tkgctlClient.DiffClusterTemplate(tkgctl.DiffClusterTemplateOptions{
+		ClusterConfigFile:      templateDiffOpts.clusterConfigFile,
+		InfrastructureProvider: templateDiffOpts.infrastructureProvider,
+		Plan:                   templateDiffOpts.plan,
+		From:                   tkgctl.TemplateSource{ProvidersDir: templateDiffOpts.fromProvidersDir, BOM: templateDiffOpts.fromBOM},
+		To:                     tkgctl.TemplateSource{ProvidersDir: templateDiffOpts.toProvidersDir, BOM: templateDiffOpts.toBOM},
+	})
+	if err != nil {
+		return err
+	}
+
+	if templateDiffOpts.outputFormat == string(component.JSONOutputType) || templateDiffOpts.outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), templateDiffOpts.outputFormat, diff).Render()
+		return nil
+	}
+	if diff.IsEmpty() {
+		fmt.Fprintln(cmd.OutOrStdout(), "No differences found")
+		return nil
+	}
+	fmt.Fprint(cmd.OutOrStdout(), diff.String())
+	return nil
+}
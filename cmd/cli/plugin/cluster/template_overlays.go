@@ -0,0 +1,143 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+)
+
+type overlayPackOptions struct {
+	image        string
+	outputFormat string
+}
+
+var overlayPackOpts = &overlayPackOptions{}
+
+var templateOverlaysCmd = &cobra.Command{
+	Use:   "overlays",
+	Short: "Manage the ytt overlay packs applied to the cluster templates",
+	Long: "Manage the ytt overlay packs applied to the cluster templates. Overlay packs are fetched from OCI images and " +
+		"pinned to the current management cluster, they are applied to the templates of all the clusters it creates. " +
+		"The pins are stored in the tkg-system namespace of the management cluster, and the overlay packs are fetched " +
+		"again on each machine that creates clusters",
+	SilenceUsage: true,
+}
+
+var addOverlayPackCmd = &cobra.Command{
+	Use:          "add OVERLAY_PACK_NAME",
+	Short:        "Fetch an overlay pack and pin it to the current management cluster",
+	Args:         cobra.ExactArgs(1),
+	RunE:         addOverlayPack,
+	SilenceUsage: true,
+}
+
+var listOverlayPacksCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the overlay packs pinned to the current management cluster",
+	Args:         cobra.NoArgs,
+	RunE:         listOverlayPacks,
+	SilenceUsage: true,
+}
+
+var deleteOverlayPackCmd = &cobra.Command{
+	Use:          "delete OVERLAY_PACK_NAME",
+	Short:        "Remove an overlay pack from the current management cluster",
+	Args:         cobra.ExactArgs(1),
+	RunE:         deleteOverlayPack,
+	SilenceUsage: true,
+}
+
+func init() {
+	addOverlayPackCmd.Flags().StringVarP(&overlayPackOpts.image, "image", "i", "", "OCI image with tag of the overlay pack")
+	_ = addOverlayPackCmd.MarkFlagRequired("image")
+	listOverlayPacksCmd.Flags().StringVarP(&overlayPackOpts.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	templateOverlaysCmd.AddCommand(addOverlayPackCmd, listOverlayPacksCmd, deleteOverlayPackCmd)
+	clusterTemplateCmd.AddCommand(templateOverlaysCmd)
+}
+
+func getOverlayPacksServer() (*configapi.Server, error) {
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return nil, err
+	}
+	if server.IsGlobal() {
+		return nil, errors.New("managing overlay packs with a global server is not implemented yet")
+	}
+	return server, nil
+}
+
+func addOverlayPack(cmd *cobra.Command, args []string) error {
+	server, err := getOverlayPacksServer()
+	if err != nil {
+		return err
+	}
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	pack, err := tkgctlClient.AddOverlayPack(tkgctl.AddOverlayPackOptions{
+		Name:  args[0],
+		Image: overlayPackOpts.image,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Overlay pack '%s' pinned to %s with checksum %s", pack.Name, pack.Image, pack.Checksum)
+	return nil
+}
+
+func listOverlayPacks(cmd *cobra.Command, args []string) error {
+	server, err := getOverlayPacksServer()
+	if err != nil {
+		return err
+	}
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	packs, err := tkgctlClient.GetOverlayPacks()
+	if err != nil {
+		return err
+	}
+
+	var t component.OutputWriter
+	if overlayPackOpts.outputFormat == string(component.JSONOutputType) || overlayPackOpts.outputFormat == string(component.YAMLOutputType) {
+		t = component.NewObjectWriter(cmd.OutOrStdout(), overlayPackOpts.outputFormat, packs)
+	} else {
+		t = component.NewOutputWriter(cmd.OutOrStdout(), overlayPackOpts.outputFormat, "NAME", "IMAGE", "CHECKSUM", "FETCHED")
+		for _, pack := range packs {
+			t.AddRow(pack.Name, pack.Image, pack.Checksum, pack.FetchedAt.Format("2006-01-02 15:04:05"))
+		}
+	}
+	t.Render()
+	return nil
+}
+
+func deleteOverlayPack(cmd *cobra.Command, args []string) error {
+	server, err := getOverlayPacksServer()
+	if err != nil {
+		return err
+	}
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	if err := tkgctlClient.DeleteOverlayPack(args[0]); err != nil {
+		return err
+	}
+	log.Infof("Overlay pack '%s' removed", args[0])
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/config"
 	tkrutils "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/utils"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
@@ -37,6 +38,7 @@ type upgradeClustersOptions struct {
 	osVersion           string
 	osArch              string
 	vSphereTemplateName string
+	bulk                bulkClusterOptions
 }
 
 const (
@@ -48,7 +50,7 @@ var uc = &upgradeClustersOptions{}
 var upgradeClusterCmd = &cobra.Command{
 	Use:   "upgrade CLUSTER_NAME",
 	Short: "Upgrade a cluster",
-	Args:  cobra.ExactArgs(1),
+	Args:  clusterNameOrSelectorArgs(&uc.bulk),
 	Example: `
   # Upgrade a workload cluster
   tanzu cluster upgrade wc-1
@@ -68,6 +70,9 @@ var upgradeClusterCmd = &cobra.Command{
   # Upgrade a workload cluster using specific os name, version and arch
   tanzu cluster upgrade wc-1 --os-name ubuntu --os-version 20.04 --os-arch amd64
 
+  # Upgrade the workload clusters labeled env=dev with tkr prefix v1.24, three at a time
+  tanzu cluster upgrade -l env=dev --tkr v1.24 --max-concurrent 3
+
   [+] : Options available for: os-name, os-version, os-arch are as follows:
   vSphere:
     --os-name ubuntu --os-version 20.04 --os-arch amd64
@@ -95,6 +100,8 @@ func init() {
 
 	upgradeClusterCmd.Flags().StringVarP(&uc.vSphereTemplateName, "vsphere-vm-template-name", "", "", "The vSphere VM template to be used with upgraded kubernetes version. Discovered automatically if not provided")
 	upgradeClusterCmd.Flags().MarkHidden("vsphere-vm-template-name") // nolint
+
+	addBulkClusterFlags(upgradeClusterCmd, &uc.bulk)
 }
 
 func upgrade(cmd *cobra.Command, args []string) error {
@@ -106,6 +113,9 @@ func upgrade(cmd *cobra.Command, args []string) error {
 	if server.IsGlobal() {
 		return errors.New("upgrading cluster with a global server is not implemented yet")
 	}
+	if uc.bulk.labelSelector != "" {
+		return upgradeClusters(cmd, server)
+	}
 	return upgradeCluster(server, args[0])
 }
 
@@ -123,7 +133,7 @@ func upgradeCluster(server *configapi.Server, clusterName string) error {
 			return err
 		}
 
-		tkrVersion, err = getValidTkrVersionFromTkrForUpgrade(tkgctlClient, clusterClient, clusterName)
+		tkrVersion, err = getValidTkrVersionFromTkrForUpgrade(tkgctlClient, clusterClient, clusterName, uc.namespace)
 		if err != nil {
 			return err
 		}
@@ -150,10 +160,46 @@ func upgradeCluster(server *configapi.Server, clusterName string) error {
 	return tkgctlClient.UpgradeCluster(upgradeClusterOptions)
 }
 
-func getValidTkrVersionFromTkrForUpgrade(tkgctlClient tkgctl.TKGClient, clusterClient clusterclient.Client, clusterName string) (string, error) {
+func upgradeClusters(cmd *cobra.Command, server *configapi.Server) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	edition, err := config.GetEdition()
+	if err != nil {
+		return err
+	}
+
+	upgradeClustersOptions := tkgctl.UpgradeClustersOptions{
+		BulkClusterOptions: uc.bulk.tkgctlOptions(uc.namespace, uc.unattended),
+		Timeout:            uc.timeout,
+		OSName:             uc.osName,
+		OSVersion:          uc.osVersion,
+		OSArch:             uc.osArch,
+		Edition:            edition,
+	}
+	if uc.tkrName != "" {
+		clusterClientOptions := clusterclient.Options{GetClientInterval: 2 * time.Second, GetClientTimeout: 5 * time.Second}
+		clusterClient, err := clusterclient.NewClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context, clusterClientOptions)
+		if err != nil {
+			return err
+		}
+		// the TKr a cluster can be upgraded to depends on its current TKr
+		upgradeClustersOptions.ResolveTkrVersion = func(cluster client.ClusterInfo) (string, error) {
+			return getValidTkrVersionFromTkrForUpgrade(tkgctlClient, clusterClient, cluster.Name, cluster.Namespace)
+		}
+	}
+
+	result, err := tkgctlClient.UpgradeClusters(upgradeClustersOptions)
+	printBulkClusterResult(cmd, result)
+	return err
+}
+
+func getValidTkrVersionFromTkrForUpgrade(tkgctlClient tkgctl.TKGClient, clusterClient clusterclient.Client, clusterName, namespace string) (string, error) {
 	result, err := tkgctlClient.DescribeCluster(tkgctl.DescribeTKGClustersOptions{
 		ClusterName: clusterName,
-		Namespace:   uc.namespace,
+		Namespace:   namespace,
 	})
 	if err != nil {
 		return "", err
@@ -167,7 +213,7 @@ func getValidTkrVersionFromTkrForUpgrade(tkgctlClient tkgctl.TKGClient, clusterC
 	// TODO: update this condition after CLI fully support the package based LCM.
 	// Since CLI should support the pre package-based-lcm where the updatesAvailable condition was part of
 	// TKRs, code checking the TKRs for available upgrade should remain.
-	cluster, err := getClusterResource(clusterClient, clusterName, uc.namespace)
+	cluster, err := getClusterResource(clusterClient, clusterName, namespace)
 	if err == nil && capiconditions.Has(cluster, runv1.ConditionUpdatesAvailable) {
 		return getValidTKRVersionFromClusterForUpgrade(cluster, uc.tkrName)
 	}
@@ -175,9 +221,9 @@ func getValidTkrVersionFromTkrForUpgrade(tkgctlClient tkgctl.TKGClient, clusterC
 	tkrForUpgrade, err := getMatchingTkrForTkrName(tkrs, uc.tkrName)
 	// If the complete TKR name is provided, use it
 	if err == nil {
-		return getValidTKRVersionForUpgradeGivenFullTKRName(clusterName, uc.namespace, result.ClusterInfo.Labels, &tkrForUpgrade, tkrs)
+		return getValidTKRVersionForUpgradeGivenFullTKRName(clusterName, namespace, result.ClusterInfo.Labels, &tkrForUpgrade, tkrs)
 	}
-	return getValidTKRVersionForUpgradeGivenTKRNamePrefix(clusterName, uc.namespace, uc.tkrName, result.ClusterInfo.K8sVersion, result.ClusterInfo.Labels, tkrs)
+	return getValidTKRVersionForUpgradeGivenTKRNamePrefix(clusterName, namespace, uc.tkrName, result.ClusterInfo.K8sVersion, result.ClusterInfo.Labels, tkrs)
 }
 
 func getValidTKRVersionForUpgradeGivenFullTKRName(clusterName, namespace string, clusterLabels map[string]string,
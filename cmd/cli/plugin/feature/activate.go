@@ -6,12 +6,16 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
 	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
 )
 
+var activateDependencies bool
+
 // FeatureActivateCmd is for activating Features
 var FeatureActivateCmd = &cobra.Command{
 	Use:   "activate <feature>",
@@ -19,12 +23,15 @@ var FeatureActivateCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Example: `
 	# Activate a cluster Feature
-	tanzu feature activate myfeature`,
+	tanzu feature activate myfeature
+	# Activate a cluster Feature and the Features it depends on without asking for confirmation
+	tanzu feature activate myfeature --yes`,
 	RunE: featureActivate,
 }
 
 func init() {
 	FeatureActivateCmd.Flags().StringVarP(&featuregate, "featuregate", "f", "tkg-system", "Activate a Feature gated by a particular FeatureGate")
+	FeatureActivateCmd.Flags().BoolVarP(&activateDependencies, "yes", "y", false, "Activate the Features the Feature depends on without asking for confirmation")
 }
 
 func featureActivate(cmd *cobra.Command, args []string) error {
@@ -37,9 +44,36 @@ func featureActivate(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
-	if err := featureGateClient.ActivateFeature(ctx, featureName, featuregate); err != nil {
+	plan, err := featureGateClient.GetActivationPlan(ctx, featureName, featuregate)
+	if err != nil {
+		return fmt.Errorf("couldn't activate feature %s: %w", featureName, err)
+	}
+	if len(plan.Conflicts) != 0 {
+		return fmt.Errorf("couldn't activate feature %s: it conflicts with activated features %s, deactivate them first",
+			featureName, strings.Join(plan.Conflicts, ", "))
+	}
+
+	if len(plan.Dependencies) == 0 {
+		if err := featureGateClient.ActivateFeature(ctx, featureName, featuregate); err != nil {
+			return fmt.Errorf("couldn't activate feature %s: %w", featureName, err)
+		}
+		cmd.Printf("Feature %s Activated", featureName)
+		return nil
+	}
+
+	cmd.Printf("Feature %s depends on the following Features, which are not activated:\n", featureName)
+	for _, dependency := range plan.Dependencies {
+		cmd.Printf("  %s\n", dependency)
+	}
+	if !activateDependencies {
+		if err := component.AskForConfirmation("Activate them together with " + featureName + "?"); err != nil {
+			return err
+		}
+	}
+	features := append(append([]string{}, plan.Dependencies...), featureName)
+	if err := featureGateClient.ActivateFeatures(ctx, featuregate, features...); err != nil {
 		return fmt.Errorf("couldn't activate feature %s: %w", featureName, err)
 	}
-	cmd.Printf("Feature %s Activated", featureName)
+	cmd.Printf("Features %s Activated", strings.Join(features, ", "))
 	return nil
 }
@@ -0,0 +1,76 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	crClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/cli/runtime/component"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+// FeatureHistoryCmd is for listing the activation changes of Features
+var FeatureHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the activation changes of Features",
+	Args:  cobra.NoArgs,
+	Example: `
+	# List the activation changes of the Features gated by tkg-system
+	tanzu feature history
+	# List the activation changes of the Features gated by tkg-system-sample
+	tanzu feature history --featuregate tkg-system-sample`,
+	RunE: featureHistory,
+}
+
+func init() {
+	FeatureHistoryCmd.Flags().StringVarP(&featuregate, "featuregate", "f", "tkg-system", "List the activation changes of Features gated by a particular FeatureGate")
+	FeatureHistoryCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+func featureHistory(cmd *cobra.Command, _ []string) error {
+	featureGateClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("couldn't get featureGateRunner: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	gate, err := featureGateClient.GetFeatureGate(ctx, featuregate)
+	if crClient.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("featuregate %s not found: %w", featuregate, err)
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "FEATURE", "TIME", "USER", "OLD", "NEW")
+	for _, change := range gate.Status.History {
+		t.AddRow(
+			change.Feature,
+			change.Time.UTC().Format(time.RFC3339),
+			change.User,
+			activationState(change.OldActivate),
+			activationState(change.NewActivate))
+	}
+	t.Render()
+
+	return nil
+}
+
+// activationState returns the activation intent of a change, or "-" when the feature was not in the spec.
+func activationState(activate *bool) string {
+	if activate == nil {
+		return "-"
+	}
+	if *activate {
+		return "activated"
+	}
+	return "deactivated"
+}
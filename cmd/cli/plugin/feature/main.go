@@ -34,6 +34,7 @@ func main() {
 		FeatureListCmd,
 		FeatureActivateCmd,
 		FeatureDeactivateCmd,
+		FeatureHistoryCmd,
 	)
 
 	if err := p.Execute(); err != nil {
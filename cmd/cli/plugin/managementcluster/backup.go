@@ -0,0 +1,93 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/backup"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+// backupPassphraseEnvVar is read when no passphrase file is given
+const backupPassphraseEnvVar = "TANZU_BACKUP_PASSPHRASE"
+
+type backupRegionOptions struct {
+	to             string
+	passphraseFile string
+	s3Endpoint     string
+	s3Region       string
+}
+
+var br = &backupRegionOptions{}
+
+var backupRegionCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the workload clusters of a management cluster",
+	Long: `Back up the Cluster API objects of the workload clusters managed by the current management cluster,
+together with the TKG objects they depend on, to an encrypted archive`,
+	Example: `
+    # Back up the current management cluster to a local file
+    TANZU_BACKUP_PASSPHRASE=... tanzu management-cluster backup --to ./mc-backup.tar.enc
+    # Back up the current management cluster to an S3-compatible object store
+    tanzu management-cluster backup --to s3://backups/mc-backup.tar.enc --s3-endpoint https://minio.example.com:9000 --passphrase-file ./passphrase`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForCurrentMC(runBackupRegion)
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	backupRegionCmd.Flags().StringVar(&br.to, "to", "", "Local file path or s3://bucket/key URL to write the backup to")
+	backupRegionCmd.Flags().StringVar(&br.passphraseFile, "passphrase-file", "", "File holding the passphrase used to encrypt the backup, the "+backupPassphraseEnvVar+" environment variable is used if not set")
+	backupRegionCmd.Flags().StringVar(&br.s3Endpoint, "s3-endpoint", "", "Endpoint of the S3-compatible object store, AWS S3 is used if not set")
+	backupRegionCmd.Flags().StringVar(&br.s3Region, "s3-region", "", "Region of the S3 bucket")
+	_ = backupRegionCmd.MarkFlagRequired("to")
+}
+
+func runBackupRegion(server *configapi.Server) error {
+	passphrase, err := readBackupPassphrase(br.passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	options := tkgctl.BackupRegionOptions{
+		ClusterName: server.Name,
+		Location:    br.to,
+		Passphrase:  passphrase,
+		S3Options:   backup.S3Options{Endpoint: br.s3Endpoint, Region: br.s3Region},
+	}
+	return tkgClient.BackupRegion(options)
+}
+
+// readBackupPassphrase reads the passphrase from the file if given, or from the environment
+func readBackupPassphrase(passphraseFile string) (string, error) {
+	if passphraseFile == "" {
+		if passphrase := os.Getenv(backupPassphraseEnvVar); passphrase != "" {
+			return passphrase, nil
+		}
+		return "", errors.Errorf("a passphrase is required, use --passphrase-file or set %s", backupPassphraseEnvVar)
+	}
+	data, err := os.ReadFile(passphraseFile)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read passphrase file")
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", errors.Errorf("passphrase file %s is empty", passphraseFile)
+	}
+	return passphrase, nil
+}
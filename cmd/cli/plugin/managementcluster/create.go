@@ -25,6 +25,7 @@ type initRegionOptions struct {
 	deployTKGonVsphere7         bool
 	unattended                  bool
 	dryRun                      bool
+	resume                      bool
 	forceConfigUpdate           bool
 	clusterConfigFile           string
 	additionalTKGManifests      string
@@ -68,7 +69,10 @@ var createCmd = &cobra.Command{
     # Create a management cluster on vSphere infrastructure by using an existing
     # bootstrapper cluster. The current kube context should point to that
     # of the existing bootstrap cluster.
-    tanzu management-cluster create --use-existing-bootstrap-cluster --file vsphere-mc-1.yaml`,
+    tanzu management-cluster create --use-existing-bootstrap-cluster --file vsphere-mc-1.yaml
+    # Resume a failed management cluster creation, skipping the phases that
+    # already completed
+    tanzu management-cluster create --resume --file vsphere-mc-1.yaml`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runInit()
@@ -124,6 +128,8 @@ func init() {
 
 	createCmd.Flags().BoolVar(&iro.dryRun, "dry-run", false, "Generates the management cluster manifest and writes the output to stdout without applying it")
 
+	createCmd.Flags().BoolVar(&iro.resume, "resume", false, "Resume a failed management cluster creation, skipping the phases that already completed")
+
 	// Hidden flags, mostly for development and testing
 
 	createCmd.Flags().StringVarP(&iro.targetNamespace, "target-namespace", "", "", "The target namespace where the providers should be deployed. If not specified, each provider will be installed in a provider's default namespace")
@@ -188,6 +194,7 @@ func runInit() error {
 		Timeout:                     iro.timeout,
 		Edition:                     edition,
 		GenerateOnly:                iro.dryRun,
+		Resume:                      iro.resume,
 		AdditionalTKGManifests:      iro.additionalTKGManifests,
 	}
 
@@ -0,0 +1,58 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+type diagnoseRegionOptions struct {
+	outputFile   string
+	logTailLines int64
+}
+
+var dgr = &diagnoseRegionOptions{}
+
+var diagnoseRegionCmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Collect a diagnostics bundle of the management cluster",
+	Long: `Collect the Cluster API objects, the ClusterBootstrap, the PackageInstalls, the Tanzu Kubernetes releases,
+the nodes and the pod logs of the current management cluster, together with a summary of the failing conditions,
+into a redacted tarball`,
+	Example: `
+    # Collect a diagnostics bundle of the current management cluster
+    tanzu management-cluster diagnose
+    # Collect a diagnostics bundle of the current management cluster with the complete pod logs
+    tanzu management-cluster diagnose --output mc.tar.gz --log-lines 0`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForCurrentMC(runDiagnoseRegion)
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	diagnoseRegionCmd.Flags().StringVarP(&dgr.outputFile, "output", "o", "", "Path of the diagnostics bundle. Assumes CLUSTER_NAME-diagnostics-TIME.tar.gz if not specified.")
+	diagnoseRegionCmd.Flags().Int64Var(&dgr.logTailLines, "log-lines", client.DefaultDiagnoseLogTailLines, "Number of lines collected from the end of each container log, 0 collects the complete logs")
+}
+
+func runDiagnoseRegion(server *configapi.Server) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	options := tkgctl.DiagnoseRegionOptions{
+		ClusterName:  server.Name,
+		OutputFile:   dgr.outputFile,
+		LogTailLines: dgr.logTailLines,
+	}
+	_, err = tkgClient.DiagnoseRegion(options)
+	return err
+}
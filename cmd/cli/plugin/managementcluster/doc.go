@@ -4,6 +4,35 @@
 /*
 Kubernetes management cluster operations.
 
+# Back up the workload clusters of a management cluster
+
+Back up the Cluster API objects of the workload clusters managed by the current management cluster,
+together with the TKG objects they depend on, to an encrypted archive
+
+Usage:
+
+	tanzu management-cluster backup [flags]
+
+Examples:
+
+	# Back up the current management cluster to a local file
+	TANZU_BACKUP_PASSPHRASE=... tanzu management-cluster backup --to ./mc-backup.tar.enc
+	# Back up the current management cluster to an S3-compatible object store
+	tanzu management-cluster backup --to s3://backups/mc-backup.tar.enc --s3-endpoint https://minio.example.com:9000 --passphrase-file ./passphrase
+
+Flags:
+
+	-h, --help                     help for backup
+	    --passphrase-file string   File holding the passphrase used to encrypt the backup, the TANZU_BACKUP_PASSPHRASE environment variable is used if not set
+	    --s3-endpoint string       Endpoint of the S3-compatible object store, AWS S3 is used if not set
+	    --s3-region string         Region of the S3 bucket
+	    --to string                Local file path or s3://bucket/key URL to write the backup to
+
+Global Flags:
+
+	--log-file string       Log file path
+	-v, --verbose int32     Number for the log level verbosity(0-9)
+
 # Get or set ceip participation
 
 Usage:
@@ -47,6 +76,9 @@ Examples:
 	# bootstrapper cluster. The current kube context should point to that
 	# of the existing bootstrap cluster.
 	tanzu management-cluster create --use-existing-bootstrap-cluster --file vsphere-mc-1.yaml
+	# Resume a failed management cluster creation, skipping the phases that
+	# already completed
+	tanzu management-cluster create --resume --file vsphere-mc-1.yaml
 
 Flags:
 
@@ -54,6 +86,7 @@ Flags:
 	    --browser string                   Specify the browser to open the Kickstart UI on. Use 'none' for no browser. Defaults to OS default browser. Supported: ['chrome', 'firefox', 'safari', 'ie', 'edge', 'none']
 	-f, --file string                      Configuration file from which to create a management cluster
 	-h, --help                             help for create
+	    --resume                           Resume a failed management cluster creation, skipping the phases that already completed
 	-t, --timeout duration                 Time duration to wait for an operation before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s) (default 30m0s)
 	-u, --ui                               Launch interactive management cluster provisioning UI
 	-e, --use-existing-bootstrap-cluster   Use an existing bootstrap cluster to deploy the management cluster
@@ -109,6 +142,34 @@ Global Flags:
 	--log-file string       Log file path
 	-v, --verbose int32     Number for the log level verbosity(0-9)
 
+# Collect a diagnostics bundle of the management cluster
+
+Collect the Cluster API objects, the ClusterBootstrap, the PackageInstalls, the Tanzu Kubernetes releases,
+the nodes and the pod logs of the current management cluster, together with a summary of the failing conditions,
+into a redacted tarball
+
+Usage:
+
+	tanzu management-cluster diagnose [flags]
+
+Examples:
+
+	# Collect a diagnostics bundle of the current management cluster
+	tanzu management-cluster diagnose
+	# Collect a diagnostics bundle of the current management cluster with the complete pod logs
+	tanzu management-cluster diagnose --output mc.tar.gz --log-lines 0
+
+Flags:
+
+	-h, --help            help for diagnose
+	    --log-lines int   Number of lines collected from the end of each container log, 0 collects the complete logs (default 2000)
+	-o, --output string   Path of the diagnostics bundle. Assumes CLUSTER_NAME-diagnostics-TIME.tar.gz if not specified.
+
+Global Flags:
+
+	--log-file string       Log file path
+	-v, --verbose int32     Number for the log level verbosity(0-9)
+
 Retrieves details about the current management cluster. Requires the current server to be a management cluster
 
 Usage:
@@ -193,6 +254,35 @@ Global Flags:
 
 Use "management-cluster permissions [command] --help" for more information about a command.
 
+# Restore the workload clusters of a backup to a management cluster
+
+Restore the workload clusters saved by "tanzu management-cluster backup" to the current management cluster.
+The TKG objects are restored first, the clusters are resumed once their Cluster API objects are restored
+
+Usage:
+
+	tanzu management-cluster restore [flags]
+
+Examples:
+
+	# Restore a local backup to the current management cluster
+	TANZU_BACKUP_PASSPHRASE=... tanzu management-cluster restore --from ./mc-backup.tar.enc
+	# Restore a backup from an S3-compatible object store
+	tanzu management-cluster restore --from s3://backups/mc-backup.tar.enc --s3-endpoint https://minio.example.com:9000 --passphrase-file ./passphrase
+
+Flags:
+
+	    --from string              Local file path or s3://bucket/key URL to read the backup from
+	-h, --help                     help for restore
+	    --passphrase-file string   File holding the passphrase used to encrypt the backup, the TANZU_BACKUP_PASSPHRASE environment variable is used if not set
+	    --s3-endpoint string       Endpoint of the S3-compatible object store, AWS S3 is used if not set
+	    --s3-region string         Region of the S3 bucket
+
+Global Flags:
+
+	--log-file string       Log file path
+	-v, --verbose int32     Number for the log level verbosity(0-9)
+
 # Upgrades the management cluster
 
 Usage:
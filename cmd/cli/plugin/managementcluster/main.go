@@ -49,6 +49,9 @@ func main() {
 		permissionsCmd,
 		importCmd,
 		clusterKubeconfigCmd,
+		backupRegionCmd,
+		restoreRegionCmd,
+		diagnoseRegionCmd,
 	)
 
 	if err = p.Execute(); err != nil {
@@ -0,0 +1,67 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/backup"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgctl"
+
+	configapi "github.com/vmware-tanzu/tanzu-framework/cli/runtime/apis/config/v1alpha1"
+)
+
+type restoreRegionOptions struct {
+	from           string
+	passphraseFile string
+	s3Endpoint     string
+	s3Region       string
+}
+
+var rr = &restoreRegionOptions{}
+
+var restoreRegionCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the workload clusters of a backup to a management cluster",
+	Long: `Restore the workload clusters saved by "tanzu management-cluster backup" to the current management cluster.
+The TKG objects are restored first, the clusters are resumed once their Cluster API objects are restored`,
+	Example: `
+    # Restore a local backup to the current management cluster
+    TANZU_BACKUP_PASSPHRASE=... tanzu management-cluster restore --from ./mc-backup.tar.enc
+    # Restore a backup from an S3-compatible object store
+    tanzu management-cluster restore --from s3://backups/mc-backup.tar.enc --s3-endpoint https://minio.example.com:9000 --passphrase-file ./passphrase`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForCurrentMC(runRestoreRegion)
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	restoreRegionCmd.Flags().StringVar(&rr.from, "from", "", "Local file path or s3://bucket/key URL to read the backup from")
+	restoreRegionCmd.Flags().StringVar(&rr.passphraseFile, "passphrase-file", "", "File holding the passphrase used to encrypt the backup, the "+backupPassphraseEnvVar+" environment variable is used if not set")
+	restoreRegionCmd.Flags().StringVar(&rr.s3Endpoint, "s3-endpoint", "", "Endpoint of the S3-compatible object store, AWS S3 is used if not set")
+	restoreRegionCmd.Flags().StringVar(&rr.s3Region, "s3-region", "", "Region of the S3 bucket")
+	_ = restoreRegionCmd.MarkFlagRequired("from")
+}
+
+func runRestoreRegion(server *configapi.Server) error {
+	passphrase, err := readBackupPassphrase(rr.passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	options := tkgctl.RestoreRegionOptions{
+		ClusterName: server.Name,
+		Location:    rr.from,
+		Passphrase:  passphrase,
+		S3Options:   backup.S3Options{Endpoint: rr.s3Endpoint, Region: rr.s3Region},
+	}
+	return tkgClient.RestoreRegion(options)
+}
@@ -96,16 +96,21 @@ func (f *FeatureGateClient) GetFeature(ctx context.Context, featureName string)
 	return feature, nil
 }
 
-// ActivateFeature activates a Feature
+// ActivateFeature activates a Feature. The Features it depends on must be activated and the activated Features must
+// not conflict with it, use ActivateFeatures to activate a Feature together with its dependencies.
 func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName, featureGateName string) error {
-	feature, err := f.GetFeature(ctx, featureName)
+	if err := f.checkMutable(ctx, featureName, "activate"); err != nil {
+		return err
+	}
+	plan, err := f.GetActivationPlan(ctx, featureName, featureGateName)
 	if err != nil {
-		return fmt.Errorf("couldn't get feature %s: %w", featureName, err)
+		return err
 	}
-	if !feature.Spec.Discoverable {
-		return fmt.Errorf("feature not found %s", featureName)
-	} else if feature.Spec.Immutable {
-		return fmt.Errorf("cannot activate an immutable feature %s", featureName)
+	if len(plan.Dependencies) != 0 {
+		return fmt.Errorf("feature %s depends on features %v, which are not activated", featureName, plan.Dependencies)
+	}
+	if len(plan.Conflicts) != 0 {
+		return fmt.Errorf("feature %s conflicts with activated features %v", featureName, plan.Conflicts)
 	}
 	gate, err := f.GetFeatureGate(ctx, featureGateName)
 	if err != nil {
@@ -114,28 +119,22 @@ func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName, fe
 	return f.setActivated(ctx, gate, featureName)
 }
 
-// setActivated sets the Feature to activate in FeatureGate
-func (f *FeatureGateClient) setActivated(ctx context.Context, gate *configv1alpha1.FeatureGate, featureName string) error {
-	for i, featureRef := range gate.Spec.Features {
-		if featureRef.Name == featureName {
-			gate.Spec.Features[i].Activate = true
-			return f.c.Update(ctx, gate)
+// ActivateFeatures activates Features together in a single update of the FeatureGate
+func (f *FeatureGateClient) ActivateFeatures(ctx context.Context, featureGateName string, featureNames ...string) error {
+	for _, featureName := range featureNames {
+		if err := f.checkMutable(ctx, featureName, "activate"); err != nil {
+			return err
 		}
 	}
-
-	ref := configv1alpha1.FeatureReference{
-		Name:     featureName,
-		Activate: true,
-	}
-	gate.Spec.Features = append(gate.Spec.Features, ref)
-	if err := f.c.Update(ctx, gate); err != nil {
-		return fmt.Errorf("couldn't update featurgate %s: %w", gate.Name, err)
+	gate, err := f.GetFeatureGate(ctx, featureGateName)
+	if err != nil {
+		return err
 	}
-	return nil
+	return f.setActivated(ctx, gate, featureNames...)
 }
 
-// DeactivateFeature deactivates a Feature
-func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName, featureGateName string) error {
+// checkMutable returns an error if the Feature cannot be activated or deactivated
+func (f *FeatureGateClient) checkMutable(ctx context.Context, featureName, action string) error {
 	feature, err := f.GetFeature(ctx, featureName)
 	if err != nil {
 		return fmt.Errorf("couldn't get feature %s: %w", featureName, err)
@@ -143,12 +142,54 @@ func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName,
 	if !feature.Spec.Discoverable {
 		return fmt.Errorf("feature not found %s", featureName)
 	} else if feature.Spec.Immutable {
-		return fmt.Errorf("cannot deactivate an immutable feature %s", featureName)
+		return fmt.Errorf("cannot %s an immutable feature %s", action, featureName)
+	}
+	return nil
+}
+
+// setActivated sets the Features to activate in FeatureGate, from now on and without expiry
+func (f *FeatureGateClient) setActivated(ctx context.Context, gate *configv1alpha1.FeatureGate, featureNames ...string) error {
+	for _, featureName := range featureNames {
+		found := false
+		for i, featureRef := range gate.Spec.Features {
+			if featureRef.Name == featureName {
+				gate.Spec.Features[i].Activate = true
+				gate.Spec.Features[i].ActivateAfter = nil
+				gate.Spec.Features[i].ExpiresAt = nil
+				found = true
+				break
+			}
+		}
+		if !found {
+			gate.Spec.Features = append(gate.Spec.Features, configv1alpha1.FeatureReference{
+				Name:     featureName,
+				Activate: true,
+			})
+		}
+	}
+
+	if err := f.c.Update(ctx, gate); err != nil {
+		return fmt.Errorf("couldn't update featurgate %s: %w", gate.Name, err)
+	}
+	return nil
+}
+
+// DeactivateFeature deactivates a Feature. No activated Feature must depend on it.
+func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName, featureGateName string) error {
+	if err := f.checkMutable(ctx, featureName, "deactivate"); err != nil {
+		return err
 	}
 	gate, err := f.GetFeatureGate(ctx, featureGateName)
 	if err != nil {
 		return err
 	}
+	features := &configv1alpha1.FeatureList{}
+	if err := f.c.List(ctx, features); err != nil {
+		return fmt.Errorf("couldn't list features: %w", err)
+	}
+	if dependents := activatedDependents(featureName, gate.Spec, features.Items); len(dependents) != 0 {
+		return fmt.Errorf("activated features %v depend on feature %s", dependents, featureName)
+	}
 	return f.setDeactivated(ctx, gate, featureName)
 }
 
@@ -160,6 +201,8 @@ func (f *FeatureGateClient) setDeactivated(ctx context.Context, gate *configv1al
 				return nil
 			}
 			gate.Spec.Features[i].Activate = false
+			gate.Spec.Features[i].ActivateAfter = nil
+			gate.Spec.Features[i].ExpiresAt = nil
 			return f.c.Update(ctx, gate)
 		}
 	}
@@ -0,0 +1,114 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+// ActivationPlan lists the changes needed to activate a Feature in a FeatureGate.
+type ActivationPlan struct {
+	// Feature is the name of the Feature to activate.
+	Feature string
+	// Dependencies are the Features the Feature depends on, directly or transitively, which are not activated.
+	Dependencies []string
+	// Conflicts are the activated Features which conflict with the Feature or its dependencies.
+	Conflicts []string
+}
+
+// GetActivationPlan computes the dependencies which need to be activated together with a Feature, and the activated
+// Features which conflict with it.
+func (f *FeatureGateClient) GetActivationPlan(ctx context.Context, featureName, featureGateName string) (*ActivationPlan, error) {
+	gate, err := f.GetFeatureGate(ctx, featureGateName)
+	if err != nil {
+		return nil, err
+	}
+	features := &configv1alpha1.FeatureList{}
+	if err := f.c.List(ctx, features); err != nil {
+		return nil, fmt.Errorf("couldn't list features: %w", err)
+	}
+	return computeActivationPlan(featureName, gate.Spec, features.Items)
+}
+
+// computeActivationPlan computes the activation plan of a Feature with the FeatureGate spec and the Features in the system.
+// Features are considered activated as of now, scheduled and expired Features are not.
+func computeActivationPlan(featureName string, spec configv1alpha1.FeatureGateSpec, features []configv1alpha1.Feature) (*ActivationPlan, error) {
+	discovered := make(map[string]*configv1alpha1.Feature)
+	for i := range features {
+		if features[i].Spec.Discoverable {
+			discovered[features[i].Name] = &features[i]
+		}
+	}
+	activatedFeatures, _, _ := util.ComputeFeatureStates(spec.SpecAt(time.Now()), features)
+	activated := sets.NewString(activatedFeatures...)
+
+	// Walk the dependencies of the feature.
+	closure := sets.NewString(featureName)
+	queue := []string{featureName}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		feature, ok := discovered[name]
+		if !ok {
+			return nil, fmt.Errorf("feature not found %s", name)
+		}
+		for _, dependency := range feature.Spec.DependsOn {
+			if closure.Has(dependency) {
+				continue
+			}
+			if _, ok := discovered[dependency]; !ok {
+				return nil, fmt.Errorf("feature %s depends on feature %s, which is not available", name, dependency)
+			}
+			closure.Insert(dependency)
+			queue = append(queue, dependency)
+		}
+	}
+
+	conflicts := sets.String{}
+	for _, name := range closure.List() {
+		for _, conflict := range discovered[name].Spec.ConflictsWith {
+			if activated.Has(conflict) || closure.Has(conflict) {
+				conflicts.Insert(conflict)
+			}
+		}
+	}
+	for _, name := range activated.List() {
+		for _, conflict := range discovered[name].Spec.ConflictsWith {
+			if closure.Has(conflict) {
+				conflicts.Insert(name)
+			}
+		}
+	}
+
+	return &ActivationPlan{
+		Feature:      featureName,
+		Dependencies: closure.Difference(activated).Delete(featureName).List(),
+		Conflicts:    conflicts.List(),
+	}, nil
+}
+
+// activatedDependents returns the Features activated as of now which depend on a Feature.
+func activatedDependents(featureName string, spec configv1alpha1.FeatureGateSpec, features []configv1alpha1.Feature) []string {
+	activatedFeatures, _, _ := util.ComputeFeatureStates(spec.SpecAt(time.Now()), features)
+	activated := sets.NewString(activatedFeatures...)
+	dependents := sets.String{}
+	for i := range features {
+		if !activated.Has(features[i].Name) {
+			continue
+		}
+		for _, dependency := range features[i].Spec.DependsOn {
+			if dependency == featureName {
+				dependents.Insert(features[i].Name)
+			}
+		}
+	}
+	return dependents.List()
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+func dependencyTestFeatures() []configv1alpha1.Feature {
+	feature := func(name string, activated bool, dependsOn, conflictsWith []string) configv1alpha1.Feature {
+		return configv1alpha1.Feature{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: configv1alpha1.FeatureSpec{
+				Discoverable:  true,
+				Activated:     activated,
+				DependsOn:     dependsOn,
+				ConflictsWith: conflictsWith,
+			},
+		}
+	}
+	return []configv1alpha1.Feature{
+		feature("base", false, nil, nil),
+		feature("middle", false, []string{"base"}, nil),
+		feature("top", false, []string{"middle", "shared"}, nil),
+		feature("shared", true, nil, nil),
+		feature("legacy", true, nil, []string{"middle"}),
+		feature("broken", false, []string{"missing"}, nil),
+	}
+}
+
+func TestComputeActivationPlan(t *testing.T) {
+	testCases := []struct {
+		description string
+		featureName string
+		spec        configv1alpha1.FeatureGateSpec
+		want        *ActivationPlan
+		err         string
+	}{
+		{
+			description: "transitive dependencies which are not activated",
+			featureName: "top",
+			want:        &ActivationPlan{Feature: "top", Dependencies: []string{"base", "middle"}, Conflicts: []string{"legacy"}},
+		},
+		{
+			description: "activated dependencies and deactivated conflicts",
+			featureName: "top",
+			spec: configv1alpha1.FeatureGateSpec{Features: []configv1alpha1.FeatureReference{
+				{Name: "base", Activate: true},
+				{Name: "legacy", Activate: false},
+			}},
+			want: &ActivationPlan{Feature: "top", Dependencies: []string{"middle"}, Conflicts: []string{}},
+		},
+		{
+			description: "scheduled and expired features are not activated",
+			featureName: "top",
+			spec: configv1alpha1.FeatureGateSpec{Features: []configv1alpha1.FeatureReference{
+				{Name: "base", Activate: true, ActivateAfter: &metav1.Time{Time: time.Now().Add(time.Hour)}},
+				{Name: "middle", Activate: true, ExpiresAt: &metav1.Time{Time: time.Now().Add(-time.Hour)}},
+				{Name: "legacy", Activate: false},
+			}},
+			want: &ActivationPlan{Feature: "top", Dependencies: []string{"base", "middle"}, Conflicts: []string{}},
+		},
+		{
+			description: "feature without dependencies",
+			featureName: "base",
+			want:        &ActivationPlan{Feature: "base", Dependencies: []string{}, Conflicts: []string{}},
+		},
+		{
+			description: "missing dependency",
+			featureName: "broken",
+			err:         "depends on feature missing, which is not available",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := computeActivationPlan(tc.featureName, tc.spec, dependencyTestFeatures())
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("error=%v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestActivateFeatureWithDependencies(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	s := scheme.Scheme
+	if err := configv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("Unable to add config scheme: (%v)", err)
+	}
+	objs := []runtime.Object{&configv1alpha1.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "tkg-system"},
+		Spec: configv1alpha1.FeatureGateSpec{Features: []configv1alpha1.FeatureReference{
+			{Name: "legacy", Activate: false},
+		}},
+	}}
+	for _, feature := range dependencyTestFeatures() {
+		feature := feature
+		objs = append(objs, &feature)
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("Unable to get FeatureGateClient: (%v)", err)
+	}
+
+	if err := featureGateClient.ActivateFeature(ctx, "middle", "tkg-system"); err == nil || !strings.Contains(err.Error(), "[base]") {
+		t.Errorf("expected an error about the base dependency, got %v", err)
+	}
+	if err := featureGateClient.ActivateFeatures(ctx, "tkg-system", "base", "middle"); err != nil {
+		t.Fatalf("error not expected, but got error: %v", err)
+	}
+	gate, err := featureGateClient.GetFeatureGate(ctx, "tkg-system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []configv1alpha1.FeatureReference{
+		{Name: "legacy", Activate: false},
+		{Name: "base", Activate: true},
+		{Name: "middle", Activate: true},
+	}
+	if !reflect.DeepEqual(gate.Spec.Features, want) {
+		t.Errorf("got features %v, want %v", gate.Spec.Features, want)
+	}
+	if err := featureGateClient.DeactivateFeature(ctx, "base", "tkg-system"); err == nil || !strings.Contains(err.Error(), "[middle]") {
+		t.Errorf("expected an error about the middle dependent, got %v", err)
+	}
+}
+
+func TestActivateScheduledFeature(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	s := scheme.Scheme
+	if err := configv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("Unable to add config scheme: (%v)", err)
+	}
+	expired := &metav1.Time{Time: time.Now().Add(-time.Hour).Truncate(time.Second)}
+	objs := []runtime.Object{&configv1alpha1.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "tkg-system"},
+		Spec: configv1alpha1.FeatureGateSpec{Features: []configv1alpha1.FeatureReference{
+			{Name: "legacy", Activate: false},
+			{Name: "base", Activate: true, ExpiresAt: expired},
+			{Name: "middle", Activate: true, ActivateAfter: &metav1.Time{Time: time.Now().Add(time.Hour).Truncate(time.Second)}},
+		}},
+	}}
+	for _, feature := range dependencyTestFeatures() {
+		feature := feature
+		objs = append(objs, &feature)
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("Unable to get FeatureGateClient: (%v)", err)
+	}
+
+	// the expired base feature is not activated, so the scheduled middle feature does not depend on it yet
+	if err := featureGateClient.DeactivateFeature(ctx, "base", "tkg-system"); err != nil {
+		t.Fatalf("error not expected, but got error: %v", err)
+	}
+	if err := featureGateClient.ActivateFeatures(ctx, "tkg-system", "base", "middle"); err != nil {
+		t.Fatalf("error not expected, but got error: %v", err)
+	}
+	gate, err := featureGateClient.GetFeatureGate(ctx, "tkg-system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []configv1alpha1.FeatureReference{
+		{Name: "legacy", Activate: false},
+		{Name: "base", Activate: true},
+		{Name: "middle", Activate: true},
+	}
+	if !reflect.DeepEqual(gate.Spec.Features, want) {
+		t.Errorf("got features %v, want %v", gate.Spec.Features, want)
+	}
+}
@@ -9,8 +9,11 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -59,14 +62,77 @@ func (r *FeatureGateReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 	featureGate.Status.Namespaces = namespaces
 
-	// Compute feature states.
-	activated, deactivated, unavailable := util.ComputeFeatureStates(featureGate.Spec, features.Items)
+	// Compute feature states, with the scheduled activation intents at the current time.
+	now := time.Now()
+	activated, deactivated, unavailable := util.ComputeFeatureStates(featureGate.Spec.SpecAt(now), features.Items)
+
+	// Record the changes made by users, and the scheduled changes.
+	pending, err := featureGate.PendingFeatureChanges()
+	if err != nil {
+		log.Error(err, "Dropping invalid pending feature changes")
+	}
+	history := featureGate.Status.History
+	history = append(history, pending...)
+	history = append(history, scheduledFeatureChanges(featureGate, activated, metav1.NewTime(now))...)
+	if len(history) > configv1alpha1.MaxFeatureGateHistory {
+		history = history[len(history)-configv1alpha1.MaxFeatureGateHistory:]
+	}
+
 	featureGate.Status.ActivatedFeatures = activated
 	featureGate.Status.DeactivatedFeatures = deactivated
 	featureGate.Status.UnavailableFeatures = unavailable
+	featureGate.Status.History = history
+	if err := r.Client.Status().Update(ctxCancel, featureGate); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The webhook keeps the pending changes which are not in the history, so the annotation can only be cleared
+	// by the patch of the FeatureGate the history was updated from. A conflict means that new changes were recorded.
+	if _, ok := featureGate.Annotations[configv1alpha1.PendingFeatureChangesAnnotation]; ok {
+		patchBase := client.MergeFromWithOptions(featureGate.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		delete(featureGate.Annotations, configv1alpha1.PendingFeatureChangesAnnotation)
+		if err := r.Client.Patch(ctxCancel, featureGate, patchBase); err != nil {
+			if apierrors.IsConflict(err) {
+				log.Info("FeatureGate changed while recording the pending feature changes, retrying")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, err
+		}
+	}
 
 	log.Info("Successfully reconciled")
-	return ctrl.Result{}, r.Client.Status().Update(ctxCancel, featureGate)
+	if next := featureGate.Spec.NextTransitionAfter(now); next != nil {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// scheduledFeatureChanges returns the changes of the activation of the features with activateAfter or expiresAt times,
+// compared with the activated features in status.
+func scheduledFeatureChanges(featureGate *configv1alpha1.FeatureGate, activated []string, now metav1.Time) []configv1alpha1.FeatureChange {
+	oldActivated := sets.NewString(featureGate.Status.ActivatedFeatures...)
+	known := oldActivated.Union(sets.NewString(featureGate.Status.DeactivatedFeatures...))
+	newActivated := sets.NewString(activated...)
+
+	var changes []configv1alpha1.FeatureChange
+	for i := range featureGate.Spec.Features {
+		ref := &featureGate.Spec.Features[i]
+		if !ref.Scheduled() || !known.Has(ref.Name) {
+			continue
+		}
+		oldActivate, newActivate := oldActivated.Has(ref.Name), newActivated.Has(ref.Name)
+		if oldActivate == newActivate {
+			continue
+		}
+		changes = append(changes, configv1alpha1.FeatureChange{
+			Feature:     ref.Name,
+			User:        configv1alpha1.FeatureGateControllerUser,
+			Time:        now,
+			OldActivate: &oldActivate,
+			NewActivate: &newActivate,
+		})
+	}
+	return changes
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -0,0 +1,99 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+func TestScheduledFeatureChanges(t *testing.T) {
+	now := metav1.NewTime(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	featureGate := &configv1alpha1.FeatureGate{
+		Spec: configv1alpha1.FeatureGateSpec{Features: []configv1alpha1.FeatureReference{
+			{Name: "window", Activate: true, ActivateAfter: &earlier},
+			{Name: "trial", Activate: true, ExpiresAt: &earlier},
+			{Name: "manual", Activate: true},
+			{Name: "new", Activate: true, ActivateAfter: &earlier},
+		}},
+		Status: configv1alpha1.FeatureGateStatus{
+			ActivatedFeatures:   []string{"trial"},
+			DeactivatedFeatures: []string{"window", "manual"},
+		},
+	}
+
+	changes := scheduledFeatureChanges(featureGate, []string{"window", "manual", "new"}, now)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %v", changes)
+	}
+	for i, want := range []struct {
+		feature     string
+		newActivate bool
+	}{{"window", true}, {"trial", false}} {
+		change := changes[i]
+		if change.Feature != want.feature || change.User != configv1alpha1.FeatureGateControllerUser || !change.Time.Equal(&now) ||
+			*change.NewActivate != want.newActivate || *change.OldActivate == want.newActivate {
+			t.Errorf("unexpected change %d: %+v", i, change)
+		}
+	}
+}
+
+func TestReconcileRecordsPendingFeatureChanges(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := configv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	recorded := metav1.NewTime(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	yes, no := true, false
+	inHistory := configv1alpha1.FeatureChange{Feature: "foo", User: "admin", Time: recorded, OldActivate: &no, NewActivate: &yes}
+	pending := configv1alpha1.FeatureChange{Feature: "bar", User: "admin", Time: recorded, NewActivate: &yes}
+
+	featureGate := &configv1alpha1.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "gate"},
+		Spec: configv1alpha1.FeatureGateSpec{Features: []configv1alpha1.FeatureReference{
+			{Name: "foo", Activate: true},
+			{Name: "bar", Activate: true},
+		}},
+		Status: configv1alpha1.FeatureGateStatus{History: []configv1alpha1.FeatureChange{inHistory}},
+	}
+	// The first change was already moved to the history, but the annotation could not be cleared.
+	if err := featureGate.SetPendingFeatureChanges([]configv1alpha1.FeatureChange{inHistory, pending}); err != nil {
+		t.Fatal(err)
+	}
+	r := &FeatureGateReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(featureGate).Build(),
+		Log:    logr.Discard(),
+		Scheme: scheme,
+	}
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "gate"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &configv1alpha1.FeatureGate{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Name: "gate"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Annotations[configv1alpha1.PendingFeatureChangesAnnotation]; ok {
+		t.Errorf("expected the pending feature changes annotation to be removed")
+	}
+	if len(got.Status.History) != 2 || got.Status.History[0].Feature != "foo" || got.Status.History[1].Feature != "bar" {
+		t.Errorf("unexpected history %+v", got.Status.History)
+	}
+}
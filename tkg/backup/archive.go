@@ -0,0 +1,181 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package backup implements the archive format and the storage locations
+// of management cluster backups
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// archiveMagic identifies an encrypted management cluster backup archive
+const archiveMagic = "TKGBACKUP1\n"
+
+const (
+	saltSize = 16
+	keySize  = 32
+
+	// scrypt parameters recommended for interactive logins
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrWrongPassphrase is returned when an archive cannot be decrypted with the given passphrase
+var ErrWrongPassphrase = errors.New("unable to decrypt backup archive, the passphrase is wrong or the archive is corrupted")
+
+// CreateArchive packs the content of srcDir in a gzipped tarball encrypted
+// with AES-256-GCM using a key derived from the passphrase
+func CreateArchive(srcDir, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("a passphrase is required to encrypt the backup archive")
+	}
+
+	var plain bytes.Buffer
+	if err := writeTarball(srcDir, &plain); err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "unable to generate salt")
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "unable to generate nonce")
+	}
+
+	out := make([]byte, 0, len(archiveMagic)+saltSize+len(nonce)+plain.Len()+gcm.Overhead())
+	out = append(out, archiveMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plain.Bytes(), []byte(archiveMagic)), nil
+}
+
+// ExtractArchive decrypts an archive created by CreateArchive and unpacks it into destDir
+func ExtractArchive(archive []byte, destDir, passphrase string) error {
+	if !bytes.HasPrefix(archive, []byte(archiveMagic)) {
+		return errors.New("not a management cluster backup archive")
+	}
+	archive = archive[len(archiveMagic):]
+	if len(archive) < saltSize {
+		return ErrWrongPassphrase
+	}
+	salt, archive := archive[:saltSize], archive[saltSize:]
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	if len(archive) < gcm.NonceSize() {
+		return ErrWrongPassphrase
+	}
+	nonce, sealed := archive[:gcm.NonceSize()], archive[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, []byte(archiveMagic))
+	if err != nil {
+		return ErrWrongPassphrase
+	}
+	return readTarball(bytes.NewReader(plain), destDir)
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to derive encryption key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func writeTarball(srcDir string, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err := filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		hdr := &tar.Header{
+			Name: filepath.ToSlash(rel),
+			Mode: 0o600,
+			Size: int64(len(content)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to archive %s", srcDir)
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func readTarball(r io.Reader, destDir string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "invalid backup archive")
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "invalid backup archive")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		target := filepath.Join(destDir, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return errors.Errorf("invalid file path %q in backup archive", hdr.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return err
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return errors.Wrap(err, "invalid backup archive")
+		}
+		if err := os.WriteFile(target, content, 0o600); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package backup_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/backup"
+)
+
+func TestBackup(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Backup Suite")
+}
+
+var _ = Describe("Backup archive", func() {
+	var (
+		srcDir  string
+		destDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		srcDir, err = os.MkdirTemp("", "backup-src")
+		Expect(err).ToNot(HaveOccurred())
+		destDir, err = os.MkdirTemp("", "backup-dest")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(os.MkdirAll(filepath.Join(srcDir, "capi", "default"), 0o700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "capi", "default", "Cluster_default_wc.yaml"), []byte("kind: Cluster"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "tkg.yaml"), []byte("kind: FeatureGate"), 0o600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(srcDir)
+		os.RemoveAll(destDir)
+	})
+
+	It("round trips the directory content", func() {
+		archive, err := backup.CreateArchive(srcDir, "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(archive)).ToNot(ContainSubstring("FeatureGate"))
+
+		Expect(backup.ExtractArchive(archive, destDir, "secret")).To(Succeed())
+		content, err := os.ReadFile(filepath.Join(destDir, "capi", "default", "Cluster_default_wc.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("kind: Cluster"))
+		content, err = os.ReadFile(filepath.Join(destDir, "tkg.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("kind: FeatureGate"))
+	})
+
+	It("fails to extract with a wrong passphrase", func() {
+		archive, err := backup.CreateArchive(srcDir, "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backup.ExtractArchive(archive, destDir, "wrong")).To(MatchError(backup.ErrWrongPassphrase))
+	})
+
+	It("requires a passphrase", func() {
+		_, err := backup.CreateArchive(srcDir, "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects data which is not a backup archive", func() {
+		Expect(backup.ExtractArchive([]byte("not an archive"), destDir, "secret")).ToNot(Succeed())
+	})
+})
+
+var _ = Describe("NewStore", func() {
+	It("uses a local file for paths", func() {
+		dir, err := os.MkdirTemp("", "backup-store")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		store, err := backup.NewStore(filepath.Join(dir, "sub", "mc.backup"), backup.S3Options{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.Save([]byte("data"))).To(Succeed())
+		data, err := store.Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("data"))
+	})
+
+	It("parses s3 locations", func() {
+		store, err := backup.NewStore("s3://bucket/backups/mc.backup", backup.S3Options{Endpoint: "https://minio.local:9000"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.String()).To(Equal("s3://bucket/backups/mc.backup"))
+
+		_, err = backup.NewStore("s3://bucket", backup.S3Options{})
+		Expect(err).To(HaveOccurred())
+	})
+})
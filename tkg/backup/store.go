@@ -0,0 +1,137 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+)
+
+const s3Scheme = "s3"
+
+// Store saves and loads a backup archive
+type Store interface {
+	// Save writes the archive to the store
+	Save(archive []byte) error
+	// Load reads the archive from the store
+	Load() ([]byte, error)
+	// String returns the location of the archive
+	String() string
+}
+
+// S3Options configures the S3-compatible endpoint used for s3:// locations.
+// Credentials are taken from the AWS environment variables or shared credentials file.
+type S3Options struct {
+	// Endpoint of the S3-compatible service, the AWS endpoint is used if empty
+	Endpoint string
+	// Region of the bucket
+	Region string
+}
+
+// NewStore returns the store for a backup location, which is either a local
+// file path or an s3://bucket/key URL
+func NewStore(location string, s3Options S3Options) (Store, error) {
+	if location == "" {
+		return nil, errors.New("backup location cannot be empty")
+	}
+	if !strings.HasPrefix(location, s3Scheme+"://") {
+		return &fileStore{path: location}, nil
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid backup location %s", location)
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return nil, errors.Errorf("invalid backup location %s, expected s3://bucket/key", location)
+	}
+	return &s3Store{bucket: u.Host, key: key, options: s3Options}, nil
+}
+
+type fileStore struct {
+	path string
+}
+
+func (s *fileStore) Save(archive []byte) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, archive, 0o600)
+}
+
+func (s *fileStore) Load() ([]byte, error) {
+	return os.ReadFile(s.path)
+}
+
+func (s *fileStore) String() string {
+	return s.path
+}
+
+type s3Store struct {
+	bucket  string
+	key     string
+	options S3Options
+}
+
+func (s *s3Store) session() (*session.Session, error) {
+	config := aws.NewConfig()
+	if s.options.Region != "" {
+		config = config.WithRegion(s.options.Region)
+	}
+	if s.options.Endpoint != "" {
+		// S3-compatible services generally do not support virtual hosted buckets
+		config = config.WithEndpoint(s.options.Endpoint).WithS3ForcePathStyle(true)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create S3 session")
+	}
+	return sess, nil
+}
+
+func (s *s3Store) Save(archive []byte) error {
+	sess, err := s.session()
+	if err != nil {
+		return err
+	}
+	_, err = s3manager.NewUploader(sess).Upload(&s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+		Body:   bytes.NewReader(archive),
+	})
+	return errors.Wrapf(err, "unable to upload backup to %s", s)
+}
+
+func (s *s3Store) Load() ([]byte, error) {
+	sess, err := s.session()
+	if err != nil {
+		return nil, err
+	}
+	buf := aws.NewWriteAtBuffer([]byte{})
+	_, err = s3manager.NewDownloader(sess).Download(buf, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to download backup from %s", s)
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *s3Store) String() string {
+	return s3Scheme + "://" + s.bucket + "/" + s.key
+}
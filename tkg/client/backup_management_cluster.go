@@ -0,0 +1,398 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctl "sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/backup"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+)
+
+const (
+	backupCAPIDir = "capi"
+	backupTKGDir  = "tkg"
+
+	clusterBootstrapSecretType = "clusterbootstrap-secret"
+)
+
+// BackupManagementClusterOptions contains options supported by BackupManagementCluster
+type BackupManagementClusterOptions struct {
+	ClusterName string
+	// Location is a local file path or an s3://bucket/key URL
+	Location   string
+	Passphrase string
+	S3Options  backup.S3Options
+}
+
+// RestoreManagementClusterOptions contains options supported by RestoreManagementCluster
+type RestoreManagementClusterOptions struct {
+	ClusterName string
+	// Location is a local file path or an s3://bucket/key URL
+	Location   string
+	Passphrase string
+	S3Options  backup.S3Options
+}
+
+// backupObjectGroup is a set of TKG objects which are saved to the same file
+// of the backup and restored together
+type backupObjectGroup struct {
+	name string
+	gvks []schema.GroupVersionKind
+	// namespaced objects are only saved from the workload cluster namespaces
+	namespaced bool
+	// filter returns false for objects which should not be saved
+	filter func(obj *unstructured.Unstructured) bool
+}
+
+// backupObjectGroups lists the TKG objects saved along the Cluster API objects,
+// in the order they are restored. Objects a ClusterBootstrap refers to must be
+// restored before it, and everything must be in place before the clusters are
+// unpaused by the Cluster API restore.
+var backupObjectGroups = []backupObjectGroup{
+	{
+		name: "featuregates",
+		gvks: []schema.GroupVersionKind{
+			{Group: "config.tanzu.vmware.com", Version: "v1alpha1", Kind: "Feature"},
+			{Group: "config.tanzu.vmware.com", Version: "v1alpha1", Kind: "FeatureGate"},
+		},
+	},
+	{
+		name: "tkrs",
+		gvks: []schema.GroupVersionKind{
+			{Group: "run.tanzu.vmware.com", Version: "v1alpha3", Kind: "OSImage"},
+			{Group: "run.tanzu.vmware.com", Version: "v1alpha3", Kind: "TanzuKubernetesRelease"},
+		},
+	},
+	{
+		name: "secrets",
+		gvks: []schema.GroupVersionKind{
+			{Group: "", Version: "v1", Kind: "Secret"},
+		},
+		namespaced: true,
+		filter: func(obj *unstructured.Unstructured) bool {
+			secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+			return secretType == clusterBootstrapSecretType
+		},
+	},
+	{
+		name: "addonconfigs",
+		gvks: []schema.GroupVersionKind{
+			{Group: "cni.tanzu.vmware.com", Version: "v1alpha1", Kind: "AntreaConfig"},
+			{Group: "cni.tanzu.vmware.com", Version: "v1alpha1", Kind: "CalicoConfig"},
+			{Group: "cpi.tanzu.vmware.com", Version: "v1alpha1", Kind: "KubevipCPIConfig"},
+			{Group: "cpi.tanzu.vmware.com", Version: "v1alpha1", Kind: "OracleCPIConfig"},
+			{Group: "cpi.tanzu.vmware.com", Version: "v1alpha1", Kind: "VSphereCPIConfig"},
+			{Group: "csi.tanzu.vmware.com", Version: "v1alpha1", Kind: "AwsEbsCSIConfig"},
+			{Group: "csi.tanzu.vmware.com", Version: "v1alpha1", Kind: "AzureDiskCSIConfig"},
+			{Group: "csi.tanzu.vmware.com", Version: "v1alpha1", Kind: "AzureFileCSIConfig"},
+			{Group: "csi.tanzu.vmware.com", Version: "v1alpha1", Kind: "VSphereCSIConfig"},
+			{Group: "run.tanzu.vmware.com", Version: "v1alpha3", Kind: "KappControllerConfig"},
+		},
+		namespaced: true,
+	},
+	{
+		name: "clusterbootstraps",
+		gvks: []schema.GroupVersionKind{
+			{Group: "run.tanzu.vmware.com", Version: "v1alpha3", Kind: "ClusterBootstrap"},
+		},
+		namespaced: true,
+	},
+}
+
+// BackupManagementCluster saves the Cluster API objects of the workload clusters managed
+// by a management cluster, together with the TKG objects they depend on, to an encrypted archive
+func (c *TkgClient) BackupManagementCluster(options BackupManagementClusterOptions) error {
+	store, err := backup.NewStore(options.Location, options.S3Options)
+	if err != nil {
+		return err
+	}
+
+	regionContext, clusterClient, err := c.getManagementClusterClient(options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := workloadClusterNamespaces(clusterClient, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	stagingDir, err := os.MkdirTemp("", "tkg-backup")
+	if err != nil {
+		return errors.Wrap(err, "unable to create staging directory")
+	}
+	defer os.RemoveAll(stagingDir)
+
+	log.Info("Saving TKG objects...")
+	if err := saveTKGObjects(clusterClient, namespaces, filepath.Join(stagingDir, backupTKGDir)); err != nil {
+		return err
+	}
+
+	for _, namespace := range namespaces {
+		log.Infof("Saving Cluster API objects from namespace %q...", namespace)
+		dir := filepath.Join(stagingDir, backupCAPIDir, namespace)
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+		backupOptions := clusterctl.BackupOptions{
+			FromKubeconfig: clusterctl.Kubeconfig{Path: regionContext.SourceFilePath, Context: regionContext.ContextName},
+			Namespace:      namespace,
+			Directory:      dir,
+		}
+		if err := c.clusterctlClient.Backup(backupOptions); err != nil {
+			return errors.Wrapf(err, "unable to back up Cluster API objects from namespace %q", namespace)
+		}
+	}
+
+	archive, err := backup.CreateArchive(stagingDir, options.Passphrase)
+	if err != nil {
+		return err
+	}
+	log.Infof("Writing backup to %s...", store)
+	return store.Save(archive)
+}
+
+// RestoreManagementCluster recreates the objects saved by BackupManagementCluster on a management cluster.
+// TKG objects are restored first, the Cluster API objects are restored last and the clusters are unpaused
+// once all of them are in place.
+func (c *TkgClient) RestoreManagementCluster(options RestoreManagementClusterOptions) error {
+	store, err := backup.NewStore(options.Location, options.S3Options)
+	if err != nil {
+		return err
+	}
+
+	regionContext, clusterClient, err := c.getManagementClusterClient(options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Reading backup from %s...", store)
+	archive, err := store.Load()
+	if err != nil {
+		return errors.Wrapf(err, "unable to read backup from %s", store)
+	}
+
+	stagingDir, err := os.MkdirTemp("", "tkg-restore")
+	if err != nil {
+		return errors.Wrap(err, "unable to create staging directory")
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := backup.ExtractArchive(archive, stagingDir, options.Passphrase); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(stagingDir, backupCAPIDir))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var namespaces []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			namespaces = append(namespaces, entry.Name())
+		}
+	}
+
+	// the namespaced TKG objects are restored before the Cluster API objects
+	for _, namespace := range namespaces {
+		if err := clusterClient.CreateNamespace(namespace); err != nil {
+			return errors.Wrapf(err, "unable to create namespace %q", namespace)
+		}
+	}
+
+	log.Info("Restoring TKG objects...")
+	if err := restoreTKGObjects(clusterClient, filepath.Join(stagingDir, backupTKGDir)); err != nil {
+		return err
+	}
+
+	for _, namespace := range namespaces {
+		log.Infof("Restoring Cluster API objects to namespace %q...", namespace)
+		restoreOptions := clusterctl.RestoreOptions{
+			ToKubeconfig: clusterctl.Kubeconfig{Path: regionContext.SourceFilePath, Context: regionContext.ContextName},
+			Directory:    filepath.Join(stagingDir, backupCAPIDir, namespace),
+		}
+		if err := c.clusterctlClient.Restore(restoreOptions); err != nil {
+			return errors.Wrapf(err, "unable to restore Cluster API objects to namespace %q", namespace)
+		}
+	}
+	return nil
+}
+
+func (c *TkgClient) getManagementClusterClient(clusterName string) (region.RegionContext, clusterclient.Client, error) {
+	contexts, err := c.GetRegionContexts(clusterName)
+	if err != nil || len(contexts) == 0 {
+		return region.RegionContext{}, nil, errors.Errorf("management cluster %s not found", clusterName)
+	}
+	regionContext := contexts[0]
+
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 5 * time.Second,
+		GetClientTimeout:  10 * time.Second,
+		OperationTimeout:  c.timeout,
+	}
+	clusterClient, err := clusterclient.NewClient(regionContext.SourceFilePath, regionContext.ContextName, clusterclientOptions)
+	if err != nil {
+		return regionContext, nil, errors.Wrap(err, "unable to create cluster client for management cluster")
+	}
+
+	isPacific, err := clusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return regionContext, nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return regionContext, nil, errors.New("backup and restore of 'Tanzu Kubernetes Cluster service for vSphere' management cluster is not supported")
+	}
+	return regionContext, clusterClient, nil
+}
+
+// workloadClusterNamespaces returns the sorted namespaces holding workload clusters. The namespace of
+// the management cluster is skipped as the target management cluster already has its own objects there.
+func workloadClusterNamespaces(clusterClient clusterclient.Client, managementClusterName string) ([]string, error) {
+	clusters, err := clusterClient.ListClusters("")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list clusters")
+	}
+
+	managementClusterNamespace := constants.TkgNamespace
+	for i := range clusters {
+		if clusters[i].Name == managementClusterName && isManagementCluster(&clusters[i]) {
+			managementClusterNamespace = clusters[i].Namespace
+		}
+	}
+
+	seen := map[string]bool{}
+	var namespaces []string
+	for i := range clusters {
+		if isManagementCluster(&clusters[i]) || seen[clusters[i].Namespace] {
+			continue
+		}
+		if clusters[i].Namespace == managementClusterNamespace {
+			log.Warningf("Skipping cluster %q, clusters in the management cluster namespace %q are not backed up", clusters[i].Name, managementClusterNamespace)
+			continue
+		}
+		seen[clusters[i].Namespace] = true
+		namespaces = append(namespaces, clusters[i].Namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+func isManagementCluster(cluster *capi.Cluster) bool {
+	_, ok := cluster.Labels[TkgLabelClusterRolePrefix+TkgLabelClusterRoleManagement]
+	return ok
+}
+
+func saveTKGObjects(clusterClient clusterclient.Client, namespaces []string, dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	for i, group := range backupObjectGroups {
+		var objects []unstructured.Unstructured
+		for _, gvk := range group.gvks {
+			listNamespaces := []string{""}
+			if group.namespaced {
+				listNamespaces = namespaces
+			}
+			for _, namespace := range listNamespaces {
+				items, err := listBackupObjects(clusterClient, gvk, namespace)
+				if err != nil {
+					return err
+				}
+				for j := range items {
+					if group.filter == nil || group.filter(&items[j]) {
+						objects = append(objects, items[j])
+					}
+				}
+			}
+		}
+		if len(objects) == 0 {
+			continue
+		}
+
+		var buf bytes.Buffer
+		for j := range objects {
+			sanitizeBackupObject(&objects[j], group.namespaced)
+			data, err := yaml.Marshal(objects[j].Object)
+			if err != nil {
+				return errors.Wrapf(err, "unable to marshal %s %s/%s", objects[j].GetKind(), objects[j].GetNamespace(), objects[j].GetName())
+			}
+			buf.WriteString("---\n")
+			buf.Write(data)
+		}
+		log.V(3).Infof("Saving %d %s", len(objects), group.name)
+		if err := os.WriteFile(filepath.Join(dir, backupGroupFileName(i, group)), buf.Bytes(), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listBackupObjects lists the objects of a kind, kinds which are not installed on the cluster are skipped
+func listBackupObjects(clusterClient clusterclient.Client, gvk schema.GroupVersionKind, namespace string) ([]unstructured.Unstructured, error) {
+	objectList := &unstructured.UnstructuredList{}
+	objectList.SetGroupVersionKind(gvk)
+	if err := clusterClient.ListResources(objectList, crtclient.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(errors.Cause(err)) {
+			log.V(3).Infof("Skipping %s, the kind is not installed on the management cluster", gvk.String())
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "unable to list resources: %s", gvk.String())
+	}
+	return objectList.Items, nil
+}
+
+// sanitizeBackupObject removes the fields set by the API server. Owner references of namespaced
+// objects point to objects which get a new UID on restore, the controllers set them again.
+func sanitizeBackupObject(obj *unstructured.Unstructured, namespaced bool) {
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetManagedFields(nil)
+	obj.SetGeneration(0)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	unstructured.RemoveNestedField(obj.Object, "status")
+	if namespaced {
+		obj.SetOwnerReferences(nil)
+	}
+}
+
+func restoreTKGObjects(clusterClient clusterclient.Client, dir string) error {
+	for i, group := range backupObjectGroups {
+		data, err := os.ReadFile(filepath.Join(dir, backupGroupFileName(i, group)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		log.V(3).Infof("Restoring %s", group.name)
+		if err := clusterClient.Apply(string(data)); err != nil {
+			return errors.Wrapf(err, "unable to restore %s", group.name)
+		}
+	}
+	return nil
+}
+
+func backupGroupFileName(i int, group backupObjectGroup) string {
+	return fmt.Sprintf("%02d-%s.yaml", i+1, group.name)
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+)
+
+// backupTestClusterClient serves the objects listed by the backup from memory
+// and records the manifests applied by the restore
+type backupTestClusterClient struct {
+	clusterclient.Client
+	clusters []capi.Cluster
+	objects  []unstructured.Unstructured
+	applied  []string
+}
+
+func (c *backupTestClusterClient) ListClusters(namespace string) ([]capi.Cluster, error) {
+	return c.clusters, nil
+}
+
+func (c *backupTestClusterClient) ListResources(resourceReference interface{}, option ...crtclient.ListOption) error {
+	list := resourceReference.(*unstructured.UnstructuredList)
+	listOptions := &crtclient.ListOptions{}
+	listOptions.ApplyOptions(option)
+
+	found := false
+	for _, obj := range c.objects {
+		if obj.GroupVersionKind() != list.GroupVersionKind() {
+			continue
+		}
+		found = true
+		if listOptions.Namespace == "" || listOptions.Namespace == obj.GetNamespace() {
+			list.Items = append(list.Items, obj)
+		}
+	}
+	if !found && list.GetKind() != "Secret" {
+		return &meta.NoKindMatchError{GroupKind: list.GroupVersionKind().GroupKind()}
+	}
+	return nil
+}
+
+func (c *backupTestClusterClient) Apply(yaml string) error {
+	c.applied = append(c.applied, yaml)
+	return nil
+}
+
+func newBackupTestCluster(name, namespace, role string) capi.Cluster {
+	return capi.Cluster{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{TkgLabelClusterRolePrefix + role: ""},
+	}}
+}
+
+func newBackupTestObject(apiVersion, kind, name, namespace string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	obj.SetResourceVersion("42")
+	obj.SetUID("uid")
+	obj.SetOwnerReferences([]metav1.OwnerReference{{Kind: "Cluster", Name: "wc", UID: "uid"}})
+	obj.Object["status"] = map[string]interface{}{"ready": true}
+	return obj
+}
+
+var _ = Describe("Management cluster backup", func() {
+	var (
+		dir           string
+		clusterClient *backupTestClusterClient
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "backup-management-cluster")
+		Expect(err).ToNot(HaveOccurred())
+
+		secret := newBackupTestObject("v1", "Secret", "wc-antrea-data-values", "prod")
+		secret.Object["type"] = clusterBootstrapSecretType
+		otherSecret := newBackupTestObject("v1", "Secret", "wc-kubeconfig", "prod")
+		otherSecret.Object["type"] = "cluster.x-k8s.io/secret"
+
+		clusterClient = &backupTestClusterClient{
+			clusters: []capi.Cluster{
+				newBackupTestCluster("mc", "tkg-system", TkgLabelClusterRoleManagement),
+				newBackupTestCluster("wc", "prod", TkgLabelClusterRoleWorkload),
+				newBackupTestCluster("wc2", "prod", TkgLabelClusterRoleWorkload),
+				newBackupTestCluster("wc3", "dev", TkgLabelClusterRoleWorkload),
+				newBackupTestCluster("wc4", "tkg-system", TkgLabelClusterRoleWorkload),
+			},
+			objects: []unstructured.Unstructured{
+				newBackupTestObject("config.tanzu.vmware.com/v1alpha1", "FeatureGate", "tkg-system", ""),
+				newBackupTestObject("run.tanzu.vmware.com/v1alpha3", "TanzuKubernetesRelease", "v1.23.8---vmware.2-tkg.1", ""),
+				secret,
+				otherSecret,
+				newBackupTestObject("run.tanzu.vmware.com/v1alpha3", "ClusterBootstrap", "wc", "prod"),
+				newBackupTestObject("run.tanzu.vmware.com/v1alpha3", "ClusterBootstrap", "mc", "tkg-system"),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("returns the workload cluster namespaces without the management cluster namespace", func() {
+		namespaces, err := workloadClusterNamespaces(clusterClient, "mc")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(namespaces).To(Equal([]string{"dev", "prod"}))
+	})
+
+	It("saves the TKG objects and restores them in order", func() {
+		Expect(saveTKGObjects(clusterClient, []string{"dev", "prod"}, dir)).To(Succeed())
+
+		entries, err := os.ReadDir(dir)
+		Expect(err).ToNot(HaveOccurred())
+		var files []string
+		for _, entry := range entries {
+			files = append(files, entry.Name())
+		}
+		Expect(files).To(Equal([]string{"01-featuregates.yaml", "02-tkrs.yaml", "03-secrets.yaml", "05-clusterbootstraps.yaml"}))
+
+		secrets, err := os.ReadFile(filepath.Join(dir, "03-secrets.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(secrets)).To(ContainSubstring("wc-antrea-data-values"))
+		Expect(string(secrets)).ToNot(ContainSubstring("wc-kubeconfig"))
+
+		bootstraps, err := os.ReadFile(filepath.Join(dir, "05-clusterbootstraps.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strings.Count(string(bootstraps), "kind: ClusterBootstrap")).To(Equal(1))
+		Expect(string(bootstraps)).ToNot(ContainSubstring("resourceVersion"))
+		Expect(string(bootstraps)).ToNot(ContainSubstring("ownerReferences"))
+		Expect(string(bootstraps)).ToNot(ContainSubstring("status"))
+
+		Expect(restoreTKGObjects(clusterClient, dir)).To(Succeed())
+		Expect(clusterClient.applied).To(HaveLen(4))
+		Expect(clusterClient.applied[0]).To(ContainSubstring("kind: FeatureGate"))
+		Expect(clusterClient.applied[3]).To(ContainSubstring("kind: ClusterBootstrap"))
+	})
+})
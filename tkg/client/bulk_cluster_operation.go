@@ -0,0 +1,131 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// BulkClusterStatus is the status of a cluster in a bulk cluster operation
+type BulkClusterStatus string
+
+const (
+	// BulkClusterPending is the status of a cluster the operation has not started on yet
+	BulkClusterPending BulkClusterStatus = "Pending"
+	// BulkClusterRunning is the status of a cluster the operation is running on
+	BulkClusterRunning BulkClusterStatus = "Running"
+	// BulkClusterSucceeded is the status of a cluster the operation succeeded on
+	BulkClusterSucceeded BulkClusterStatus = "Succeeded"
+	// BulkClusterFailed is the status of a cluster the operation failed on
+	BulkClusterFailed BulkClusterStatus = "Failed"
+	// BulkClusterSkipped is the status of a cluster the operation was not started on because too many clusters failed
+	BulkClusterSkipped BulkClusterStatus = "Skipped"
+)
+
+// BulkClusterProgress is the progress of a bulk cluster operation on one cluster
+type BulkClusterProgress struct {
+	Name      string            `json:"name" yaml:"name"`
+	Namespace string            `json:"namespace" yaml:"namespace"`
+	Status    BulkClusterStatus `json:"status" yaml:"status"`
+	Error     string            `json:"error,omitempty" yaml:"error,omitempty"`
+	Duration  time.Duration     `json:"duration" yaml:"duration"`
+}
+
+// BulkClusterOperationOptions contains options supported by RunBulkClusterOperation
+type BulkClusterOperationOptions struct {
+	// MaxConcurrent is the number of clusters operated on at the same time, 1 if not positive
+	MaxConcurrent int
+	// MaxFailures is the number of failed clusters tolerated: once exceeded, the operation is not started on the
+	// remaining clusters, which are skipped. The operations already running complete.
+	MaxFailures int
+	// OnProgress, if set, is called each time the status of a cluster changes. Calls are serialized.
+	OnProgress func(progress BulkClusterProgress)
+}
+
+// BulkClusterOperationResult is the outcome of a bulk cluster operation, in the order of the clusters
+type BulkClusterOperationResult struct {
+	Clusters []BulkClusterProgress `json:"clusters" yaml:"clusters"`
+}
+
+// Count returns the number of clusters with the given status
+func (r *BulkClusterOperationResult) Count(status BulkClusterStatus) int {
+	count := 0
+	for i := range r.Clusters {
+		if r.Clusters[i].Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// RunBulkClusterOperation runs operation on the clusters, at most options.MaxConcurrent at a time and in order, and
+// stops starting new ones once more than options.MaxFailures failed
+func RunBulkClusterOperation(clusters []ClusterInfo, options BulkClusterOperationOptions, operation func(cluster ClusterInfo) error) *BulkClusterOperationResult {
+	maxConcurrent := options.MaxConcurrent
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	result := &BulkClusterOperationResult{Clusters: make([]BulkClusterProgress, len(clusters))}
+	for i := range clusters {
+		result.Clusters[i] = BulkClusterProgress{Name: clusters[i].Name, Namespace: clusters[i].Namespace, Status: BulkClusterPending}
+	}
+
+	var (
+		mu       sync.Mutex
+		failures int
+		wg       sync.WaitGroup
+	)
+	// update records the status of a cluster and reports it, it must be called with mu held
+	update := func(i int, status BulkClusterStatus, err error, duration time.Duration) {
+		result.Clusters[i].Status = status
+		result.Clusters[i].Duration = duration
+		if err != nil {
+			result.Clusters[i].Error = err.Error()
+		}
+		if options.OnProgress != nil {
+			options.OnProgress(result.Clusters[i])
+		}
+	}
+
+	slots := make(chan struct{}, maxConcurrent)
+	for i := range clusters {
+		slots <- struct{}{}
+
+		mu.Lock()
+		if failures > options.MaxFailures {
+			for j := i; j < len(clusters); j++ {
+				update(j, BulkClusterSkipped, nil, 0)
+			}
+			mu.Unlock()
+			break
+		}
+		update(i, BulkClusterRunning, nil, 0)
+		mu.Unlock()
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			start := time.Now()
+			err := operation(clusters[i])
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures++
+				update(i, BulkClusterFailed, err, time.Since(start))
+				return
+			}
+			update(i, BulkClusterSucceeded, nil, time.Since(start))
+		}(i)
+	}
+	wg.Wait()
+
+	return result
+}
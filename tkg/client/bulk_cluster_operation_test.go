@@ -0,0 +1,94 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunBulkClusterOperation", func() {
+	var (
+		clusters []ClusterInfo
+		options  BulkClusterOperationOptions
+		progress []BulkClusterProgress
+		mu       sync.Mutex
+	)
+
+	BeforeEach(func() {
+		clusters = []ClusterInfo{
+			{Name: "wc-1", Namespace: "default"},
+			{Name: "wc-2", Namespace: "default"},
+			{Name: "wc-3", Namespace: "dev"},
+			{Name: "wc-4", Namespace: "dev"},
+		}
+		progress = nil
+		options = BulkClusterOperationOptions{
+			OnProgress: func(p BulkClusterProgress) {
+				mu.Lock()
+				defer mu.Unlock()
+				progress = append(progress, p)
+			},
+		}
+	})
+
+	It("runs the operation on the clusters in order, one at a time by default", func() {
+		var order []string
+		result := RunBulkClusterOperation(clusters, options, func(cluster ClusterInfo) error {
+			order = append(order, cluster.Namespace+"/"+cluster.Name)
+			return nil
+		})
+		Expect(order).To(Equal([]string{"default/wc-1", "default/wc-2", "dev/wc-3", "dev/wc-4"}))
+		Expect(result.Count(BulkClusterSucceeded)).To(Equal(4))
+		Expect(progress).To(HaveLen(8))
+		Expect(progress[0].Status).To(Equal(BulkClusterRunning))
+		Expect(progress[1].Status).To(Equal(BulkClusterSucceeded))
+	})
+
+	It("runs at most MaxConcurrent operations at a time", func() {
+		options.MaxConcurrent = 2
+		var running, maxRunning int32
+		release := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			Eventually(func() int32 { return atomic.LoadInt32(&running) }).Should(Equal(int32(2)))
+			close(release)
+		}()
+		result := RunBulkClusterOperation(clusters, options, func(cluster ClusterInfo) error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				observed := atomic.LoadInt32(&maxRunning)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+		Expect(maxRunning).To(Equal(int32(2)))
+		Expect(result.Count(BulkClusterSucceeded)).To(Equal(4))
+	})
+
+	It("skips the remaining clusters once more than MaxFailures clusters failed", func() {
+		options.MaxFailures = 1
+		result := RunBulkClusterOperation(clusters, options, func(cluster ClusterInfo) error {
+			if cluster.Name == "wc-4" {
+				return nil
+			}
+			return errors.New("upgrade failed")
+		})
+		Expect(result.Clusters[0].Status).To(Equal(BulkClusterFailed))
+		Expect(result.Clusters[0].Error).To(Equal("upgrade failed"))
+		Expect(result.Clusters[1].Status).To(Equal(BulkClusterFailed))
+		Expect(result.Clusters[2].Status).To(Equal(BulkClusterSkipped))
+		Expect(result.Clusters[3].Status).To(Equal(BulkClusterSkipped))
+		Expect(result.Count(BulkClusterFailed)).To(Equal(2))
+		Expect(result.Count(BulkClusterSkipped)).To(Equal(2))
+	})
+})
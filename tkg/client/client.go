@@ -5,8 +5,10 @@
 package client
 
 import (
+	"context"
 	"time"
 
+	"github.com/juju/fslock"
 	"github.com/pkg/errors"
 	capiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -31,6 +33,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigupdater"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/types"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/vc"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
 )
 
 type (
@@ -82,6 +85,7 @@ type InitRegionOptions struct {
 	CeipOptIn                    bool
 	UseExistingCluster           bool
 	IsInputFileClusterClassBased bool
+	Resume                       bool
 }
 
 // DeleteRegionOptions contains options supported by DeleteRegion
@@ -123,6 +127,22 @@ type Client interface {
 	CreateAWSCloudFormationStack() error
 	// DeleteRegion deletes management cluster via a self-provisioned kind cluster
 	DeleteRegion(options DeleteRegionOptions) error
+	// DiagnoseCluster collects the objects, logs and failing conditions of a cluster into a diagnostics bundle
+	DiagnoseCluster(options DiagnoseClusterOptions) (string, error)
+	// WatchClusterTimeline calls a handler with the condition transitions and events of the objects of a cluster
+	WatchClusterTimeline(ctx context.Context, options WatchClusterTimelineOptions) error
+	// GetClusterCertificates returns the expiry dates of the control plane certificates of a cluster
+	GetClusterCertificates(options GetClusterCertificatesOptions) ([]ClusterCertificate, error)
+	// RotateClusterCertificates renews the control plane certificates of a cluster by rolling out its control plane
+	RotateClusterCertificates(options RotateClusterCertificatesOptions) error
+	// PauseCluster pauses the reconciliation of a workload cluster and of its add-ons
+	PauseCluster(options PauseClusterOptions) error
+	// ResumeCluster resumes the reconciliation of a workload cluster paused by PauseCluster
+	ResumeCluster(options ResumeClusterOptions) error
+	// BackupManagementCluster saves the workload cluster objects of a management cluster to an encrypted archive
+	BackupManagementCluster(options BackupManagementClusterOptions) error
+	// RestoreManagementCluster recreates the objects of a management cluster backup on a management cluster
+	RestoreManagementCluster(options RestoreManagementClusterOptions) error
 	// VerifyRegion checks if the kube context points to a management clusters,
 	VerifyRegion(kubeConfigPath string) (region.RegionContext, error)
 	// AddRegionContext adds a management cluster context to tkg config file
@@ -135,6 +155,10 @@ type Client interface {
 	GenerateAWSCloudFormationTemplate() (string, error)
 	// GetCurrentRegionContext() gets the current management cluster context
 	GetCurrentRegionContext() (region.RegionContext, error)
+	// GetOverlayPackLock returns the lock of the overlay packs pinned to the current management cluster
+	GetOverlayPackLock() (*yamlprocessor.OverlayPackLock, error)
+	// SaveOverlayPackLock saves the lock of the overlay packs pinned to the current management cluster
+	SaveOverlayPackLock(lock *yamlprocessor.OverlayPackLock) error
 	// GetWorkloadClusterCredentials merges workload cluster credentials into kubeconfig path
 	GetWorkloadClusterCredentials(options GetWorkloadClusterCredentialsOptions) (string, string, error)
 	// ListTKGClusters lists workload clusters managed by the management cluster
@@ -148,6 +172,12 @@ type Client interface {
 	// ConfigureAndValidateManagementClusterConfiguration validates the management cluster configuration
 	// User is expected to validate the configuration before creating management cluster using init operation
 	ConfigureAndValidateManagementClusterConfiguration(options *InitRegionOptions, skipValidation bool) *ValidationError
+	// ValidateManagementClusterConfiguration runs all the configuration checks of the management cluster provider
+	// and reports every problem found
+	ValidateManagementClusterConfiguration(options *InitRegionOptions) (*ValidationReport, error)
+	// ValidateWorkloadClusterConfiguration runs all the configuration checks of the workload cluster provider
+	// and reports every problem found
+	ValidateWorkloadClusterConfiguration(options *CreateClusterOptions) (*ValidationReport, error)
 	// UpgradeManagementCluster upgrades tkg cluster to specific kubernetes version
 	UpgradeManagementCluster(options *UpgradeClusterOptions) error
 	// Opt-in/out to CEIP on Management Cluster
@@ -239,6 +269,8 @@ type TkgClient struct {
 	clusterClientFactory     clusterclient.ClusterClientFactory
 	vcClientFactory          vc.VcClientFactory
 	featureFlagClient        FeatureFlagClient
+	// persistentKindClusterLock is held while the current operation uses the persistent bootstrap cluster
+	persistentKindClusterLock *fslock.Lock
 }
 
 // Options new client options
@@ -682,8 +682,15 @@ func (c *TkgClient) ConfigureAndValidateWorkloadClusterConfiguration(options *Cr
 		return err
 	}
 
-	if err = c.ConfigureAndValidateHTTPProxyConfiguration(providerName); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
+	// the HTTP proxy configuration, the first of the common checks of workload clusters, is validated before the
+	// other settings of the cluster
+	commonChecks := c.commonConfigValidationChecks(&configValidationOptions{
+		provider:    providerName,
+		clusterRole: TkgLabelClusterRoleWorkload,
+		cniType:     options.CniType,
+	})
+	if verr := runConfigValidationChecksUntilFailure(providerName, commonChecks[:1]); verr != nil {
+		return verr
 	}
 
 	if options.ClusterType == "" {
@@ -708,25 +715,8 @@ func (c *TkgClient) ConfigureAndValidateWorkloadClusterConfiguration(options *Cr
 		}
 	}
 
-	err = c.ConfigureAndValidateCNIType(options.CniType)
-	if err != nil {
-		return NewValidationError(ValidationErrorCode, errors.Wrap(err, "unable to validate CNI type").Error())
-	}
-
-	if err = c.configureAndValidateIPFamilyConfiguration(TkgLabelClusterRoleWorkload); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
-	}
-
-	if err = c.configureAndValidateCoreDNSIP(); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
-	}
-
-	if err = c.validateServiceCIDRNetmask(); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
-	}
-
-	if err = c.ConfigureAndValidateNameserverConfiguration(TkgLabelClusterRoleWorkload); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
+	if verr := runConfigValidationChecksUntilFailure(providerName, commonChecks[1:]); verr != nil {
+		return verr
 	}
 
 	if err := c.configureAndValidateProviderConfig(providerName, options, clusterClient, skipValidation); err != nil {
@@ -0,0 +1,167 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/run/util/certificates"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+const (
+	// CertificateSourceAnnotation is the source of the expiry dates read from the certificates expiry annotation of a machine
+	CertificateSourceAnnotation = "annotation"
+	// CertificateSourceBootstrap is the source of the expiry dates estimated from the bootstrap time of a machine
+	CertificateSourceBootstrap = "bootstrap"
+	// CertificateSourceSecret is the source of the expiry dates read from a certificate authority secret
+	CertificateSourceSecret = "secret"
+)
+
+// ClusterCertificate is the expiry of certificates of a cluster: the kubeadm certificates of a control
+// plane machine, or a certificate authority
+type ClusterCertificate struct {
+	// Kind is Machine or Secret
+	Kind        string    `json:"kind"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	// Source is annotation, bootstrap or secret
+	Source string `json:"source"`
+}
+
+// GetClusterCertificatesOptions contains options supported by GetClusterCertificates
+type GetClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// RotateClusterCertificatesOptions contains options supported by RotateClusterCertificates
+type RotateClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+	// SkipWait returns once the rollout of the control plane is started
+	SkipWait bool
+}
+
+// GetClusterCertificates returns the expiry dates of the kubeadm certificates of the control plane machines and of
+// the certificate authorities of a workload cluster, sorted by expiry
+func (c *TkgClient) GetClusterCertificates(options GetClusterCertificatesOptions) ([]ClusterCertificate, error) {
+	regionalClusterClient, err := c.getRegionalClusterClient()
+	if err != nil {
+		return nil, err
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	return getClusterCertificates(regionalClusterClient, options.ClusterName, options.Namespace)
+}
+
+// RotateClusterCertificates rolls out the control plane of a workload cluster, which renews the kubeadm certificates
+// of the control plane nodes, and waits for the rollout to complete unless SkipWait is set
+func (c *TkgClient) RotateClusterCertificates(options RotateClusterCertificatesOptions) error {
+	regionalClusterClient, err := c.getRegionalClusterClient()
+	if err != nil {
+		return err
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	rolloutAfter := time.Now()
+	log.Infof("Rolling out the control plane of cluster %s/%s to renew its certificates...", options.Namespace, options.ClusterName)
+	if err := regionalClusterClient.RolloutKubeadmControlPlane(options.ClusterName, options.Namespace, rolloutAfter); err != nil {
+		return err
+	}
+	if options.SkipWait {
+		return nil
+	}
+	log.Info("Waiting for the control plane machines to be replaced...")
+	return errors.Wrap(regionalClusterClient.WaitForControlPlaneRollout(options.ClusterName, options.Namespace, rolloutAfter),
+		"control plane rollout did not complete")
+}
+
+func getClusterCertificates(clusterClient clusterclient.Client, clusterName, namespace string) ([]ClusterCertificate, error) {
+	machines := &capi.MachineList{}
+	if err := clusterClient.GetResourceList(machines, clusterName, namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to list the machines of cluster %s/%s", namespace, clusterName)
+	}
+
+	var clusterCertificates []ClusterCertificate
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if _, isControlPlane := machine.Labels[capi.MachineControlPlaneLabelName]; !isControlPlane || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		expiresAt, source, err := machineCertificatesExpiry(clusterClient, machine)
+		if err != nil {
+			return nil, err
+		}
+		clusterCertificates = append(clusterCertificates, ClusterCertificate{
+			Kind:        "Machine",
+			Name:        machine.Name,
+			Description: "kubeadm certificates",
+			ExpiresAt:   expiresAt,
+			Source:      source,
+		})
+	}
+
+	for _, ca := range certificates.CertificateAuthorities {
+		secret := &corev1.Secret{}
+		name := ca.SecretName(clusterName)
+		if err := clusterClient.GetResource(secret, name, namespace, nil, nil); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "unable to get secret %s/%s", namespace, name)
+		}
+		expiresAt, err := certificates.CertificateExpiry(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid certificate in secret %s/%s", namespace, name)
+		}
+		clusterCertificates = append(clusterCertificates, ClusterCertificate{
+			Kind:        "Secret",
+			Name:        name,
+			Description: ca.Description,
+			ExpiresAt:   expiresAt,
+			Source:      CertificateSourceSecret,
+		})
+	}
+
+	if len(clusterCertificates) == 0 {
+		return nil, errors.Errorf("no kubeadm control plane certificates found for cluster %s/%s", namespace, clusterName)
+	}
+	sort.SliceStable(clusterCertificates, func(i, j int) bool {
+		if clusterCertificates[i].ExpiresAt.Equal(clusterCertificates[j].ExpiresAt) {
+			return clusterCertificates[i].Name < clusterCertificates[j].Name
+		}
+		return clusterCertificates[i].ExpiresAt.Before(clusterCertificates[j].ExpiresAt)
+	})
+	return clusterCertificates, nil
+}
+
+// machineCertificatesExpiry returns the expiry date of the kubeadm certificates of a control plane machine, read from
+// its certificates expiry annotation or estimated from the creation of its bootstrap config
+func machineCertificatesExpiry(clusterClient clusterclient.Client, machine *capi.Machine) (time.Time, string, error) {
+	expiresAt, ok, err := certificates.AnnotatedMachineCertificatesExpiry(machine)
+	if err != nil {
+		log.Warningf("Ignoring %v", err)
+	} else if ok {
+		return expiresAt, CertificateSourceAnnotation, nil
+	}
+
+	expiresAt, err = certificates.EstimatedMachineCertificatesExpiry(machine, func(config *unstructured.Unstructured) error {
+		return clusterClient.GetResource(config, config.GetName(), config.GetNamespace(), nil, nil)
+	})
+	return expiresAt, CertificateSourceBootstrap, err
+}
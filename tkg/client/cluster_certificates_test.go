@@ -0,0 +1,182 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/run/util/certificates"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+)
+
+// certificatesTestClusterClient serves machines, bootstrap configs and secrets from memory
+type certificatesTestClusterClient struct {
+	clusterclient.Client
+	machines         []capi.Machine
+	bootstrapConfigs map[string]time.Time
+	secrets          map[string][]byte
+}
+
+func (c *certificatesTestClusterClient) GetResourceList(resourceReference interface{}, clusterName, namespace string, postVerify clusterclient.PostVerifyListrFunc, pollOptions *clusterclient.PollOptions) error {
+	machines := resourceReference.(*capi.MachineList)
+	for i := range c.machines {
+		if c.machines[i].Labels[capi.ClusterLabelName] == clusterName && c.machines[i].Namespace == namespace {
+			machines.Items = append(machines.Items, c.machines[i])
+		}
+	}
+	return nil
+}
+
+func (c *certificatesTestClusterClient) GetResource(resourceReference interface{}, resourceName, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+	switch obj := resourceReference.(type) {
+	case *unstructured.Unstructured:
+		if obj.GetKind() == "Unreadable" {
+			return errors.New("forbidden")
+		}
+		created, ok := c.bootstrapConfigs[obj.GetKind()+"/"+resourceName]
+		if !ok {
+			return apierrors.NewNotFound(schema.GroupResource{Resource: obj.GetKind()}, resourceName)
+		}
+		obj.SetCreationTimestamp(metav1.NewTime(created))
+	case *corev1.Secret:
+		data, ok := c.secrets[resourceName]
+		if !ok {
+			return apierrors.NewNotFound(corev1.Resource("secrets"), resourceName)
+		}
+		obj.Data = map[string][]byte{corev1.TLSCertKey: data}
+	default:
+		return errors.New("unexpected resource")
+	}
+	return nil
+}
+
+func newCertificatesTestMachine(name string, created time.Time, controlPlane bool, annotations map[string]string) capi.Machine {
+	machine := capi.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            map[string]string{capi.ClusterLabelName: "wc"},
+			Annotations:       annotations,
+		},
+		Spec: capi.MachineSpec{
+			Bootstrap: capi.Bootstrap{ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfig", Name: name + "-config"}},
+		},
+	}
+	if controlPlane {
+		machine.Labels[capi.MachineControlPlaneLabelName] = ""
+	}
+	return machine
+}
+
+func newCertificatesTestCertificate(notAfter time.Time) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kubernetes"},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+var _ = Describe("getClusterCertificates", func() {
+	var (
+		start         time.Time
+		clusterClient *certificatesTestClusterClient
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+		clusterClient = &certificatesTestClusterClient{
+			machines: []capi.Machine{
+				newCertificatesTestMachine("wc-control-plane-a", start, true, nil),
+				newCertificatesTestMachine("wc-control-plane-b", start, true,
+					map[string]string{certificates.MachineCertificatesExpiryAnnotation: "2023-01-01T00:00:00Z"}),
+				newCertificatesTestMachine("wc-control-plane-c", start.Add(time.Hour), true, nil),
+				newCertificatesTestMachine("wc-md-0-a", start, false, nil),
+			},
+			bootstrapConfigs: map[string]time.Time{
+				"KubeadmConfig/wc-control-plane-a-config": start.Add(time.Minute),
+			},
+			secrets: map[string][]byte{
+				"wc-ca": newCertificatesTestCertificate(start.Add(10 * certificates.KubeadmCertificatesValidity)),
+			},
+		}
+	})
+
+	It("returns the expiry of the control plane machines and certificate authorities sorted by expiry", func() {
+		clusterCertificates, err := getClusterCertificates(clusterClient, "wc", "default")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusterCertificates).To(HaveLen(4))
+
+		Expect(clusterCertificates[0].Name).To(Equal("wc-control-plane-b"))
+		Expect(clusterCertificates[0].Source).To(Equal(CertificateSourceAnnotation))
+		Expect(clusterCertificates[0].ExpiresAt).To(Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+		Expect(clusterCertificates[1].Name).To(Equal("wc-control-plane-a"))
+		Expect(clusterCertificates[1].Source).To(Equal(CertificateSourceBootstrap))
+		Expect(clusterCertificates[1].ExpiresAt).To(BeTemporally("==", start.Add(time.Minute).Add(certificates.KubeadmCertificatesValidity)))
+
+		// the KubeadmConfig of the machine is not found, its creation is the bootstrap time
+		Expect(clusterCertificates[2].Name).To(Equal("wc-control-plane-c"))
+		Expect(clusterCertificates[2].ExpiresAt).To(Equal(start.Add(time.Hour).Add(certificates.KubeadmCertificatesValidity)))
+
+		Expect(clusterCertificates[3]).To(Equal(ClusterCertificate{
+			Kind:        "Secret",
+			Name:        "wc-ca",
+			Description: "Kubernetes CA",
+			ExpiresAt:   start.Add(10 * certificates.KubeadmCertificatesValidity),
+			Source:      CertificateSourceSecret,
+		}))
+	})
+
+	It("fails when the cluster has no control plane certificates", func() {
+		_, err := getClusterCertificates(clusterClient, "other", "default")
+		Expect(err).To(MatchError("no kubeadm control plane certificates found for cluster default/other"))
+	})
+
+	It("estimates the expiry from bootstrap configs of any kind", func() {
+		clusterClient.machines[0].Spec.Bootstrap.ConfigRef.Kind = "OtherConfig"
+		clusterClient.bootstrapConfigs["OtherConfig/wc-control-plane-a-config"] = start.Add(2 * time.Minute)
+		clusterCertificates, err := getClusterCertificates(clusterClient, "wc", "default")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusterCertificates[1].Name).To(Equal("wc-control-plane-a"))
+		Expect(clusterCertificates[1].ExpiresAt).To(BeTemporally("==", start.Add(2*time.Minute).Add(certificates.KubeadmCertificatesValidity)))
+	})
+
+	It("fails when a bootstrap config cannot be read", func() {
+		clusterClient.machines[0].Spec.Bootstrap.ConfigRef.Kind = "Unreadable"
+		_, err := getClusterCertificates(clusterClient, "wc", "default")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to get the bootstrap config of machine wc-control-plane-a"))
+	})
+
+	It("fails when a certificate authority secret is invalid", func() {
+		clusterClient.secrets["wc-etcd"] = []byte("invalid")
+		_, err := getClusterCertificates(clusterClient, "wc", "default")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid certificate in secret default/wc-etcd"))
+	})
+})
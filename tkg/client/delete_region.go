@@ -51,6 +51,8 @@ func (c *TkgClient) DeleteRegion(options DeleteRegionOptions) error { //nolint:f
 	var cleanupClusterKubeconfigPath string
 
 	defer func() {
+		defer c.unlockPersistentKindCluster()
+
 		// if management cluster deletion is not being started and kind cluster is already created
 		if !isSuccessful && isStartedRegionalClusterDeletion {
 			c.displayHelpTextOnDeleteRegionFailure(cleanupClusterKubeconfigPath, isCleanupClusterCreated, cleanupClusterName, options.ClusterName)
@@ -0,0 +1,350 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	runv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/diagnostics"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+const (
+	diagnoseManagementClusterDir = "management-cluster"
+	diagnoseWorkloadClusterDir   = "workload-cluster"
+	diagnoseSummaryFile          = "summary.txt"
+
+	// DefaultDiagnoseLogTailLines is the default number of lines collected from the end of the container logs
+	DefaultDiagnoseLogTailLines = 2000
+)
+
+// DiagnoseClusterOptions contains options supported by DiagnoseCluster
+type DiagnoseClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	// OutputFile is the path of the bundle, <cluster>-diagnostics-<time>.tar.gz if empty
+	OutputFile string
+	// LogTailLines limits the container logs to their last lines, all lines are collected if not positive
+	LogTailLines        int64
+	IsManagementCluster bool
+}
+
+var (
+	clusterGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"}
+	machineGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Machine"}
+	podGVK     = schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	nodeGVK    = schema.GroupVersionKind{Version: "v1", Kind: "Node"}
+)
+
+// diagnoseClusterObjectKinds are the Cluster API kinds whose objects are collected from the
+// namespace of the cluster, they are selected by the cluster name label
+var diagnoseClusterObjectKinds = []schema.GroupVersionKind{
+	{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta1", Kind: "KubeadmControlPlane"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineDeployment"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineSet"},
+	machineGVK,
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineHealthCheck"},
+	{Group: "bootstrap.cluster.x-k8s.io", Version: "v1beta1", Kind: "KubeadmConfig"},
+}
+
+// diagnoseWorkloadClusterNamespaces are the namespaces of the workload cluster whose pod logs are collected
+var diagnoseWorkloadClusterNamespaces = []string{"kube-system", constants.TkgNamespace}
+
+// diagnoseManagementClusterNamespaces are the namespaces of the management cluster whose pod logs are
+// collected, in addition to the namespaces of the Cluster API providers
+var diagnoseManagementClusterNamespaces = []string{constants.TkgNamespace, constants.TkrNamespace}
+
+// DiagnoseCluster collects the Cluster API objects, the ClusterBootstrap, the PackageInstalls, the Tanzu Kubernetes
+// releases, the nodes and the pod logs of a cluster, together with a summary of the failing conditions, into a
+// redacted diagnostics bundle. The management cluster objects are collected even if the cluster is not reachable.
+// It returns the path of the bundle.
+func (c *TkgClient) DiagnoseCluster(options DiagnoseClusterOptions) (string, error) {
+	regionalClusterClient, err := c.getDiagnoseRegionalClusterClient(options)
+	if err != nil {
+		return "", err
+	}
+
+	namespace := options.Namespace
+	if options.IsManagementCluster {
+		namespace, err = managementClusterNamespace(regionalClusterClient, options.ClusterName)
+		if err != nil {
+			return "", err
+		}
+	} else if namespace == "" {
+		namespace = constants.DefaultNamespace
+	}
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(clusterGVK)
+	if err := regionalClusterClient.GetResource(cluster, options.ClusterName, namespace, nil, nil); err != nil {
+		return "", errors.Wrapf(err, "unable to get cluster %s/%s", namespace, options.ClusterName)
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-diagnostics-%s", options.ClusterName, now.UTC().Format("20060102150405"))
+	outputFile := options.OutputFile
+	if outputFile == "" {
+		outputFile = name + ".tar.gz"
+	}
+	bundle := diagnostics.NewBundle(name)
+	report := &diagnostics.Report{Title: fmt.Sprintf("cluster %s/%s", namespace, options.ClusterName), Time: now}
+
+	d := &clusterDiagnoser{bundle: bundle, report: report, logTailLines: options.LogTailLines}
+	log.Infof("Collecting objects of cluster %s/%s from the management cluster...", namespace, options.ClusterName)
+	d.collectClusterObjects(regionalClusterClient, cluster)
+	d.collectTKRs(regionalClusterClient, cluster)
+
+	logNamespaces := diagnoseManagementClusterNamespaces
+	if providerNamespaces, err := clusterAPIProviderNamespaces(regionalClusterClient); err != nil {
+		report.AddError(err)
+	} else {
+		logNamespaces = append(providerNamespaces, logNamespaces...)
+	}
+	log.Info("Collecting pod logs from the management cluster...")
+	d.collectPods(regionalClusterClient, diagnoseManagementClusterDir, logNamespaces)
+
+	if options.IsManagementCluster {
+		d.collectClusterState(regionalClusterClient, diagnoseManagementClusterDir)
+	} else {
+		log.Infof("Collecting nodes, packages and pod logs from cluster %s...", options.ClusterName)
+		workloadClusterClient, err := c.getWorkloadClusterClient(options.ClusterName, namespace)
+		if err != nil {
+			report.AddError(errors.Wrap(err, "unable to collect the workload cluster diagnostics"))
+		} else {
+			d.collectClusterState(workloadClusterClient, diagnoseWorkloadClusterDir)
+			d.collectPods(workloadClusterClient, diagnoseWorkloadClusterDir, diagnoseWorkloadClusterNamespaces)
+		}
+	}
+
+	bundle.AddFile(diagnoseSummaryFile, diagnostics.RedactLogs([]byte(report.String())))
+	log.Infof("Writing diagnostics bundle to %s...", outputFile)
+	if err := bundle.WriteFile(outputFile); err != nil {
+		return "", err
+	}
+	return outputFile, nil
+}
+
+func (c *TkgClient) getDiagnoseRegionalClusterClient(options DiagnoseClusterOptions) (clusterclient.Client, error) {
+	if !options.IsManagementCluster {
+		return c.getRegionalClusterClient()
+	}
+	contexts, err := c.GetRegionContexts(options.ClusterName)
+	if err != nil || len(contexts) == 0 {
+		return nil, errors.Errorf("management cluster %s not found", options.ClusterName)
+	}
+	clusterClient, err := clusterclient.NewClient(contexts[0].SourceFilePath, contexts[0].ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create cluster client for management cluster")
+	}
+	return clusterClient, nil
+}
+
+// managementClusterNamespace returns the namespace of the Cluster object of a management cluster
+func managementClusterNamespace(clusterClient clusterclient.Client, managementClusterName string) (string, error) {
+	clusters, err := clusterClient.ListClusters("")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to list clusters")
+	}
+	for i := range clusters {
+		if clusters[i].Name == managementClusterName && isManagementCluster(&clusters[i]) {
+			return clusters[i].Namespace, nil
+		}
+	}
+	return constants.TkgNamespace, nil
+}
+
+// clusterAPIProviderNamespaces returns the sorted namespaces of the Cluster API providers of a management cluster
+func clusterAPIProviderNamespaces(clusterClient clusterclient.Client) ([]string, error) {
+	var providers clusterctlv1.ProviderList
+	if err := clusterClient.ListResources(&providers); err != nil {
+		return nil, errors.Wrap(err, "unable to list Cluster API providers")
+	}
+	seen := map[string]bool{}
+	var namespaces []string
+	for i := range providers.Items {
+		if namespace := providers.Items[i].Namespace; !seen[namespace] {
+			seen[namespace] = true
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// clusterDiagnoser adds the collected objects and logs to a bundle, and their problems to its report.
+// Collection errors are added to the report so that a partial bundle is still produced.
+type clusterDiagnoser struct {
+	bundle       *diagnostics.Bundle
+	report       *diagnostics.Report
+	logTailLines int64
+}
+
+// addObjects adds objects to the bundle and their failing conditions to the report
+func (d *clusterDiagnoser) addObjects(name string, objects []unstructured.Unstructured) {
+	if len(objects) == 0 {
+		return
+	}
+	if err := d.bundle.AddObjects(name, objects); err != nil {
+		d.report.AddError(err)
+		return
+	}
+	d.report.AddObjects(objects)
+}
+
+// list lists the objects of a kind, kinds which are not installed on the cluster are skipped
+func (d *clusterDiagnoser) list(clusterClient clusterclient.Client, gvk schema.GroupVersionKind, options ...crtclient.ListOption) []unstructured.Unstructured {
+	objectList := &unstructured.UnstructuredList{}
+	objectList.SetGroupVersionKind(gvk)
+	if err := clusterClient.ListResources(objectList, options...); err != nil {
+		if meta.IsNoMatchError(errors.Cause(err)) {
+			log.V(3).Infof("Skipping %s, the kind is not installed on the cluster", gvk.String())
+			return nil
+		}
+		d.report.AddError(errors.Wrapf(err, "unable to list resources: %s", gvk.String()))
+		return nil
+	}
+	return objectList.Items
+}
+
+// collectClusterObjects collects the Cluster API objects and the ClusterBootstrap of a cluster from the management cluster
+func (d *clusterDiagnoser) collectClusterObjects(clusterClient clusterclient.Client, cluster *unstructured.Unstructured) {
+	d.addObjects(path.Join(diagnoseManagementClusterDir, "capi", "cluster.yaml"), []unstructured.Unstructured{*cluster})
+
+	var references []map[string]interface{}
+	if ref, found, _ := unstructured.NestedMap(cluster.Object, "spec", "infrastructureRef"); found {
+		references = append(references, ref)
+	}
+	for _, gvk := range diagnoseClusterObjectKinds {
+		objects := d.list(clusterClient, gvk, crtclient.InNamespace(cluster.GetNamespace()),
+			crtclient.MatchingLabels{"cluster.x-k8s.io/cluster-name": cluster.GetName()})
+		if gvk == machineGVK {
+			for i := range objects {
+				if ref, found, _ := unstructured.NestedMap(objects[i].Object, "spec", "infrastructureRef"); found {
+					references = append(references, ref)
+				}
+			}
+		}
+		d.addObjects(path.Join(diagnoseManagementClusterDir, "capi", strings.ToLower(gvk.Kind)+".yaml"), objects)
+	}
+
+	var infrastructure []unstructured.Unstructured
+	for _, ref := range references {
+		apiVersion, _, _ := unstructured.NestedString(ref, "apiVersion")
+		kind, _, _ := unstructured.NestedString(ref, "kind")
+		name, _, _ := unstructured.NestedString(ref, "name")
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(apiVersion, kind))
+		if err := clusterClient.GetResource(obj, name, cluster.GetNamespace(), nil, nil); err != nil {
+			d.report.AddError(errors.Wrapf(err, "unable to get %s %s/%s", kind, cluster.GetNamespace(), name))
+			continue
+		}
+		infrastructure = append(infrastructure, *obj)
+	}
+	d.addObjects(path.Join(diagnoseManagementClusterDir, "capi", "infrastructure.yaml"), infrastructure)
+
+	clusterBootstrap := &unstructured.Unstructured{}
+	clusterBootstrap.SetGroupVersionKind(runv1alpha3.GroupVersion.WithKind("ClusterBootstrap"))
+	if err := clusterClient.GetResource(clusterBootstrap, cluster.GetName(), cluster.GetNamespace(), nil, nil); err != nil {
+		d.report.AddError(errors.Wrap(err, "unable to get the ClusterBootstrap of the cluster"))
+	} else {
+		d.addObjects(path.Join(diagnoseManagementClusterDir, "tkg", "clusterbootstrap.yaml"), []unstructured.Unstructured{*clusterBootstrap})
+	}
+}
+
+// collectTKRs collects the Tanzu Kubernetes releases and OS images, and reports how the TKR of the cluster is resolved.
+// Only the failing conditions of the TKR of the cluster are reported, as incompatible TKRs are expected.
+func (d *clusterDiagnoser) collectTKRs(clusterClient clusterclient.Client, cluster *unstructured.Unstructured) {
+	tkrName := cluster.GetLabels()[runv1alpha3.LabelTKR]
+	d.report.Details = append(d.report.Details,
+		fmt.Sprintf("Tanzu Kubernetes release: %s", valueOrNone(tkrName)),
+		fmt.Sprintf("Tanzu Kubernetes release query: %s", valueOrNone(cluster.GetAnnotations()[runv1alpha3.AnnotationResolveTKR])))
+
+	tkrs := d.list(clusterClient, runv1alpha3.GroupVersion.WithKind("TanzuKubernetesRelease"))
+	for i := range tkrs {
+		if tkrs[i].GetName() == tkrName {
+			d.report.AddObjects(tkrs[i : i+1])
+		}
+	}
+	if len(tkrs) != 0 {
+		if err := d.bundle.AddObjects(path.Join(diagnoseManagementClusterDir, "tkg", "tanzukubernetesrelease.yaml"), tkrs); err != nil {
+			d.report.AddError(err)
+		}
+	}
+	osImages := d.list(clusterClient, runv1alpha3.GroupVersion.WithKind("OSImage"))
+	if len(osImages) != 0 {
+		if err := d.bundle.AddObjects(path.Join(diagnoseManagementClusterDir, "tkg", "osimage.yaml"), osImages); err != nil {
+			d.report.AddError(err)
+		}
+	}
+}
+
+// collectClusterState collects the nodes and the PackageInstalls of a cluster
+func (d *clusterDiagnoser) collectClusterState(clusterClient clusterclient.Client, dir string) {
+	d.addObjects(path.Join(dir, "node.yaml"), d.list(clusterClient, nodeGVK))
+	d.addObjects(path.Join(dir, "packageinstall.yaml"),
+		d.list(clusterClient, schema.GroupVersionKind{Group: "packaging.carvel.dev", Version: "v1alpha1", Kind: "PackageInstall"}))
+}
+
+// collectPods collects the pods of namespaces of a cluster and the logs of their containers
+func (d *clusterDiagnoser) collectPods(clusterClient clusterclient.Client, dir string, namespaces []string) {
+	var pods []unstructured.Unstructured
+	for _, namespace := range namespaces {
+		pods = append(pods, d.list(clusterClient, podGVK, crtclient.InNamespace(namespace))...)
+	}
+	if len(pods) == 0 {
+		return
+	}
+	if err := d.bundle.AddObjects(path.Join(dir, "pod.yaml"), pods); err != nil {
+		d.report.AddError(err)
+	}
+	d.report.AddPods(pods)
+
+	for i := range pods {
+		for _, container := range podContainerNames(&pods[i]) {
+			logs, err := clusterClient.GetPodLogs(pods[i].GetNamespace(), pods[i].GetName(), container, d.logTailLines)
+			if err != nil {
+				d.report.AddError(err)
+				continue
+			}
+			d.bundle.AddLogs(path.Join(dir, "logs", pods[i].GetNamespace(), pods[i].GetName(), container+".log"), logs)
+		}
+	}
+}
+
+// podContainerNames returns the names of the init containers and containers of a pod
+func podContainerNames(pod *unstructured.Unstructured) []string {
+	var names []string
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", field)
+		for _, c := range containers {
+			if container, ok := c.(map[string]interface{}); ok {
+				if name, _, _ := unstructured.NestedString(container, "name"); name != "" {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -0,0 +1,142 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/diagnostics"
+)
+
+// diagnoseTestClusterClient serves objects and pod logs from memory
+type diagnoseTestClusterClient struct {
+	clusterclient.Client
+	objects []unstructured.Unstructured
+	logs    map[string]string
+}
+
+func (c *diagnoseTestClusterClient) ListResources(resourceReference interface{}, option ...crtclient.ListOption) error {
+	list := resourceReference.(*unstructured.UnstructuredList)
+	listOptions := &crtclient.ListOptions{}
+	listOptions.ApplyOptions(option)
+
+	found := false
+	for _, obj := range c.objects {
+		if obj.GroupVersionKind() != list.GroupVersionKind() {
+			continue
+		}
+		found = true
+		if (listOptions.Namespace == "" || listOptions.Namespace == obj.GetNamespace()) &&
+			(listOptions.LabelSelector == nil || listOptions.LabelSelector.Matches(labels.Set(obj.GetLabels()))) {
+			list.Items = append(list.Items, obj)
+		}
+	}
+	if !found && list.GetKind() != "Pod" {
+		return &meta.NoKindMatchError{GroupKind: list.GroupVersionKind().GroupKind()}
+	}
+	return nil
+}
+
+func (c *diagnoseTestClusterClient) GetResource(resourceReference interface{}, resourceName, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+	obj := resourceReference.(*unstructured.Unstructured)
+	for i := range c.objects {
+		if c.objects[i].GroupVersionKind() == obj.GroupVersionKind() && c.objects[i].GetName() == resourceName && c.objects[i].GetNamespace() == namespace {
+			obj.Object = c.objects[i].DeepCopy().Object
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+func (c *diagnoseTestClusterClient) GetPodLogs(namespace, podName, containerName string, tailLines int64) ([]byte, error) {
+	logs, ok := c.logs[namespace+"/"+podName+"/"+containerName]
+	if !ok {
+		return nil, errors.New("container is waiting to start")
+	}
+	return []byte(logs), nil
+}
+
+func newDiagnoseTestObject(apiVersion, kind, name string, labels map[string]string, spec map[string]interface{}) unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	obj.SetLabels(labels)
+	return obj
+}
+
+var _ = Describe("DiagnoseCluster", func() {
+	var (
+		d             *clusterDiagnoser
+		bundle        *diagnostics.Bundle
+		report        *diagnostics.Report
+		clusterClient *diagnoseTestClusterClient
+		clusterLabels map[string]string
+	)
+
+	BeforeEach(func() {
+		bundle = diagnostics.NewBundle("wc-diagnostics")
+		report = &diagnostics.Report{}
+		d = &clusterDiagnoser{bundle: bundle, report: report}
+		clusterLabels = map[string]string{"cluster.x-k8s.io/cluster-name": "wc"}
+		clusterClient = &diagnoseTestClusterClient{}
+	})
+
+	It("should collect the Cluster API objects of the cluster and the infrastructure objects they refer to", func() {
+		cluster := newDiagnoseTestObject("cluster.x-k8s.io/v1beta1", "Cluster", "wc", nil, map[string]interface{}{
+			"infrastructureRef": map[string]interface{}{"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1", "kind": "DockerCluster", "name": "wc-abcde"},
+		})
+		clusterClient.objects = []unstructured.Unstructured{
+			cluster,
+			newDiagnoseTestObject("infrastructure.cluster.x-k8s.io/v1beta1", "DockerCluster", "wc-abcde", nil, nil),
+			newDiagnoseTestObject("infrastructure.cluster.x-k8s.io/v1beta1", "DockerMachine", "wc-md-0-fghij", nil, nil),
+			newDiagnoseTestObject("cluster.x-k8s.io/v1beta1", "Machine", "wc-md-0", clusterLabels, map[string]interface{}{
+				"infrastructureRef": map[string]interface{}{"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1", "kind": "DockerMachine", "name": "wc-md-0-fghij"},
+			}),
+			newDiagnoseTestObject("cluster.x-k8s.io/v1beta1", "Machine", "other-md-0", map[string]string{"cluster.x-k8s.io/cluster-name": "other"}, nil),
+			newDiagnoseTestObject("cluster.x-k8s.io/v1beta1", "MachineDeployment", "wc-md", clusterLabels, nil),
+		}
+
+		d.collectClusterObjects(clusterClient, &cluster)
+
+		Expect(bundle.Files()).To(Equal([]string{
+			"management-cluster/capi/cluster.yaml",
+			"management-cluster/capi/machinedeployment.yaml",
+			"management-cluster/capi/machine.yaml",
+			"management-cluster/capi/infrastructure.yaml",
+		}))
+		Expect(report.Errors).To(ConsistOf(ContainSubstring("unable to get the ClusterBootstrap of the cluster")))
+	})
+
+	It("should collect the pods and the logs of their containers", func() {
+		pod := newDiagnoseTestObject("v1", "Pod", "antrea-agent", nil, map[string]interface{}{
+			"initContainers": []interface{}{map[string]interface{}{"name": "install-cni"}},
+			"containers":     []interface{}{map[string]interface{}{"name": "antrea-agent"}},
+		})
+		pod.SetNamespace("kube-system")
+		pod.Object["status"] = map[string]interface{}{"phase": "Pending"}
+		clusterClient.objects = []unstructured.Unstructured{pod}
+		clusterClient.logs = map[string]string{"kube-system/antrea-agent/install-cni": "password=s3cr3t"}
+
+		d.collectPods(clusterClient, diagnoseWorkloadClusterDir, diagnoseWorkloadClusterNamespaces)
+
+		Expect(bundle.Files()).To(Equal([]string{
+			"workload-cluster/pod.yaml",
+			"workload-cluster/logs/kube-system/antrea-agent/install-cni.log",
+		}))
+		Expect(report.UnhealthyPods).To(Equal([]string{"Pod kube-system/antrea-agent: phase Pending"}))
+		Expect(report.Errors).To(HaveLen(1))
+		Expect(strings.Join(report.Errors, "")).To(ContainSubstring("waiting to start"))
+	})
+})
@@ -10,6 +10,7 @@ import (
 	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 )
 
 // ListTKGClustersOptions contains options supported by ListClusters
@@ -31,6 +32,8 @@ type ClusterInfo struct {
 	Roles             []string          `json:"roles" yaml:"roles"`
 	TKR               string            `json:"tkr" yaml:"tkr"`
 	Labels            map[string]string `json:"labels" yaml:"labels"`
+	Paused            bool              `json:"paused" yaml:"paused"`
+	PausedBy          string            `json:"pausedBy,omitempty" yaml:"pausedBy,omitempty"`
 }
 
 // ListTKGClusters lists tkg cluster information
@@ -92,6 +95,8 @@ func (c *TkgClient) GetClusterObjects(clusterClient clusterclient.Client, listOp
 		cluster.Roles = getClusterRoles(clusterInfo.cluster.Labels)
 		cluster.Labels = clusterInfo.cluster.Labels
 		cluster.TKR = getClusterTKR(clusterInfo.cluster.Labels)
+		cluster.Paused = clusterInfo.cluster.Spec.Paused
+		cluster.PausedBy = clusterInfo.cluster.Annotations[constants.ClusterPausedByAnnotation]
 		clusters = append(clusters, cluster)
 	}
 
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 	"github.com/go-openapi/swag"
 	"github.com/juju/fslock"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -100,24 +102,61 @@ func (c *TkgClient) InitRegionDryRun(options *InitRegionOptions) ([]byte, error)
 // InitRegion create management cluster
 func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funlen,gocyclo
 	var err error
-	var regionalConfigBytes []byte
 	var isSuccessful = false
 	var isStartedRegionalClusterCreation = false
 	var isBootstrapClusterCreated = false
 	var bootstrapClusterName string
+	var bootstrapClusterKubeconfigPath string
 	var regionContext region.RegionContext
 	var filelock *fslock.Lock
-	var configFilePath string
-
-	bootstrapClusterKubeconfigPath, err := getTKGKubeConfigPath(false)
-	if err != nil {
-		return err
+	var providerName string
+	var bootStrapClusterClient, regionalClusterClient clusterclient.Client
+	var bootstrapPkgClient, regionalPkgClient packageclient.PackageClient
+	var regionalClusterKubeconfigPath, kubeContext string
+
+	var statePath string
+	state := &InitRegionState{}
+	if options.Resume {
+		if statePath, err = c.findInitRegionStateFile(options.ClusterName); err != nil {
+			return err
+		}
+		if state, err = LoadInitRegionState(statePath); err != nil {
+			return err
+		}
+		options.ClusterName = state.ClusterName
+		bootstrapClusterName = state.BootstrapClusterName
+		bootstrapClusterKubeconfigPath = state.BootstrapClusterKubeconfigPath
+		if bootstrapClusterName == kind.PersistentKindClusterName {
+			if err := c.lockPersistentKindCluster(); err != nil {
+				return err
+			}
+		}
+		log.Infof("Resuming the creation of management cluster %s", state.ClusterName)
+	} else {
+		// the cluster name is needed up front as it keys the state of the creation
+		if options.ClusterName == "" {
+			options.ClusterName = generateRegionalClusterName(options.InfrastructureProvider, "")
+		}
+		statePath = c.initRegionStateFilePath(options.ClusterName)
+		state.ClusterName = options.ClusterName
+	}
+	if bootstrapClusterKubeconfigPath == "" {
+		if bootstrapClusterKubeconfigPath, err = getTKGKubeConfigPath(false); err != nil {
+			return err
+		}
+		state.BootstrapClusterKubeconfigPath = bootstrapClusterKubeconfigPath
 	}
 
-	log.SendProgressUpdate(statusRunning, StepValidateConfiguration, InitRegionSteps)
+	targetClusterNamespace := defaultTkgNamespace
+	if options.Namespace != "" {
+		targetClusterNamespace = options.Namespace
+	}
+	state.Namespace = targetClusterNamespace
 
-	log.Info("Validating configuration...")
 	defer func() {
+		// a failed operation releases the persistent bootstrap cluster too, it is not reused while it has clusters
+		defer c.unlockPersistentKindCluster()
+
 		if regionContext != (region.RegionContext{}) {
 			filelock, err = utils.GetFileLockWithTimeOut(filepath.Join(c.tkgConfigDir, constants.LocalTanzuFileLock), utils.DefaultLockTimeout)
 			if err != nil {
@@ -143,9 +182,9 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 			log.SendProgressUpdate(statusFailed, "", InitRegionSteps)
 		}
 
-		// if management cluster creation failed after bootstrap kind cluster was successfully created
-		if !isSuccessful && isStartedRegionalClusterCreation {
-			c.displayHelpTextOnFailure(options, isBootstrapClusterCreated, bootstrapClusterKubeconfigPath)
+		// keep the bootstrap cluster of a failed creation only when resuming the creation relies on it
+		if !isSuccessful && (isStartedRegionalClusterCreation || state.needsBootstrapCluster()) {
+			c.displayHelpTextOnFailure(options, isStartedRegionalClusterCreation, bootstrapClusterName, bootstrapClusterKubeconfigPath)
 			return
 		}
 
@@ -157,258 +196,400 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 		_ = utils.DeleteFile(bootstrapClusterKubeconfigPath)
 	}()
 
-	if customImageRepo, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableCustomImageRepository); err != nil && customImageRepo != "" && tkgconfighelper.IsCustomRepository(customImageRepo) {
-		log.Infof("Using custom image repository: %s", customImageRepo)
-	}
-
-	c.ensureClusterTopologyConfiguration()
+	// connectBootstrapCluster creates the clients of the bootstrap cluster
+	connectBootstrapCluster := func() error {
+		// Configure kubeconfig as part of options as bootstrap cluster kubeconfig
+		options.Kubeconfig = bootstrapClusterKubeconfigPath
 
-	providerName, _, err := ParseProviderName(options.InfrastructureProvider)
-	if err != nil {
-		return errors.Wrap(err, "unable to parse provider name")
-	}
-
-	// validate docker only if user is not using an existing cluster
-	// Note: Validating in client code as well to cover the usecase where users use client code instead of command line.
-
-	if err := c.ValidatePrerequisites(!options.UseExistingCluster, true); err != nil {
-		return err
-	}
-
-	// validate docker resources if provider is docker
-	if providerName == "docker" {
-		if err := c.ValidateDockerResourcePrerequisites(); err != nil {
-			return err
+		isBootstrapClusterCreated = true
+		if bootStrapClusterClient, err = clusterclient.NewClient(bootstrapClusterKubeconfigPath, "", clusterclient.Options{OperationTimeout: c.timeout}); err != nil {
+			return errors.Wrap(err, "unable to get bootstrap cluster client")
 		}
-	}
-
-	log.Infof("Using infrastructure provider %s", options.InfrastructureProvider)
-	log.SendProgressUpdate(statusRunning, StepGenerateClusterConfiguration, InitRegionSteps)
-	log.Info("Generating cluster configuration...")
-
-	log.SendProgressUpdate(statusRunning, StepSetupBootstrapCluster, InitRegionSteps)
-	log.Info("Setting up bootstrapper...")
-	// Ensure bootstrap cluster and copy boostrap cluster kubeconfig to ~/kube-tkg directory
-	if bootstrapClusterName, err = c.ensureKindCluster(options.Kubeconfig, options.UseExistingCluster, bootstrapClusterKubeconfigPath); err != nil {
-		return errors.Wrap(err, "unable to create bootstrap cluster")
-	}
-
-	// Configure kubeconfig as part of options as bootstrap cluster kubeconfig
-	options.Kubeconfig = bootstrapClusterKubeconfigPath
-
-	isBootstrapClusterCreated = true
-	log.Infof("Bootstrapper created. Kubeconfig: %s", bootstrapClusterKubeconfigPath)
-	bootStrapClusterClient, err := clusterclient.NewClient(bootstrapClusterKubeconfigPath, "", clusterclient.Options{OperationTimeout: c.timeout})
-	if err != nil {
-		return errors.Wrap(err, "unable to get bootstrap cluster client")
-	}
-	bootstrapPkgClient, err := packageclient.NewPackageClientForContext(bootstrapClusterKubeconfigPath, "")
-	if err != nil {
-		return errors.Wrap(err, "unable to get a PackageClient")
-	}
-
-	// configure variables required to deploy providers
-	if err := c.configureVariablesForProvidersInstallation(nil); err != nil {
-		return errors.Wrap(err, "unable to configure variables for provider installation")
-	}
-
-	// If clusterclass feature flag is enabled then deploy kapp-controller
-	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		log.Info("Installing kapp-controller on bootstrap cluster...")
-		if err = c.InstallOrUpgradeKappController(bootStrapClusterClient, constants.OperationTypeInstall); err != nil {
-			return errors.Wrap(err, "unable to install kapp-controller to bootstrap cluster")
+		if bootstrapPkgClient, err = packageclient.NewPackageClientForContext(bootstrapClusterKubeconfigPath, ""); err != nil {
+			return errors.Wrap(err, "unable to get a PackageClient")
 		}
+		return nil
 	}
 
-	log.SendProgressUpdate(statusRunning, StepInstallProvidersOnBootstrapCluster, InitRegionSteps)
-	log.Info("Installing providers on bootstrapper...")
-	// Initialize bootstrap cluster with providers
-	if err = c.InitializeProviders(options, bootStrapClusterClient, bootstrapClusterKubeconfigPath); err != nil {
-		return errors.Wrap(err, "unable to initialize providers")
-	}
-
-	// If clusterclass feature flag is enabled then deploy management components
-	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		if err = c.InstallOrUpgradeManagementComponents(bootStrapClusterClient, bootstrapPkgClient, "", false); err != nil {
-			return errors.Wrap(err, "unable to install management components to bootstrap cluster")
+	// connectManagementCluster creates the clients of the management cluster from the TKG managed kubeconfig
+	connectManagementCluster := func() error {
+		if regionalClusterKubeconfigPath, err = getTKGKubeConfigPath(true); err != nil {
+			return err
 		}
-
-		akoRequired, err := c.isAKORequiredInBootstrapCluster()
-		if err != nil {
-			return errors.Wrap(err, "unable to check whether avi ha is enabled")
+		if regionalClusterClient, err = clusterclient.NewClient(regionalClusterKubeconfigPath, kubeContext, clusterclient.Options{OperationTimeout: c.timeout}); err != nil {
+			return errors.Wrap(err, "unable to get management cluster client")
 		}
-		if akoRequired {
-			log.Info("Installing AKO on bootstrapper...")
-			if err = c.InstallAKO(bootStrapClusterClient); err != nil {
-				return errors.Wrap(err, "unable to install ako")
-			}
+		if regionalPkgClient, err = packageclient.NewPackageClientForContext(regionalClusterKubeconfigPath, kubeContext); err != nil {
+			return errors.Wrap(err, "unable to get a PackageClient")
 		}
+		return nil
 	}
 
-	if options.AdditionalTKGManifests != "" {
-		log.Infof("Apply additional manifests %s for the bootstrap cluster in tkg-system", options.AdditionalTKGManifests)
-		if err = bootStrapClusterClient.ApplyFileRecursively(options.AdditionalTKGManifests, "tkg-system"); err != nil {
-			return errors.Wrap(err, "unable to apply additional manifests")
+	// saveManagementClusterKubeconfig saves the management cluster kubeconfig and connects to the management cluster
+	saveManagementClusterKubeconfig := func() error {
+		kubeConfigBytes, err := bootStrapClusterClient.GetKubeConfigForCluster(options.ClusterName, targetClusterNamespace, nil)
+		if err != nil {
+			return errors.Wrapf(err, "unable to extract kube config for cluster %s", options.ClusterName)
 		}
-	}
 
-	// Obtain management cluster configuration of a provided flavor
-	if regionalConfigBytes, options.ClusterName, configFilePath, err = c.BuildRegionalClusterConfiguration(options); err != nil {
-		return errors.Wrap(err, "unable to build management cluster configuration")
-	}
-	log.Infof("Management cluster config file has been generated and stored at: '%v'", configFilePath)
-
-	isStartedRegionalClusterCreation = true
-
-	targetClusterNamespace := defaultTkgNamespace
-	if options.Namespace != "" {
-		targetClusterNamespace = options.Namespace
-	}
-
-	log.SendProgressUpdate(statusRunning, StepCreateManagementCluster, InitRegionSteps)
-	log.Info("Start creating management cluster...")
-	err = c.DoCreateCluster(bootStrapClusterClient, options.ClusterName, targetClusterNamespace, string(regionalConfigBytes))
-	if err != nil {
-		return errors.Wrap(err, "unable to create management cluster")
-	}
-
-	// save this context to tkg config incase the management cluster creation fails
-	bootstrapClusterContext := "kind-" + bootstrapClusterName
-	if options.UseExistingCluster {
-		bootstrapClusterContext, err = getCurrentContextFromDefaultKubeConfig()
+		kubeconfigPath, err := getTKGKubeConfigPath(true)
 		if err != nil {
 			return err
 		}
-	}
-	regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: bootstrapClusterContext, SourceFilePath: bootstrapClusterKubeconfigPath, Status: region.Failed}
-
-	err = bootStrapClusterClient.WaitForControlPlaneAvailable(options.ClusterName, targetClusterNamespace)
-	if err != nil {
-		return errors.Wrap(err, "unable to wait for cluster control plane available")
-	}
-	log.Info("Management cluster control plane is available, means API server is ready to receive requests")
-
-	kubeConfigBytes, err := bootStrapClusterClient.GetKubeConfigForCluster(options.ClusterName, targetClusterNamespace, nil)
-	if err != nil {
-		return errors.Wrapf(err, "unable to extract kube config for cluster %s", options.ClusterName)
-	}
-
-	regionalClusterKubeconfigPath, err := getTKGKubeConfigPath(true)
-	if err != nil {
-		return err
-	}
-	// put a filelock to ensure mutual exclusion on updating kubeconfig
-	filelock, err = utils.GetFileLockWithTimeOut(filepath.Join(c.tkgConfigDir, constants.LocalTanzuFileLock), utils.DefaultLockTimeout)
-	if err != nil {
-		return errors.Wrap(err, "cannot acquire lock for updating management cluster kubeconfig")
-	}
-
-	mergeFile := getDefaultKubeConfigFile()
-	log.Infof("Saving management cluster kubeconfig into %s", mergeFile)
-	// merge the management cluster kubeconfig into user input kubeconfig path/default kubeconfig path
-	err = MergeKubeConfigWithoutSwitchContext(kubeConfigBytes, mergeFile)
-	if err != nil {
-		return errors.Wrap(err, "unable to merge management cluster kubeconfig")
-	}
-
-	// merge the management cluster kubeconfig into tkg managed kubeconfig
-	kubeContext, err := MergeKubeConfigAndSwitchContext(kubeConfigBytes, regionalClusterKubeconfigPath)
-	if err != nil {
-		return errors.Wrap(err, "unable to save management cluster kubeconfig to TKG managed kubeconfig")
-	}
-
-	if err := filelock.Unlock(); err != nil {
-		log.Warningf("cannot acquire lock for updating management cluster kubeconfigconfig, reason: %v", err)
-	}
-
-	regionalClusterClient, err := clusterclient.NewClient(regionalClusterKubeconfigPath, kubeContext, clusterclient.Options{OperationTimeout: c.timeout})
-	if err != nil {
-		return errors.Wrap(err, "unable to get management cluster client")
-	}
-	regionalPkgClient, err := packageclient.NewPackageClientForContext(regionalClusterKubeconfigPath, kubeContext)
-	if err != nil {
-		return errors.Wrap(err, "unable to get a PackageClient")
-	}
-
-	// If clusterclass feature flag is enabled then deploy kapp-controller
-	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		log.Info("Installing kapp-controller on management cluster...")
-		if err = c.InstallOrUpgradeKappController(regionalClusterClient, constants.OperationTypeInstall); err != nil {
-			return errors.Wrap(err, "unable to install kapp-controller to management cluster")
+		// put a filelock to ensure mutual exclusion on updating kubeconfig
+		filelock, err = utils.GetFileLockWithTimeOut(filepath.Join(c.tkgConfigDir, constants.LocalTanzuFileLock), utils.DefaultLockTimeout)
+		if err != nil {
+			return errors.Wrap(err, "cannot acquire lock for updating management cluster kubeconfig")
 		}
-	}
 
-	err = bootStrapClusterClient.WaitForClusterInitialized(options.ClusterName, targetClusterNamespace)
-	if err != nil {
-		return errors.Wrap(err, "error waiting for cluster to be provisioned (this may take a few minutes)")
-	}
-
-	log.SendProgressUpdate(statusRunning, StepInstallProvidersOnRegionalCluster, InitRegionSteps)
-	log.Info("Installing providers on management cluster...")
-	if err = c.InitializeProviders(options, regionalClusterClient, regionalClusterKubeconfigPath); err != nil {
-		return errors.Wrap(err, "unable to initialize providers on management cluster")
-	}
-
-	if err := regionalClusterClient.PatchClusterAPIAWSControllersToUseEC2Credentials(); err != nil {
-		return err
-	}
-
-	// If clusterclass feature flag is enabled then deploy management components to the cluster
-	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		if err = c.InstallOrUpgradeManagementComponents(regionalClusterClient, regionalPkgClient, kubeContext, false); err != nil {
-			return errors.Wrap(err, "unable to install management components to management cluster")
+		mergeFile := getDefaultKubeConfigFile()
+		log.Infof("Saving management cluster kubeconfig into %s", mergeFile)
+		// merge the management cluster kubeconfig into user input kubeconfig path/default kubeconfig path
+		err = MergeKubeConfigWithoutSwitchContext(kubeConfigBytes, mergeFile)
+		if err != nil {
+			return errors.Wrap(err, "unable to merge management cluster kubeconfig")
 		}
-	}
 
-	log.Info("Waiting for the management cluster to get ready for move...")
-	if err := c.WaitForClusterReadyForMove(bootStrapClusterClient, options.ClusterName, targetClusterNamespace); err != nil {
-		return errors.Wrap(err, "unable to wait for cluster getting ready for move")
-	}
+		// merge the management cluster kubeconfig into tkg managed kubeconfig
+		kubeContext, err = MergeKubeConfigAndSwitchContext(kubeConfigBytes, kubeconfigPath)
+		if err != nil {
+			return errors.Wrap(err, "unable to save management cluster kubeconfig to TKG managed kubeconfig")
+		}
+		state.ManagementClusterContext = kubeContext
 
-	log.Info("Waiting for addons installation...")
-	if err := c.WaitForAddons(waitForAddonsOptions{
-		regionalClusterClient: bootStrapClusterClient,
-		workloadClusterClient: regionalClusterClient,
-		clusterName:           options.ClusterName,
-		namespace:             options.Namespace,
-		waitForCNI:            true,
-	}); err != nil {
-		return errors.Wrap(err, "error waiting for addons to get installed")
+		if err := filelock.Unlock(); err != nil {
+			log.Warningf("cannot acquire lock for updating management cluster kubeconfigconfig, reason: %v", err)
+		}
+		return connectManagementCluster()
 	}
 
-	if options.AdditionalTKGManifests != "" {
-		log.Infof("Apply additional manifests %s for the management cluster in %s", options.AdditionalTKGManifests, defaultTkgNamespace)
-		if err = regionalClusterClient.ApplyFileRecursively(options.AdditionalTKGManifests, defaultTkgNamespace); err != nil {
-			return errors.Wrap(err, "unable to apply additional manifests")
+	// setFailedRegionContext saves the bootstrap cluster context to tkg config in case the management cluster creation fails
+	setFailedRegionContext := func() error {
+		bootstrapClusterContext := "kind-" + bootstrapClusterName
+		if options.UseExistingCluster {
+			if bootstrapClusterContext, err = getCurrentContextFromDefaultKubeConfig(); err != nil {
+				return err
+			}
 		}
+		regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: bootstrapClusterContext, SourceFilePath: bootstrapClusterKubeconfigPath, Status: region.Failed}
+		return nil
 	}
 
-	// Applying ClusterBootstrap and its associated resources on the management cluster
-	if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
-		log.Infof("Applying ClusterBootstrap and its associated resources on management cluster")
-		if err := c.ApplyClusterBootstrapObjects(bootStrapClusterClient, regionalClusterClient); err != nil {
-			return errors.Wrap(err, "Unable to apply ClusterBootstarp and its associated resources on management cluster")
-		}
+	phases := []initRegionPhase{
+		{
+			name: InitRegionPhaseValidateConfiguration,
+			step: StepValidateConfiguration,
+			run: func() error {
+				log.Info("Validating configuration...")
+				if customImageRepo, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableCustomImageRepository); err != nil && customImageRepo != "" && tkgconfighelper.IsCustomRepository(customImageRepo) {
+					log.Infof("Using custom image repository: %s", customImageRepo)
+				}
+
+				c.ensureClusterTopologyConfiguration()
+
+				if providerName, _, err = ParseProviderName(options.InfrastructureProvider); err != nil {
+					return errors.Wrap(err, "unable to parse provider name")
+				}
+
+				// validate docker only if user is not using an existing cluster
+				// Note: Validating in client code as well to cover the usecase where users use client code instead of command line.
+				if err := c.ValidatePrerequisites(!options.UseExistingCluster, true); err != nil {
+					return err
+				}
+
+				// validate docker resources if provider is docker
+				if providerName == "docker" {
+					if err := c.ValidateDockerResourcePrerequisites(); err != nil {
+						return err
+					}
+				}
+
+				log.Infof("Using infrastructure provider %s", options.InfrastructureProvider)
+				log.SendProgressUpdate(statusRunning, StepGenerateClusterConfiguration, InitRegionSteps)
+				log.Info("Generating cluster configuration...")
+
+				// configure variables required to deploy providers
+				if err := c.configureVariablesForProvidersInstallation(nil); err != nil {
+					return errors.Wrap(err, "unable to configure variables for provider installation")
+				}
+				return nil
+			},
+		},
+		{
+			name: InitRegionPhaseSetupBootstrapCluster,
+			step: StepSetupBootstrapCluster,
+			run: func() error {
+				log.Info("Setting up bootstrapper...")
+				// Ensure bootstrap cluster and copy boostrap cluster kubeconfig to ~/kube-tkg directory
+				if bootstrapClusterName, err = c.ensureKindCluster(options.Kubeconfig, options.UseExistingCluster, bootstrapClusterKubeconfigPath); err != nil {
+					return errors.Wrap(err, "unable to create bootstrap cluster")
+				}
+				state.BootstrapClusterName = bootstrapClusterName
+				if err := connectBootstrapCluster(); err != nil {
+					return err
+				}
+				log.Infof("Bootstrapper created. Kubeconfig: %s", bootstrapClusterKubeconfigPath)
+
+				// If clusterclass feature flag is enabled then deploy kapp-controller
+				if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
+					log.Info("Installing kapp-controller on bootstrap cluster...")
+					if err = c.InstallOrUpgradeKappController(bootStrapClusterClient, constants.OperationTypeInstall); err != nil {
+						return errors.Wrap(err, "unable to install kapp-controller to bootstrap cluster")
+					}
+				}
+				return nil
+			},
+			verify: func() error {
+				if _, err := os.Stat(bootstrapClusterKubeconfigPath); err != nil {
+					return errors.Wrap(err, "bootstrap cluster kubeconfig not found")
+				}
+				// options are only pointed at the bootstrap cluster once it is known to be reachable
+				clusterClient, err := clusterclient.NewClient(bootstrapClusterKubeconfigPath, "", clusterclient.Options{OperationTimeout: c.timeout})
+				if err != nil {
+					return errors.Wrap(err, "unable to get bootstrap cluster client")
+				}
+				if err := clusterClient.ListResources(&corev1.NamespaceList{}); err != nil {
+					return errors.Wrap(err, "bootstrap cluster is not reachable")
+				}
+				return connectBootstrapCluster()
+			},
+		},
+		{
+			name: InitRegionPhaseInstallProvidersOnBootstrapCluster,
+			step: StepInstallProvidersOnBootstrapCluster,
+			run: func() error {
+				log.Info("Installing providers on bootstrapper...")
+				// Initialize bootstrap cluster with providers
+				if err = c.InitializeProviders(options, bootStrapClusterClient, bootstrapClusterKubeconfigPath); err != nil {
+					return errors.Wrap(err, "unable to initialize providers")
+				}
+
+				// If clusterclass feature flag is enabled then deploy management components
+				if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
+					if err = c.InstallOrUpgradeManagementComponents(bootStrapClusterClient, bootstrapPkgClient, "", false); err != nil {
+						return errors.Wrap(err, "unable to install management components to bootstrap cluster")
+					}
+
+					akoRequired, err := c.isAKORequiredInBootstrapCluster()
+					if err != nil {
+						return errors.Wrap(err, "unable to check whether avi ha is enabled")
+					}
+					if akoRequired {
+						log.Info("Installing AKO on bootstrapper...")
+						if err = c.InstallAKO(bootStrapClusterClient); err != nil {
+							return errors.Wrap(err, "unable to install ako")
+						}
+					}
+				}
+
+				if options.AdditionalTKGManifests != "" {
+					log.Infof("Apply additional manifests %s for the bootstrap cluster in tkg-system", options.AdditionalTKGManifests)
+					if err = bootStrapClusterClient.ApplyFileRecursively(options.AdditionalTKGManifests, "tkg-system"); err != nil {
+						return errors.Wrap(err, "unable to apply additional manifests")
+					}
+				}
+				return nil
+			},
+			verify: func() error {
+				return bootStrapClusterClient.IsRegionalCluster()
+			},
+		},
+		{
+			name: InitRegionPhaseCreateManagementCluster,
+			step: StepCreateManagementCluster,
+			run: func() error {
+				var regionalConfigBytes []byte
+				var configFilePath string
+				// Obtain management cluster configuration of a provided flavor
+				if regionalConfigBytes, options.ClusterName, configFilePath, err = c.BuildRegionalClusterConfiguration(options); err != nil {
+					return errors.Wrap(err, "unable to build management cluster configuration")
+				}
+				log.Infof("Management cluster config file has been generated and stored at: '%v'", configFilePath)
+				state.ClusterName = options.ClusterName
+				state.ConfigFilePath = configFilePath
+
+				isStartedRegionalClusterCreation = true
+
+				log.Info("Start creating management cluster...")
+				err = c.DoCreateCluster(bootStrapClusterClient, options.ClusterName, targetClusterNamespace, string(regionalConfigBytes))
+				if err != nil {
+					return errors.Wrap(err, "unable to create management cluster")
+				}
+
+				if err := setFailedRegionContext(); err != nil {
+					return err
+				}
+
+				err = bootStrapClusterClient.WaitForControlPlaneAvailable(options.ClusterName, targetClusterNamespace)
+				if err != nil {
+					return errors.Wrap(err, "unable to wait for cluster control plane available")
+				}
+				log.Info("Management cluster control plane is available, means API server is ready to receive requests")
+
+				if err := saveManagementClusterKubeconfig(); err != nil {
+					return err
+				}
+
+				// If clusterclass feature flag is enabled then deploy kapp-controller
+				if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
+					log.Info("Installing kapp-controller on management cluster...")
+					if err = c.InstallOrUpgradeKappController(regionalClusterClient, constants.OperationTypeInstall); err != nil {
+						return errors.Wrap(err, "unable to install kapp-controller to management cluster")
+					}
+				}
+
+				err = bootStrapClusterClient.WaitForClusterInitialized(options.ClusterName, targetClusterNamespace)
+				if err != nil {
+					return errors.Wrap(err, "error waiting for cluster to be provisioned (this may take a few minutes)")
+				}
+				return nil
+			},
+			verify: func() error {
+				// the following phases rely on the configuration derived while building the cluster configuration
+				if err := c.restoreRegionalClusterConfiguration(options, state.ConfigFilePath); err != nil {
+					return err
+				}
+				isStartedRegionalClusterCreation = true
+				if err := setFailedRegionContext(); err != nil {
+					return err
+				}
+				err := bootStrapClusterClient.GetResource(&capi.Cluster{}, options.ClusterName, targetClusterNamespace, nil, nil)
+				if apierrors.IsNotFound(err) && state.ManagementClusterContext != "" {
+					// the Cluster API objects may already have been moved to the management cluster
+					kubeContext = state.ManagementClusterContext
+					return connectManagementCluster()
+				}
+				if err != nil {
+					return err
+				}
+				return saveManagementClusterKubeconfig()
+			},
+		},
+		{
+			name: InitRegionPhaseInstallProvidersOnManagementCluster,
+			step: StepInstallProvidersOnRegionalCluster,
+			run: func() error {
+				log.Info("Installing providers on management cluster...")
+				if err = c.InitializeProviders(options, regionalClusterClient, regionalClusterKubeconfigPath); err != nil {
+					return errors.Wrap(err, "unable to initialize providers on management cluster")
+				}
+
+				if err := regionalClusterClient.PatchClusterAPIAWSControllersToUseEC2Credentials(); err != nil {
+					return err
+				}
+
+				// If clusterclass feature flag is enabled then deploy management components to the cluster
+				if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
+					if err = c.InstallOrUpgradeManagementComponents(regionalClusterClient, regionalPkgClient, kubeContext, false); err != nil {
+						return errors.Wrap(err, "unable to install management components to management cluster")
+					}
+				}
+				return nil
+			},
+			verify: func() error {
+				return regionalClusterClient.IsRegionalCluster()
+			},
+		},
+		{
+			name: InitRegionPhaseWaitForManagementClusterReady,
+			step: StepInstallProvidersOnRegionalCluster,
+			run: func() error {
+				log.Info("Waiting for the management cluster to get ready for move...")
+				if err := c.WaitForClusterReadyForMove(bootStrapClusterClient, options.ClusterName, targetClusterNamespace); err != nil {
+					return errors.Wrap(err, "unable to wait for cluster getting ready for move")
+				}
+
+				log.Info("Waiting for addons installation...")
+				if err := c.WaitForAddons(waitForAddonsOptions{
+					regionalClusterClient: bootStrapClusterClient,
+					workloadClusterClient: regionalClusterClient,
+					clusterName:           options.ClusterName,
+					namespace:             options.Namespace,
+					waitForCNI:            true,
+				}); err != nil {
+					return errors.Wrap(err, "error waiting for addons to get installed")
+				}
+
+				if options.AdditionalTKGManifests != "" {
+					log.Infof("Apply additional manifests %s for the management cluster in %s", options.AdditionalTKGManifests, defaultTkgNamespace)
+					if err = regionalClusterClient.ApplyFileRecursively(options.AdditionalTKGManifests, defaultTkgNamespace); err != nil {
+						return errors.Wrap(err, "unable to apply additional manifests")
+					}
+				}
+
+				// Applying ClusterBootstrap and its associated resources on the management cluster
+				if config.IsFeatureActivated(constants.FeatureFlagPackageBasedLCM) {
+					log.Infof("Applying ClusterBootstrap and its associated resources on management cluster")
+					if err := c.ApplyClusterBootstrapObjects(bootStrapClusterClient, regionalClusterClient); err != nil {
+						return errors.Wrap(err, "Unable to apply ClusterBootstarp and its associated resources on management cluster")
+					}
+				}
+				return nil
+			},
+			verify: func() error {
+				err := bootStrapClusterClient.GetResource(&capi.Cluster{}, options.ClusterName, targetClusterNamespace, clusterclient.VerifyClusterReady, nil)
+				if apierrors.IsNotFound(err) {
+					// the Cluster API objects were already moved to the management cluster
+					return nil
+				}
+				if err != nil {
+					return errors.Wrap(err, "management cluster is not ready for move")
+				}
+				if err := bootStrapClusterClient.GetResourceList(&capi.MachineList{}, options.ClusterName, targetClusterNamespace, clusterclient.VerifyMachinesReady, nil); err != nil {
+					return errors.Wrap(err, "management cluster machines are not ready")
+				}
+				return nil
+			},
+		},
+		{
+			name: InitRegionPhaseMoveClusterAPIObjects,
+			step: StepMoveClusterAPIObjects,
+			run: func() error {
+				log.Info("Moving all Cluster API objects from bootstrap cluster to management cluster...")
+				// Move all Cluster API objects from bootstrap cluster to created to management cluster for all namespaces
+				if err = c.MoveObjects(bootstrapClusterKubeconfigPath, regionalClusterKubeconfigPath, targetClusterNamespace); err != nil {
+					return errors.Wrap(err, "unable to move Cluster API objects from bootstrap cluster to management cluster")
+				}
+				regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: kubeContext, SourceFilePath: regionalClusterKubeconfigPath, Status: region.Success}
+				return nil
+			},
+			verify: func() error {
+				if err := regionalClusterClient.GetResource(&capi.Cluster{}, options.ClusterName, targetClusterNamespace, nil, nil); err != nil {
+					return errors.Wrap(err, "management cluster object not found on the management cluster")
+				}
+				if err := bootStrapClusterClient.GetResource(&capi.Cluster{}, options.ClusterName, targetClusterNamespace, nil, nil); !apierrors.IsNotFound(err) {
+					return errors.New("the move of the Cluster API objects to the management cluster did not complete")
+				}
+				regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: kubeContext, SourceFilePath: regionalClusterKubeconfigPath, Status: region.Success}
+				return nil
+			},
+		},
+		{
+			name: InitRegionPhaseFinalizeManagementCluster,
+			step: StepMoveClusterAPIObjects,
+			run: func() error {
+				return c.finalizeManagementCluster(regionalClusterClient, options, targetClusterNamespace, providerName)
+			},
+		},
 	}
 
-	log.SendProgressUpdate(statusRunning, StepMoveClusterAPIObjects, InitRegionSteps)
-	log.Info("Moving all Cluster API objects from bootstrap cluster to management cluster...")
-	// Move all Cluster API objects from bootstrap cluster to created to management cluster for all namespaces
-	if err = c.MoveObjects(bootstrapClusterKubeconfigPath, regionalClusterKubeconfigPath, targetClusterNamespace); err != nil {
-		return errors.Wrap(err, "unable to move Cluster API objects from bootstrap cluster to management cluster")
+	if err := runInitRegionPhases(phases, state, statePath, options.Resume); err != nil {
+		return err
 	}
 
-	regionContext = region.RegionContext{ClusterName: options.ClusterName, ContextName: kubeContext, SourceFilePath: regionalClusterKubeconfigPath, Status: region.Success}
+	_ = utils.DeleteFile(statePath)
+	log.Infof("You can now access the management cluster %s by running 'kubectl config use-context %s'", options.ClusterName, kubeContext)
+	isSuccessful = true
+	return nil
+}
 
-	err = c.PatchClusterInitOperations(regionalClusterClient, options, targetClusterNamespace)
+// finalizeManagementCluster patches the moved management cluster, starts CEIP telemetry and waits for the packages
+func (c *TkgClient) finalizeManagementCluster(regionalClusterClient clusterclient.Client, options *InitRegionOptions, targetClusterNamespace, providerName string) error {
+	err := c.PatchClusterInitOperations(regionalClusterClient, options, targetClusterNamespace)
 	if err != nil {
 		return errors.Wrap(err, "unable to patch cluster object")
 	}
 
-	if err != nil {
-		return errors.Wrap(err, "unable to parse provider name")
-	}
-
 	// start CEIP telemetry cronjob if cluster is opt-in
 	if options.CeipOptIn {
 		bomConfig, err := c.tkgBomClient.GetDefaultTkgBOMConfiguration()
@@ -456,9 +637,6 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 			log.Warningf("Warning: Management cluster is created successfully, but the tkg-bom versioned ConfigMaps creation is failing. %v", err)
 		}
 	}
-
-	log.Infof("You can now access the management cluster %s by running 'kubectl config use-context %s'", options.ClusterName, kubeContext)
-	isSuccessful = true
 	return nil
 }
 
@@ -620,24 +798,35 @@ func (c *TkgClient) ensureKindCluster(kubeconfig string, useExistingCluster bool
 		return "", err
 	}
 
+	persistent := c.isPersistentBootstrapCluster()
+	if persistent {
+		if err := c.lockPersistentKindCluster(); err != nil {
+			return "", err
+		}
+	}
+
+	imageCache, _ := c.TKGConfigReaderWriter().Get(constants.ConfigVariableBootstrapImageCache)
 	c.kindClient = kind.New(&kind.KindClusterOptions{
 		KubeConfigPath:   backupPath,
 		TKGConfigDir:     c.tkgConfigDir,
 		Readerwriter:     c.TKGConfigReaderWriter(),
 		DefaultImageRepo: bomConfig.ImageConfig.ImageRepository,
+		Persistent:       persistent,
+		ImageCache:       imageCache,
 	})
 
 	// Create kind cluster which will be used to deploy management cluster
 	clusterName, err := c.kindClient.CreateKindCluster()
 	if err != nil {
+		c.unlockPersistentKindCluster()
 		return "", err
 	}
 	return clusterName, nil
 }
 
 func (c *TkgClient) teardownKindCluster(clusterName, kubeconfig string, useExistingCluster bool) error {
-	// skip if using existing cluster
-	if useExistingCluster {
+	// skip if using existing cluster, a persistent bootstrap cluster is only cleaned up to be reused
+	if useExistingCluster || c.isPersistentBootstrapCluster() {
 		err := c.clusterctlClient.Delete(clusterctl.DeleteOptions{
 			Kubeconfig: clusterctl.Kubeconfig{
 				Path: kubeconfig,
@@ -646,7 +835,15 @@ func (c *TkgClient) teardownKindCluster(clusterName, kubeconfig string, useExist
 			IncludeNamespace: true,
 			IncludeCRDs:      true,
 		})
-		log.V(3).Error(err, "Failed to delete resources from bootstrap cluster")
+		if useExistingCluster {
+			log.V(3).Error(err, "Failed to delete resources from bootstrap cluster")
+			return nil
+		}
+		// the persistent bootstrap cluster is only released once it is cleaned up, so that it is not reused dirty
+		if err != nil {
+			return errors.Wrapf(err, "failed to clean up the bootstrap cluster %s, delete it with 'kind delete cluster --name %s'", clusterName, clusterName)
+		}
+		c.unlockPersistentKindCluster()
 		return nil
 	}
 
@@ -672,6 +869,40 @@ func (c *TkgClient) teardownKindCluster(clusterName, kubeconfig string, useExist
 	return nil
 }
 
+// isPersistentBootstrapCluster tells whether the bootstrap cluster is kept to be reused by the next operations
+func (c *TkgClient) isPersistentBootstrapCluster() bool {
+	persistent, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableBootstrapClusterPersistent)
+	return err == nil && persistent == trueString
+}
+
+// lockPersistentKindCluster locks the persistent bootstrap cluster for the current operation, as the operations
+// of other processes would otherwise reuse it under the same name
+func (c *TkgClient) lockPersistentKindCluster() error {
+	if c.persistentKindClusterLock != nil {
+		return nil
+	}
+	lock := fslock.New(filepath.Join(c.tkgConfigDir, constants.PersistentKindClusterLockFileName))
+	if err := lock.TryLock(); err != nil {
+		if err == fslock.ErrLocked {
+			return errors.Errorf("bootstrap cluster %s is in use by another operation, retry once it completes", kind.PersistentKindClusterName)
+		}
+		return errors.Wrapf(err, "unable to lock bootstrap cluster %s", kind.PersistentKindClusterName)
+	}
+	c.persistentKindClusterLock = lock
+	return nil
+}
+
+// unlockPersistentKindCluster releases the persistent bootstrap cluster locked by lockPersistentKindCluster
+func (c *TkgClient) unlockPersistentKindCluster() {
+	if c.persistentKindClusterLock == nil {
+		return
+	}
+	if err := c.persistentKindClusterLock.Unlock(); err != nil {
+		log.Warningf("unable to release the lock of bootstrap cluster %s, %s", kind.PersistentKindClusterName, err.Error())
+	}
+	c.persistentKindClusterLock = nil
+}
+
 // InitializeProviders initializes providers
 func (c *TkgClient) InitializeProviders(options *InitRegionOptions, clusterClient clusterclient.Client, kubeconfigPath string) error {
 	clusterctlClientInitOptions := clusterctl.InitOptions{
@@ -815,6 +1046,28 @@ func (c *TkgClient) BuildRegionalClusterConfiguration(options *InitRegionOptions
 	return bytes, options.ClusterName, configFilePath, err
 }
 
+// restoreRegionalClusterConfiguration builds the management cluster configuration of a resumed creation again, so that
+// the configuration derived while building it is set again. The configuration stored at configFilePath is the one the
+// management cluster was created with, it is kept when the configuration built again differs.
+func (c *TkgClient) restoreRegionalClusterConfiguration(options *InitRegionOptions, configFilePath string) error {
+	var previousConfigBytes []byte
+	if configFilePath != "" {
+		var err error
+		if previousConfigBytes, err = os.ReadFile(configFilePath); err != nil {
+			return errors.Wrapf(err, "unable to read management cluster config file %s", configFilePath)
+		}
+	}
+	regionalConfigBytes, _, _, err := c.BuildRegionalClusterConfiguration(options)
+	if err != nil {
+		return errors.Wrap(err, "unable to build management cluster configuration")
+	}
+	if previousConfigBytes == nil || bytes.Equal(previousConfigBytes, regionalConfigBytes) {
+		return nil
+	}
+	log.Warningf("The management cluster configuration changed since the creation of the management cluster started, the management cluster keeps the configuration stored at %s", configFilePath)
+	return utils.SaveFile(configFilePath, previousConfigBytes)
+}
+
 type waitForProvidersOptions struct {
 	Kubeconfig      string
 	TargetNamespace string
@@ -895,16 +1148,25 @@ func (c *TkgClient) waitForProvider(clusterClient clusterclient.Client, name, pr
 }
 
 func (c *TkgClient) displayHelpTextOnFailure(options *InitRegionOptions,
-	isBootstrapClusterCreated bool, bootstrapClusterKubeconfigPath string) {
+	isStartedRegionalClusterCreation bool, bootstrapClusterName, bootstrapClusterKubeconfigPath string) {
 
 	log.Warningf("\n\nFailure while deploying management cluster, Here are some steps to investigate the cause:\n")
 	log.Warningf("\nDebug:")
 	log.Warningf("    kubectl get po,deploy,cluster,kubeadmcontrolplane,machine,machinedeployment -A --kubeconfig %s", bootstrapClusterKubeconfigPath)
 	log.Warningf("    kubectl logs deployment.apps/<deployment-name> -n <deployment-namespace> manager --kubeconfig %s", bootstrapClusterKubeconfigPath)
 
-	if !options.UseExistingCluster && isBootstrapClusterCreated {
+	log.Warningf("\nTo resume the creation of the management cluster once the cause is fixed:")
+	log.Warningf("	  tanzu management-cluster create --name %s --resume --file %s", options.ClusterName, options.ClusterConfigFile)
+
+	if options.UseExistingCluster {
+		return
+	}
+	if isStartedRegionalClusterCreation {
 		log.Warningf("\nTo clean up the resources created by the management cluster:")
 		log.Warningf("	  tanzu management-cluster delete")
+	} else if bootstrapClusterName != "" {
+		log.Warningf("\nTo clean up the bootstrap cluster:")
+		log.Warningf("	  kind delete cluster --name %s", bootstrapClusterName)
 	}
 }
 
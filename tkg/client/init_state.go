@@ -0,0 +1,205 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// InitRegionPhase is a named phase of the management cluster creation
+type InitRegionPhase string
+
+// management cluster init phase constants
+const (
+	InitRegionPhaseValidateConfiguration               InitRegionPhase = "ValidateConfiguration"
+	InitRegionPhaseSetupBootstrapCluster               InitRegionPhase = "SetupBootstrapCluster"
+	InitRegionPhaseInstallProvidersOnBootstrapCluster  InitRegionPhase = "InstallProvidersOnBootstrapCluster"
+	InitRegionPhaseCreateManagementCluster             InitRegionPhase = "CreateManagementCluster"
+	InitRegionPhaseInstallProvidersOnManagementCluster InitRegionPhase = "InstallProvidersOnManagementCluster"
+	InitRegionPhaseWaitForManagementClusterReady       InitRegionPhase = "WaitForManagementClusterReady"
+	InitRegionPhaseMoveClusterAPIObjects               InitRegionPhase = "MoveClusterAPIObjects"
+	InitRegionPhaseFinalizeManagementCluster           InitRegionPhase = "FinalizeManagementCluster"
+)
+
+// InitRegionPhaseStatus is the outcome of a management cluster init phase
+type InitRegionPhaseStatus struct {
+	Name      InitRegionPhase `yaml:"name"`
+	Status    string          `yaml:"status"`
+	StartTime time.Time       `yaml:"startTime,omitempty"`
+	EndTime   time.Time       `yaml:"endTime,omitempty"`
+	Message   string          `yaml:"message,omitempty"`
+}
+
+// InitRegionState is the progress of a management cluster creation. It is persisted under the TKG config directory
+// so that a failed creation can be resumed from the first phase that did not complete. The state is kept when the
+// creation fails and is deleted once the management cluster is created.
+type InitRegionState struct {
+	ClusterName                    string                  `yaml:"clusterName,omitempty"`
+	Namespace                      string                  `yaml:"namespace,omitempty"`
+	ConfigFilePath                 string                  `yaml:"configFilePath,omitempty"`
+	BootstrapClusterName           string                  `yaml:"bootstrapClusterName,omitempty"`
+	BootstrapClusterKubeconfigPath string                  `yaml:"bootstrapClusterKubeconfigPath,omitempty"`
+	ManagementClusterContext       string                  `yaml:"managementClusterContext,omitempty"`
+	Phases                         []InitRegionPhaseStatus `yaml:"phases,omitempty"`
+}
+
+// initRegionPhase is a step of InitRegion
+type initRegionPhase struct {
+	name InitRegionPhase
+	// step is the management cluster init step reported to the UI while the phase runs
+	step string
+	run  func() error
+	// verify checks that the results of the phase completed by a previous attempt are still valid and restores them.
+	// Phases without verify are run on every attempt.
+	verify func() error
+}
+
+// LoadInitRegionState reads the management cluster creation progress from the state file
+func LoadInitRegionState(path string) (*InitRegionState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New("no management cluster creation to resume, please create the management cluster without --resume")
+		}
+		return nil, errors.Wrapf(err, "unable to read management cluster creation state file %s", path)
+	}
+	state := &InitRegionState{}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse management cluster creation state file %s", path)
+	}
+	return state, nil
+}
+
+// Save writes the management cluster creation progress to the state file
+func (s *InitRegionState) Save(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal management cluster creation state")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), constants.DefaultDirectoryPermissions); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, constants.ConfigFilePermissions)
+}
+
+// IsPhaseCompleted tells whether the phase completed successfully
+func (s *InitRegionState) IsPhaseCompleted(name InitRegionPhase) bool {
+	for i := range s.Phases {
+		if s.Phases[i].Name == name {
+			return s.Phases[i].Status == statusSuccessful
+		}
+	}
+	return false
+}
+
+// needsBootstrapCluster tells whether resuming the creation relies on its bootstrap cluster, that is once the providers
+// are installed on the bootstrap cluster or the creation of the management cluster was attempted from it
+func (s *InitRegionState) needsBootstrapCluster() bool {
+	if s.IsPhaseCompleted(InitRegionPhaseInstallProvidersOnBootstrapCluster) {
+		return true
+	}
+	for i := range s.Phases {
+		if s.Phases[i].Name == InitRegionPhaseCreateManagementCluster {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *InitRegionState) setPhaseStatus(name InitRegionPhase, status, message string) {
+	i := 0
+	for ; i < len(s.Phases); i++ {
+		if s.Phases[i].Name == name {
+			break
+		}
+	}
+	if i == len(s.Phases) {
+		s.Phases = append(s.Phases, InitRegionPhaseStatus{Name: name})
+	}
+	phase := &s.Phases[i]
+	phase.Status = status
+	phase.Message = message
+	if status == statusRunning {
+		phase.StartTime = time.Now().UTC()
+		phase.EndTime = time.Time{}
+	} else {
+		phase.EndTime = time.Now().UTC()
+	}
+}
+
+// initRegionStateFilePath is the state file of the creation of the management cluster. Each management cluster has its
+// own state file so that concurrent creations do not overwrite each other's progress.
+func (c *TkgClient) initRegionStateFilePath(clusterName string) string {
+	return filepath.Join(c.tkgConfigDir, constants.InitRegionStateFolderName, clusterName+".yaml")
+}
+
+// findInitRegionStateFile returns the state file of the management cluster creation to resume. When no cluster name is
+// given, the only management cluster creation in progress is resumed.
+func (c *TkgClient) findInitRegionStateFile(clusterName string) (string, error) {
+	if clusterName != "" {
+		return c.initRegionStateFilePath(clusterName), nil
+	}
+	paths, err := filepath.Glob(c.initRegionStateFilePath("*"))
+	if err != nil {
+		return "", err
+	}
+	switch len(paths) {
+	case 0:
+		return "", errors.New("no management cluster creation to resume, please create the management cluster without --resume")
+	case 1:
+		return paths[0], nil
+	}
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(path), ".yaml"))
+	}
+	return "", errors.Errorf("the creations of management clusters %s are in progress, please set the name of the management cluster to resume", strings.Join(names, ", "))
+}
+
+// runInitRegionPhases runs the phases in order and persists their outcome to the state file. When resuming, phases
+// completed by a previous attempt are skipped as long as their results are still valid; once a phase has to run again,
+// all the following phases run as well.
+func runInitRegionPhases(phases []initRegionPhase, state *InitRegionState, statePath string, resume bool) error {
+	for _, phase := range phases {
+		log.SendProgressUpdate(statusRunning, phase.step, InitRegionSteps)
+
+		if resume && phase.verify != nil {
+			if state.IsPhaseCompleted(phase.name) {
+				err := phase.verify()
+				if err == nil {
+					log.Infof("Skipping phase %s, it was completed by a previous attempt", phase.name)
+					continue
+				}
+				log.Warningf("Phase %s was completed by a previous attempt but its results are no longer valid, running it again: %v", phase.name, err)
+			}
+			resume = false
+		}
+
+		state.setPhaseStatus(phase.name, statusRunning, "")
+		saveInitRegionState(state, statePath)
+		if err := phase.run(); err != nil {
+			state.setPhaseStatus(phase.name, statusFailed, err.Error())
+			saveInitRegionState(state, statePath)
+			return err
+		}
+		state.setPhaseStatus(phase.name, statusSuccessful, "")
+		saveInitRegionState(state, statePath)
+	}
+	return nil
+}
+
+func saveInitRegionState(state *InitRegionState, statePath string) {
+	if err := state.Save(statePath); err != nil {
+		log.Warningf("Unable to persist management cluster creation progress to %s: %v", statePath, err)
+	}
+}
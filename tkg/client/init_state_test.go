@@ -0,0 +1,178 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Management cluster init phases", func() {
+	var (
+		dir       string
+		statePath string
+		state     *InitRegionState
+		ran       []InitRegionPhase
+		verified  []InitRegionPhase
+		failing   InitRegionPhase
+		invalid   InitRegionPhase
+		phases    []initRegionPhase
+	)
+
+	newPhase := func(name InitRegionPhase, verifiable bool) initRegionPhase {
+		phase := initRegionPhase{
+			name: name,
+			step: string(name),
+			run: func() error {
+				ran = append(ran, name)
+				if name == failing {
+					return errors.New("phase failed")
+				}
+				return nil
+			},
+		}
+		if verifiable {
+			phase.verify = func() error {
+				verified = append(verified, name)
+				if name == invalid {
+					return errors.New("results no longer valid")
+				}
+				return nil
+			}
+		}
+		return phase
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "init-region-state")
+		Expect(err).ToNot(HaveOccurred())
+		statePath = filepath.Join(dir, "init-region-state.yaml")
+		state = &InitRegionState{ClusterName: "mgmt"}
+		ran, verified = nil, nil
+		failing, invalid = "", ""
+		phases = []initRegionPhase{
+			newPhase(InitRegionPhaseValidateConfiguration, false),
+			newPhase(InitRegionPhaseSetupBootstrapCluster, true),
+			newPhase(InitRegionPhaseCreateManagementCluster, true),
+			newPhase(InitRegionPhaseMoveClusterAPIObjects, true),
+			newPhase(InitRegionPhaseFinalizeManagementCluster, false),
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should persist the outcome of the phases", func() {
+		failing = InitRegionPhaseMoveClusterAPIObjects
+		Expect(runInitRegionPhases(phases, state, statePath, false)).To(MatchError("phase failed"))
+		Expect(ran).To(HaveLen(4))
+
+		loaded, err := LoadInitRegionState(statePath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded.ClusterName).To(Equal("mgmt"))
+		Expect(loaded.Phases).To(HaveLen(4))
+		Expect(loaded.IsPhaseCompleted(InitRegionPhaseCreateManagementCluster)).To(BeTrue())
+		Expect(loaded.IsPhaseCompleted(InitRegionPhaseMoveClusterAPIObjects)).To(BeFalse())
+		Expect(loaded.Phases[3].Status).To(Equal(statusFailed))
+		Expect(loaded.Phases[3].Message).To(Equal("phase failed"))
+	})
+
+	It("should skip the verified phases completed by a previous attempt when resuming", func() {
+		failing = InitRegionPhaseMoveClusterAPIObjects
+		Expect(runInitRegionPhases(phases, state, statePath, false)).ToNot(Succeed())
+
+		ran, failing = nil, ""
+		loaded, err := LoadInitRegionState(statePath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(runInitRegionPhases(phases, loaded, statePath, true)).To(Succeed())
+		Expect(verified).To(Equal([]InitRegionPhase{InitRegionPhaseSetupBootstrapCluster, InitRegionPhaseCreateManagementCluster}))
+		Expect(ran).To(Equal([]InitRegionPhase{
+			InitRegionPhaseValidateConfiguration,
+			InitRegionPhaseMoveClusterAPIObjects,
+			InitRegionPhaseFinalizeManagementCluster,
+		}))
+	})
+
+	It("should run again the phases following a phase whose results are no longer valid", func() {
+		failing = InitRegionPhaseFinalizeManagementCluster
+		Expect(runInitRegionPhases(phases, state, statePath, false)).ToNot(Succeed())
+
+		ran, failing, invalid = nil, "", InitRegionPhaseCreateManagementCluster
+		Expect(runInitRegionPhases(phases, state, statePath, true)).To(Succeed())
+		Expect(verified).To(Equal([]InitRegionPhase{InitRegionPhaseSetupBootstrapCluster, InitRegionPhaseCreateManagementCluster}))
+		Expect(ran).To(Equal([]InitRegionPhase{
+			InitRegionPhaseValidateConfiguration,
+			InitRegionPhaseCreateManagementCluster,
+			InitRegionPhaseMoveClusterAPIObjects,
+			InitRegionPhaseFinalizeManagementCluster,
+		}))
+	})
+
+	It("should only need the bootstrap cluster to resume once the providers are installed on it", func() {
+		phases = []initRegionPhase{
+			newPhase(InitRegionPhaseValidateConfiguration, false),
+			newPhase(InitRegionPhaseSetupBootstrapCluster, true),
+			newPhase(InitRegionPhaseInstallProvidersOnBootstrapCluster, true),
+			newPhase(InitRegionPhaseCreateManagementCluster, true),
+		}
+		failing = InitRegionPhaseInstallProvidersOnBootstrapCluster
+		Expect(runInitRegionPhases(phases, state, statePath, false)).ToNot(Succeed())
+		Expect(state.needsBootstrapCluster()).To(BeFalse())
+
+		failing = InitRegionPhaseCreateManagementCluster
+		Expect(runInitRegionPhases(phases, state, statePath, true)).ToNot(Succeed())
+		Expect(state.needsBootstrapCluster()).To(BeTrue())
+	})
+
+	It("should fail to resume without a state file", func() {
+		_, err := LoadInitRegionState(statePath)
+		Expect(err).To(MatchError(ContainSubstring("no management cluster creation to resume")))
+	})
+
+	Context("when looking up the management cluster creation to resume", func() {
+		var tkgClient *TkgClient
+
+		BeforeEach(func() {
+			tkgClient = &TkgClient{tkgConfigDir: dir}
+		})
+
+		It("should keep the state of each management cluster in its own file", func() {
+			Expect((&InitRegionState{ClusterName: "mgmt-a"}).Save(tkgClient.initRegionStateFilePath("mgmt-a"))).To(Succeed())
+			Expect((&InitRegionState{ClusterName: "mgmt-b"}).Save(tkgClient.initRegionStateFilePath("mgmt-b"))).To(Succeed())
+
+			path, err := tkgClient.findInitRegionStateFile("mgmt-b")
+			Expect(err).ToNot(HaveOccurred())
+			loaded, err := LoadInitRegionState(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loaded.ClusterName).To(Equal("mgmt-b"))
+		})
+
+		It("should resume the only management cluster creation in progress when no name is given", func() {
+			Expect((&InitRegionState{ClusterName: "mgmt-a"}).Save(tkgClient.initRegionStateFilePath("mgmt-a"))).To(Succeed())
+
+			path, err := tkgClient.findInitRegionStateFile("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal(tkgClient.initRegionStateFilePath("mgmt-a")))
+		})
+
+		It("should require the name when several management cluster creations are in progress", func() {
+			Expect((&InitRegionState{ClusterName: "mgmt-a"}).Save(tkgClient.initRegionStateFilePath("mgmt-a"))).To(Succeed())
+			Expect((&InitRegionState{ClusterName: "mgmt-b"}).Save(tkgClient.initRegionStateFilePath("mgmt-b"))).To(Succeed())
+
+			_, err := tkgClient.findInitRegionStateFile("")
+			Expect(err).To(MatchError(ContainSubstring("mgmt-a, mgmt-b")))
+		})
+
+		It("should fail when no management cluster creation is in progress", func() {
+			_, err := tkgClient.findInitRegionStateFile("")
+			Expect(err).To(MatchError(ContainSubstring("no management cluster creation to resume")))
+		})
+	})
+})
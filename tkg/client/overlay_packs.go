@@ -0,0 +1,72 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+// GetOverlayPackLock returns the lock of the overlay packs pinned to the current management cluster.
+// An empty lock is returned when no overlay pack was pinned yet.
+func (c *TkgClient) GetOverlayPackLock() (*yamlprocessor.OverlayPackLock, error) {
+	clusterClient, err := c.getClusterClient()
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := clusterClient.GetResource(configMap, constants.OverlayPacksConfigMapName, constants.TkgNamespace, nil, nil); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &yamlprocessor.OverlayPackLock{}, nil
+		}
+		return nil, errors.Wrap(err, "unable to get the overlay packs of the management cluster")
+	}
+	lock, err := yamlprocessor.ParseOverlayPackLock([]byte(configMap.Data[constants.OverlayPacksConfigMapKey]))
+	if err != nil {
+		return nil, err
+	}
+	lock.ResourceVersion = configMap.ResourceVersion
+	return lock, nil
+}
+
+// SaveOverlayPackLock saves the lock of the overlay packs pinned to the current management cluster.
+// Saving fails if the lock was changed since it was read with GetOverlayPackLock.
+func (c *TkgClient) SaveOverlayPackLock(lock *yamlprocessor.OverlayPackLock) error {
+	clusterClient, err := c.getClusterClient()
+	if err != nil {
+		return err
+	}
+
+	data, err := lock.Marshal()
+	if err != nil {
+		return err
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            constants.OverlayPacksConfigMapName,
+			Namespace:       constants.TkgNamespace,
+			ResourceVersion: lock.ResourceVersion,
+		},
+		Data: map[string]string{constants.OverlayPacksConfigMapKey: string(data)},
+	}
+	if lock.ResourceVersion == "" {
+		err = clusterClient.CreateResource(configMap, configMap.Name, configMap.Namespace)
+	} else {
+		err = clusterClient.UpdateResource(configMap, configMap.Name, configMap.Namespace)
+	}
+	if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+		return errors.New("the overlay packs of the management cluster were changed concurrently, please retry")
+	}
+	if err != nil {
+		return errors.Wrap(err, "unable to save the overlay packs of the management cluster")
+	}
+	lock.ResourceVersion = configMap.ResourceVersion
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	. "github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+var _ = Describe("Overlay pack lock", func() {
+	var (
+		clusterClient *fakes.ClusterClient
+		tkgClient     *TkgClient
+	)
+
+	BeforeEach(func() {
+		clusterClientFactory := &fakes.ClusterClientFactory{}
+		clusterClient = &fakes.ClusterClient{}
+		clusterClientFactory.NewClientReturns(clusterClient, nil)
+		tkgClient, err = CreateTKGClientOptsMutator("../fakes/config/config2.yaml", testingDir, "../fakes/config/bom/tkg-bom-v1.3.1.yaml", 2*time.Second, func(o Options) Options {
+			o.ClusterClientFactory = clusterClientFactory
+			return o
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should return an empty lock when no overlay pack was pinned", func() {
+		clusterClient.GetResourceReturns(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, constants.OverlayPacksConfigMapName))
+		lock, err := tkgClient.GetOverlayPackLock()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.Packs).To(BeEmpty())
+		Expect(lock.ResourceVersion).To(BeEmpty())
+	})
+
+	It("should read the lock from the ConfigMap of the management cluster", func() {
+		clusterClient.GetResourceCalls(func(o interface{}, name, namespace string, _ clusterclient.PostVerifyrFunc, _ *clusterclient.PollOptions) error {
+			Expect(name).To(Equal(constants.OverlayPacksConfigMapName))
+			Expect(namespace).To(Equal(constants.TkgNamespace))
+			configMap := o.(*corev1.ConfigMap)
+			configMap.ResourceVersion = "42"
+			configMap.Data = map[string]string{constants.OverlayPacksConfigMapKey: "packs:\n- name: labels\n  image: registry.example.com/overlays/labels:v1\n  checksum: sha256:abc\n"}
+			return nil
+		})
+		lock, err := tkgClient.GetOverlayPackLock()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.ResourceVersion).To(Equal("42"))
+		Expect(lock.Packs).To(HaveLen(1))
+		Expect(lock.Packs[0].Checksum).To(Equal("sha256:abc"))
+	})
+
+	It("should reject a lock with an overlay pack name escaping the overlay packs directory", func() {
+		clusterClient.GetResourceCalls(func(o interface{}, name, namespace string, _ clusterclient.PostVerifyrFunc, _ *clusterclient.PollOptions) error {
+			configMap := o.(*corev1.ConfigMap)
+			configMap.Data = map[string]string{constants.OverlayPacksConfigMapKey: "packs:\n- name: ../../.ssh\n  image: registry.example.com/overlays/labels:v1\n  checksum: sha256:abc\n"}
+			return nil
+		})
+		_, err := tkgClient.GetOverlayPackLock()
+		Expect(err).To(MatchError(ContainSubstring(`invalid overlay pack name "../../.ssh"`)))
+	})
+
+	It("should create the ConfigMap when saving the first lock", func() {
+		lock := &yamlprocessor.OverlayPackLock{Packs: []yamlprocessor.OverlayPack{{Name: "labels", Image: "registry.example.com/overlays/labels:v1", Checksum: "sha256:abc"}}}
+		Expect(tkgClient.SaveOverlayPackLock(lock)).To(Succeed())
+		Expect(clusterClient.CreateResourceCallCount()).To(Equal(1))
+		Expect(clusterClient.UpdateResourceCallCount()).To(BeZero())
+		o, _, _, _ := clusterClient.CreateResourceArgsForCall(0)
+		configMap := o.(*corev1.ConfigMap)
+		Expect(configMap.Namespace).To(Equal(constants.TkgNamespace))
+		Expect(configMap.Data[constants.OverlayPacksConfigMapKey]).To(ContainSubstring("registry.example.com/overlays/labels:v1"))
+	})
+
+	It("should update the ConfigMap with the resource version the lock was read from", func() {
+		lock := &yamlprocessor.OverlayPackLock{ResourceVersion: "42"}
+		Expect(tkgClient.SaveOverlayPackLock(lock)).To(Succeed())
+		Expect(clusterClient.UpdateResourceCallCount()).To(Equal(1))
+		o, _, _, _ := clusterClient.UpdateResourceArgsForCall(0)
+		Expect(o.(*corev1.ConfigMap).ResourceVersion).To(Equal("42"))
+	})
+
+	It("should fail when the lock was changed concurrently", func() {
+		clusterClient.UpdateResourceReturns(apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, constants.OverlayPacksConfigMapName, nil))
+		err := tkgClient.SaveOverlayPackLock(&yamlprocessor.OverlayPackLock{ResourceVersion: "42"})
+		Expect(err).To(MatchError(ContainSubstring("changed concurrently")))
+	})
+})
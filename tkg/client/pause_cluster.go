@@ -0,0 +1,214 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// PauseClusterOptions contains options supported by PauseCluster
+type PauseClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	// PausedBy is the user pausing the cluster
+	PausedBy string
+	// Reason is why the cluster is paused
+	Reason string
+}
+
+// ResumeClusterOptions contains options supported by ResumeCluster
+type ResumeClusterOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// PauseCluster pauses the reconciliation of a workload cluster: the Cluster API reconciliation of the Cluster, the
+// reconciliation of its ClusterBootstrap and the PackageInstalls of its core packages. The user pausing the cluster
+// and the reason are recorded in annotations of the Cluster.
+func (c *TkgClient) PauseCluster(options PauseClusterOptions) error {
+	regionalClusterClient, err := c.getRegionalClusterClient()
+	if err != nil {
+		return err
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	if options.PausedBy == "" {
+		return errors.New("the user pausing the cluster is required")
+	}
+	return pauseCluster(regionalClusterClient, c.getWorkloadClusterClientForPause(options.ClusterName, options.Namespace), options)
+}
+
+// ResumeCluster resumes the reconciliation of a workload cluster paused by PauseCluster
+func (c *TkgClient) ResumeCluster(options ResumeClusterOptions) error {
+	regionalClusterClient, err := c.getRegionalClusterClient()
+	if err != nil {
+		return err
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	return resumeCluster(regionalClusterClient, c.getWorkloadClusterClientForPause(options.ClusterName, options.Namespace), options)
+}
+
+// getWorkloadClusterClientForPause returns the client of the workload cluster, or nil if the cluster is unreachable:
+// pausing is typically needed while a cluster is unhealthy, so the remote PackageInstalls are paused on a best effort basis
+func (c *TkgClient) getWorkloadClusterClientForPause(clusterName, namespace string) clusterclient.Client {
+	workloadClusterClient, err := c.getWorkloadClusterClient(clusterName, namespace)
+	if err != nil {
+		log.Warningf("Warning: unable to connect to cluster %s/%s, the PackageInstalls of the cluster are left unchanged: %v", namespace, clusterName, err)
+		return nil
+	}
+	return workloadClusterClient
+}
+
+func pauseCluster(regionalClusterClient, workloadClusterClient clusterclient.Client, options PauseClusterOptions) error { //nolint:gocritic
+	cluster := &capi.Cluster{}
+	if err := regionalClusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to get cluster %s/%s", options.Namespace, options.ClusterName)
+	}
+
+	// pause the Cluster first, the ClusterBootstrap controller does not reconcile paused clusters
+	log.Infof("Pausing cluster %s/%s...", options.Namespace, options.ClusterName)
+	annotations := map[string]interface{}{
+		constants.ClusterPausedByAnnotation:    options.PausedBy,
+		constants.ClusterPauseReasonAnnotation: nil,
+	}
+	if options.Reason != "" {
+		annotations[constants.ClusterPauseReasonAnnotation] = options.Reason
+	}
+	if err := patchPaused(regionalClusterClient, &capi.Cluster{}, options.ClusterName, options.Namespace, annotations, true); err != nil {
+		return errors.Wrapf(err, "unable to pause cluster %s/%s", options.Namespace, options.ClusterName)
+	}
+
+	markers := map[string]interface{}{constants.ClusterPausedByAnnotation: options.PausedBy}
+	clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{}
+	err := regionalClusterClient.GetResource(clusterBootstrap, options.ClusterName, options.Namespace, nil, nil)
+	switch {
+	case apierrors.IsNotFound(err):
+		log.V(3).Infof("No ClusterBootstrap found for cluster %s/%s", options.Namespace, options.ClusterName)
+	case err != nil:
+		return errors.Wrapf(err, "unable to get the ClusterBootstrap of cluster %s/%s", options.Namespace, options.ClusterName)
+	case clusterBootstrap.Spec != nil && clusterBootstrap.Spec.Paused:
+		// paused by someone else, who is left in charge of resuming it
+		log.V(3).Infof("ClusterBootstrap %s/%s is already paused", options.Namespace, options.ClusterName)
+	default:
+		if err := patchPaused(regionalClusterClient, &runtanzuv1alpha3.ClusterBootstrap{}, options.ClusterName, options.Namespace, markers, true); err != nil {
+			return errors.Wrapf(err, "unable to pause the ClusterBootstrap of cluster %s/%s", options.Namespace, options.ClusterName)
+		}
+	}
+
+	return forEachClusterPackageInstall(regionalClusterClient, workloadClusterClient, cluster, func(clusterClient clusterclient.Client, pkgi *kappipkg.PackageInstall) error {
+		if pkgi.Spec.Paused {
+			log.V(3).Infof("PackageInstall %s/%s is already paused", pkgi.Namespace, pkgi.Name)
+			return nil
+		}
+		log.V(3).Infof("Pausing PackageInstall %s/%s", pkgi.Namespace, pkgi.Name)
+		return errors.Wrapf(patchPaused(clusterClient, &kappipkg.PackageInstall{}, pkgi.Name, pkgi.Namespace, markers, true),
+			"unable to pause PackageInstall %s/%s", pkgi.Namespace, pkgi.Name)
+	})
+}
+
+func resumeCluster(regionalClusterClient, workloadClusterClient clusterclient.Client, options ResumeClusterOptions) error { //nolint:gocritic
+	cluster := &capi.Cluster{}
+	if err := regionalClusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to get cluster %s/%s", options.Namespace, options.ClusterName)
+	}
+	if _, pausedByUser := cluster.Annotations[constants.ClusterPausedByAnnotation]; !pausedByUser && !cluster.Spec.Paused {
+		return errors.Errorf("cluster %s/%s is not paused", options.Namespace, options.ClusterName)
+	}
+
+	// resume in the reverse order of pausing, only what PauseCluster paused
+	markers := map[string]interface{}{constants.ClusterPausedByAnnotation: nil}
+	err := forEachClusterPackageInstall(regionalClusterClient, workloadClusterClient, cluster, func(clusterClient clusterclient.Client, pkgi *kappipkg.PackageInstall) error {
+		if _, ok := pkgi.Annotations[constants.ClusterPausedByAnnotation]; !ok {
+			return nil
+		}
+		log.V(3).Infof("Resuming PackageInstall %s/%s", pkgi.Namespace, pkgi.Name)
+		return errors.Wrapf(patchPaused(clusterClient, &kappipkg.PackageInstall{}, pkgi.Name, pkgi.Namespace, markers, false),
+			"unable to resume PackageInstall %s/%s", pkgi.Namespace, pkgi.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	clusterBootstrap := &runtanzuv1alpha3.ClusterBootstrap{}
+	err = regionalClusterClient.GetResource(clusterBootstrap, options.ClusterName, options.Namespace, nil, nil)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "unable to get the ClusterBootstrap of cluster %s/%s", options.Namespace, options.ClusterName)
+	}
+	if _, ok := clusterBootstrap.Annotations[constants.ClusterPausedByAnnotation]; err == nil && ok {
+		if err := patchPaused(regionalClusterClient, &runtanzuv1alpha3.ClusterBootstrap{}, options.ClusterName, options.Namespace, markers, false); err != nil {
+			return errors.Wrapf(err, "unable to resume the ClusterBootstrap of cluster %s/%s", options.Namespace, options.ClusterName)
+		}
+	}
+
+	log.Infof("Resuming cluster %s/%s...", options.Namespace, options.ClusterName)
+	annotations := map[string]interface{}{
+		constants.ClusterPausedByAnnotation:    nil,
+		constants.ClusterPauseReasonAnnotation: nil,
+	}
+	// a cluster being upgraded stays paused until the ClusterBootstrap controller has reconciled the new TKR
+	_, upgrading := cluster.Annotations[constants.ClusterPauseLabel]
+	if upgrading {
+		log.Infof("Cluster %s/%s is being upgraded, it will be unpaused once its add-ons are reconciled", options.Namespace, options.ClusterName)
+	}
+	return errors.Wrapf(patchPaused(regionalClusterClient, &capi.Cluster{}, options.ClusterName, options.Namespace, annotations, upgrading),
+		"unable to resume cluster %s/%s", options.Namespace, options.ClusterName)
+}
+
+// forEachClusterPackageInstall calls fn on the kapp-controller PackageInstall of the cluster, in the management cluster,
+// and on the PackageInstalls of the core packages in the workload cluster, if reachable
+func forEachClusterPackageInstall(regionalClusterClient, workloadClusterClient clusterclient.Client, cluster *capi.Cluster,
+	fn func(clusterClient clusterclient.Client, pkgi *kappipkg.PackageInstall) error) error {
+
+	clusterClients := []clusterclient.Client{regionalClusterClient}
+	namespaces := []string{cluster.Namespace}
+	if workloadClusterClient != nil {
+		clusterClients = append(clusterClients, workloadClusterClient)
+		namespaces = append(namespaces, constants.TkgNamespace)
+	}
+
+	for i, clusterClient := range clusterClients {
+		pkgis := &kappipkg.PackageInstallList{}
+		if err := clusterClient.ListResources(pkgis, crtclient.InNamespace(namespaces[i])); err != nil {
+			return errors.Wrapf(err, "unable to list the PackageInstalls in namespace %s", namespaces[i])
+		}
+		for j := range pkgis.Items {
+			pkgi := &pkgis.Items[j]
+			if pkgi.Annotations[constants.ClusterNameLabel] != cluster.Name || pkgi.Annotations[constants.ClusterNamespaceAnnotation] != cluster.Namespace {
+				continue
+			}
+			if err := fn(clusterClient, pkgi); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// patchPaused sets spec.paused of a Cluster, ClusterBootstrap or PackageInstall and its annotations, removing those
+// with a nil value
+func patchPaused(clusterClient clusterclient.Client, resourceReference interface{}, name, namespace string, annotations map[string]interface{}, paused bool) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+		"spec":     map[string]interface{}{"paused": paused},
+	})
+	if err != nil {
+		return err
+	}
+	return clusterClient.PatchResource(resourceReference, name, namespace, string(patch), types.MergePatchType, nil)
+}
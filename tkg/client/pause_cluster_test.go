@@ -0,0 +1,196 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtanzuv1alpha3 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha3"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+)
+
+// pauseTestClusterClient serves a cluster, its ClusterBootstrap and PackageInstalls from memory and records the patches
+type pauseTestClusterClient struct {
+	clusterclient.Client
+	cluster          *capi.Cluster
+	clusterBootstrap *runtanzuv1alpha3.ClusterBootstrap
+	pkgis            []kappipkg.PackageInstall
+	patches          []string
+}
+
+func (c *pauseTestClusterClient) GetResource(resourceReference interface{}, resourceName, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+	switch obj := resourceReference.(type) {
+	case *capi.Cluster:
+		if c.cluster == nil {
+			return apierrors.NewNotFound(schema.GroupResource{Resource: "clusters"}, resourceName)
+		}
+		c.cluster.DeepCopyInto(obj)
+	case *runtanzuv1alpha3.ClusterBootstrap:
+		if c.clusterBootstrap == nil {
+			return apierrors.NewNotFound(schema.GroupResource{Resource: "clusterbootstraps"}, resourceName)
+		}
+		c.clusterBootstrap.DeepCopyInto(obj)
+	default:
+		return errors.New("unexpected resource")
+	}
+	return nil
+}
+
+func (c *pauseTestClusterClient) ListResources(resourceReference interface{}, option ...crtclient.ListOption) error {
+	listOptions := &crtclient.ListOptions{}
+	listOptions.ApplyOptions(option)
+	pkgis := resourceReference.(*kappipkg.PackageInstallList)
+	for i := range c.pkgis {
+		if c.pkgis[i].Namespace == listOptions.Namespace {
+			pkgis.Items = append(pkgis.Items, c.pkgis[i])
+		}
+	}
+	return nil
+}
+
+func (c *pauseTestClusterClient) PatchResource(resourceReference interface{}, resourceName, namespace, patchJSONString string, patchType types.PatchType, pollOptions *clusterclient.PollOptions) error {
+	Expect(patchType).To(Equal(types.MergePatchType))
+	kind := reflect.TypeOf(resourceReference).Elem().Name()
+	c.patches = append(c.patches, fmt.Sprintf("%s %s/%s %s", kind, namespace, resourceName, patchJSONString))
+	return nil
+}
+
+func newPauseTestPackageInstall(name, namespace, clusterName string, annotations map[string]string, paused bool) kappipkg.PackageInstall {
+	pkgi := kappipkg.PackageInstall{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				constants.ClusterNameLabel:           clusterName,
+				constants.ClusterNamespaceAnnotation: "default",
+			},
+		},
+		Spec: kappipkg.PackageInstallSpec{Paused: paused},
+	}
+	for key, value := range annotations {
+		pkgi.Annotations[key] = value
+	}
+	return pkgi
+}
+
+var _ = Describe("pauseCluster and resumeCluster", func() {
+	var (
+		regionalClusterClient *pauseTestClusterClient
+		workloadClusterClient *pauseTestClusterClient
+	)
+
+	BeforeEach(func() {
+		regionalClusterClient = &pauseTestClusterClient{
+			cluster: &capi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "wc", Namespace: "default"}},
+			clusterBootstrap: &runtanzuv1alpha3.ClusterBootstrap{
+				ObjectMeta: metav1.ObjectMeta{Name: "wc", Namespace: "default"},
+				Spec:       &runtanzuv1alpha3.ClusterBootstrapTemplateSpec{},
+			},
+			pkgis: []kappipkg.PackageInstall{
+				newPauseTestPackageInstall("wc-kapp-controller", "default", "wc", nil, false),
+				newPauseTestPackageInstall("other-kapp-controller", "default", "other", nil, false),
+			},
+		}
+		workloadClusterClient = &pauseTestClusterClient{
+			pkgis: []kappipkg.PackageInstall{
+				newPauseTestPackageInstall("wc-antrea", constants.TkgNamespace, "wc", nil, false),
+				newPauseTestPackageInstall("wc-vsphere-csi", constants.TkgNamespace, "wc", nil, true),
+				newPauseTestPackageInstall("user-pkgi", constants.TkgNamespace, "", nil, false),
+			},
+		}
+	})
+
+	Context("pauseCluster", func() {
+		It("pauses the cluster, its ClusterBootstrap and PackageInstalls and records who paused it and why", func() {
+			err := pauseCluster(regionalClusterClient, workloadClusterClient, PauseClusterOptions{
+				ClusterName: "wc", Namespace: "default", PausedBy: "alice", Reason: "storage maintenance",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(regionalClusterClient.patches).To(Equal([]string{
+				`Cluster default/wc {"metadata":{"annotations":{"tkg.tanzu.vmware.com/pause-reason":"storage maintenance","tkg.tanzu.vmware.com/paused-by":"alice"}},"spec":{"paused":true}}`,
+				`ClusterBootstrap default/wc {"metadata":{"annotations":{"tkg.tanzu.vmware.com/paused-by":"alice"}},"spec":{"paused":true}}`,
+				`PackageInstall default/wc-kapp-controller {"metadata":{"annotations":{"tkg.tanzu.vmware.com/paused-by":"alice"}},"spec":{"paused":true}}`,
+			}))
+			// the PackageInstall already paused is left to whoever paused it
+			Expect(workloadClusterClient.patches).To(Equal([]string{
+				`PackageInstall tkg-system/wc-antrea {"metadata":{"annotations":{"tkg.tanzu.vmware.com/paused-by":"alice"}},"spec":{"paused":true}}`,
+			}))
+		})
+
+		It("pauses the cluster without a ClusterBootstrap when the workload cluster is unreachable", func() {
+			regionalClusterClient.clusterBootstrap = nil
+			err := pauseCluster(regionalClusterClient, nil, PauseClusterOptions{ClusterName: "wc", Namespace: "default", PausedBy: "alice"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(regionalClusterClient.patches).To(Equal([]string{
+				`Cluster default/wc {"metadata":{"annotations":{"tkg.tanzu.vmware.com/pause-reason":null,"tkg.tanzu.vmware.com/paused-by":"alice"}},"spec":{"paused":true}}`,
+				`PackageInstall default/wc-kapp-controller {"metadata":{"annotations":{"tkg.tanzu.vmware.com/paused-by":"alice"}},"spec":{"paused":true}}`,
+			}))
+		})
+
+		It("fails when the cluster does not exist", func() {
+			regionalClusterClient.cluster = nil
+			err := pauseCluster(regionalClusterClient, workloadClusterClient, PauseClusterOptions{ClusterName: "wc", Namespace: "default", PausedBy: "alice"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to get cluster default/wc"))
+			Expect(regionalClusterClient.patches).To(BeEmpty())
+		})
+	})
+
+	Context("resumeCluster", func() {
+		BeforeEach(func() {
+			pausedBy := map[string]string{constants.ClusterPausedByAnnotation: "alice"}
+			regionalClusterClient.cluster.Spec.Paused = true
+			regionalClusterClient.cluster.Annotations = map[string]string{
+				constants.ClusterPausedByAnnotation:    "alice",
+				constants.ClusterPauseReasonAnnotation: "storage maintenance",
+			}
+			regionalClusterClient.clusterBootstrap.Annotations = pausedBy
+			regionalClusterClient.clusterBootstrap.Spec.Paused = true
+			regionalClusterClient.pkgis[0] = newPauseTestPackageInstall("wc-kapp-controller", "default", "wc", pausedBy, true)
+			workloadClusterClient.pkgis[0] = newPauseTestPackageInstall("wc-antrea", constants.TkgNamespace, "wc", pausedBy, true)
+		})
+
+		It("resumes what was paused in the reverse order and removes the annotations", func() {
+			err := resumeCluster(regionalClusterClient, workloadClusterClient, ResumeClusterOptions{ClusterName: "wc", Namespace: "default"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(regionalClusterClient.patches).To(Equal([]string{
+				`PackageInstall default/wc-kapp-controller {"metadata":{"annotations":{"tkg.tanzu.vmware.com/paused-by":null}},"spec":{"paused":false}}`,
+				`ClusterBootstrap default/wc {"metadata":{"annotations":{"tkg.tanzu.vmware.com/paused-by":null}},"spec":{"paused":false}}`,
+				`Cluster default/wc {"metadata":{"annotations":{"tkg.tanzu.vmware.com/pause-reason":null,"tkg.tanzu.vmware.com/paused-by":null}},"spec":{"paused":false}}`,
+			}))
+			Expect(workloadClusterClient.patches).To(Equal([]string{
+				`PackageInstall tkg-system/wc-antrea {"metadata":{"annotations":{"tkg.tanzu.vmware.com/paused-by":null}},"spec":{"paused":false}}`,
+			}))
+		})
+
+		It("keeps a cluster being upgraded paused", func() {
+			regionalClusterClient.cluster.Annotations[constants.ClusterPauseLabel] = "v1.23.8---vmware.2-tkg.1"
+			err := resumeCluster(regionalClusterClient, nil, ResumeClusterOptions{ClusterName: "wc", Namespace: "default"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(regionalClusterClient.patches[len(regionalClusterClient.patches)-1]).To(Equal(
+				`Cluster default/wc {"metadata":{"annotations":{"tkg.tanzu.vmware.com/pause-reason":null,"tkg.tanzu.vmware.com/paused-by":null}},"spec":{"paused":true}}`))
+		})
+
+		It("fails when the cluster is not paused", func() {
+			regionalClusterClient.cluster.Spec.Paused = false
+			regionalClusterClient.cluster.Annotations = nil
+			err := resumeCluster(regionalClusterClient, workloadClusterClient, ResumeClusterOptions{ClusterName: "wc", Namespace: "default"})
+			Expect(err).To(MatchError("cluster default/wc is not paused"))
+			Expect(regionalClusterClient.patches).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,84 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	clusterctl "sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/kind"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+)
+
+// deleteClusterctlClient is a clusterctl client whose Delete returns err
+type deleteClusterctlClient struct {
+	clusterctl.Client
+	err error
+}
+
+func (c *deleteClusterctlClient) Delete(clusterctl.DeleteOptions) error {
+	return c.err
+}
+
+var _ = Describe("Persistent bootstrap cluster", func() {
+	var (
+		dir              string
+		clusterctlClient *deleteClusterctlClient
+		tkgClient        *TkgClient
+	)
+
+	newTKGClient := func() *TkgClient {
+		configPath := filepath.Join(dir, "config.yaml")
+		Expect(os.WriteFile(configPath, []byte(constants.ConfigVariableBootstrapClusterPersistent+": \"true\"\n"), 0o600)).To(Succeed())
+		readerWriter, err := tkgconfigreaderwriter.NewReaderWriterFromConfigFile(configPath, configPath)
+		Expect(err).ToNot(HaveOccurred())
+		readerWriterClient, err := tkgconfigreaderwriter.NewWithReaderWriter(readerWriter)
+		Expect(err).ToNot(HaveOccurred())
+		return &TkgClient{tkgConfigDir: dir, clusterctlClient: clusterctlClient, readerwriterConfigClient: readerWriterClient}
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "persistent-kind-cluster")
+		Expect(err).ToNot(HaveOccurred())
+		clusterctlClient = &deleteClusterctlClient{}
+		tkgClient = newTKGClient()
+		Expect(tkgClient.lockPersistentKindCluster()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		tkgClient.unlockPersistentKindCluster()
+		os.RemoveAll(dir)
+	})
+
+	It("should not be used by two operations at once", func() {
+		other := newTKGClient()
+		err := other.lockPersistentKindCluster()
+		Expect(err).To(MatchError(ContainSubstring("bootstrap cluster " + kind.PersistentKindClusterName + " is in use by another operation")))
+
+		tkgClient.unlockPersistentKindCluster()
+		Expect(other.lockPersistentKindCluster()).To(Succeed())
+		other.unlockPersistentKindCluster()
+	})
+
+	It("should be released once cleaned up", func() {
+		Expect(tkgClient.teardownKindCluster(kind.PersistentKindClusterName, "", false)).To(Succeed())
+		other := newTKGClient()
+		Expect(other.lockPersistentKindCluster()).To(Succeed())
+		other.unlockPersistentKindCluster()
+	})
+
+	It("should return the error cleaning it up", func() {
+		clusterctlClient.err = errors.New("connection refused")
+		err := tkgClient.teardownKindCluster(kind.PersistentKindClusterName, "", false)
+		Expect(err).To(MatchError(ContainSubstring("failed to clean up the bootstrap cluster " + kind.PersistentKindClusterName)))
+		Expect(err).To(MatchError(ContainSubstring("connection refused")))
+	})
+})
@@ -561,38 +561,17 @@ func (c *TkgClient) ConfigureAndValidateManagementClusterConfiguration(options *
 		return NewValidationError(ValidationErrorCode, errors.Wrap(err, "unable to check infrastructure provider version").Error())
 	}
 
-	err = c.ConfigureAndValidateCNIType(options.CniType)
-	if err != nil {
-		return NewValidationError(ValidationErrorCode, errors.Wrap(err, "unable to validate CNI type").Error())
-	}
-
 	name, _, err := ParseProviderName(options.InfrastructureProvider)
 	if err != nil {
 		return NewValidationError(ValidationErrorCode, err.Error())
 	}
 
-	if err = c.configureAndValidateIPFamilyConfiguration(TkgLabelClusterRoleManagement); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
-	}
-
-	if err = c.configureAndValidateCoreDNSIP(); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
-	}
-
-	if err = c.validateServiceCIDRNetmask(); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
-	}
-
-	if err = c.ConfigureAndValidateHTTPProxyConfiguration(name); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
-	}
-
-	if err = c.ConfigureAndValidateNameserverConfiguration(TkgLabelClusterRoleManagement); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
-	}
-
-	if err = c.ConfigureAndValidateAviConfiguration(); err != nil {
-		return NewValidationError(ValidationErrorCode, err.Error())
+	if verr := runConfigValidationChecksUntilFailure(name, c.commonConfigValidationChecks(&configValidationOptions{
+		provider:    name,
+		clusterRole: TkgLabelClusterRoleManagement,
+		cniType:     options.CniType,
+	})); verr != nil {
+		return verr
 	}
 
 	isProdPlan := IsProdPlan(options.Plan)
@@ -1412,20 +1391,7 @@ func (c *TkgClient) DistributeMachineDeploymentWorkers(workerMachineCount int64,
 	predefinedDistribution := err1 == nil && err2 == nil && err3 == nil && workerCount1Str != "" && workerCount2Str != "" && workerCount3Str != ""
 
 	if isProdConfig && !predefinedDistribution {
-		workersPerAz := workerMachineCount / 3
-		remainder := workerMachineCount % 3
-		workerCounts[0] = int(workersPerAz)
-		workerCounts[1] = int(workersPerAz)
-		workerCounts[2] = int(workersPerAz)
-		for remainder > 0 {
-			for i := range workerCounts {
-				if remainder == 0 {
-					break
-				}
-				workerCounts[i]++
-				remainder--
-			}
-		}
+		workerCounts = DistributeWorkersAcrossAZs(workerMachineCount)
 	} else if isProdConfig {
 		workerCount1, e1 := strconv.Atoi(workerCount1Str)
 		workerCount2, e2 := strconv.Atoi(workerCount2Str)
@@ -1453,6 +1419,21 @@ func (c *TkgClient) DistributeMachineDeploymentWorkers(workerMachineCount int64,
 	return workerCounts, nil
 }
 
+// DistributeWorkersAcrossAZs evenly distributes the workers of a prod plan cluster across the machine deployments of
+// its three availability zones
+func DistributeWorkersAcrossAZs(workerMachineCount int64) []int {
+	workerCounts := make([]int, 3)
+	workersPerAz := workerMachineCount / 3
+	remainder := workerMachineCount % 3
+	for i := range workerCounts {
+		workerCounts[i] = int(workersPerAz)
+		if int64(i) < remainder {
+			workerCounts[i]++
+		}
+	}
+	return workerCounts
+}
+
 // SetMachineDeploymentWorkerCounts sets machine deployment counts
 func (c *TkgClient) SetMachineDeploymentWorkerCounts(workerCounts []int, totalWorkerMachineCount int64, isProdConfig bool) {
 	c.TKGConfigReaderWriter().Set(constants.ConfigVariableWorkerMachineCount, strconv.Itoa(int(totalWorkerMachineCount)))
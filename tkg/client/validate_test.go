@@ -1485,6 +1485,17 @@ var _ = Describe("Validate", func() {
 			})
 		})
 
+		It("validates the HTTP proxy configuration before the other settings", func() {
+			tkgConfigReaderWriter.Set(constants.TKGHTTPProxyEnabled, "true")
+			tkgConfigReaderWriter.Set(constants.TKGHTTPProxy, "proxy.example.com:3128")
+			createClusterOptions.CniType = "weave"
+			createClusterOptions.Edition = ""
+
+			validationError := tkgClient.ConfigureAndValidateWorkloadClusterConfiguration(createClusterOptions, clusterClient, true)
+			Expect(validationError).To(HaveOccurred())
+			Expect(validationError.Error()).To(ContainSubstring("error validating TKG_HTTP_PROXY"))
+		})
+
 		DescribeTable("SERVICE_CIDR size validation - invalid cases", func(ipFamily, serviceCIDR, problematicCIDR, netmaskSizeConstraint string) {
 			tkgConfigReaderWriter.Set(constants.ConfigVariableIPFamily, ipFamily)
 			tkgConfigReaderWriter.Set(constants.ConfigVariableServiceCIDR, serviceCIDR)
@@ -0,0 +1,543 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	clusterctlclient "sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/aws"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigproviders"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/vc"
+)
+
+// ValidationSeverity is the severity of a configuration validation finding
+type ValidationSeverity string
+
+// configuration validation severity constants
+const (
+	// ValidationSeverityError is used for findings that prevent the cluster from being created
+	ValidationSeverityError ValidationSeverity = "error"
+	// ValidationSeverityWarning is used for findings that do not prevent the cluster from being created
+	// but might affect it once created
+	ValidationSeverityWarning ValidationSeverity = "warning"
+)
+
+// ValidationFinding is a problem found while validating the cluster configuration
+type ValidationFinding struct {
+	Check       string             `json:"check" yaml:"check"`
+	Severity    ValidationSeverity `json:"severity" yaml:"severity"`
+	ConfigKey   string             `json:"configKey,omitempty" yaml:"configKey,omitempty"`
+	Message     string             `json:"message" yaml:"message"`
+	Remediation string             `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+}
+
+// ValidationReport is the outcome of running all the configuration checks for a provider
+type ValidationReport struct {
+	ClusterName string              `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
+	Provider    string              `json:"provider" yaml:"provider"`
+	Checks      []string            `json:"checks" yaml:"checks"`
+	Findings    []ValidationFinding `json:"findings" yaml:"findings"`
+}
+
+// HasErrors tells whether the report contains findings preventing the cluster from being created
+func (r *ValidationReport) HasErrors() bool {
+	for i := range r.Findings {
+		if r.Findings[i].Severity == ValidationSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ValidationReport) addFinding(check, configKey, remediation string, severity ValidationSeverity, err error) {
+	r.Findings = append(r.Findings, ValidationFinding{
+		Check:       check,
+		Severity:    severity,
+		ConfigKey:   configKey,
+		Message:     err.Error(),
+		Remediation: remediation,
+	})
+}
+
+// configValidationCheck is a check run while collecting the configuration validation report
+type configValidationCheck struct {
+	name        string
+	configKey   string
+	remediation string
+	// severity of the finding reported when the check fails, defaults to ValidationSeverityError
+	severity ValidationSeverity
+	// providers the check applies to, the check applies to all the providers when empty
+	providers []string
+	run       func() error
+}
+
+func (check *configValidationCheck) appliesTo(provider string) bool {
+	if len(check.providers) == 0 {
+		return true
+	}
+	for _, p := range check.providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// runConfigValidationChecks runs every check applying to the provider. Unlike the validation done while creating
+// the cluster, a failing check does not stop the validation so that all the problems are reported at once.
+func runConfigValidationChecks(provider string, checks []configValidationCheck, report *ValidationReport) {
+	report.Provider = provider
+	for i := range checks {
+		check := &checks[i]
+		if !check.appliesTo(provider) {
+			continue
+		}
+		report.Checks = append(report.Checks, check.name)
+		log.V(6).Infof("Running configuration check %s", check.name)
+		if err := check.run(); err != nil {
+			severity := check.severity
+			if severity == "" {
+				severity = ValidationSeverityError
+			}
+			report.addFinding(check.name, check.configKey, check.remediation, severity, err)
+		}
+	}
+}
+
+// runConfigValidationChecksUntilFailure runs every check applying to the provider and stops at the first failing
+// check, the way the configuration is validated while creating the cluster. Failing checks of warning severity are
+// logged and do not stop the validation.
+func runConfigValidationChecksUntilFailure(provider string, checks []configValidationCheck) *ValidationError {
+	for i := range checks {
+		check := &checks[i]
+		if !check.appliesTo(provider) {
+			continue
+		}
+		log.V(6).Infof("Running configuration check %s", check.name)
+		if err := check.run(); err != nil {
+			if check.severity == ValidationSeverityWarning {
+				log.Warningf("WARNING: %s", err.Error())
+				continue
+			}
+			return NewValidationError(ValidationErrorCode, err.Error())
+		}
+	}
+	return nil
+}
+
+// configValidationOptions are the inputs of the configuration checks
+type configValidationOptions struct {
+	provider    string
+	clusterRole string
+	plan        string
+	tkrVersion  string
+	nodeSizes   NodeSizeOptions
+	vip         string
+	cniType     string
+	// clusterClient is the management cluster client, nil when validating a management cluster configuration
+	clusterClient clusterclient.Client
+}
+
+// commonConfigValidationChecks returns the checks of the configuration that does not depend on the infrastructure
+// provider. They are run both while creating the cluster and while collecting the configuration validation report,
+// in the order the cluster creation runs them: the HTTP proxy check comes first for workload clusters.
+func (c *TkgClient) commonConfigValidationChecks(options *configValidationOptions) []configValidationCheck {
+	httpProxy := configValidationCheck{
+		name:        "http-proxy",
+		configKey:   constants.TKGHTTPProxy,
+		remediation: "Set TKG_HTTP_PROXY_ENABLED to true along with valid TKG_HTTP_PROXY and TKG_HTTPS_PROXY URLs, and list the hosts not to proxy in TKG_NO_PROXY",
+		run:         func() error { return c.ConfigureAndValidateHTTPProxyConfiguration(options.provider) },
+	}
+	networkChecks := []configValidationCheck{
+		{
+			name:        "cni",
+			configKey:   constants.ConfigVariableCNI,
+			remediation: "Set CNI to one of antrea, calico or none",
+			run: func() error {
+				return errors.Wrap(c.ConfigureAndValidateCNIType(options.cniType), "unable to validate CNI type")
+			},
+		},
+		{
+			name:        "ip-family",
+			configKey:   constants.ConfigVariableIPFamily,
+			remediation: "Set TKG_IP_FAMILY to ipv4, ipv6, ipv4,ipv6 or ipv6,ipv4 and make sure CLUSTER_CIDR, SERVICE_CIDR and the endpoints match it",
+			run:         func() error { return c.configureAndValidateIPFamilyConfiguration(options.clusterRole) },
+		},
+		{
+			name:        "coredns-ip",
+			configKey:   constants.ConfigVariableServiceCIDR,
+			remediation: "Make sure SERVICE_CIDR is a valid CIDR leaving room for the CoreDNS service IP",
+			run:         c.configureAndValidateCoreDNSIP,
+		},
+		{
+			name:        "service-cidr",
+			configKey:   constants.ConfigVariableServiceCIDR,
+			remediation: "Use a SERVICE_CIDR netmask allowing at most 2^20 addresses, for example /12 or longer for IPv4",
+			run:         c.validateServiceCIDRNetmask,
+		},
+	}
+	nameservers := configValidationCheck{
+		name:        "nameservers",
+		configKey:   constants.ConfigVariableControlPlaneNodeNameservers,
+		remediation: "Use comma separated IP addresses of the cluster IP family and activate the custom nameservers feature flag",
+		run:         func() error { return c.ConfigureAndValidateNameserverConfiguration(options.clusterRole) },
+	}
+
+	if options.clusterRole != TkgLabelClusterRoleManagement {
+		checks := append([]configValidationCheck{httpProxy}, networkChecks...)
+		return append(checks, nameservers)
+	}
+	// the NSX Advanced Load Balancer configuration of workload clusters is managed by the management cluster
+	return append(networkChecks, httpProxy, nameservers, configValidationCheck{
+		name:        "avi",
+		configKey:   constants.ConfigVariableAviControllerAddress,
+		remediation: "Check the NSX Advanced Load Balancer controller address, credentials, cloud, service engine group and networks, or set AVI_ENABLE to false",
+		run:         c.ConfigureAndValidateAviConfiguration,
+	})
+}
+
+// configValidationChecks returns the checks validating the cluster configuration: the checks run while creating the
+// cluster followed by the checks of the infrastructure provider. The checks are run in order, and the ones requiring
+// an infrastructure client are skipped when the check creating the client failed as the failure has already been
+// reported.
+func (c *TkgClient) configValidationChecks(options *configValidationOptions) []configValidationCheck { // nolint:funlen
+	isManagementCluster := options.clusterRole == TkgLabelClusterRoleManagement
+
+	var vcClient vc.Client
+	var awsClient aws.Client
+
+	checks := append(c.commonConfigValidationChecks(options), []configValidationCheck{
+		{
+			name:        "vsphere-node-size",
+			configKey:   constants.ConfigVariableVsphereCPNumCpus,
+			remediation: "Increase the vSphere control plane and worker machine CPUs, memory and disk to at least the minimum requirements",
+			providers:   []string{VSphereProviderName},
+			run: func() error {
+				if err := c.OverrideVsphereNodeSizeWithOptions(options.nodeSizes); err != nil {
+					return err
+				}
+				c.SetVsphereNodeSize()
+				return c.ValidateVsphereNodeSize()
+			},
+		},
+		{
+			name:        "vsphere-control-plane-endpoint",
+			configKey:   constants.ConfigVariableVsphereControlPlaneEndpoint,
+			remediation: "Set VSPHERE_CONTROL_PLANE_ENDPOINT to a static address that is not the vSphere server address and is not used by another cluster",
+			providers:   []string{VSphereProviderName},
+			run: func() error {
+				if err := c.configureAndValidateVIPForVsphereCluster(options.vip); err != nil {
+					return err
+				}
+				if options.clusterClient == nil {
+					return nil
+				}
+				vip, _ := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereControlPlaneEndpoint)
+				return c.ValidateVsphereVipWorkloadCluster(options.clusterClient, vip, false)
+			},
+		},
+		{
+			name:        "vsphere-connection",
+			configKey:   constants.ConfigVariableVsphereServer,
+			remediation: "Check VSPHERE_SERVER, VSPHERE_USERNAME and VSPHERE_PASSWORD, and VSPHERE_TLS_THUMBPRINT unless VSPHERE_INSECURE is true",
+			providers:   []string{VSphereProviderName},
+			run: func() error {
+				var err error
+				vcClient, err = c.GetVSphereEndpoint(options.clusterClient)
+				return err
+			},
+		},
+		{
+			name:        "vsphere-resources",
+			configKey:   constants.ConfigVariableVsphereDatacenter,
+			remediation: "Make sure VSPHERE_DATACENTER, VSPHERE_NETWORK, VSPHERE_RESOURCE_POOL, VSPHERE_FOLDER and VSPHERE_DATASTORE or VSPHERE_STORAGE_POLICY_ID exist in vCenter",
+			providers:   []string{VSphereProviderName},
+			run: func() error {
+				if vcClient == nil {
+					return nil
+				}
+				dc, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereDatacenter)
+				if err != nil {
+					return errors.Errorf("failed to get %s", constants.ConfigVariableVsphereDatacenter)
+				}
+				return c.ValidateVsphereResources(vcClient, dc)
+			},
+		},
+		{
+			name:        "vsphere-template",
+			configKey:   constants.ConfigVariableVsphereTemplate,
+			remediation: "Import the OVA matching the Tanzu Kubernetes release in vCenter and mark it as a template",
+			providers:   []string{VSphereProviderName},
+			run: func() error {
+				if vcClient == nil {
+					return nil
+				}
+				dc, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereDatacenter)
+				if err != nil {
+					return errors.Errorf("failed to get %s", constants.ConfigVariableVsphereDatacenter)
+				}
+				return c.ConfigureAndValidateVSphereTemplate(vcClient, options.tkrVersion, dc)
+			},
+		},
+		{
+			name:        "aws-credentials",
+			configKey:   constants.ConfigVariableAWSRegion,
+			remediation: "Set AWS_REGION and the AWS credentials, either through AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or an AWS profile",
+			providers:   []string{AWSProviderName},
+			run: func() error {
+				var err error
+				c.SetProviderType(AWSProviderName)
+				if awsClient, err = c.EncodeAWSCredentialsAndGetClient(options.clusterClient); err != nil {
+					awsClient = nil
+				}
+				return err
+			},
+		},
+		{
+			name:        "aws-instance-types",
+			configKey:   constants.ConfigVariableControlPlaneMachineType,
+			remediation: "Use CONTROL_PLANE_MACHINE_TYPE and NODE_MACHINE_TYPE instance types available in the AWS region",
+			providers:   []string{AWSProviderName},
+			run: func() error {
+				if awsClient == nil {
+					return nil
+				}
+				return c.OverrideAWSNodeSizeWithOptions(options.nodeSizes, awsClient, false)
+			},
+		},
+		{
+			name:        "aws-vpc",
+			configKey:   constants.ConfigVariableAWSVPCID,
+			remediation: "Either set AWS_VPC_ID and the subnet IDs of an existing VPC, or set AWS_VPC_CIDR for a new VPC, but not both",
+			providers:   []string{AWSProviderName},
+			run: func() error {
+				if awsClient == nil {
+					return nil
+				}
+				_, err := c.SetAndValidateDefaultAWSVPCConfiguration(IsProdPlan(options.plan), awsClient, false)
+				return err
+			},
+		},
+		{
+			name:        "azure-ssh-key",
+			configKey:   constants.ConfigVariableAzureSSHPublicKeyB64,
+			remediation: "Set AZURE_SSH_PUBLIC_KEY_B64 to the base64 encoded SSH public key",
+			providers:   []string{AzureProviderName},
+			run:         c.ValidateAzurePublicSSHKey,
+		},
+	}...)
+
+	if isManagementCluster {
+		checks = append(checks, configValidationCheck{
+			name:        "vsphere-control-plane-endpoint-in-use",
+			configKey:   constants.ConfigVariableVsphereControlPlaneEndpoint,
+			remediation: "Make sure VSPHERE_CONTROL_PLANE_ENDPOINT is not used by another cluster in VSPHERE_NETWORK",
+			severity:    ValidationSeverityWarning,
+			providers:   []string{VSphereProviderName},
+			run: func() error {
+				vip, _ := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereControlPlaneEndpoint)
+				if vip == "" {
+					return nil
+				}
+				if err := c.ValidateVsphereControlPlaneEndpointIP(vip); err != nil {
+					return errors.Errorf("the control plane endpoint '%s' might already be used by another cluster", vip)
+				}
+				return nil
+			},
+		})
+	}
+	return checks
+}
+
+// withConfigCopy returns a copy of the client reading and writing a copy of its configuration, so that the values
+// configured while validating the configuration do not leak into the configuration of the cluster. The clients
+// holding the configuration are rebuilt on the copy as well.
+func (c *TkgClient) withConfigCopy() (*TkgClient, error) {
+	readerWriterConfigClient, err := tkgconfigreaderwriter.NewWithReaderWriter(tkgconfigreaderwriter.NewOverlayReaderWriter(c.TKGConfigReaderWriter()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to copy the configuration")
+	}
+	configCopy := readerWriterConfigClient.TKGConfigReaderWriter()
+	clusterctlClient, err := clusterctlclient.New("", clusterctlclient.InjectConfig(readerWriterConfigClient.ClusterConfigClient()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize clusterctl client with the copy of the configuration")
+	}
+	clientCopy := *c
+	clientCopy.readerwriterConfigClient = readerWriterConfigClient
+	clientCopy.clusterctlClient = clusterctlClient
+	clientCopy.tkgConfigProvidersClient = tkgconfigproviders.New(c.tkgConfigDir, configCopy)
+	clientCopy.tkgBomClient = c.tkgBomClient.WithTKGConfigReaderWriter(configCopy)
+	clientCopy.tkgConfigUpdaterClient = c.tkgConfigUpdaterClient.WithTKGConfigReaderWriter(configCopy)
+	return &clientCopy, nil
+}
+
+// ValidateWorkloadClusterConfiguration runs all the configuration checks of the workload cluster infrastructure
+// provider against the current management cluster and reports every problem found instead of stopping at the
+// first one. The checks are run against a copy of the configuration and of the options, which are left unchanged.
+// The returned error is only set when the configuration could not be validated at all.
+func (c *TkgClient) ValidateWorkloadClusterConfiguration(options *CreateClusterOptions) (*ValidationReport, error) {
+	clientCopy, err := c.withConfigCopy()
+	if err != nil {
+		return nil, err
+	}
+	optionsCopy := *options
+	return clientCopy.validateWorkloadClusterConfiguration(&optionsCopy)
+}
+
+func (c *TkgClient) validateWorkloadClusterConfiguration(options *CreateClusterOptions) (*ValidationReport, error) {
+	report := &ValidationReport{ClusterName: options.ClusterName}
+	if err := CheckClusterNameFormat(options.ClusterName, options.ProviderRepositorySource.InfrastructureProvider); err != nil {
+		report.addFinding("cluster-name", constants.ConfigVariableClusterName, "Use a cluster name made of lower case alphanumeric characters and '-'", ValidationSeverityError, err)
+	}
+
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	options.Kubeconfig = clusterctlclient.Kubeconfig{Path: currentRegion.SourceFilePath, Context: currentRegion.ContextName}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+		OperationTimeout:  c.timeout,
+	}
+	regionalClusterClient, err := c.clusterClientFactory.NewClient(options.Kubeconfig.Path, options.Kubeconfig.Context, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while validating cluster configuration")
+	}
+	isPacific, err := regionalClusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining Tanzu Kubernetes Cluster service for vSphere management cluster ")
+	}
+	if isPacific {
+		return nil, errors.New("validating the configuration of clusters created by the Tanzu Kubernetes Cluster service for vSphere is not supported")
+	}
+
+	if err := c.ValidateAndConfigureClusterOptions(options); err != nil {
+		return nil, errors.Wrap(err, "unable to configure the cluster options")
+	}
+	infraProvider := options.ProviderRepositorySource.InfrastructureProvider
+	if infraProvider == "" {
+		if infraProvider, err = regionalClusterClient.GetRegionalClusterDefaultProviderName(clusterctlv1.InfrastructureProviderType); err != nil {
+			return nil, err
+		}
+	}
+	providerName, _, err := ParseProviderName(infraProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.ClusterType == "" {
+		options.ClusterType = WorkloadCluster
+	}
+	c.SetBuildEdition(options.Edition)
+	c.SetTKGClusterRole(options.ClusterType)
+	c.SetTKGVersion()
+	c.SetProviderType(providerName)
+
+	if _, _, err := regionalClusterClient.GetPinnipedIssuerURLAndCA(); err != nil {
+		report.addFinding("pinniped", constants.ConfigVariableIdentityManagementType,
+			"Configure an identity provider on the management cluster to set up authentication via Pinniped",
+			ValidationSeverityWarning, errors.New("Pinniped configuration not found on the management cluster, authentication via Pinniped will not be set up in this cluster"))
+	}
+
+	runConfigValidationChecks(providerName, c.configValidationChecks(&configValidationOptions{
+		provider:      providerName,
+		clusterRole:   TkgLabelClusterRoleWorkload,
+		plan:          options.ProviderRepositorySource.Flavor,
+		tkrVersion:    options.TKRVersion,
+		nodeSizes:     options.NodeSizeOptions,
+		vip:           options.VsphereControlPlaneEndpoint,
+		cniType:       options.CniType,
+		clusterClient: regionalClusterClient,
+	}), report)
+
+	if err := c.ValidateSupportOfK8sVersionForManagmentCluster(regionalClusterClient, options.KubernetesVersion, false); err != nil {
+		report.addFinding("kubernetes-version", constants.ConfigVariableKubernetesVersion,
+			"Use a Tanzu Kubernetes release supported by the management cluster", ValidationSeverityError, err)
+	}
+	return report, nil
+}
+
+// ValidateManagementClusterConfiguration runs all the configuration checks of the management cluster infrastructure
+// provider and reports every problem found instead of stopping at the first one. The checks are run against a copy
+// of the configuration and of the options, which are left unchanged. The returned error is only set when the
+// configuration could not be validated at all.
+func (c *TkgClient) ValidateManagementClusterConfiguration(options *InitRegionOptions) (*ValidationReport, error) {
+	clientCopy, err := c.withConfigCopy()
+	if err != nil {
+		return nil, err
+	}
+	optionsCopy := *options
+	return clientCopy.validateManagementClusterConfiguration(&optionsCopy)
+}
+
+func (c *TkgClient) validateManagementClusterConfiguration(options *InitRegionOptions) (*ValidationReport, error) {
+	report := &ValidationReport{ClusterName: options.ClusterName}
+	if options.ClusterName != "" {
+		if err := CheckClusterNameFormat(options.ClusterName, options.InfrastructureProvider); err != nil {
+			report.addFinding("cluster-name", constants.ConfigVariableClusterName, "Use a cluster name made of lower case alphanumeric characters and '-'", ValidationSeverityError, err)
+		}
+	}
+	if options.Plan == "" {
+		report.addFinding("cluster-plan", constants.ConfigVariableClusterPlan, "Set CLUSTER_PLAN to dev or prod", ValidationSeverityError,
+			errors.New("required config variable 'CLUSTER_PLAN' is not set"))
+	}
+
+	infraProvider, err := c.tkgConfigUpdaterClient.CheckInfrastructureVersion(options.InfrastructureProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to check infrastructure provider version")
+	}
+	providerName, _, err := ParseProviderName(infraProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	_, tkrVersion, err := c.ConfigureAndValidateTkrVersion("")
+	if err != nil {
+		return nil, err
+	}
+	c.SetBuildEdition(options.Edition)
+	c.SetTKGClusterRole(ManagementCluster)
+	c.SetTKGVersion()
+	c.SetProviderType(providerName)
+
+	if idpType, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableIdentityManagementType); err != nil || idpType == "" || idpType == "none" {
+		report.addFinding("identity-management", constants.ConfigVariableIdentityManagementType,
+			"Set IDENTITY_MANAGEMENT_TYPE to oidc or ldap and configure the identity provider",
+			ValidationSeverityWarning, errors.New("identity provider not configured, some authentication features won't work"))
+	}
+
+	runConfigValidationChecks(providerName, c.configValidationChecks(&configValidationOptions{
+		provider:    providerName,
+		clusterRole: TkgLabelClusterRoleManagement,
+		plan:        options.Plan,
+		tkrVersion:  tkrVersion,
+		nodeSizes:   options.NodeSizeOptions,
+		vip:         options.VsphereControlPlaneEndpoint,
+		cniType:     options.CniType,
+	}), report)
+	return report, nil
+}
+
+// String returns a human readable summary of the report
+func (r *ValidationReport) String() string {
+	errorCount, warningCount := 0, 0
+	for i := range r.Findings {
+		if r.Findings[i].Severity == ValidationSeverityError {
+			errorCount++
+		} else {
+			warningCount++
+		}
+	}
+	return fmt.Sprintf("%d checks run for provider %s: %d errors, %d warnings", len(r.Checks), r.Provider, errorCount, warningCount)
+}
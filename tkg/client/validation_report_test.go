@@ -0,0 +1,163 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigbom"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+)
+
+var _ = Describe("Configuration validation report", func() {
+	var (
+		tkgClient             *client.TkgClient
+		tkgConfigReaderWriter tkgconfigreaderwriter.TKGConfigReaderWriter
+		initRegionOptions     *client.InitRegionOptions
+		tkgBomClient          *fakes.TKGConfigBomClient
+		tkgConfigUpdater      *fakes.TKGConfigUpdaterClient
+	)
+
+	BeforeEach(func() {
+		tkgBomClient = new(fakes.TKGConfigBomClient)
+		tkgBomClient.WithTKGConfigReaderWriterReturns(tkgBomClient)
+		tkgBomClient.GetDefaultTkrBOMConfigurationReturns(&tkgconfigbom.BOMConfiguration{
+			Release: &tkgconfigbom.ReleaseInfo{Version: "v1.3"},
+			Components: map[string][]*tkgconfigbom.ComponentInfo{
+				"kubernetes": {{Version: "v1.20"}},
+			},
+		}, nil)
+		tkgBomClient.GetDefaultTkgBOMConfigurationReturns(&tkgconfigbom.BOMConfiguration{
+			Release: &tkgconfigbom.ReleaseInfo{Version: "v1.23"},
+		}, nil)
+
+		configFile, err := os.CreateTemp(os.TempDir(), "cluster-config-*.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configFile.Close()).To(Succeed())
+
+		tkgConfigReaderWriter, err = tkgconfigreaderwriter.NewReaderWriterFromConfigFile(configFile.Name(), configFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+		readerWriter, err := tkgconfigreaderwriter.NewWithReaderWriter(tkgConfigReaderWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		tkgConfigUpdater = new(fakes.TKGConfigUpdaterClient)
+		tkgConfigUpdater.WithTKGConfigReaderWriterReturns(tkgConfigUpdater)
+		tkgConfigUpdater.CheckInfrastructureVersionStub = func(providerName string) (string, error) {
+			return providerName, nil
+		}
+		featureFlagClient := &fakes.FeatureFlagClient{}
+		featureFlagClient.IsConfigFeatureActivatedReturns(true, nil)
+
+		tkgClient, err = client.New(client.Options{
+			ReaderWriterConfigClient: readerWriter,
+			TKGConfigUpdater:         tkgConfigUpdater,
+			TKGBomClient:             tkgBomClient,
+			RegionManager:            new(fakes.RegionManager),
+			FeatureFlagClient:        featureFlagClient,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		initRegionOptions = &client.InitRegionOptions{
+			ClusterName:            "mgmt",
+			Plan:                   "dev",
+			InfrastructureProvider: "docker",
+			Edition:                "tkg",
+		}
+		tkgConfigReaderWriter.Set(constants.ConfigVariableIdentityManagementType, "oidc")
+	})
+
+	It("should not report findings for a valid configuration", func() {
+		report, err := tkgClient.ValidateManagementClusterConfiguration(initRegionOptions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Provider).To(Equal(client.DockerProviderName))
+		Expect(report.Checks).To(ContainElements("ip-family", "http-proxy", "avi"))
+		Expect(report.Checks).NotTo(ContainElement("vsphere-resources"))
+		Expect(report.Findings).To(BeEmpty())
+		Expect(report.HasErrors()).To(BeFalse())
+	})
+
+	It("should report all the problems found instead of the first one", func() {
+		tkgConfigReaderWriter.Set(constants.ConfigVariableServiceCIDR, "::1/108")
+		tkgConfigReaderWriter.Set(constants.TKGHTTPProxy, "http://1.2.3.4:3128")
+		initRegionOptions.Plan = ""
+		initRegionOptions.CniType = "weave"
+
+		report, err := tkgClient.ValidateManagementClusterConfiguration(initRegionOptions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.HasErrors()).To(BeTrue())
+
+		findings := map[string]client.ValidationFinding{}
+		for _, finding := range report.Findings {
+			findings[finding.Check] = finding
+		}
+		Expect(findings).To(HaveKey("cluster-plan"))
+		Expect(findings).To(HaveKey("cni"))
+		Expect(findings).To(HaveKey("ip-family"))
+		Expect(findings).To(HaveKey("http-proxy"))
+		Expect(findings["ip-family"].Severity).To(Equal(client.ValidationSeverityError))
+		Expect(findings["ip-family"].ConfigKey).To(Equal(constants.ConfigVariableIPFamily))
+		Expect(findings["ip-family"].Message).To(ContainSubstring("invalid SERVICE_CIDR \"::1/108\""))
+		Expect(findings["http-proxy"].ConfigKey).To(Equal(constants.TKGHTTPProxy))
+		Expect(findings["http-proxy"].Remediation).NotTo(BeEmpty())
+	})
+
+	It("should report a missing identity provider as a warning", func() {
+		tkgConfigReaderWriter.Set(constants.ConfigVariableIdentityManagementType, "none")
+
+		report, err := tkgClient.ValidateManagementClusterConfiguration(initRegionOptions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Findings).To(HaveLen(1))
+		Expect(report.Findings[0].Severity).To(Equal(client.ValidationSeverityWarning))
+		Expect(report.HasErrors()).To(BeFalse())
+	})
+
+	It("should leave the configuration and the options unchanged", func() {
+		_, err := tkgConfigReaderWriter.Get(constants.ConfigVariableServiceCIDR)
+		Expect(err).To(HaveOccurred())
+
+		_, err = tkgClient.ValidateManagementClusterConfiguration(initRegionOptions)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = tkgConfigReaderWriter.Get(constants.ConfigVariableServiceCIDR)
+		Expect(err).To(HaveOccurred())
+		_, err = tkgConfigReaderWriter.Get(constants.ConfigVariableIPFamily)
+		Expect(err).To(HaveOccurred())
+		Expect(initRegionOptions.InfrastructureProvider).To(Equal("docker"))
+	})
+
+	It("should read and write the copy of the configuration from the clients holding the configuration", func() {
+		_, err := tkgClient.ValidateManagementClusterConfiguration(initRegionOptions)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(tkgBomClient.WithTKGConfigReaderWriterCallCount()).To(Equal(1))
+		configCopy := tkgBomClient.WithTKGConfigReaderWriterArgsForCall(0)
+		Expect(configCopy).NotTo(BeIdenticalTo(tkgConfigReaderWriter))
+		Expect(tkgConfigUpdater.WithTKGConfigReaderWriterCallCount()).To(Equal(1))
+		Expect(tkgConfigUpdater.WithTKGConfigReaderWriterArgsForCall(0)).To(BeIdenticalTo(configCopy))
+
+		// the copy holds the values configured by the checks
+		_, err = configCopy.Get(constants.ConfigVariableServiceCIDR)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tkgConfigReaderWriter.Get(constants.ConfigVariableServiceCIDR)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should validate the configuration with the same checks as the cluster creation", func() {
+		initRegionOptions.CniType = "weave"
+
+		report, err := tkgClient.ValidateManagementClusterConfiguration(initRegionOptions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Findings).To(HaveLen(1))
+
+		verr := tkgClient.ConfigureAndValidateManagementClusterConfiguration(initRegionOptions, true)
+		Expect(verr).To(HaveOccurred())
+		Expect(verr.Error()).To(Equal(report.Findings[0].Message))
+	})
+})
@@ -0,0 +1,50 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/timeline"
+)
+
+// WatchClusterTimelineOptions contains options supported by WatchClusterTimeline
+type WatchClusterTimelineOptions struct {
+	ClusterName string
+	Namespace   string
+	// Follow keeps watching the cluster until the context is done
+	Follow bool
+	// Handler is called with each entry of the timeline
+	Handler func(timeline.Entry)
+}
+
+// WatchClusterTimeline calls the handler with the condition transitions and the Kubernetes Events
+// of the objects of a workload cluster, as they are observed on the management cluster
+func (c *TkgClient) WatchClusterTimeline(ctx context.Context, options WatchClusterTimelineOptions) error {
+	if options.Handler == nil {
+		return errors.New("timeline handler is required")
+	}
+	regionalClusterClient, err := c.getRegionalClusterClient()
+	if err != nil {
+		return err
+	}
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = constants.DefaultNamespace
+	}
+	dynamicClient := regionalClusterClient.GetDynamicClient()
+	if dynamicClient == nil {
+		return errors.New("unable to get the dynamic client of the management cluster")
+	}
+	timelineOptions := timeline.Options{
+		ClusterName: options.ClusterName,
+		Namespace:   namespace,
+		Follow:      options.Follow,
+	}
+	return errors.Wrapf(timeline.Watch(ctx, dynamicClient, timelineOptions, options.Handler),
+		"unable to watch the timeline of cluster %s/%s", namespace, options.ClusterName)
+}
@@ -40,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	capav1beta2 "sigs.k8s.io/cluster-api-provider-aws/api/v1beta2"
@@ -149,6 +150,8 @@ type Client interface {
 	GetPackage(carvelPkgName, carvelPkgNamespace string) (*kapppkgv1alpha1.Package, error)
 	// GetSecretValue returns the value for a given key in a Secret
 	GetSecretValue(secretName, key, namespace string, pollOptions *PollOptions) ([]byte, error)
+	// GetPodLogs returns the logs of a container of a pod, only the last tailLines lines if tailLines is positive
+	GetPodLogs(namespace, podName, containerName string, tailLines int64) ([]byte, error)
 	// GetCurrentNamespace returns the namespace from the current context in the kubeconfig file
 	GetCurrentNamespace() (string, error)
 	// CreateNamespace creates namespace if missing
@@ -335,8 +338,14 @@ type Client interface {
 	UpdateCAPZControllerManagerDeploymentReplicas(replicas int32) error
 	// UpdateAzureKCP recycles KCP for the azure cloud provider
 	UpdateAzureKCP(clusterName string, namespace string) error
+	// RolloutKubeadmControlPlane replaces the control plane machines of a cluster created before rolloutAfter
+	RolloutKubeadmControlPlane(clusterName, namespace string, rolloutAfter time.Time) error
+	// WaitForControlPlaneRollout waits for the control plane machines created before rolloutAfter to be replaced
+	WaitForControlPlaneRollout(clusterName, namespace string, rolloutAfter time.Time) error
 	// GetClientSet gets one clientset used to generate objects list
 	GetClientSet() CrtClient
+	// GetDynamicClient gets the dynamic client used to watch objects of any kind
+	GetDynamicClient() DynamicClient
 	// GetPinnipedIssuerURLAndCA fetches Pinniped supervisor IssuerURL and IssuerCA data from management cluster
 	GetPinnipedIssuerURLAndCA() (string, string, error)
 	// GetTanzuKubernetesReleases returns the TKr's with 'tkrName' prefix match. If tkrName is not provided it returns all the available TKr's
@@ -535,6 +544,10 @@ func (c *client) GetClientSet() CrtClient {
 	return c.clientSet
 }
 
+func (c *client) GetDynamicClient() DynamicClient {
+	return c.dynamicClient
+}
+
 func (c *client) GetKubeConfigPath() string {
 	return c.kubeConfigPath
 }
@@ -1572,6 +1585,29 @@ func (c *client) GetSecretValue(secretName, key, namespace string, pollOptions *
 	return data, nil
 }
 
+func (c *client) GetPodLogs(namespace, podName, containerName string, tailLines int64) ([]byte, error) {
+	restConfig, err := c.GetRestConfigClient()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create kubernetes clientset")
+	}
+
+	logOptions := &corev1.PodLogOptions{Container: containerName}
+	if tailLines > 0 {
+		logOptions.TailLines = &tailLines
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.operationTimeout)
+	defer cancel()
+	logs, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, logOptions).DoRaw(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get logs of container %s of pod %s/%s", containerName, namespace, podName)
+	}
+	return logs, nil
+}
+
 func (c *client) GetKubeConfigForCluster(clusterName, namespace string, pollOptions *PollOptions) ([]byte, error) {
 	log.V(4).Info("getting secret for cluster")
 	clusterSecretName := fmt.Sprintf("%s-%s", clusterName, kubeConfigSecretSuffix)
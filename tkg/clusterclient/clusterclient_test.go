@@ -3777,6 +3777,104 @@ prod.repo.com: prod.custom.repo.com`
 		})
 	})
 
+	Describe("Unit tests for RolloutKubeadmControlPlane", func() {
+		var (
+			resources    []runtime.Object
+			rolloutAfter time.Time
+		)
+
+		JustBeforeEach(func() {
+			reInitialize()
+
+			fakeClientSet := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(resources...).Build()
+			crtClientFactory.NewClientReturns(fakeClientSet, nil)
+
+			clusterClientOptions = NewOptions(poller, crtClientFactory, discoveryClientFactory, nil)
+			kubeConfigPath := getConfigFilePath("config1.yaml")
+			clstClient, err = NewClient(kubeConfigPath, "", clusterClientOptions)
+			Expect(err).NotTo(HaveOccurred())
+
+			rolloutAfter = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+			err = clstClient.RolloutKubeadmControlPlane("fake-cluster-1", "fake-namespace", rolloutAfter)
+		})
+
+		Context("When the cluster has no KubeadmControlPlane", func() {
+			BeforeEach(func() {
+				resources = []runtime.Object{}
+			})
+			It("should return error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unable to find the KubeadmControlPlane of cluster fake-namespace/fake-cluster-1"))
+			})
+		})
+
+		Context("When the cluster has a KubeadmControlPlane", func() {
+			BeforeEach(func() {
+				resources = []runtime.Object{&controlplanev1.KubeadmControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-cluster-1-control-plane",
+						Namespace: "fake-namespace",
+						Labels:    map[string]string{capi.ClusterLabelName: "fake-cluster-1"},
+					},
+				}}
+			})
+			It("should set the rolloutAfter of the KubeadmControlPlane", func() {
+				Expect(err).NotTo(HaveOccurred())
+				kcp := &controlplanev1.KubeadmControlPlane{}
+				err = clstClient.GetResource(kcp, "fake-cluster-1-control-plane", "fake-namespace", nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kcp.Spec.RolloutAfter).NotTo(BeNil())
+				Expect(kcp.Spec.RolloutAfter.Time.Equal(rolloutAfter)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Unit tests for VerifyControlPlaneRolledOut", func() {
+		rolloutAfter := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+		newMachine := func(name string, created time.Time, controlPlane bool, phase capi.MachinePhase) capi.Machine {
+			machine := capi.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: metav1.NewTime(created),
+					Labels:            map[string]string{capi.ClusterLabelName: "fake-cluster-1"},
+				},
+				Status: capi.MachineStatus{Phase: string(phase)},
+			}
+			if controlPlane {
+				machine.Labels[capi.MachineControlPlaneLabelName] = ""
+			}
+			if phase == capi.MachinePhaseRunning {
+				machine.Status.NodeRef = &corev1.ObjectReference{Name: name}
+			}
+			return machine
+		}
+
+		It("should fail while outdated control plane machines are left", func() {
+			machines := &capi.MachineList{Items: []capi.Machine{
+				newMachine("cp-old", rolloutAfter.Add(-time.Hour), true, capi.MachinePhaseRunning),
+				newMachine("cp-new", rolloutAfter.Add(time.Minute), true, capi.MachinePhaseRunning),
+			}}
+			err := VerifyControlPlaneRolledOut(1, rolloutAfter)(machines)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("1 outdated machines left"))
+		})
+		It("should fail while the new control plane machines are not running", func() {
+			machines := &capi.MachineList{Items: []capi.Machine{
+				newMachine("cp-new-1", rolloutAfter.Add(time.Minute), true, capi.MachinePhaseRunning),
+				newMachine("cp-new-2", rolloutAfter.Add(time.Minute), true, capi.MachinePhaseProvisioning),
+			}}
+			err := VerifyControlPlaneRolledOut(2, rolloutAfter)(machines)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("1 of 2 machines replaced"))
+		})
+		It("should succeed once the control plane machines are replaced, ignoring worker machines", func() {
+			machines := &capi.MachineList{Items: []capi.Machine{
+				newMachine("cp-new", rolloutAfter.Add(500*time.Millisecond).Truncate(time.Second), true, capi.MachinePhaseRunning),
+				newMachine("md-old", rolloutAfter.Add(-time.Hour), false, capi.MachinePhaseRunning),
+			}}
+			Expect(VerifyControlPlaneRolledOut(1, rolloutAfter.Add(500*time.Millisecond))(machines)).To(Succeed())
+		})
+	})
 })
 
 func createTempDirectory() {
@@ -0,0 +1,85 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// ControlPlaneRolloutPollInterval is the interval at which the control plane machines are checked during a rollout
+const ControlPlaneRolloutPollInterval = 15 * time.Second
+
+// RolloutKubeadmControlPlane sets the rolloutAfter of the KubeadmControlPlane of a cluster, which replaces all the
+// control plane machines created before it. The new machines are bootstrapped with renewed kubeadm certificates.
+func (c *client) RolloutKubeadmControlPlane(clusterName, namespace string, rolloutAfter time.Time) error {
+	kcp, err := c.GetKCPObjectForCluster(clusterName, namespace)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find the KubeadmControlPlane of cluster %s/%s", namespace, clusterName)
+	}
+	patchString := fmt.Sprintf(`{"spec":{"rolloutAfter":%q}}`, rolloutAfter.UTC().Format(time.RFC3339))
+
+	log.V(4).Infof("Rolling out KubeadmControlPlane %s/%s after %s", namespace, kcp.Name, rolloutAfter.UTC().Format(time.RFC3339))
+	pollOptions := &PollOptions{Interval: CheckResourceInterval, Timeout: c.operationTimeout}
+	if err := c.PatchResource(&controlplanev1.KubeadmControlPlane{}, kcp.Name, namespace, patchString, types.MergePatchType, pollOptions); err != nil {
+		return errors.Wrapf(err, "unable to roll out KubeadmControlPlane %s/%s", namespace, kcp.Name)
+	}
+	return nil
+}
+
+// WaitForControlPlaneRollout waits until the control plane machines of a cluster created before rolloutAfter
+// have all been replaced by running machines
+func (c *client) WaitForControlPlaneRollout(clusterName, namespace string, rolloutAfter time.Time) error {
+	kcp, err := c.GetKCPObjectForCluster(clusterName, namespace)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find the KubeadmControlPlane of cluster %s/%s", namespace, clusterName)
+	}
+	replicas := int32(1)
+	if kcp.Spec.Replicas != nil {
+		replicas = *kcp.Spec.Replicas
+	}
+	pollOptions := &PollOptions{Interval: ControlPlaneRolloutPollInterval, Timeout: c.operationTimeout}
+	return c.GetResourceList(&capi.MachineList{}, clusterName, namespace, VerifyControlPlaneRolledOut(replicas, rolloutAfter), pollOptions)
+}
+
+// VerifyControlPlaneRolledOut returns a function verifying that a machine list holds the expected number of
+// running control plane machines, all created after rolloutAfter
+func VerifyControlPlaneRolledOut(replicas int32, rolloutAfter time.Time) PostVerifyListrFunc {
+	// creation timestamps have a precision of a second
+	rolloutAfter = rolloutAfter.Truncate(time.Second)
+	return func(obj crtclient.ObjectList) error {
+		machineList, ok := obj.(*capi.MachineList)
+		if !ok {
+			return errors.Errorf("expected a MachineList, got %T", obj)
+		}
+		var running, outdated int32
+		for i := range machineList.Items {
+			machine := &machineList.Items[i]
+			if _, isControlPlane := machine.Labels[capi.MachineControlPlaneLabelName]; !isControlPlane {
+				continue
+			}
+			if machine.CreationTimestamp.Time.Before(rolloutAfter) {
+				outdated++
+				continue
+			}
+			if machine.DeletionTimestamp.IsZero() && machine.Status.NodeRef != nil &&
+				machine.Status.Phase == string(capi.MachinePhaseRunning) {
+				running++
+			}
+		}
+		if outdated > 0 || running < replicas {
+			return errors.Errorf("control plane is still being rolled out, %d of %d machines replaced, %d outdated machines left",
+				running, replicas, outdated)
+		}
+		return nil
+	}
+}
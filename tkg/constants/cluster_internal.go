@@ -52,6 +52,10 @@ const (
 	TkgPublicNamespace = "tkg-system-public"
 	TmcNamespace       = "vmware-system-tmc"
 
+	// OverlayPacksConfigMapName is the ConfigMap in TkgNamespace holding the lock of the overlay packs pinned to the management cluster
+	OverlayPacksConfigMapName = "tkg-overlay-packs"
+	OverlayPacksConfigMapKey  = "overlays.lock.yaml"
+
 	KappControllerNamespace     = "tkg-system"
 	KappControllerConfigMapName = "kapp-controller-config"
 
@@ -140,8 +144,16 @@ const (
 	AddonNameLabel = "tkg.tanzu.vmware.com/addon-name"
 	// ClusterNameLabel is the label on the Secret to indicate the cluster on which addon is to be installed
 	ClusterNameLabel = "tkg.tanzu.vmware.com/cluster-name"
+	// ClusterNamespaceAnnotation is the annotation on the PackageInstall to indicate the namespace of the cluster on which
+	// the package is installed
+	ClusterNamespaceAnnotation = "tkg.tanzu.vmware.com/cluster-namespace"
 	// ClusterPauseLabel is the label on the Cluster Object to indicate the cluster is paused by TKG
 	ClusterPauseLabel = "tkg.tanzu.vmware.com/paused"
+	// ClusterPausedByAnnotation is the annotation on the Cluster, ClusterBootstrap and PackageInstall objects to
+	// indicate who paused them with the tanzu cluster pause command
+	ClusterPausedByAnnotation = "tkg.tanzu.vmware.com/paused-by"
+	// ClusterPauseReasonAnnotation is the annotation on the Cluster Object to indicate why the cluster is paused
+	ClusterPauseReasonAnnotation = "tkg.tanzu.vmware.com/pause-reason"
 	// PackageTypeLabel is the label on the PackageInstall which mentions type of the package
 	PackageTypeLabel = "tkg.tanzu.vmware.com/package-type"
 	// CLIPluginImageRepositoryOverrideLabel is the label on the configmap which specifies CLIPlugin image repository override
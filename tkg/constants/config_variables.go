@@ -18,6 +18,8 @@ const (
 	ConfigVariableClusterAPIServerPort                = "CLUSTER_API_SERVER_PORT"
 	ConfigVariableBastionHostEnabled                  = "BASTION_HOST_ENABLED"
 	ConfigVariableVipNetworkInterface                 = "VIP_NETWORK_INTERFACE"
+	ConfigVariableBootstrapClusterPersistent          = "TKG_BOOTSTRAP_CLUSTER_PERSISTENT"
+	ConfigVariableBootstrapImageCache                 = "TKG_BOOTSTRAP_IMAGE_CACHE"
 
 	ConfigVariableAWSRegion          = "AWS_REGION"
 	ConfigVariableAWSSecretAccessKey = "AWS_SECRET_ACCESS_KEY" //nolint:gosec
@@ -159,6 +161,7 @@ const (
 
 	ConfigVariablePinnipedSupervisorIssuerURL          = "SUPERVISOR_ISSUER_URL"
 	ConfigVariablePinnipedSupervisorIssuerCABundleData = "SUPERVISOR_ISSUER_CA_BUNDLE_DATA_B64"
+	ConfigVariableIdentityManagementType               = "IDENTITY_MANAGEMENT_TYPE"
 
 	ConfigVariableClusterRole                = "TKG_CLUSTER_ROLE"
 	ConfigVariableForceRole                  = "_TKG_CLUSTER_FORCE_ROLE"
@@ -14,6 +14,8 @@ const (
 	LocalProvidersFolderName  = "providers"
 	LocalProvidersZipFileName = "providers.zip"
 	LocalTanzuFileLock        = ".tanzu.lock"
+	// PersistentKindClusterLockFileName is the lock of the persistent bootstrap cluster, held by the operation using it
+	PersistentKindClusterLockFileName = ".tkg-kind-persistent.lock"
 
 	LocalProvidersConfigFileName = "config.yaml"
 	LocalBOMsFolderName          = "bom"
@@ -36,6 +38,16 @@ const (
 	TKGRegistryTrustedRootCAFileForWindows = ".registry_trusted_root_certs_win"
 
 	LogFolderName = "logs"
+	// UILogEventsFileName is the file the installer UI log events are persisted to, within LogFolderName
+	UILogEventsFileName = "ui-events.log"
 
 	TKGPackageValuesFile = "tkgpackagevalues.yaml"
+
+	// InitRegionStateFolderName is the folder the progress of the management cluster creations is persisted to, one file per cluster
+	InitRegionStateFolderName = "init-region-state"
+
+	// OverlayPacksFolderName is the folder caching the overlay packs pinned to the management clusters
+	OverlayPacksFolderName = "overlays"
+
+	ConfigProfilesFolderName = "profiles"
 )
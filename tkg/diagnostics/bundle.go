@@ -0,0 +1,107 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diagnostics implements the redaction, the summary report and the
+// tarball of cluster diagnostics bundles
+package diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Bundle holds the files of a diagnostics bundle until it is written as a gzipped tarball.
+// All the files are stored under a root directory named after the bundle.
+type Bundle struct {
+	root    string
+	created time.Time
+	names   []string
+	files   map[string][]byte
+}
+
+// NewBundle creates an empty bundle whose files are stored under the root directory
+func NewBundle(root string) *Bundle {
+	return &Bundle{root: root, created: time.Now(), files: map[string][]byte{}}
+}
+
+// AddFile adds a file to the bundle, the content of a file added twice is replaced
+func (b *Bundle) AddFile(name string, data []byte) {
+	if _, ok := b.files[name]; !ok {
+		b.names = append(b.names, name)
+	}
+	b.files[name] = data
+}
+
+// AddObjects adds a multi-document YAML file holding the redacted objects
+func (b *Bundle) AddObjects(name string, objects []unstructured.Unstructured) error {
+	var buf bytes.Buffer
+	for i := range objects {
+		obj := objects[i].DeepCopy()
+		RedactObject(obj)
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return errors.Wrapf(err, "unable to marshal %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+	b.AddFile(name, buf.Bytes())
+	return nil
+}
+
+// AddLogs adds a file holding the redacted logs of a container
+func (b *Bundle) AddLogs(name string, logs []byte) {
+	b.AddFile(name, RedactLogs(logs))
+}
+
+// Files returns the names of the files in the bundle, in the order they were added
+func (b *Bundle) Files() []string {
+	return append([]string(nil), b.names...)
+}
+
+// Write writes the bundle as a gzipped tarball
+func (b *Bundle) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, name := range b.names {
+		data := b.files[name]
+		header := &tar.Header{
+			Name:    path.Join(b.root, name),
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: b.created,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// WriteFile writes the bundle as a gzipped tarball to a file, which is only readable by the user
+func (b *Bundle) WriteFile(filename string) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create diagnostics bundle %s", filename)
+	}
+	if err := b.Write(f); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "unable to write diagnostics bundle %s", filename)
+	}
+	return f.Close()
+}
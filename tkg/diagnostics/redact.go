@@ -0,0 +1,123 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Redacted replaces the sensitive values of the bundle
+const Redacted = "REDACTED"
+
+// sensitiveKeySuffixes are the suffixes of the lower-cased field names whose values are redacted
+var sensitiveKeySuffixes = []string{
+	"password",
+	"passwd",
+	"token",
+	"privatekey",
+	"secretkey",
+	"accesskey",
+	"clientsecret",
+	"apikey",
+	"credentials",
+}
+
+// keySeparators are removed from the names checked against sensitiveKeySuffixes
+var keySeparators = strings.NewReplacer("_", "", "-", "", ".", "")
+
+var (
+	// pemPrivateKeyPattern matches PEM encoded private keys
+	pemPrivateKeyPattern = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+	// urlUserinfoPattern matches the password of the userinfo of URLs, e.g. proxy URLs
+	urlUserinfoPattern = regexp.MustCompile(`(://[^:/@\s]+):[^@/\s]+@`)
+	// logSecretPattern matches key-value pairs of secrets in log lines and in embedded files, e.g. cloud-configs
+	logSecretPattern = regexp.MustCompile(`(?i)((?:password|passwd|token|secret|apikey|api_key|authorization)["']?\s*[:=]\s*(?:(?:bearer|basic)\s+)?)("[^"]*"|'[^']*'|\S+)`)
+	// bearerPattern matches bearer tokens in log lines
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	// secretNamePattern matches the names of name-value pairs, e.g. environment variables, which hold secrets
+	secretNamePattern = regexp.MustCompile(`(?i)(^|[_.-])(secret|password|passwd|token)($|[_.-])`)
+)
+
+// RedactObject redacts the sensitive values of an object in place. The values of sensitive fields,
+// the secrets found in string values, e.g. in the files of bootstrap configs, and the encoded contents
+// of files are replaced, and the last applied configuration annotation, which duplicates the object,
+// is removed.
+func RedactObject(obj *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	if obj.GetKind() == "Secret" {
+		unstructured.RemoveNestedField(obj.Object, "data")
+		unstructured.RemoveNestedField(obj.Object, "stringData")
+	}
+	obj.Object = redactMap(obj.Object)
+}
+
+func redactMap(m map[string]interface{}) map[string]interface{} {
+	for key, value := range m {
+		if isSensitiveKey(key) {
+			if _, ok := value.(string); ok {
+				m[key] = Redacted
+				continue
+			}
+		}
+		m[key] = redactValue(value)
+	}
+	// The values of name-value pairs, e.g. the environment variables of containers, are sensitive when their name is
+	if name, ok := m["name"].(string); ok && (isSensitiveKey(name) || secretNamePattern.MatchString(name)) {
+		if _, ok := m["value"].(string); ok {
+			m["value"] = Redacted
+		}
+	}
+	// The encoded contents of files, e.g. in the files of bootstrap configs, cannot be searched for secrets
+	if encoding, ok := m["encoding"].(string); ok && encoding != "" {
+		if _, ok := m["content"].(string); ok {
+			m["content"] = Redacted
+		}
+	}
+	return m
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return redactMap(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+		return v
+	case string:
+		return redactString(v)
+	default:
+		return v
+	}
+}
+
+func redactString(s string) string {
+	s = pemPrivateKeyPattern.ReplaceAllString(s, Redacted)
+	s = urlUserinfoPattern.ReplaceAllString(s, "${1}:"+Redacted+"@")
+	s = logSecretPattern.ReplaceAllString(s, "${1}"+Redacted)
+	return bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+}
+
+// isSensitiveKey tells whether a field or variable name ends with a sensitive suffix, ignoring the case and the
+// separators, so that e.g. secretAccessKey and AWS_SECRET_ACCESS_KEY are both sensitive
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(keySeparators.Replace(key))
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactLogs redacts the secrets, bearer tokens, private keys and URL passwords found in logs
+func RedactLogs(logs []byte) []byte {
+	return []byte(redactString(string(logs)))
+}
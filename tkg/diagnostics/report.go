@@ -0,0 +1,186 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// negativePolarityConditions are the condition types which report a problem when they are true,
+// in addition to the ones ending with "Failed" and "Pressure"
+var negativePolarityConditions = map[string]bool{
+	"NetworkUnavailable": true,
+}
+
+// ignoredConditions are the condition types which do not report a problem whatever their status
+var ignoredConditions = map[string]bool{
+	"Reconciling": true,
+	"Paused":      true,
+}
+
+// FailingCondition is a condition of an object reporting a problem
+type FailingCondition struct {
+	// Object identifies the object, e.g. Machine default/wc-md-0-abcde
+	Object  string
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+// String returns a readable form of the condition
+func (c FailingCondition) String() string {
+	s := fmt.Sprintf("%s: %s=%s", c.Object, c.Type, c.Status)
+	if c.Reason != "" {
+		s += fmt.Sprintf(" (%s)", c.Reason)
+	}
+	if c.Message != "" {
+		s += ": " + strings.Join(strings.Fields(c.Message), " ")
+	}
+	return s
+}
+
+// FailingConditions returns the conditions in the status of an object which report a problem:
+// conditions which are not true, except for the ones which report a problem when they are true.
+func FailingConditions(obj *unstructured.Unstructured) []FailingCondition {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	var failing []FailingCondition
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		status, _, _ := unstructured.NestedString(condition, "status")
+		if conditionType == "" || ignoredConditions[conditionType] {
+			continue
+		}
+		if isNegativePolarity(conditionType) == (status != "True") {
+			continue
+		}
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+		failing = append(failing, FailingCondition{
+			Object:  ObjectName(obj),
+			Type:    conditionType,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+	return failing
+}
+
+func isNegativePolarity(conditionType string) bool {
+	return negativePolarityConditions[conditionType] ||
+		strings.HasSuffix(conditionType, "Failed") ||
+		strings.HasSuffix(conditionType, "Pressure")
+}
+
+// ObjectName returns the kind and the namespaced name of an object, e.g. Machine default/wc-md-0-abcde
+func ObjectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// Report is the summary of a diagnostics bundle
+type Report struct {
+	// Title identifies the diagnosed cluster
+	Title string
+	// Time is the time the diagnostics were collected
+	Time time.Time
+	// Details are informational lines, e.g. the Tanzu Kubernetes release of the cluster
+	Details []string
+	// FailingConditions are the conditions of the collected objects which report a problem
+	FailingConditions []FailingCondition
+	// UnhealthyPods describe the pods which are not running or not ready
+	UnhealthyPods []string
+	// Errors are the problems met while collecting the diagnostics
+	Errors []string
+}
+
+// AddObjects adds the failing conditions of objects to the report
+func (r *Report) AddObjects(objects []unstructured.Unstructured) {
+	for i := range objects {
+		r.FailingConditions = append(r.FailingConditions, FailingConditions(&objects[i])...)
+	}
+}
+
+// AddPods adds the pods which are not running or not ready to the report
+func (r *Report) AddPods(pods []unstructured.Unstructured) {
+	for i := range pods {
+		if problem := podProblem(&pods[i]); problem != "" {
+			r.UnhealthyPods = append(r.UnhealthyPods, fmt.Sprintf("%s: %s", ObjectName(&pods[i]), problem))
+		}
+	}
+}
+
+// podProblem returns why a pod is unhealthy, or an empty string if it is healthy
+func podProblem(pod *unstructured.Unstructured) string {
+	phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase")
+	switch phase {
+	case "Succeeded":
+		return ""
+	case "Running":
+	default:
+		return "phase " + phase
+	}
+	statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", "containerStatuses")
+	var notReady []string
+	for _, s := range statuses {
+		status, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ready, _, _ := unstructured.NestedBool(status, "ready"); ready {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(status, "name")
+		if reason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason"); reason != "" {
+			name += " (" + reason + ")"
+		}
+		notReady = append(notReady, name)
+	}
+	if len(notReady) == 0 {
+		return ""
+	}
+	return "containers not ready: " + strings.Join(notReady, ", ")
+}
+
+// AddError adds a problem met while collecting the diagnostics to the report
+func (r *Report) AddError(err error) {
+	r.Errors = append(r.Errors, err.Error())
+}
+
+// String returns the report as text
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Diagnostics of %s\n", r.Title)
+	fmt.Fprintf(&b, "Collected at %s\n", r.Time.UTC().Format(time.RFC3339))
+	for _, detail := range r.Details {
+		fmt.Fprintf(&b, "%s\n", detail)
+	}
+
+	fmt.Fprintf(&b, "\nFailing conditions (%d):\n", len(r.FailingConditions))
+	for _, c := range r.FailingConditions {
+		fmt.Fprintf(&b, "  %s\n", c)
+	}
+	fmt.Fprintf(&b, "\nUnhealthy pods (%d):\n", len(r.UnhealthyPods))
+	for _, pod := range r.UnhealthyPods {
+		fmt.Fprintf(&b, "  %s\n", pod)
+	}
+	if len(r.Errors) != 0 {
+		fmt.Fprintf(&b, "\nCollection errors (%d):\n", len(r.Errors))
+		for _, err := range r.Errors {
+			fmt.Fprintf(&b, "  %s\n", err)
+		}
+	}
+	return b.String()
+}
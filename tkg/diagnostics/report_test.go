@@ -0,0 +1,110 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/diagnostics"
+)
+
+func objectWithConditions(kind, namespace, name string, conditions ...map[string]interface{}) unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]interface{}{"kind": kind}}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	items := make([]interface{}, len(conditions))
+	for i := range conditions {
+		items[i] = conditions[i]
+	}
+	_ = unstructured.SetNestedSlice(obj.Object, items, "status", "conditions")
+	return obj
+}
+
+func condition(conditionType, status, reason, message string) map[string]interface{} {
+	return map[string]interface{}{"type": conditionType, "status": status, "reason": reason, "message": message}
+}
+
+func pod(name, phase string, containerStatuses ...interface{}) unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":   "Pod",
+		"status": map[string]interface{}{"phase": phase, "containerStatuses": containerStatuses},
+	}}
+	obj.SetNamespace("kube-system")
+	obj.SetName(name)
+	return obj
+}
+
+var _ = Describe("Report", func() {
+	Context("FailingConditions", func() {
+		It("should return the conditions reporting a problem", func() {
+			machine := objectWithConditions("Machine", "default", "wc-md-0",
+				condition("Ready", "False", "WaitingForInfrastructure", "1 of 2\n  completed"),
+				condition("BootstrapReady", "True", "", ""),
+				condition("Paused", "False", "", ""))
+			node := objectWithConditions("Node", "", "wc-md-0",
+				condition("Ready", "Unknown", "NodeStatusUnknown", ""),
+				condition("MemoryPressure", "False", "", ""),
+				condition("DiskPressure", "True", "KubeletHasDiskPressure", ""),
+				condition("NetworkUnavailable", "False", "", ""))
+			packageInstall := objectWithConditions("PackageInstall", "tkg-system", "antrea",
+				condition("Reconciling", "True", "", ""),
+				condition("ReconcileFailed", "True", "", "kapp: Error"))
+
+			Expect(diagnostics.FailingConditions(&machine)).To(Equal([]diagnostics.FailingCondition{
+				{Object: "Machine default/wc-md-0", Type: "Ready", Status: "False", Reason: "WaitingForInfrastructure", Message: "1 of 2\n  completed"},
+			}))
+			Expect(diagnostics.FailingConditions(&node)).To(Equal([]diagnostics.FailingCondition{
+				{Object: "Node wc-md-0", Type: "Ready", Status: "Unknown", Reason: "NodeStatusUnknown"},
+				{Object: "Node wc-md-0", Type: "DiskPressure", Status: "True", Reason: "KubeletHasDiskPressure"},
+			}))
+			Expect(diagnostics.FailingConditions(&packageInstall)).To(Equal([]diagnostics.FailingCondition{
+				{Object: "PackageInstall tkg-system/antrea", Type: "ReconcileFailed", Status: "True", Message: "kapp: Error"},
+			}))
+		})
+	})
+
+	Context("String", func() {
+		It("should summarize the failing conditions, unhealthy pods and errors", func() {
+			report := &diagnostics.Report{
+				Title:   "cluster default/wc",
+				Time:    time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+				Details: []string{"Tanzu Kubernetes release: v1.23.8---vmware.2-tkg.1"},
+			}
+			report.AddObjects([]unstructured.Unstructured{
+				objectWithConditions("Cluster", "default", "wc", condition("ControlPlaneReady", "False", "WaitingForControlPlane", "1 of 2\n completed")),
+			})
+			report.AddPods([]unstructured.Unstructured{
+				pod("coredns", "Running", map[string]interface{}{"name": "coredns", "ready": true}),
+				pod("antrea-agent", "Running", map[string]interface{}{
+					"name":  "antrea-agent",
+					"ready": false,
+					"state": map[string]interface{}{"waiting": map[string]interface{}{"reason": "CrashLoopBackOff"}},
+				}),
+				pod("kube-proxy", "Pending"),
+				pod("job", "Succeeded"),
+			})
+			report.AddError(errors.New("unable to get the ClusterBootstrap of the cluster"))
+
+			Expect(report.String()).To(Equal(`Diagnostics of cluster default/wc
+Collected at 2022-10-01T12:00:00Z
+Tanzu Kubernetes release: v1.23.8---vmware.2-tkg.1
+
+Failing conditions (1):
+  Cluster default/wc: ControlPlaneReady=False (WaitingForControlPlane): 1 of 2 completed
+
+Unhealthy pods (2):
+  Pod kube-system/antrea-agent: containers not ready: antrea-agent (CrashLoopBackOff)
+  Pod kube-system/kube-proxy: phase Pending
+
+Collection errors (1):
+  unable to get the ClusterBootstrap of the cluster
+`))
+		})
+	})
+})
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/vc"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
 )
 
 type Client struct {
@@ -44,6 +46,17 @@ type Client struct {
 	addRegionContextReturnsOnCall map[int]struct {
 		result1 error
 	}
+	BackupManagementClusterStub        func(client.BackupManagementClusterOptions) error
+	backupManagementClusterMutex       sync.RWMutex
+	backupManagementClusterArgsForCall []struct {
+		arg1 client.BackupManagementClusterOptions
+	}
+	backupManagementClusterReturns struct {
+		result1 error
+	}
+	backupManagementClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ConfigureAndValidateManagementClusterConfigurationStub        func(*client.InitRegionOptions, bool) *client.ValidationError
 	configureAndValidateManagementClusterConfigurationMutex       sync.RWMutex
 	configureAndValidateManagementClusterConfigurationArgsForCall []struct {
@@ -184,6 +197,19 @@ type Client struct {
 		result1 *v1alpha3.ProviderList
 		result2 error
 	}
+	DiagnoseClusterStub        func(client.DiagnoseClusterOptions) (string, error)
+	diagnoseClusterMutex       sync.RWMutex
+	diagnoseClusterArgsForCall []struct {
+		arg1 client.DiagnoseClusterOptions
+	}
+	diagnoseClusterReturns struct {
+		result1 string
+		result2 error
+	}
+	diagnoseClusterReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	DownloadBomFileStub        func(string) error
 	downloadBomFileMutex       sync.RWMutex
 	downloadBomFileArgsForCall []struct {
@@ -219,6 +245,19 @@ type Client struct {
 		result1 client.ClusterCeipInfo
 		result2 error
 	}
+	GetClusterCertificatesStub        func(client.GetClusterCertificatesOptions) ([]client.ClusterCertificate, error)
+	getClusterCertificatesMutex       sync.RWMutex
+	getClusterCertificatesArgsForCall []struct {
+		arg1 client.GetClusterCertificatesOptions
+	}
+	getClusterCertificatesReturns struct {
+		result1 []client.ClusterCertificate
+		result2 error
+	}
+	getClusterCertificatesReturnsOnCall map[int]struct {
+		result1 []client.ClusterCertificate
+		result2 error
+	}
 	GetClusterConfigurationStub        func(*client.CreateClusterOptions) ([]byte, error)
 	getClusterConfigurationMutex       sync.RWMutex
 	getClusterConfigurationArgsForCall []struct {
@@ -295,6 +334,18 @@ type Client struct {
 		result1 []client.MachineHealthCheck
 		result2 error
 	}
+	GetOverlayPackLockStub        func() (*yamlprocessor.OverlayPackLock, error)
+	getOverlayPackLockMutex       sync.RWMutex
+	getOverlayPackLockArgsForCall []struct {
+	}
+	getOverlayPackLockReturns struct {
+		result1 *yamlprocessor.OverlayPackLock
+		result2 error
+	}
+	getOverlayPackLockReturnsOnCall map[int]struct {
+		result1 *yamlprocessor.OverlayPackLock
+		result2 error
+	}
 	GetPacificClusterObjectStub        func(string, string) (*v1alpha2.TanzuKubernetesCluster, error)
 	getPacificClusterObjectMutex       sync.RWMutex
 	getPacificClusterObjectArgsForCall []struct {
@@ -466,6 +517,50 @@ type Client struct {
 	parseHiddenArgsAsFeatureFlagsArgsForCall []struct {
 		arg1 *client.InitRegionOptions
 	}
+	PauseClusterStub        func(client.PauseClusterOptions) error
+	pauseClusterMutex       sync.RWMutex
+	pauseClusterArgsForCall []struct {
+		arg1 client.PauseClusterOptions
+	}
+	pauseClusterReturns struct {
+		result1 error
+	}
+	pauseClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RestoreManagementClusterStub        func(client.RestoreManagementClusterOptions) error
+	restoreManagementClusterMutex       sync.RWMutex
+	restoreManagementClusterArgsForCall []struct {
+		arg1 client.RestoreManagementClusterOptions
+	}
+	restoreManagementClusterReturns struct {
+		result1 error
+	}
+	restoreManagementClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResumeClusterStub        func(client.ResumeClusterOptions) error
+	resumeClusterMutex       sync.RWMutex
+	resumeClusterArgsForCall []struct {
+		arg1 client.ResumeClusterOptions
+	}
+	resumeClusterReturns struct {
+		result1 error
+	}
+	resumeClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RotateClusterCertificatesStub        func(client.RotateClusterCertificatesOptions) error
+	rotateClusterCertificatesMutex       sync.RWMutex
+	rotateClusterCertificatesArgsForCall []struct {
+		arg1 client.RotateClusterCertificatesOptions
+	}
+	rotateClusterCertificatesReturns struct {
+		result1 error
+	}
+	rotateClusterCertificatesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveFeatureFlagsStub        func(map[string]string) error
 	saveFeatureFlagsMutex       sync.RWMutex
 	saveFeatureFlagsArgsForCall []struct {
@@ -477,6 +572,17 @@ type Client struct {
 	saveFeatureFlagsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveOverlayPackLockStub        func(*yamlprocessor.OverlayPackLock) error
+	saveOverlayPackLockMutex       sync.RWMutex
+	saveOverlayPackLockArgsForCall []struct {
+		arg1 *yamlprocessor.OverlayPackLock
+	}
+	saveOverlayPackLockReturns struct {
+		result1 error
+	}
+	saveOverlayPackLockReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ScaleClusterStub        func(client.ScaleClusterOptions) error
 	scaleClusterMutex       sync.RWMutex
 	scaleClusterArgsForCall []struct {
@@ -599,6 +705,19 @@ type Client struct {
 	validateDockerResourcePrerequisitesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateManagementClusterConfigurationStub        func(*client.InitRegionOptions) (*client.ValidationReport, error)
+	validateManagementClusterConfigurationMutex       sync.RWMutex
+	validateManagementClusterConfigurationArgsForCall []struct {
+		arg1 *client.InitRegionOptions
+	}
+	validateManagementClusterConfigurationReturns struct {
+		result1 *client.ValidationReport
+		result2 error
+	}
+	validateManagementClusterConfigurationReturnsOnCall map[int]struct {
+		result1 *client.ValidationReport
+		result2 error
+	}
 	ValidatePrerequisitesStub        func(bool, bool) error
 	validatePrerequisitesMutex       sync.RWMutex
 	validatePrerequisitesArgsForCall []struct {
@@ -611,6 +730,19 @@ type Client struct {
 	validatePrerequisitesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateWorkloadClusterConfigurationStub        func(*client.CreateClusterOptions) (*client.ValidationReport, error)
+	validateWorkloadClusterConfigurationMutex       sync.RWMutex
+	validateWorkloadClusterConfigurationArgsForCall []struct {
+		arg1 *client.CreateClusterOptions
+	}
+	validateWorkloadClusterConfigurationReturns struct {
+		result1 *client.ValidationReport
+		result2 error
+	}
+	validateWorkloadClusterConfigurationReturnsOnCall map[int]struct {
+		result1 *client.ValidationReport
+		result2 error
+	}
 	VerifyRegionStub        func(string) (region.RegionContext, error)
 	verifyRegionMutex       sync.RWMutex
 	verifyRegionArgsForCall []struct {
@@ -624,6 +756,18 @@ type Client struct {
 		result1 region.RegionContext
 		result2 error
 	}
+	WatchClusterTimelineStub        func(context.Context, client.WatchClusterTimelineOptions) error
+	watchClusterTimelineMutex       sync.RWMutex
+	watchClusterTimelineArgsForCall []struct {
+		arg1 context.Context
+		arg2 client.WatchClusterTimelineOptions
+	}
+	watchClusterTimelineReturns struct {
+		result1 error
+	}
+	watchClusterTimelineReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -752,6 +896,67 @@ func (fake *Client) AddRegionContextReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Client) BackupManagementCluster(arg1 client.BackupManagementClusterOptions) error {
+	fake.backupManagementClusterMutex.Lock()
+	ret, specificReturn := fake.backupManagementClusterReturnsOnCall[len(fake.backupManagementClusterArgsForCall)]
+	fake.backupManagementClusterArgsForCall = append(fake.backupManagementClusterArgsForCall, struct {
+		arg1 client.BackupManagementClusterOptions
+	}{arg1})
+	stub := fake.BackupManagementClusterStub
+	fakeReturns := fake.backupManagementClusterReturns
+	fake.recordInvocation("BackupManagementCluster", []interface{}{arg1})
+	fake.backupManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) BackupManagementClusterCallCount() int {
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
+	return len(fake.backupManagementClusterArgsForCall)
+}
+
+func (fake *Client) BackupManagementClusterCalls(stub func(client.BackupManagementClusterOptions) error) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = stub
+}
+
+func (fake *Client) BackupManagementClusterArgsForCall(i int) client.BackupManagementClusterOptions {
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
+	argsForCall := fake.backupManagementClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) BackupManagementClusterReturns(result1 error) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = nil
+	fake.backupManagementClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) BackupManagementClusterReturnsOnCall(i int, result1 error) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = nil
+	if fake.backupManagementClusterReturnsOnCall == nil {
+		fake.backupManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.backupManagementClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) ConfigureAndValidateManagementClusterConfiguration(arg1 *client.InitRegionOptions, arg2 bool) *client.ValidationError {
 	fake.configureAndValidateManagementClusterConfigurationMutex.Lock()
 	ret, specificReturn := fake.configureAndValidateManagementClusterConfigurationReturnsOnCall[len(fake.configureAndValidateManagementClusterConfigurationArgsForCall)]
@@ -1462,6 +1667,70 @@ func (fake *Client) DescribeProviderReturnsOnCall(i int, result1 *v1alpha3.Provi
 	}{result1, result2}
 }
 
+func (fake *Client) DiagnoseCluster(arg1 client.DiagnoseClusterOptions) (string, error) {
+	fake.diagnoseClusterMutex.Lock()
+	ret, specificReturn := fake.diagnoseClusterReturnsOnCall[len(fake.diagnoseClusterArgsForCall)]
+	fake.diagnoseClusterArgsForCall = append(fake.diagnoseClusterArgsForCall, struct {
+		arg1 client.DiagnoseClusterOptions
+	}{arg1})
+	stub := fake.DiagnoseClusterStub
+	fakeReturns := fake.diagnoseClusterReturns
+	fake.recordInvocation("DiagnoseCluster", []interface{}{arg1})
+	fake.diagnoseClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) DiagnoseClusterCallCount() int {
+	fake.diagnoseClusterMutex.RLock()
+	defer fake.diagnoseClusterMutex.RUnlock()
+	return len(fake.diagnoseClusterArgsForCall)
+}
+
+func (fake *Client) DiagnoseClusterCalls(stub func(client.DiagnoseClusterOptions) (string, error)) {
+	fake.diagnoseClusterMutex.Lock()
+	defer fake.diagnoseClusterMutex.Unlock()
+	fake.DiagnoseClusterStub = stub
+}
+
+func (fake *Client) DiagnoseClusterArgsForCall(i int) client.DiagnoseClusterOptions {
+	fake.diagnoseClusterMutex.RLock()
+	defer fake.diagnoseClusterMutex.RUnlock()
+	argsForCall := fake.diagnoseClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) DiagnoseClusterReturns(result1 string, result2 error) {
+	fake.diagnoseClusterMutex.Lock()
+	defer fake.diagnoseClusterMutex.Unlock()
+	fake.DiagnoseClusterStub = nil
+	fake.diagnoseClusterReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) DiagnoseClusterReturnsOnCall(i int, result1 string, result2 error) {
+	fake.diagnoseClusterMutex.Lock()
+	defer fake.diagnoseClusterMutex.Unlock()
+	fake.DiagnoseClusterStub = nil
+	if fake.diagnoseClusterReturnsOnCall == nil {
+		fake.diagnoseClusterReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.diagnoseClusterReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) DownloadBomFile(arg1 string) error {
 	fake.downloadBomFileMutex.Lock()
 	ret, specificReturn := fake.downloadBomFileReturnsOnCall[len(fake.downloadBomFileArgsForCall)]
@@ -1635,6 +1904,70 @@ func (fake *Client) GetCEIPParticipationReturnsOnCall(i int, result1 client.Clus
 	}{result1, result2}
 }
 
+func (fake *Client) GetClusterCertificates(arg1 client.GetClusterCertificatesOptions) ([]client.ClusterCertificate, error) {
+	fake.getClusterCertificatesMutex.Lock()
+	ret, specificReturn := fake.getClusterCertificatesReturnsOnCall[len(fake.getClusterCertificatesArgsForCall)]
+	fake.getClusterCertificatesArgsForCall = append(fake.getClusterCertificatesArgsForCall, struct {
+		arg1 client.GetClusterCertificatesOptions
+	}{arg1})
+	stub := fake.GetClusterCertificatesStub
+	fakeReturns := fake.getClusterCertificatesReturns
+	fake.recordInvocation("GetClusterCertificates", []interface{}{arg1})
+	fake.getClusterCertificatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetClusterCertificatesCallCount() int {
+	fake.getClusterCertificatesMutex.RLock()
+	defer fake.getClusterCertificatesMutex.RUnlock()
+	return len(fake.getClusterCertificatesArgsForCall)
+}
+
+func (fake *Client) GetClusterCertificatesCalls(stub func(client.GetClusterCertificatesOptions) ([]client.ClusterCertificate, error)) {
+	fake.getClusterCertificatesMutex.Lock()
+	defer fake.getClusterCertificatesMutex.Unlock()
+	fake.GetClusterCertificatesStub = stub
+}
+
+func (fake *Client) GetClusterCertificatesArgsForCall(i int) client.GetClusterCertificatesOptions {
+	fake.getClusterCertificatesMutex.RLock()
+	defer fake.getClusterCertificatesMutex.RUnlock()
+	argsForCall := fake.getClusterCertificatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetClusterCertificatesReturns(result1 []client.ClusterCertificate, result2 error) {
+	fake.getClusterCertificatesMutex.Lock()
+	defer fake.getClusterCertificatesMutex.Unlock()
+	fake.GetClusterCertificatesStub = nil
+	fake.getClusterCertificatesReturns = struct {
+		result1 []client.ClusterCertificate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetClusterCertificatesReturnsOnCall(i int, result1 []client.ClusterCertificate, result2 error) {
+	fake.getClusterCertificatesMutex.Lock()
+	defer fake.getClusterCertificatesMutex.Unlock()
+	fake.GetClusterCertificatesStub = nil
+	if fake.getClusterCertificatesReturnsOnCall == nil {
+		fake.getClusterCertificatesReturnsOnCall = make(map[int]struct {
+			result1 []client.ClusterCertificate
+			result2 error
+		})
+	}
+	fake.getClusterCertificatesReturnsOnCall[i] = struct {
+		result1 []client.ClusterCertificate
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) GetClusterConfiguration(arg1 *client.CreateClusterOptions) ([]byte, error) {
 	fake.getClusterConfigurationMutex.Lock()
 	ret, specificReturn := fake.getClusterConfigurationReturnsOnCall[len(fake.getClusterConfigurationArgsForCall)]
@@ -2003,6 +2336,62 @@ func (fake *Client) GetMachineHealthChecksReturnsOnCall(i int, result1 []client.
 	}{result1, result2}
 }
 
+func (fake *Client) GetOverlayPackLock() (*yamlprocessor.OverlayPackLock, error) {
+	fake.getOverlayPackLockMutex.Lock()
+	ret, specificReturn := fake.getOverlayPackLockReturnsOnCall[len(fake.getOverlayPackLockArgsForCall)]
+	fake.getOverlayPackLockArgsForCall = append(fake.getOverlayPackLockArgsForCall, struct {
+	}{})
+	stub := fake.GetOverlayPackLockStub
+	fakeReturns := fake.getOverlayPackLockReturns
+	fake.recordInvocation("GetOverlayPackLock", []interface{}{})
+	fake.getOverlayPackLockMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetOverlayPackLockCallCount() int {
+	fake.getOverlayPackLockMutex.RLock()
+	defer fake.getOverlayPackLockMutex.RUnlock()
+	return len(fake.getOverlayPackLockArgsForCall)
+}
+
+func (fake *Client) GetOverlayPackLockCalls(stub func() (*yamlprocessor.OverlayPackLock, error)) {
+	fake.getOverlayPackLockMutex.Lock()
+	defer fake.getOverlayPackLockMutex.Unlock()
+	fake.GetOverlayPackLockStub = stub
+}
+
+func (fake *Client) GetOverlayPackLockReturns(result1 *yamlprocessor.OverlayPackLock, result2 error) {
+	fake.getOverlayPackLockMutex.Lock()
+	defer fake.getOverlayPackLockMutex.Unlock()
+	fake.GetOverlayPackLockStub = nil
+	fake.getOverlayPackLockReturns = struct {
+		result1 *yamlprocessor.OverlayPackLock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetOverlayPackLockReturnsOnCall(i int, result1 *yamlprocessor.OverlayPackLock, result2 error) {
+	fake.getOverlayPackLockMutex.Lock()
+	defer fake.getOverlayPackLockMutex.Unlock()
+	fake.GetOverlayPackLockStub = nil
+	if fake.getOverlayPackLockReturnsOnCall == nil {
+		fake.getOverlayPackLockReturnsOnCall = make(map[int]struct {
+			result1 *yamlprocessor.OverlayPackLock
+			result2 error
+		})
+	}
+	fake.getOverlayPackLockReturnsOnCall[i] = struct {
+		result1 *yamlprocessor.OverlayPackLock
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) GetPacificClusterObject(arg1 string, arg2 string) (*v1alpha2.TanzuKubernetesCluster, error) {
 	fake.getPacificClusterObjectMutex.Lock()
 	ret, specificReturn := fake.getPacificClusterObjectReturnsOnCall[len(fake.getPacificClusterObjectArgsForCall)]
@@ -2849,6 +3238,250 @@ func (fake *Client) ParseHiddenArgsAsFeatureFlagsArgsForCall(i int) *client.Init
 	return argsForCall.arg1
 }
 
+func (fake *Client) PauseCluster(arg1 client.PauseClusterOptions) error {
+	fake.pauseClusterMutex.Lock()
+	ret, specificReturn := fake.pauseClusterReturnsOnCall[len(fake.pauseClusterArgsForCall)]
+	fake.pauseClusterArgsForCall = append(fake.pauseClusterArgsForCall, struct {
+		arg1 client.PauseClusterOptions
+	}{arg1})
+	stub := fake.PauseClusterStub
+	fakeReturns := fake.pauseClusterReturns
+	fake.recordInvocation("PauseCluster", []interface{}{arg1})
+	fake.pauseClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) PauseClusterCallCount() int {
+	fake.pauseClusterMutex.RLock()
+	defer fake.pauseClusterMutex.RUnlock()
+	return len(fake.pauseClusterArgsForCall)
+}
+
+func (fake *Client) PauseClusterCalls(stub func(client.PauseClusterOptions) error) {
+	fake.pauseClusterMutex.Lock()
+	defer fake.pauseClusterMutex.Unlock()
+	fake.PauseClusterStub = stub
+}
+
+func (fake *Client) PauseClusterArgsForCall(i int) client.PauseClusterOptions {
+	fake.pauseClusterMutex.RLock()
+	defer fake.pauseClusterMutex.RUnlock()
+	argsForCall := fake.pauseClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) PauseClusterReturns(result1 error) {
+	fake.pauseClusterMutex.Lock()
+	defer fake.pauseClusterMutex.Unlock()
+	fake.PauseClusterStub = nil
+	fake.pauseClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) PauseClusterReturnsOnCall(i int, result1 error) {
+	fake.pauseClusterMutex.Lock()
+	defer fake.pauseClusterMutex.Unlock()
+	fake.PauseClusterStub = nil
+	if fake.pauseClusterReturnsOnCall == nil {
+		fake.pauseClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RestoreManagementCluster(arg1 client.RestoreManagementClusterOptions) error {
+	fake.restoreManagementClusterMutex.Lock()
+	ret, specificReturn := fake.restoreManagementClusterReturnsOnCall[len(fake.restoreManagementClusterArgsForCall)]
+	fake.restoreManagementClusterArgsForCall = append(fake.restoreManagementClusterArgsForCall, struct {
+		arg1 client.RestoreManagementClusterOptions
+	}{arg1})
+	stub := fake.RestoreManagementClusterStub
+	fakeReturns := fake.restoreManagementClusterReturns
+	fake.recordInvocation("RestoreManagementCluster", []interface{}{arg1})
+	fake.restoreManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) RestoreManagementClusterCallCount() int {
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	return len(fake.restoreManagementClusterArgsForCall)
+}
+
+func (fake *Client) RestoreManagementClusterCalls(stub func(client.RestoreManagementClusterOptions) error) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = stub
+}
+
+func (fake *Client) RestoreManagementClusterArgsForCall(i int) client.RestoreManagementClusterOptions {
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	argsForCall := fake.restoreManagementClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) RestoreManagementClusterReturns(result1 error) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = nil
+	fake.restoreManagementClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RestoreManagementClusterReturnsOnCall(i int, result1 error) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = nil
+	if fake.restoreManagementClusterReturnsOnCall == nil {
+		fake.restoreManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.restoreManagementClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ResumeCluster(arg1 client.ResumeClusterOptions) error {
+	fake.resumeClusterMutex.Lock()
+	ret, specificReturn := fake.resumeClusterReturnsOnCall[len(fake.resumeClusterArgsForCall)]
+	fake.resumeClusterArgsForCall = append(fake.resumeClusterArgsForCall, struct {
+		arg1 client.ResumeClusterOptions
+	}{arg1})
+	stub := fake.ResumeClusterStub
+	fakeReturns := fake.resumeClusterReturns
+	fake.recordInvocation("ResumeCluster", []interface{}{arg1})
+	fake.resumeClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) ResumeClusterCallCount() int {
+	fake.resumeClusterMutex.RLock()
+	defer fake.resumeClusterMutex.RUnlock()
+	return len(fake.resumeClusterArgsForCall)
+}
+
+func (fake *Client) ResumeClusterCalls(stub func(client.ResumeClusterOptions) error) {
+	fake.resumeClusterMutex.Lock()
+	defer fake.resumeClusterMutex.Unlock()
+	fake.ResumeClusterStub = stub
+}
+
+func (fake *Client) ResumeClusterArgsForCall(i int) client.ResumeClusterOptions {
+	fake.resumeClusterMutex.RLock()
+	defer fake.resumeClusterMutex.RUnlock()
+	argsForCall := fake.resumeClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ResumeClusterReturns(result1 error) {
+	fake.resumeClusterMutex.Lock()
+	defer fake.resumeClusterMutex.Unlock()
+	fake.ResumeClusterStub = nil
+	fake.resumeClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ResumeClusterReturnsOnCall(i int, result1 error) {
+	fake.resumeClusterMutex.Lock()
+	defer fake.resumeClusterMutex.Unlock()
+	fake.ResumeClusterStub = nil
+	if fake.resumeClusterReturnsOnCall == nil {
+		fake.resumeClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RotateClusterCertificates(arg1 client.RotateClusterCertificatesOptions) error {
+	fake.rotateClusterCertificatesMutex.Lock()
+	ret, specificReturn := fake.rotateClusterCertificatesReturnsOnCall[len(fake.rotateClusterCertificatesArgsForCall)]
+	fake.rotateClusterCertificatesArgsForCall = append(fake.rotateClusterCertificatesArgsForCall, struct {
+		arg1 client.RotateClusterCertificatesOptions
+	}{arg1})
+	stub := fake.RotateClusterCertificatesStub
+	fakeReturns := fake.rotateClusterCertificatesReturns
+	fake.recordInvocation("RotateClusterCertificates", []interface{}{arg1})
+	fake.rotateClusterCertificatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) RotateClusterCertificatesCallCount() int {
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
+	return len(fake.rotateClusterCertificatesArgsForCall)
+}
+
+func (fake *Client) RotateClusterCertificatesCalls(stub func(client.RotateClusterCertificatesOptions) error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = stub
+}
+
+func (fake *Client) RotateClusterCertificatesArgsForCall(i int) client.RotateClusterCertificatesOptions {
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
+	argsForCall := fake.rotateClusterCertificatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) RotateClusterCertificatesReturns(result1 error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = nil
+	fake.rotateClusterCertificatesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RotateClusterCertificatesReturnsOnCall(i int, result1 error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = nil
+	if fake.rotateClusterCertificatesReturnsOnCall == nil {
+		fake.rotateClusterCertificatesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rotateClusterCertificatesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) SaveFeatureFlags(arg1 map[string]string) error {
 	fake.saveFeatureFlagsMutex.Lock()
 	ret, specificReturn := fake.saveFeatureFlagsReturnsOnCall[len(fake.saveFeatureFlagsArgsForCall)]
@@ -2910,6 +3543,67 @@ func (fake *Client) SaveFeatureFlagsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Client) SaveOverlayPackLock(arg1 *yamlprocessor.OverlayPackLock) error {
+	fake.saveOverlayPackLockMutex.Lock()
+	ret, specificReturn := fake.saveOverlayPackLockReturnsOnCall[len(fake.saveOverlayPackLockArgsForCall)]
+	fake.saveOverlayPackLockArgsForCall = append(fake.saveOverlayPackLockArgsForCall, struct {
+		arg1 *yamlprocessor.OverlayPackLock
+	}{arg1})
+	stub := fake.SaveOverlayPackLockStub
+	fakeReturns := fake.saveOverlayPackLockReturns
+	fake.recordInvocation("SaveOverlayPackLock", []interface{}{arg1})
+	fake.saveOverlayPackLockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) SaveOverlayPackLockCallCount() int {
+	fake.saveOverlayPackLockMutex.RLock()
+	defer fake.saveOverlayPackLockMutex.RUnlock()
+	return len(fake.saveOverlayPackLockArgsForCall)
+}
+
+func (fake *Client) SaveOverlayPackLockCalls(stub func(*yamlprocessor.OverlayPackLock) error) {
+	fake.saveOverlayPackLockMutex.Lock()
+	defer fake.saveOverlayPackLockMutex.Unlock()
+	fake.SaveOverlayPackLockStub = stub
+}
+
+func (fake *Client) SaveOverlayPackLockArgsForCall(i int) *yamlprocessor.OverlayPackLock {
+	fake.saveOverlayPackLockMutex.RLock()
+	defer fake.saveOverlayPackLockMutex.RUnlock()
+	argsForCall := fake.saveOverlayPackLockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) SaveOverlayPackLockReturns(result1 error) {
+	fake.saveOverlayPackLockMutex.Lock()
+	defer fake.saveOverlayPackLockMutex.Unlock()
+	fake.SaveOverlayPackLockStub = nil
+	fake.saveOverlayPackLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) SaveOverlayPackLockReturnsOnCall(i int, result1 error) {
+	fake.saveOverlayPackLockMutex.Lock()
+	defer fake.saveOverlayPackLockMutex.Unlock()
+	fake.SaveOverlayPackLockStub = nil
+	if fake.saveOverlayPackLockReturnsOnCall == nil {
+		fake.saveOverlayPackLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveOverlayPackLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) ScaleCluster(arg1 client.ScaleClusterOptions) error {
 	fake.scaleClusterMutex.Lock()
 	ret, specificReturn := fake.scaleClusterReturnsOnCall[len(fake.scaleClusterArgsForCall)]
@@ -3568,6 +4262,70 @@ func (fake *Client) ValidateDockerResourcePrerequisitesReturnsOnCall(i int, resu
 	}{result1}
 }
 
+func (fake *Client) ValidateManagementClusterConfiguration(arg1 *client.InitRegionOptions) (*client.ValidationReport, error) {
+	fake.validateManagementClusterConfigurationMutex.Lock()
+	ret, specificReturn := fake.validateManagementClusterConfigurationReturnsOnCall[len(fake.validateManagementClusterConfigurationArgsForCall)]
+	fake.validateManagementClusterConfigurationArgsForCall = append(fake.validateManagementClusterConfigurationArgsForCall, struct {
+		arg1 *client.InitRegionOptions
+	}{arg1})
+	stub := fake.ValidateManagementClusterConfigurationStub
+	fakeReturns := fake.validateManagementClusterConfigurationReturns
+	fake.recordInvocation("ValidateManagementClusterConfiguration", []interface{}{arg1})
+	fake.validateManagementClusterConfigurationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ValidateManagementClusterConfigurationCallCount() int {
+	fake.validateManagementClusterConfigurationMutex.RLock()
+	defer fake.validateManagementClusterConfigurationMutex.RUnlock()
+	return len(fake.validateManagementClusterConfigurationArgsForCall)
+}
+
+func (fake *Client) ValidateManagementClusterConfigurationCalls(stub func(*client.InitRegionOptions) (*client.ValidationReport, error)) {
+	fake.validateManagementClusterConfigurationMutex.Lock()
+	defer fake.validateManagementClusterConfigurationMutex.Unlock()
+	fake.ValidateManagementClusterConfigurationStub = stub
+}
+
+func (fake *Client) ValidateManagementClusterConfigurationArgsForCall(i int) *client.InitRegionOptions {
+	fake.validateManagementClusterConfigurationMutex.RLock()
+	defer fake.validateManagementClusterConfigurationMutex.RUnlock()
+	argsForCall := fake.validateManagementClusterConfigurationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ValidateManagementClusterConfigurationReturns(result1 *client.ValidationReport, result2 error) {
+	fake.validateManagementClusterConfigurationMutex.Lock()
+	defer fake.validateManagementClusterConfigurationMutex.Unlock()
+	fake.ValidateManagementClusterConfigurationStub = nil
+	fake.validateManagementClusterConfigurationReturns = struct {
+		result1 *client.ValidationReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ValidateManagementClusterConfigurationReturnsOnCall(i int, result1 *client.ValidationReport, result2 error) {
+	fake.validateManagementClusterConfigurationMutex.Lock()
+	defer fake.validateManagementClusterConfigurationMutex.Unlock()
+	fake.ValidateManagementClusterConfigurationStub = nil
+	if fake.validateManagementClusterConfigurationReturnsOnCall == nil {
+		fake.validateManagementClusterConfigurationReturnsOnCall = make(map[int]struct {
+			result1 *client.ValidationReport
+			result2 error
+		})
+	}
+	fake.validateManagementClusterConfigurationReturnsOnCall[i] = struct {
+		result1 *client.ValidationReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) ValidatePrerequisites(arg1 bool, arg2 bool) error {
 	fake.validatePrerequisitesMutex.Lock()
 	ret, specificReturn := fake.validatePrerequisitesReturnsOnCall[len(fake.validatePrerequisitesArgsForCall)]
@@ -3630,6 +4388,70 @@ func (fake *Client) ValidatePrerequisitesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Client) ValidateWorkloadClusterConfiguration(arg1 *client.CreateClusterOptions) (*client.ValidationReport, error) {
+	fake.validateWorkloadClusterConfigurationMutex.Lock()
+	ret, specificReturn := fake.validateWorkloadClusterConfigurationReturnsOnCall[len(fake.validateWorkloadClusterConfigurationArgsForCall)]
+	fake.validateWorkloadClusterConfigurationArgsForCall = append(fake.validateWorkloadClusterConfigurationArgsForCall, struct {
+		arg1 *client.CreateClusterOptions
+	}{arg1})
+	stub := fake.ValidateWorkloadClusterConfigurationStub
+	fakeReturns := fake.validateWorkloadClusterConfigurationReturns
+	fake.recordInvocation("ValidateWorkloadClusterConfiguration", []interface{}{arg1})
+	fake.validateWorkloadClusterConfigurationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ValidateWorkloadClusterConfigurationCallCount() int {
+	fake.validateWorkloadClusterConfigurationMutex.RLock()
+	defer fake.validateWorkloadClusterConfigurationMutex.RUnlock()
+	return len(fake.validateWorkloadClusterConfigurationArgsForCall)
+}
+
+func (fake *Client) ValidateWorkloadClusterConfigurationCalls(stub func(*client.CreateClusterOptions) (*client.ValidationReport, error)) {
+	fake.validateWorkloadClusterConfigurationMutex.Lock()
+	defer fake.validateWorkloadClusterConfigurationMutex.Unlock()
+	fake.ValidateWorkloadClusterConfigurationStub = stub
+}
+
+func (fake *Client) ValidateWorkloadClusterConfigurationArgsForCall(i int) *client.CreateClusterOptions {
+	fake.validateWorkloadClusterConfigurationMutex.RLock()
+	defer fake.validateWorkloadClusterConfigurationMutex.RUnlock()
+	argsForCall := fake.validateWorkloadClusterConfigurationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ValidateWorkloadClusterConfigurationReturns(result1 *client.ValidationReport, result2 error) {
+	fake.validateWorkloadClusterConfigurationMutex.Lock()
+	defer fake.validateWorkloadClusterConfigurationMutex.Unlock()
+	fake.ValidateWorkloadClusterConfigurationStub = nil
+	fake.validateWorkloadClusterConfigurationReturns = struct {
+		result1 *client.ValidationReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ValidateWorkloadClusterConfigurationReturnsOnCall(i int, result1 *client.ValidationReport, result2 error) {
+	fake.validateWorkloadClusterConfigurationMutex.Lock()
+	defer fake.validateWorkloadClusterConfigurationMutex.Unlock()
+	fake.ValidateWorkloadClusterConfigurationStub = nil
+	if fake.validateWorkloadClusterConfigurationReturnsOnCall == nil {
+		fake.validateWorkloadClusterConfigurationReturnsOnCall = make(map[int]struct {
+			result1 *client.ValidationReport
+			result2 error
+		})
+	}
+	fake.validateWorkloadClusterConfigurationReturnsOnCall[i] = struct {
+		result1 *client.ValidationReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) VerifyRegion(arg1 string) (region.RegionContext, error) {
 	fake.verifyRegionMutex.Lock()
 	ret, specificReturn := fake.verifyRegionReturnsOnCall[len(fake.verifyRegionArgsForCall)]
@@ -3694,6 +4516,68 @@ func (fake *Client) VerifyRegionReturnsOnCall(i int, result1 region.RegionContex
 	}{result1, result2}
 }
 
+func (fake *Client) WatchClusterTimeline(arg1 context.Context, arg2 client.WatchClusterTimelineOptions) error {
+	fake.watchClusterTimelineMutex.Lock()
+	ret, specificReturn := fake.watchClusterTimelineReturnsOnCall[len(fake.watchClusterTimelineArgsForCall)]
+	fake.watchClusterTimelineArgsForCall = append(fake.watchClusterTimelineArgsForCall, struct {
+		arg1 context.Context
+		arg2 client.WatchClusterTimelineOptions
+	}{arg1, arg2})
+	stub := fake.WatchClusterTimelineStub
+	fakeReturns := fake.watchClusterTimelineReturns
+	fake.recordInvocation("WatchClusterTimeline", []interface{}{arg1, arg2})
+	fake.watchClusterTimelineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) WatchClusterTimelineCallCount() int {
+	fake.watchClusterTimelineMutex.RLock()
+	defer fake.watchClusterTimelineMutex.RUnlock()
+	return len(fake.watchClusterTimelineArgsForCall)
+}
+
+func (fake *Client) WatchClusterTimelineCalls(stub func(context.Context, client.WatchClusterTimelineOptions) error) {
+	fake.watchClusterTimelineMutex.Lock()
+	defer fake.watchClusterTimelineMutex.Unlock()
+	fake.WatchClusterTimelineStub = stub
+}
+
+func (fake *Client) WatchClusterTimelineArgsForCall(i int) (context.Context, client.WatchClusterTimelineOptions) {
+	fake.watchClusterTimelineMutex.RLock()
+	defer fake.watchClusterTimelineMutex.RUnlock()
+	argsForCall := fake.watchClusterTimelineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) WatchClusterTimelineReturns(result1 error) {
+	fake.watchClusterTimelineMutex.Lock()
+	defer fake.watchClusterTimelineMutex.Unlock()
+	fake.WatchClusterTimelineStub = nil
+	fake.watchClusterTimelineReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) WatchClusterTimelineReturnsOnCall(i int, result1 error) {
+	fake.watchClusterTimelineMutex.Lock()
+	defer fake.watchClusterTimelineMutex.Unlock()
+	fake.WatchClusterTimelineStub = nil
+	if fake.watchClusterTimelineReturnsOnCall == nil {
+		fake.watchClusterTimelineReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.watchClusterTimelineReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -3701,6 +4585,8 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.activateTanzuKubernetesReleasesMutex.RUnlock()
 	fake.addRegionContextMutex.RLock()
 	defer fake.addRegionContextMutex.RUnlock()
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
 	fake.configureAndValidateManagementClusterConfigurationMutex.RLock()
 	defer fake.configureAndValidateManagementClusterConfigurationMutex.RUnlock()
 	fake.configureAndValidateTkrVersionMutex.RLock()
@@ -3725,12 +4611,16 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.describeClusterMutex.RUnlock()
 	fake.describeProviderMutex.RLock()
 	defer fake.describeProviderMutex.RUnlock()
+	fake.diagnoseClusterMutex.RLock()
+	defer fake.diagnoseClusterMutex.RUnlock()
 	fake.downloadBomFileMutex.RLock()
 	defer fake.downloadBomFileMutex.RUnlock()
 	fake.generateAWSCloudFormationTemplateMutex.RLock()
 	defer fake.generateAWSCloudFormationTemplateMutex.RUnlock()
 	fake.getCEIPParticipationMutex.RLock()
 	defer fake.getCEIPParticipationMutex.RUnlock()
+	fake.getClusterCertificatesMutex.RLock()
+	defer fake.getClusterCertificatesMutex.RUnlock()
 	fake.getClusterConfigurationMutex.RLock()
 	defer fake.getClusterConfigurationMutex.RUnlock()
 	fake.getClusterPinnipedInfoMutex.RLock()
@@ -3743,6 +4633,8 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.getMachineDeploymentsMutex.RUnlock()
 	fake.getMachineHealthChecksMutex.RLock()
 	defer fake.getMachineHealthChecksMutex.RUnlock()
+	fake.getOverlayPackLockMutex.RLock()
+	defer fake.getOverlayPackLockMutex.RUnlock()
 	fake.getPacificClusterObjectMutex.RLock()
 	defer fake.getPacificClusterObjectMutex.RUnlock()
 	fake.getPacificMachineDeploymentsMutex.RLock()
@@ -3771,8 +4663,18 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.listTKGClustersMutex.RUnlock()
 	fake.parseHiddenArgsAsFeatureFlagsMutex.RLock()
 	defer fake.parseHiddenArgsAsFeatureFlagsMutex.RUnlock()
+	fake.pauseClusterMutex.RLock()
+	defer fake.pauseClusterMutex.RUnlock()
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	fake.resumeClusterMutex.RLock()
+	defer fake.resumeClusterMutex.RUnlock()
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
 	fake.saveFeatureFlagsMutex.RLock()
 	defer fake.saveFeatureFlagsMutex.RUnlock()
+	fake.saveOverlayPackLockMutex.RLock()
+	defer fake.saveOverlayPackLockMutex.RUnlock()
 	fake.scaleClusterMutex.RLock()
 	defer fake.scaleClusterMutex.RUnlock()
 	fake.setCEIPParticipationMutex.RLock()
@@ -3795,10 +4697,16 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.upgradeManagementClusterMutex.RUnlock()
 	fake.validateDockerResourcePrerequisitesMutex.RLock()
 	defer fake.validateDockerResourcePrerequisitesMutex.RUnlock()
+	fake.validateManagementClusterConfigurationMutex.RLock()
+	defer fake.validateManagementClusterConfigurationMutex.RUnlock()
 	fake.validatePrerequisitesMutex.RLock()
 	defer fake.validatePrerequisitesMutex.RUnlock()
+	fake.validateWorkloadClusterConfigurationMutex.RLock()
+	defer fake.validateWorkloadClusterConfigurationMutex.RUnlock()
 	fake.verifyRegionMutex.RLock()
 	defer fake.verifyRegionMutex.RUnlock()
+	fake.watchClusterTimelineMutex.RLock()
+	defer fake.watchClusterTimelineMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
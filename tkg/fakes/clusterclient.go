@@ -391,6 +391,16 @@ type ClusterClient struct {
 		result1 v1a.Deployment
 		result2 error
 	}
+	GetDynamicClientStub        func() clusterclient.DynamicClient
+	getDynamicClientMutex       sync.RWMutex
+	getDynamicClientArgsForCall []struct {
+	}
+	getDynamicClientReturns struct {
+		result1 clusterclient.DynamicClient
+	}
+	getDynamicClientReturnsOnCall map[int]struct {
+		result1 clusterclient.DynamicClient
+	}
 	GetKCPObjectForClusterStub        func(string, string) (*v1beta1.KubeadmControlPlane, error)
 	getKCPObjectForClusterMutex       sync.RWMutex
 	getKCPObjectForClusterArgsForCall []struct {
@@ -542,6 +552,22 @@ type ClusterClient struct {
 		result2 string
 		result3 error
 	}
+	GetPodLogsStub        func(string, string, string, int64) ([]byte, error)
+	getPodLogsMutex       sync.RWMutex
+	getPodLogsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int64
+	}
+	getPodLogsReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getPodLogsReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	GetRegionalClusterDefaultProviderNameStub        func(v1alpha3a.ProviderType) (string, error)
 	getRegionalClusterDefaultProviderNameMutex       sync.RWMutex
 	getRegionalClusterDefaultProviderNameArgsForCall []struct {
@@ -1032,6 +1058,19 @@ type ClusterClient struct {
 	removeMatchingMetadataFromResourcesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RolloutKubeadmControlPlaneStub        func(string, string, time.Time) error
+	rolloutKubeadmControlPlaneMutex       sync.RWMutex
+	rolloutKubeadmControlPlaneArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}
+	rolloutKubeadmControlPlaneReturns struct {
+		result1 error
+	}
+	rolloutKubeadmControlPlaneReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ScalePacificClusterControlPlaneStub        func(string, string, int32) error
 	scalePacificClusterControlPlaneMutex       sync.RWMutex
 	scalePacificClusterControlPlaneArgsForCall []struct {
@@ -1328,6 +1367,19 @@ type ClusterClient struct {
 	waitForControlPlaneAvailableReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WaitForControlPlaneRolloutStub        func(string, string, time.Time) error
+	waitForControlPlaneRolloutMutex       sync.RWMutex
+	waitForControlPlaneRolloutArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}
+	waitForControlPlaneRolloutReturns struct {
+		result1 error
+	}
+	waitForControlPlaneRolloutReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WaitForDeploymentStub        func(string, string) error
 	waitForDeploymentMutex       sync.RWMutex
 	waitForDeploymentArgsForCall []struct {
@@ -3205,6 +3257,59 @@ func (fake *ClusterClient) GetDeploymentReturnsOnCall(i int, result1 v1a.Deploym
 	}{result1, result2}
 }
 
+func (fake *ClusterClient) GetDynamicClient() clusterclient.DynamicClient {
+	fake.getDynamicClientMutex.Lock()
+	ret, specificReturn := fake.getDynamicClientReturnsOnCall[len(fake.getDynamicClientArgsForCall)]
+	fake.getDynamicClientArgsForCall = append(fake.getDynamicClientArgsForCall, struct {
+	}{})
+	stub := fake.GetDynamicClientStub
+	fakeReturns := fake.getDynamicClientReturns
+	fake.recordInvocation("GetDynamicClient", []interface{}{})
+	fake.getDynamicClientMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) GetDynamicClientCallCount() int {
+	fake.getDynamicClientMutex.RLock()
+	defer fake.getDynamicClientMutex.RUnlock()
+	return len(fake.getDynamicClientArgsForCall)
+}
+
+func (fake *ClusterClient) GetDynamicClientCalls(stub func() clusterclient.DynamicClient) {
+	fake.getDynamicClientMutex.Lock()
+	defer fake.getDynamicClientMutex.Unlock()
+	fake.GetDynamicClientStub = stub
+}
+
+func (fake *ClusterClient) GetDynamicClientReturns(result1 clusterclient.DynamicClient) {
+	fake.getDynamicClientMutex.Lock()
+	defer fake.getDynamicClientMutex.Unlock()
+	fake.GetDynamicClientStub = nil
+	fake.getDynamicClientReturns = struct {
+		result1 clusterclient.DynamicClient
+	}{result1}
+}
+
+func (fake *ClusterClient) GetDynamicClientReturnsOnCall(i int, result1 clusterclient.DynamicClient) {
+	fake.getDynamicClientMutex.Lock()
+	defer fake.getDynamicClientMutex.Unlock()
+	fake.GetDynamicClientStub = nil
+	if fake.getDynamicClientReturnsOnCall == nil {
+		fake.getDynamicClientReturnsOnCall = make(map[int]struct {
+			result1 clusterclient.DynamicClient
+		})
+	}
+	fake.getDynamicClientReturnsOnCall[i] = struct {
+		result1 clusterclient.DynamicClient
+	}{result1}
+}
+
 func (fake *ClusterClient) GetKCPObjectForCluster(arg1 string, arg2 string) (*v1beta1.KubeadmControlPlane, error) {
 	fake.getKCPObjectForClusterMutex.Lock()
 	ret, specificReturn := fake.getKCPObjectForClusterReturnsOnCall[len(fake.getKCPObjectForClusterArgsForCall)]
@@ -3891,6 +3996,73 @@ func (fake *ClusterClient) GetPinnipedIssuerURLAndCAReturnsOnCall(i int, result1
 	}{result1, result2, result3}
 }
 
+func (fake *ClusterClient) GetPodLogs(arg1 string, arg2 string, arg3 string, arg4 int64) ([]byte, error) {
+	fake.getPodLogsMutex.Lock()
+	ret, specificReturn := fake.getPodLogsReturnsOnCall[len(fake.getPodLogsArgsForCall)]
+	fake.getPodLogsArgsForCall = append(fake.getPodLogsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetPodLogsStub
+	fakeReturns := fake.getPodLogsReturns
+	fake.recordInvocation("GetPodLogs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getPodLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ClusterClient) GetPodLogsCallCount() int {
+	fake.getPodLogsMutex.RLock()
+	defer fake.getPodLogsMutex.RUnlock()
+	return len(fake.getPodLogsArgsForCall)
+}
+
+func (fake *ClusterClient) GetPodLogsCalls(stub func(string, string, string, int64) ([]byte, error)) {
+	fake.getPodLogsMutex.Lock()
+	defer fake.getPodLogsMutex.Unlock()
+	fake.GetPodLogsStub = stub
+}
+
+func (fake *ClusterClient) GetPodLogsArgsForCall(i int) (string, string, string, int64) {
+	fake.getPodLogsMutex.RLock()
+	defer fake.getPodLogsMutex.RUnlock()
+	argsForCall := fake.getPodLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ClusterClient) GetPodLogsReturns(result1 []byte, result2 error) {
+	fake.getPodLogsMutex.Lock()
+	defer fake.getPodLogsMutex.Unlock()
+	fake.GetPodLogsStub = nil
+	fake.getPodLogsReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ClusterClient) GetPodLogsReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getPodLogsMutex.Lock()
+	defer fake.getPodLogsMutex.Unlock()
+	fake.GetPodLogsStub = nil
+	if fake.getPodLogsReturnsOnCall == nil {
+		fake.getPodLogsReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getPodLogsReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ClusterClient) GetRegionalClusterDefaultProviderName(arg1 v1alpha3a.ProviderType) (string, error) {
 	fake.getRegionalClusterDefaultProviderNameMutex.Lock()
 	ret, specificReturn := fake.getRegionalClusterDefaultProviderNameReturnsOnCall[len(fake.getRegionalClusterDefaultProviderNameArgsForCall)]
@@ -6218,6 +6390,69 @@ func (fake *ClusterClient) RemoveMatchingMetadataFromResourcesReturnsOnCall(i in
 	}{result1}
 }
 
+func (fake *ClusterClient) RolloutKubeadmControlPlane(arg1 string, arg2 string, arg3 time.Time) error {
+	fake.rolloutKubeadmControlPlaneMutex.Lock()
+	ret, specificReturn := fake.rolloutKubeadmControlPlaneReturnsOnCall[len(fake.rolloutKubeadmControlPlaneArgsForCall)]
+	fake.rolloutKubeadmControlPlaneArgsForCall = append(fake.rolloutKubeadmControlPlaneArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.RolloutKubeadmControlPlaneStub
+	fakeReturns := fake.rolloutKubeadmControlPlaneReturns
+	fake.recordInvocation("RolloutKubeadmControlPlane", []interface{}{arg1, arg2, arg3})
+	fake.rolloutKubeadmControlPlaneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) RolloutKubeadmControlPlaneCallCount() int {
+	fake.rolloutKubeadmControlPlaneMutex.RLock()
+	defer fake.rolloutKubeadmControlPlaneMutex.RUnlock()
+	return len(fake.rolloutKubeadmControlPlaneArgsForCall)
+}
+
+func (fake *ClusterClient) RolloutKubeadmControlPlaneCalls(stub func(string, string, time.Time) error) {
+	fake.rolloutKubeadmControlPlaneMutex.Lock()
+	defer fake.rolloutKubeadmControlPlaneMutex.Unlock()
+	fake.RolloutKubeadmControlPlaneStub = stub
+}
+
+func (fake *ClusterClient) RolloutKubeadmControlPlaneArgsForCall(i int) (string, string, time.Time) {
+	fake.rolloutKubeadmControlPlaneMutex.RLock()
+	defer fake.rolloutKubeadmControlPlaneMutex.RUnlock()
+	argsForCall := fake.rolloutKubeadmControlPlaneArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ClusterClient) RolloutKubeadmControlPlaneReturns(result1 error) {
+	fake.rolloutKubeadmControlPlaneMutex.Lock()
+	defer fake.rolloutKubeadmControlPlaneMutex.Unlock()
+	fake.RolloutKubeadmControlPlaneStub = nil
+	fake.rolloutKubeadmControlPlaneReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) RolloutKubeadmControlPlaneReturnsOnCall(i int, result1 error) {
+	fake.rolloutKubeadmControlPlaneMutex.Lock()
+	defer fake.rolloutKubeadmControlPlaneMutex.Unlock()
+	fake.RolloutKubeadmControlPlaneStub = nil
+	if fake.rolloutKubeadmControlPlaneReturnsOnCall == nil {
+		fake.rolloutKubeadmControlPlaneReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rolloutKubeadmControlPlaneReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ClusterClient) ScalePacificClusterControlPlane(arg1 string, arg2 string, arg3 int32) error {
 	fake.scalePacificClusterControlPlaneMutex.Lock()
 	ret, specificReturn := fake.scalePacificClusterControlPlaneReturnsOnCall[len(fake.scalePacificClusterControlPlaneArgsForCall)]
@@ -7659,6 +7894,69 @@ func (fake *ClusterClient) WaitForControlPlaneAvailableReturnsOnCall(i int, resu
 	}{result1}
 }
 
+func (fake *ClusterClient) WaitForControlPlaneRollout(arg1 string, arg2 string, arg3 time.Time) error {
+	fake.waitForControlPlaneRolloutMutex.Lock()
+	ret, specificReturn := fake.waitForControlPlaneRolloutReturnsOnCall[len(fake.waitForControlPlaneRolloutArgsForCall)]
+	fake.waitForControlPlaneRolloutArgsForCall = append(fake.waitForControlPlaneRolloutArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.WaitForControlPlaneRolloutStub
+	fakeReturns := fake.waitForControlPlaneRolloutReturns
+	fake.recordInvocation("WaitForControlPlaneRollout", []interface{}{arg1, arg2, arg3})
+	fake.waitForControlPlaneRolloutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ClusterClient) WaitForControlPlaneRolloutCallCount() int {
+	fake.waitForControlPlaneRolloutMutex.RLock()
+	defer fake.waitForControlPlaneRolloutMutex.RUnlock()
+	return len(fake.waitForControlPlaneRolloutArgsForCall)
+}
+
+func (fake *ClusterClient) WaitForControlPlaneRolloutCalls(stub func(string, string, time.Time) error) {
+	fake.waitForControlPlaneRolloutMutex.Lock()
+	defer fake.waitForControlPlaneRolloutMutex.Unlock()
+	fake.WaitForControlPlaneRolloutStub = stub
+}
+
+func (fake *ClusterClient) WaitForControlPlaneRolloutArgsForCall(i int) (string, string, time.Time) {
+	fake.waitForControlPlaneRolloutMutex.RLock()
+	defer fake.waitForControlPlaneRolloutMutex.RUnlock()
+	argsForCall := fake.waitForControlPlaneRolloutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ClusterClient) WaitForControlPlaneRolloutReturns(result1 error) {
+	fake.waitForControlPlaneRolloutMutex.Lock()
+	defer fake.waitForControlPlaneRolloutMutex.Unlock()
+	fake.WaitForControlPlaneRolloutStub = nil
+	fake.waitForControlPlaneRolloutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ClusterClient) WaitForControlPlaneRolloutReturnsOnCall(i int, result1 error) {
+	fake.waitForControlPlaneRolloutMutex.Lock()
+	defer fake.waitForControlPlaneRolloutMutex.Unlock()
+	fake.WaitForControlPlaneRolloutStub = nil
+	if fake.waitForControlPlaneRolloutReturnsOnCall == nil {
+		fake.waitForControlPlaneRolloutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitForControlPlaneRolloutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ClusterClient) WaitForDeployment(arg1 string, arg2 string) error {
 	fake.waitForDeploymentMutex.Lock()
 	ret, specificReturn := fake.waitForDeploymentReturnsOnCall[len(fake.waitForDeploymentArgsForCall)]
@@ -8100,6 +8398,8 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.getCurrentNamespaceMutex.RUnlock()
 	fake.getDeploymentMutex.RLock()
 	defer fake.getDeploymentMutex.RUnlock()
+	fake.getDynamicClientMutex.RLock()
+	defer fake.getDynamicClientMutex.RUnlock()
 	fake.getKCPObjectForClusterMutex.RLock()
 	defer fake.getKCPObjectForClusterMutex.RUnlock()
 	fake.getKubeConfigForClusterMutex.RLock()
@@ -8122,6 +8422,8 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.getPackageMutex.RUnlock()
 	fake.getPinnipedIssuerURLAndCAMutex.RLock()
 	defer fake.getPinnipedIssuerURLAndCAMutex.RUnlock()
+	fake.getPodLogsMutex.RLock()
+	defer fake.getPodLogsMutex.RUnlock()
 	fake.getRegionalClusterDefaultProviderNameMutex.RLock()
 	defer fake.getRegionalClusterDefaultProviderNameMutex.RUnlock()
 	fake.getResourceMutex.RLock()
@@ -8196,6 +8498,8 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.removeCEIPTelemetryJobMutex.RUnlock()
 	fake.removeMatchingMetadataFromResourcesMutex.RLock()
 	defer fake.removeMatchingMetadataFromResourcesMutex.RUnlock()
+	fake.rolloutKubeadmControlPlaneMutex.RLock()
+	defer fake.rolloutKubeadmControlPlaneMutex.RUnlock()
 	fake.scalePacificClusterControlPlaneMutex.RLock()
 	defer fake.scalePacificClusterControlPlaneMutex.RUnlock()
 	fake.scalePacificClusterWorkerNodesMutex.RLock()
@@ -8242,6 +8546,8 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.waitForClusterReadyMutex.RUnlock()
 	fake.waitForControlPlaneAvailableMutex.RLock()
 	defer fake.waitForControlPlaneAvailableMutex.RUnlock()
+	fake.waitForControlPlaneRolloutMutex.RLock()
+	defer fake.waitForControlPlaneRolloutMutex.RUnlock()
 	fake.waitForDeploymentMutex.RLock()
 	defer fake.waitForDeploymentMutex.RUnlock()
 	fake.waitForPacificClusterMutex.RLock()
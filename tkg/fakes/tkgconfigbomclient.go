@@ -6,6 +6,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-framework/tkg/registry"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigbom"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
 )
 
 type TKGConfigBomClient struct {
@@ -265,6 +266,17 @@ type TKGConfigBomClient struct {
 	isCustomRepositorySkipTLSVerifyReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	WithTKGConfigReaderWriterStub        func(tkgconfigreaderwriter.TKGConfigReaderWriter) tkgconfigbom.Client
+	withTKGConfigReaderWriterMutex       sync.RWMutex
+	withTKGConfigReaderWriterArgsForCall []struct {
+		arg1 tkgconfigreaderwriter.TKGConfigReaderWriter
+	}
+	withTKGConfigReaderWriterReturns struct {
+		result1 tkgconfigbom.Client
+	}
+	withTKGConfigReaderWriterReturnsOnCall map[int]struct {
+		result1 tkgconfigbom.Client
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1482,6 +1494,67 @@ func (fake *TKGConfigBomClient) IsCustomRepositorySkipTLSVerifyReturnsOnCall(i i
 	}{result1}
 }
 
+func (fake *TKGConfigBomClient) WithTKGConfigReaderWriter(arg1 tkgconfigreaderwriter.TKGConfigReaderWriter) tkgconfigbom.Client {
+	fake.withTKGConfigReaderWriterMutex.Lock()
+	ret, specificReturn := fake.withTKGConfigReaderWriterReturnsOnCall[len(fake.withTKGConfigReaderWriterArgsForCall)]
+	fake.withTKGConfigReaderWriterArgsForCall = append(fake.withTKGConfigReaderWriterArgsForCall, struct {
+		arg1 tkgconfigreaderwriter.TKGConfigReaderWriter
+	}{arg1})
+	stub := fake.WithTKGConfigReaderWriterStub
+	fakeReturns := fake.withTKGConfigReaderWriterReturns
+	fake.recordInvocation("WithTKGConfigReaderWriter", []interface{}{arg1})
+	fake.withTKGConfigReaderWriterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TKGConfigBomClient) WithTKGConfigReaderWriterCallCount() int {
+	fake.withTKGConfigReaderWriterMutex.RLock()
+	defer fake.withTKGConfigReaderWriterMutex.RUnlock()
+	return len(fake.withTKGConfigReaderWriterArgsForCall)
+}
+
+func (fake *TKGConfigBomClient) WithTKGConfigReaderWriterCalls(stub func(tkgconfigreaderwriter.TKGConfigReaderWriter) tkgconfigbom.Client) {
+	fake.withTKGConfigReaderWriterMutex.Lock()
+	defer fake.withTKGConfigReaderWriterMutex.Unlock()
+	fake.WithTKGConfigReaderWriterStub = stub
+}
+
+func (fake *TKGConfigBomClient) WithTKGConfigReaderWriterArgsForCall(i int) tkgconfigreaderwriter.TKGConfigReaderWriter {
+	fake.withTKGConfigReaderWriterMutex.RLock()
+	defer fake.withTKGConfigReaderWriterMutex.RUnlock()
+	argsForCall := fake.withTKGConfigReaderWriterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TKGConfigBomClient) WithTKGConfigReaderWriterReturns(result1 tkgconfigbom.Client) {
+	fake.withTKGConfigReaderWriterMutex.Lock()
+	defer fake.withTKGConfigReaderWriterMutex.Unlock()
+	fake.WithTKGConfigReaderWriterStub = nil
+	fake.withTKGConfigReaderWriterReturns = struct {
+		result1 tkgconfigbom.Client
+	}{result1}
+}
+
+func (fake *TKGConfigBomClient) WithTKGConfigReaderWriterReturnsOnCall(i int, result1 tkgconfigbom.Client) {
+	fake.withTKGConfigReaderWriterMutex.Lock()
+	defer fake.withTKGConfigReaderWriterMutex.Unlock()
+	fake.WithTKGConfigReaderWriterStub = nil
+	if fake.withTKGConfigReaderWriterReturnsOnCall == nil {
+		fake.withTKGConfigReaderWriterReturnsOnCall = make(map[int]struct {
+			result1 tkgconfigbom.Client
+		})
+	}
+	fake.withTKGConfigReaderWriterReturnsOnCall[i] = struct {
+		result1 tkgconfigbom.Client
+	}{result1}
+}
+
 func (fake *TKGConfigBomClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1527,6 +1600,8 @@ func (fake *TKGConfigBomClient) Invocations() map[string][][]interface{} {
 	defer fake.initBOMRegistryMutex.RUnlock()
 	fake.isCustomRepositorySkipTLSVerifyMutex.RLock()
 	defer fake.isCustomRepositorySkipTLSVerifyMutex.RUnlock()
+	fake.withTKGConfigReaderWriterMutex.RLock()
+	defer fake.withTKGConfigReaderWriterMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -6,6 +6,7 @@ import (
 
 	yaml "gopkg.in/yaml.v3"
 
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigupdater"
 )
 
@@ -193,6 +194,17 @@ type TKGConfigUpdaterClient struct {
 	setDefaultConfigurationMutex       sync.RWMutex
 	setDefaultConfigurationArgsForCall []struct {
 	}
+	WithTKGConfigReaderWriterStub        func(tkgconfigreaderwriter.TKGConfigReaderWriter) tkgconfigupdater.Client
+	withTKGConfigReaderWriterMutex       sync.RWMutex
+	withTKGConfigReaderWriterArgsForCall []struct {
+		arg1 tkgconfigreaderwriter.TKGConfigReaderWriter
+	}
+	withTKGConfigReaderWriterReturns struct {
+		result1 tkgconfigupdater.Client
+	}
+	withTKGConfigReaderWriterReturnsOnCall map[int]struct {
+		result1 tkgconfigupdater.Client
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1122,6 +1134,67 @@ func (fake *TKGConfigUpdaterClient) SetDefaultConfigurationCalls(stub func()) {
 	fake.SetDefaultConfigurationStub = stub
 }
 
+func (fake *TKGConfigUpdaterClient) WithTKGConfigReaderWriter(arg1 tkgconfigreaderwriter.TKGConfigReaderWriter) tkgconfigupdater.Client {
+	fake.withTKGConfigReaderWriterMutex.Lock()
+	ret, specificReturn := fake.withTKGConfigReaderWriterReturnsOnCall[len(fake.withTKGConfigReaderWriterArgsForCall)]
+	fake.withTKGConfigReaderWriterArgsForCall = append(fake.withTKGConfigReaderWriterArgsForCall, struct {
+		arg1 tkgconfigreaderwriter.TKGConfigReaderWriter
+	}{arg1})
+	stub := fake.WithTKGConfigReaderWriterStub
+	fakeReturns := fake.withTKGConfigReaderWriterReturns
+	fake.recordInvocation("WithTKGConfigReaderWriter", []interface{}{arg1})
+	fake.withTKGConfigReaderWriterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TKGConfigUpdaterClient) WithTKGConfigReaderWriterCallCount() int {
+	fake.withTKGConfigReaderWriterMutex.RLock()
+	defer fake.withTKGConfigReaderWriterMutex.RUnlock()
+	return len(fake.withTKGConfigReaderWriterArgsForCall)
+}
+
+func (fake *TKGConfigUpdaterClient) WithTKGConfigReaderWriterCalls(stub func(tkgconfigreaderwriter.TKGConfigReaderWriter) tkgconfigupdater.Client) {
+	fake.withTKGConfigReaderWriterMutex.Lock()
+	defer fake.withTKGConfigReaderWriterMutex.Unlock()
+	fake.WithTKGConfigReaderWriterStub = stub
+}
+
+func (fake *TKGConfigUpdaterClient) WithTKGConfigReaderWriterArgsForCall(i int) tkgconfigreaderwriter.TKGConfigReaderWriter {
+	fake.withTKGConfigReaderWriterMutex.RLock()
+	defer fake.withTKGConfigReaderWriterMutex.RUnlock()
+	argsForCall := fake.withTKGConfigReaderWriterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TKGConfigUpdaterClient) WithTKGConfigReaderWriterReturns(result1 tkgconfigupdater.Client) {
+	fake.withTKGConfigReaderWriterMutex.Lock()
+	defer fake.withTKGConfigReaderWriterMutex.Unlock()
+	fake.WithTKGConfigReaderWriterStub = nil
+	fake.withTKGConfigReaderWriterReturns = struct {
+		result1 tkgconfigupdater.Client
+	}{result1}
+}
+
+func (fake *TKGConfigUpdaterClient) WithTKGConfigReaderWriterReturnsOnCall(i int, result1 tkgconfigupdater.Client) {
+	fake.withTKGConfigReaderWriterMutex.Lock()
+	defer fake.withTKGConfigReaderWriterMutex.Unlock()
+	fake.WithTKGConfigReaderWriterStub = nil
+	if fake.withTKGConfigReaderWriterReturnsOnCall == nil {
+		fake.withTKGConfigReaderWriterReturnsOnCall = make(map[int]struct {
+			result1 tkgconfigupdater.Client
+		})
+	}
+	fake.withTKGConfigReaderWriterReturnsOnCall[i] = struct {
+		result1 tkgconfigupdater.Client
+	}{result1}
+}
+
 func (fake *TKGConfigUpdaterClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1159,6 +1232,8 @@ func (fake *TKGConfigUpdaterClient) Invocations() map[string][][]interface{} {
 	defer fake.getProvidersChecksumMutex.RUnlock()
 	fake.setDefaultConfigurationMutex.RLock()
 	defer fake.setDefaultConfigurationMutex.RUnlock()
+	fake.withTKGConfigReaderWriterMutex.RLock()
+	defer fake.withTKGConfigReaderWriterMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/yaml.v2"
 	kindv1 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
 	"sigs.k8s.io/kind/pkg/errors"
 
 	"github.com/vmware-tanzu/tanzu-framework/tkg/cli/clientconfighelpers"
@@ -30,8 +31,14 @@ const (
 	kindClusterNamePrefix       = "tkg-kind-"
 	kindClusterWaitForReadyTime = 2 * time.Minute
 	kindRegistryCAPath          = "/etc/containerd/tkg-registry-ca.crt"
+	// kindNodeImageMarkerPath records the node image of a persistent bootstrap cluster on its nodes
+	kindNodeImageMarkerPath = "/kind/tkg-node-image"
+	kindAdminKubeconfigPath = "/etc/kubernetes/admin.conf"
 )
 
+// PersistentKindClusterName is the name of the bootstrap cluster reused across operations
+const PersistentKindClusterName = kindClusterNamePrefix + "persistent"
+
 var (
 	dockerMount = kindv1.Mount{
 		HostPath:      "/var/run/docker.sock",
@@ -83,6 +90,8 @@ type KindClusterProvider interface {
 	Create(name string, options ...cluster.CreateOption) error
 	Delete(name, explicitKubeconfigPath string) error
 	KubeConfig(name string, internal bool) (string, error)
+	List() ([]string, error)
+	ListNodes(name string) ([]nodes.Node, error)
 }
 
 // KindClusterOptions carries options to configure kind cluster
@@ -94,6 +103,10 @@ type KindClusterOptions struct {
 	TKGConfigDir     string
 	Readerwriter     tkgconfigreaderwriter.TKGConfigReaderWriter
 	DefaultImageRepo string
+	// Persistent reuses the healthy bootstrap cluster left by a previous operation instead of creating a new one
+	Persistent bool
+	// ImageCache is an image archive, or a directory of image archives, from which the BoM images are preloaded
+	ImageCache string
 }
 
 // KindClusterProxy return the Proxy used for operating kubernetes-in-docker clusters
@@ -119,7 +132,11 @@ func New(options *KindClusterOptions) Client {
 // CreateKindCluster creates new kind cluster
 func (k *KindClusterProxy) CreateKindCluster() (string, error) {
 	if k.options.ClusterName == "" {
-		k.options.ClusterName = kindClusterNamePrefix + xid.New().String()
+		if k.options.Persistent {
+			k.options.ClusterName = PersistentKindClusterName
+		} else {
+			k.options.ClusterName = kindClusterNamePrefix + xid.New().String()
+		}
 	}
 
 	log.V(3).Infof("Fetching configuration for kind node image...")
@@ -130,6 +147,17 @@ func (k *KindClusterProxy) CreateKindCluster() (string, error) {
 		return "", errors.Wrap(err, "unable to get kind node image and configuration from BoM file")
 	}
 
+	if k.options.Persistent {
+		reused, err := k.reusePersistentKindCluster()
+		if err != nil {
+			return "", err
+		}
+		if reused {
+			k.preloadImagesOrWarn()
+			return k.options.ClusterName, nil
+		}
+	}
+
 	log.V(3).Infof("Creating kind cluster: %s", k.options.ClusterName)
 
 	// setup proxy envvars for kind clusrer if being configured in TKG
@@ -155,6 +183,13 @@ func (k *KindClusterProxy) CreateKindCluster() (string, error) {
 		_ = k.DeleteKindCluster()
 		return "", errors.Wrap(err, "unable to retrieve kubeconfig for created kind cluster")
 	}
+
+	if k.options.Persistent {
+		if err := k.writeNodeImageMarker(); err != nil {
+			log.Warningf("Unable to record the node image of kind cluster %s, it will not be reused: %v", k.options.ClusterName, err)
+		}
+	}
+	k.preloadImagesOrWarn()
 	return k.options.ClusterName, nil
 }
 
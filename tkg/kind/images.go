@@ -0,0 +1,192 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kind
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigbom"
+)
+
+// imageArchiveManifestFile is the manifest of the archives created with 'docker save'
+const imageArchiveManifestFile = "manifest.json"
+
+func (k *KindClusterProxy) preloadImagesOrWarn() {
+	if k.options.ImageCache == "" {
+		return
+	}
+	if err := k.preloadImages(); err != nil {
+		log.Warningf("Unable to preload images into kind cluster %s, they will be pulled: %v", k.options.ClusterName, err)
+	}
+}
+
+// preloadImages loads the BoM images found in the image cache into the nodes of the kind cluster.
+// Image archives whose BoM images are all present on a node are not loaded again.
+func (k *KindClusterProxy) preloadImages() error {
+	bomConfiguration, err := tkgconfigbom.New(k.options.TKGConfigDir, k.options.Readerwriter).GetDefaultTkgBOMConfiguration()
+	if err != nil {
+		return errors.Wrap(err, "unable to get default BoM file")
+	}
+	images := bomImages(bomConfiguration)
+
+	archives, err := imageArchives(k.options.ImageCache)
+	if err != nil {
+		return err
+	}
+	clusterNodes, err := k.options.Provider.ListNodes(k.options.ClusterName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to list nodes of kind cluster %s", k.options.ClusterName)
+	}
+	nodeImages := make([]map[string]bool, len(clusterNodes))
+	for i, node := range clusterNodes {
+		if nodeImages[i], err = imagesOnNode(node); err != nil {
+			return err
+		}
+	}
+
+	found := map[string]bool{}
+	loaded := 0
+	for _, archive := range archives {
+		tags, err := imageArchiveTags(archive)
+		if err != nil {
+			log.V(3).Infof("Skipping image archive %s: %v", archive, err)
+			continue
+		}
+		var selected []string
+		for _, tag := range tags {
+			if images[tag] {
+				selected = append(selected, tag)
+				found[tag] = true
+			}
+		}
+		for i, node := range clusterNodes {
+			if !missingImages(nodeImages[i], selected) {
+				continue
+			}
+			log.V(3).Infof("Loading image archive %s into node %s", archive, node.String())
+			if err := loadImageArchive(node, archive); err != nil {
+				return err
+			}
+			loaded++
+		}
+	}
+
+	log.Infof("Preloaded %d image archives from %s into kind cluster %s", loaded, k.options.ImageCache, k.options.ClusterName)
+	if len(found) != len(images) {
+		log.V(3).Infof("%d images of the BoM are not in the image cache and will be pulled", len(images)-len(found))
+	}
+	return nil
+}
+
+// bomImages returns the references of all the images of the BoM
+func bomImages(bomConfiguration *tkgconfigbom.BOMConfiguration) map[string]bool {
+	images := map[string]bool{}
+	for _, components := range bomConfiguration.Components {
+		for _, component := range components {
+			for _, image := range component.Images {
+				images[tkgconfigbom.GetFullImagePath(image, bomConfiguration.ImageConfig.ImageRepository)+":"+image.Tag] = true
+			}
+		}
+	}
+	return images
+}
+
+// imageArchives returns the image cache if it is a file, or the image archives of the image cache directory
+func imageArchives(imageCache string) ([]string, error) {
+	info, err := os.Stat(imageCache)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read image cache %s", imageCache)
+	}
+	if !info.IsDir() {
+		return []string{imageCache}, nil
+	}
+	archives, err := filepath.Glob(filepath.Join(imageCache, "*.tar"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(archives)
+	return archives, nil
+}
+
+// imageArchiveTags returns the image references of an archive created with 'docker save'
+func imageArchiveTags(archive string) ([]string, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := tar.NewReader(f)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("%s not found", imageArchiveManifestFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Name != imageArchiveManifestFile {
+			continue
+		}
+		var manifest []struct {
+			RepoTags []string `json:"RepoTags"`
+		}
+		if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse %s", imageArchiveManifestFile)
+		}
+		var tags []string
+		for _, image := range manifest {
+			tags = append(tags, image.RepoTags...)
+		}
+		return tags, nil
+	}
+}
+
+// imagesOnNode returns the images already present in the containerd image store of the node
+func imagesOnNode(node nodes.Node) (map[string]bool, error) {
+	var out bytes.Buffer
+	if err := node.Command("ctr", "--namespace=k8s.io", "images", "list", "-q").SetStdout(&out).Run(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list images of node %s", node.String())
+	}
+	images := map[string]bool{}
+	for _, image := range strings.Split(out.String(), "\n") {
+		if image = strings.TrimSpace(image); image != "" {
+			images[image] = true
+		}
+	}
+	return images, nil
+}
+
+func missingImages(present map[string]bool, images []string) bool {
+	for _, image := range images {
+		if !present[image] {
+			return true
+		}
+	}
+	return false
+}
+
+func loadImageArchive(node nodes.Node, archive string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := nodeutils.LoadImageArchive(node, f); err != nil {
+		return errors.Wrapf(err, "unable to load image archive %s into node %s", archive, node.String())
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kind
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// reusePersistentKindCluster reuses the persistent kind cluster if it exists and is healthy,
+// an unhealthy persistent kind cluster is deleted so that a new one can be created
+func (k *KindClusterProxy) reusePersistentKindCluster() (bool, error) {
+	clusters, err := k.options.Provider.List()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to list kind clusters")
+	}
+	exists := false
+	for _, name := range clusters {
+		if name == k.options.ClusterName {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		return false, nil
+	}
+
+	if err := k.checkPersistentKindCluster(); err != nil {
+		log.Infof("Recreating bootstrap cluster %s: %v", k.options.ClusterName, err)
+		if err := k.DeleteKindCluster(); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	// a failed operation leaves its clusters on the bootstrap cluster to be resumed, they must not be mixed up
+	clusters, err = k.clustersOfPersistentKindCluster()
+	if err != nil {
+		return false, err
+	}
+	if len(clusters) != 0 {
+		return false, errors.Errorf("bootstrap cluster %s still has the clusters %s of a previous operation, resume the operation or delete the kind cluster",
+			k.options.ClusterName, strings.Join(clusters, ", "))
+	}
+
+	kubeconfig, err := k.options.Provider.KubeConfig(k.options.ClusterName, false)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to retrieve kubeconfig for kind cluster %s", k.options.ClusterName)
+	}
+	if k.options.KubeConfigPath != "" {
+		if err := os.WriteFile(k.options.KubeConfigPath, []byte(kubeconfig), constants.ConfigFilePermissions); err != nil {
+			return false, errors.Wrapf(err, "unable to write kubeconfig for kind cluster %s", k.options.ClusterName)
+		}
+	}
+	log.Infof("Reusing bootstrap cluster %s", k.options.ClusterName)
+	return true, nil
+}
+
+// checkPersistentKindCluster verifies that the persistent kind cluster runs the node image of the BoM
+// and that its API server is ready
+func (k *KindClusterProxy) checkPersistentKindCluster() error {
+	controlPlane, err := k.controlPlaneNode()
+	if err != nil {
+		return err
+	}
+
+	var marker bytes.Buffer
+	if err := controlPlane.Command("cat", kindNodeImageMarkerPath).SetStdout(&marker).Run(); err != nil {
+		return errors.New("node image is unknown")
+	}
+	if nodeImage := strings.TrimSpace(marker.String()); nodeImage != k.options.NodeImage {
+		return errors.Errorf("node image %s does not match %s", nodeImage, k.options.NodeImage)
+	}
+
+	if err := controlPlane.Command("kubectl", "--kubeconfig="+kindAdminKubeconfigPath, "get", "--raw=/readyz").Run(); err != nil {
+		return errors.New("API server is not ready")
+	}
+	return nil
+}
+
+// clustersOfPersistentKindCluster returns the Cluster API clusters of the persistent kind cluster
+func (k *KindClusterProxy) clustersOfPersistentKindCluster() ([]string, error) {
+	controlPlane, err := k.controlPlaneNode()
+	if err != nil {
+		return nil, err
+	}
+	// there are no clusters when Cluster API is not installed
+	var crd bytes.Buffer
+	if err := controlPlane.Command("kubectl", "--kubeconfig="+kindAdminKubeconfigPath, "get", "customresourcedefinitions",
+		"clusters.cluster.x-k8s.io", "--ignore-not-found", "-o", "name").SetStdout(&crd).Run(); err != nil {
+		return nil, errors.Wrapf(err, "unable to check whether Cluster API is installed on kind cluster %s", k.options.ClusterName)
+	}
+	if strings.TrimSpace(crd.String()) == "" {
+		return nil, nil
+	}
+
+	var out bytes.Buffer
+	if err := controlPlane.Command("kubectl", "--kubeconfig="+kindAdminKubeconfigPath, "get", "clusters.cluster.x-k8s.io",
+		"--all-namespaces", "-o", "name").SetStdout(&out).Run(); err != nil {
+		return nil, errors.Wrapf(err, "unable to list the clusters of kind cluster %s", k.options.ClusterName)
+	}
+	return strings.Fields(out.String()), nil
+}
+
+// writeNodeImageMarker records the node image of the persistent kind cluster,
+// so that it is recreated when the BoM moves to another node image
+func (k *KindClusterProxy) writeNodeImageMarker() error {
+	controlPlane, err := k.controlPlaneNode()
+	if err != nil {
+		return err
+	}
+	return nodeutils.WriteFile(controlPlane, kindNodeImageMarkerPath, k.options.NodeImage)
+}
+
+func (k *KindClusterProxy) controlPlaneNode() (nodes.Node, error) {
+	clusterNodes, err := k.options.Provider.ListNodes(k.options.ClusterName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list nodes of kind cluster %s", k.options.ClusterName)
+	}
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(clusterNodes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to find control plane node of kind cluster %s", k.options.ClusterName)
+	}
+	return controlPlane, nil
+}
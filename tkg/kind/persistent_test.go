@@ -0,0 +1,261 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kind_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/exec"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/kind"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+)
+
+const (
+	persistentClusterName = "tkg-kind-persistent"
+	bomImage              = "projects-stg.registry.vmware.com/tkg/cluster-api/cluster-api-controller:v0.3.11-13-ga74685ee9_vmware.1"
+	containerdConfig      = `[plugins."io.containerd.grpc.v1.cri".containerd]
+  snapshotter = "overlayfs"
+`
+)
+
+// fakeNode is a kind control plane node running the commands with canned outputs
+type fakeNode struct {
+	// outputs are the outputs of the commands, by command prefix
+	outputs map[string]string
+	// failures are the prefixes of the failing commands
+	failures []string
+	// commands are the commands run on the node, with their stdin if any
+	commands []string
+}
+
+func (n *fakeNode) Command(name string, args ...string) exec.Cmd {
+	return &fakeCmd{node: n, command: strings.Join(append([]string{name}, args...), " ")}
+}
+
+func (n *fakeNode) CommandContext(_ context.Context, name string, args ...string) exec.Cmd {
+	return n.Command(name, args...)
+}
+
+func (n *fakeNode) String() string                     { return persistentClusterName + "-control-plane" }
+func (n *fakeNode) Role() (string, error)              { return "control-plane", nil }
+func (n *fakeNode) IP() (ipv4, ipv6 string, err error) { return "", "", nil }
+func (n *fakeNode) SerialLogs(writer io.Writer) error  { return nil }
+
+func (n *fakeNode) ran(prefix string) []string {
+	var commands []string
+	for _, command := range n.commands {
+		if strings.HasPrefix(command, prefix) {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+type fakeCmd struct {
+	node    *fakeNode
+	command string
+	stdin   io.Reader
+	stdout  io.Writer
+}
+
+func (c *fakeCmd) Run() error {
+	command := c.command
+	if c.stdin != nil {
+		stdin, _ := io.ReadAll(c.stdin)
+		command += " < " + string(stdin)
+	}
+	c.node.commands = append(c.node.commands, command)
+	for _, prefix := range c.node.failures {
+		if strings.HasPrefix(c.command, prefix) {
+			return errors.New("fake-error")
+		}
+	}
+	for prefix, output := range c.node.outputs {
+		if strings.HasPrefix(c.command, prefix) && c.stdout != nil {
+			_, _ = c.stdout.Write([]byte(output))
+		}
+	}
+	return nil
+}
+
+func (c *fakeCmd) SetEnv(...string) exec.Cmd           { return c }
+func (c *fakeCmd) SetStdin(stdin io.Reader) exec.Cmd   { c.stdin = stdin; return c }
+func (c *fakeCmd) SetStdout(stdout io.Writer) exec.Cmd { c.stdout = stdout; return c }
+func (c *fakeCmd) SetStderr(io.Writer) exec.Cmd        { return c }
+
+// writeImageArchive writes an image archive as created by 'docker save' with the given image references
+func writeImageArchive(path string, tags ...string) {
+	manifest, err := json.Marshal([]map[string]interface{}{{"Config": "config.json", "RepoTags": tags}})
+	Expect(err).NotTo(HaveOccurred())
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	Expect(writer.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0o644, Size: int64(len(manifest))})).To(Succeed())
+	_, err = writer.Write(manifest)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(writer.Close()).To(Succeed())
+	Expect(os.WriteFile(path, buf.Bytes(), 0o600)).To(Succeed())
+}
+
+var _ = Describe("Persistent bootstrap kind cluster", func() {
+	var (
+		node           *fakeNode
+		options        *kind.KindClusterOptions
+		kubeconfigPath string
+		nodeImage      string
+	)
+
+	BeforeEach(func() {
+		setupTestingFiles(configPath, testingDir, defaultBoMFileForTesting)
+		tkgConfigReaderWriter, err := tkgconfigreaderwriter.NewReaderWriterFromConfigFile(configPath, filepath.Join(testingDir, "config.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+
+		kubeconfigPath = filepath.Join(testingDir, "bootstrap-kubeconfig")
+		kindProvider = &fakes.KindProvider{}
+		kindProvider.KubeConfigReturns("fake-kube-config", nil)
+		node = &fakeNode{outputs: map[string]string{"containerd config dump": containerdConfig}}
+		kindProvider.ListNodesReturns([]nodes.Node{node}, nil)
+		options = &kind.KindClusterOptions{
+			Provider:       kindProvider,
+			KubeConfigPath: kubeconfigPath,
+			TKGConfigDir:   testingDir,
+			Readerwriter:   tkgConfigReaderWriter,
+			Persistent:     true,
+		}
+		nodeImage, _, err = kind.New(options).GetKindNodeImageAndConfig()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(kubeconfigPath)
+	})
+
+	JustBeforeEach(func() {
+		kindClient = kind.New(options)
+		clusterName, err = kindClient.CreateKindCluster()
+	})
+
+	Context("When the persistent kind cluster does not exist", func() {
+		It("creates it and records its node image", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterName).To(Equal(persistentClusterName))
+			Expect(kindProvider.CreateCallCount()).To(Equal(1))
+			Expect(node.ran("cp /dev/stdin /kind/tkg-node-image")).To(ConsistOf("cp /dev/stdin /kind/tkg-node-image < " + nodeImage))
+		})
+	})
+
+	Context("When the persistent kind cluster exists and is healthy", func() {
+		BeforeEach(func() {
+			kindProvider.ListReturns([]string{persistentClusterName}, nil)
+			node.outputs["cat /kind/tkg-node-image"] = nodeImage + "\n"
+		})
+		It("reuses it", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterName).To(Equal(persistentClusterName))
+			Expect(kindProvider.CreateCallCount()).To(Equal(0))
+			Expect(kindProvider.DeleteCallCount()).To(Equal(0))
+			kubeconfig, err := os.ReadFile(kubeconfigPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(kubeconfig)).To(Equal("fake-kube-config"))
+		})
+	})
+
+	Context("When the persistent kind cluster runs another node image", func() {
+		BeforeEach(func() {
+			kindProvider.ListReturns([]string{persistentClusterName}, nil)
+			node.outputs["cat /kind/tkg-node-image"] = "kindest/node:v1.0.0\n"
+		})
+		It("recreates it", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kindProvider.DeleteCallCount()).To(Equal(1))
+			Expect(kindProvider.CreateCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("When the API server of the persistent kind cluster is not ready", func() {
+		BeforeEach(func() {
+			kindProvider.ListReturns([]string{persistentClusterName}, nil)
+			node.outputs["cat /kind/tkg-node-image"] = nodeImage
+			node.failures = []string{"kubectl --kubeconfig=/etc/kubernetes/admin.conf get --raw=/readyz"}
+		})
+		It("recreates it", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kindProvider.DeleteCallCount()).To(Equal(1))
+			Expect(kindProvider.CreateCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("When the persistent kind cluster has the clusters of a previous operation", func() {
+		BeforeEach(func() {
+			kindProvider.ListReturns([]string{persistentClusterName}, nil)
+			node.outputs["cat /kind/tkg-node-image"] = nodeImage
+			node.outputs["kubectl --kubeconfig=/etc/kubernetes/admin.conf get customresourcedefinitions clusters.cluster.x-k8s.io"] = "customresourcedefinition.apiextensions.k8s.io/clusters.cluster.x-k8s.io\n"
+			node.outputs["kubectl --kubeconfig=/etc/kubernetes/admin.conf get clusters.cluster.x-k8s.io"] = "cluster.cluster.x-k8s.io/mc1\n"
+		})
+		It("returns an error without deleting it", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("still has the clusters cluster.cluster.x-k8s.io/mc1 of a previous operation"))
+			Expect(kindProvider.DeleteCallCount()).To(Equal(0))
+			Expect(kindProvider.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("When the clusters of the persistent kind cluster cannot be listed", func() {
+		BeforeEach(func() {
+			kindProvider.ListReturns([]string{persistentClusterName}, nil)
+			node.outputs["cat /kind/tkg-node-image"] = nodeImage
+			node.outputs["kubectl --kubeconfig=/etc/kubernetes/admin.conf get customresourcedefinitions clusters.cluster.x-k8s.io"] = "customresourcedefinition.apiextensions.k8s.io/clusters.cluster.x-k8s.io\n"
+			node.failures = []string{"kubectl --kubeconfig=/etc/kubernetes/admin.conf get clusters.cluster.x-k8s.io"}
+		})
+		It("returns an error without reusing it", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to list the clusters of kind cluster " + persistentClusterName))
+			Expect(kindProvider.DeleteCallCount()).To(Equal(0))
+			Expect(kindProvider.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("When an image cache is configured", func() {
+		var imageCache string
+
+		BeforeEach(func() {
+			imageCache = filepath.Join(testingDir, "image-cache")
+			Expect(os.MkdirAll(imageCache, 0o700)).To(Succeed())
+			writeImageArchive(filepath.Join(imageCache, "bom.tar"), bomImage)
+			writeImageArchive(filepath.Join(imageCache, "other.tar"), "docker.io/library/busybox:latest")
+			options.ImageCache = imageCache
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(imageCache)
+		})
+
+		It("loads the image archives of the BoM images into the nodes", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(node.ran("ctr --namespace=k8s.io images import")).To(HaveLen(1))
+		})
+
+		Context("When the BoM images are already present on the nodes", func() {
+			BeforeEach(func() {
+				node.outputs["ctr --namespace=k8s.io images list -q"] = bomImage + "\n"
+			})
+			It("does not load them again", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(node.ran("ctr --namespace=k8s.io images import")).To(BeEmpty())
+			})
+		})
+	})
+})
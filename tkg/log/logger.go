@@ -160,7 +160,7 @@ func (l *logger) Print(msg string, err error, logType string, kvs ...interface{}
 		values = append(values, "error", err)
 	}
 	header := []byte(l.header(logType, l.callDepth))
-	_, _ = logWriter.Write(header, []byte(l.getLogString(values)), l.Enabled(), l.level, logType)
+	_, _ = logWriter.WriteWithValues(header, []byte(l.getLogString(values)), structuredValues(values), l.Enabled(), l.level, logType)
 }
 
 func (l *logger) getLogString(values []interface{}) string {
@@ -177,6 +177,23 @@ func (l *logger) getLogString(values []interface{}) string {
 	return f
 }
 
+// structuredValues returns the key/value pairs of a log line, other than
+// the message and error which are already part of the text
+func structuredValues(values []interface{}) map[string]interface{} {
+	var res map[string]interface{}
+	for i := 0; i+1 < len(values); i += 2 {
+		k, ok := values[i].(string)
+		if !ok || k == "msg" || k == "error" {
+			continue
+		}
+		if res == nil {
+			res = make(map[string]interface{}, len(values)/2)
+		}
+		res[k] = values[i+1]
+	}
+	return res
+}
+
 func copySlice(in []interface{}) []interface{} {
 	out := make([]interface{}, len(in))
 	copy(out, in)
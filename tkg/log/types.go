@@ -3,11 +3,15 @@
 
 package log
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 const (
 	msgTypeLog      = "log"
 	msgTypeProgress = "progress"
+	msgTypeResult   = "result"
 )
 
 const (
@@ -18,6 +22,12 @@ const (
 	logTypeUNKNOWN = "UNKNOWN"
 )
 
+// progress statuses which mark the end of an operation
+const (
+	progressStatusFailed     = "failed"
+	progressStatusSuccessful = "successful"
+)
+
 // Update is a structure to be used for sending websocket message
 type logUpdate struct {
 	Type string  `json:"type"`
@@ -26,22 +36,35 @@ type logUpdate struct {
 
 // Data is a structure to be used for describing message data
 type logData struct {
-	Message string `json:"message,omitempty"`
-	LogType string `json:"logType,omitempty"`
+	Message   string                 `json:"message,omitempty"`
+	LogType   string                 `json:"logType,omitempty"`
+	Timestamp string                 `json:"timestamp,omitempty"`
+	Phase     string                 `json:"phase,omitempty"`
+	Values    map[string]interface{} `json:"values,omitempty"`
 
 	Status       string   `json:"status,omitempty"`
 	CurrentPhase string   `json:"currentPhase,omitempty"`
 	TotalPhases  []string `json:"totalPhases,omitempty"`
+
+	FailedPhase string `json:"failedPhase,omitempty"`
 }
 
-func convertLogMsgToJSONBytes(logMsg []byte) []byte {
+func convertLogMsgToJSONBytes(logMsg []byte, phase string, values map[string]interface{}) []byte {
 	data := logData{
-		Message: string(logMsg),
-		LogType: getLogType(logMsg),
+		Message:   string(logMsg),
+		LogType:   getLogType(logMsg),
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Phase:     phase,
+		Values:    values,
 	}
 	update := logUpdate{Type: msgTypeLog, Data: data}
 
 	updateBytes, err := json.Marshal(update)
+	if err != nil && values != nil {
+		// values are best effort, the message itself should still be delivered
+		update.Data.Values = nil
+		updateBytes, err = json.Marshal(update)
+	}
 	if err != nil {
 		ForceWriteToStdErr([]byte("unable unmarshal log message"))
 		return []byte{}
@@ -60,6 +83,17 @@ func convertProgressMsgToJSONBytes(data *logData) []byte {
 	return updateBytes
 }
 
+func convertResultMsgToJSONBytes(data *logData) []byte {
+	update := logUpdate{Type: msgTypeResult, Data: *data}
+
+	updateBytes, err := json.Marshal(update)
+	if err != nil {
+		ForceWriteToStdErr([]byte("unable unmarshal result message"))
+		return []byte{}
+	}
+	return updateBytes
+}
+
 func getLogType(logMsg []byte) string {
 	logType := logTypeUNKNOWN
 	if len(msgTypeLog) > 0 {
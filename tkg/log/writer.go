@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path"
+	"time"
 )
 
 // Writer defines methods to write and configure tkg writer
@@ -21,6 +22,10 @@ type Writer interface {
 	// logType used to decide should write to stdout or stderr
 	Write(header []byte, msg []byte, logEnabled bool, logVerbosity int32, logType string) (n int, err error)
 
+	// WriteWithValues is like Write, but also forwards the key/value pairs
+	// of the message to the channel as structured values
+	WriteWithValues(header []byte, msg []byte, values map[string]interface{}, logEnabled bool, logVerbosity int32, logType string) (n int, err error)
+
 	// SetFile sets the logFile to writer
 	// if the non-empty file name is used, writer will also
 	// write the logs to this file
@@ -42,6 +47,8 @@ type Writer interface {
 	SetVerbosity(verbosity int32)
 
 	// SendProgressUpdate sends the progress to the listening logChannel
+	// A final "failed" or "successful" status is followed by a result message
+	// which records the phase the operation failed in
 	SendProgressUpdate(status string, step string, totalSteps []string)
 }
 
@@ -63,6 +70,9 @@ type writer struct {
 	verbosity  int32
 	quiet      bool
 	auditFile  string
+	// phase is the current phase of the running operation, used to
+	// annotate messages sent to the channel
+	phase string
 }
 
 // SetFile sets the logFile to writer
@@ -106,6 +116,12 @@ func (w *writer) SetVerbosity(verbosity int32) {
 // logVerbosity is used to decide which message to write for different output types
 // logType used to decide should write to stdout or stderr
 func (w *writer) Write(header, msg []byte, logEnabled bool, logVerbosity int32, logType string) (n int, err error) {
+	return w.WriteWithValues(header, msg, nil, logEnabled, logVerbosity, logType)
+}
+
+// WriteWithValues is like Write, but also forwards the key/value pairs
+// of the message to the channel as structured values
+func (w *writer) WriteWithValues(header, msg []byte, values map[string]interface{}, logEnabled bool, logVerbosity int32, logType string) (n int, err error) {
 	fullMsg := append(header, msg...) //nolint:gocritic
 
 	// Always write to the audit log so it captures everything
@@ -119,7 +135,7 @@ func (w *writer) Write(header, msg []byte, logEnabled bool, logVerbosity int32,
 			fileWriter(w.logFile, fullMsg)
 		}
 		if w.logChannel != nil {
-			w.logChannel <- convertLogMsgToJSONBytes(fullMsg)
+			w.logChannel <- convertLogMsgToJSONBytes(fullMsg, w.phase, values)
 		}
 	}
 
@@ -139,6 +155,9 @@ func (w *writer) SendProgressUpdate(status, currentPhase string, totalPhases []s
 	if w.logChannel == nil {
 		return
 	}
+	if currentPhase != "" {
+		w.phase = currentPhase
+	}
 
 	msgData := logData{
 		Status:       status,
@@ -146,6 +165,19 @@ func (w *writer) SendProgressUpdate(status, currentPhase string, totalPhases []s
 		TotalPhases:  totalPhases,
 	}
 	w.logChannel <- convertProgressMsgToJSONBytes(&msgData)
+
+	if status != progressStatusFailed && status != progressStatusSuccessful {
+		return
+	}
+	resultData := logData{
+		Status:    status,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if status == progressStatusFailed {
+		resultData.FailedPhase = w.phase
+	}
+	w.phase = ""
+	w.logChannel <- convertResultMsgToJSONBytes(&resultData)
 }
 
 // UnsetStdoutStderr intercept the actual stdout and stderr
@@ -0,0 +1,361 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package timeline implements the timeline of the condition transitions and the
+// Kubernetes Events of the objects of a cluster, built from informers
+package timeline
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// SourceCondition is the source of the entries of condition transitions
+	SourceCondition = "Condition"
+	// SourceEvent is the source of the entries of Kubernetes Events
+	SourceEvent = "Event"
+	// SourceObject is the source of the entries of object deletions
+	SourceObject = "Object"
+
+	clusterNameLabel = "cluster.x-k8s.io/cluster-name"
+)
+
+// Entry is an entry of the timeline of a cluster
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	// Source is Condition for a condition transition, Event for a Kubernetes Event and Object for a deletion
+	Source string `json:"source"`
+	// Type is the condition type, the event type (Normal or Warning) or Deleted
+	Type    string `json:"type"`
+	Status  string `json:"status,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Object returns the kind and the name of the object of the entry, e.g. Machine/wc-md-0-abcde
+func (e Entry) Object() string {
+	return e.Kind + "/" + e.Name
+}
+
+// Options are the options of Watch
+type Options struct {
+	ClusterName string
+	Namespace   string
+	// Follow keeps watching the cluster until the context is done,
+	// otherwise Watch returns once the current timeline is handled
+	Follow bool
+}
+
+// watchedResource is a resource whose objects are part of the timeline of a cluster
+type watchedResource struct {
+	gvr  schema.GroupVersionResource
+	kind string
+	// byName selects the object named after the cluster, otherwise the objects are selected by the cluster name label
+	byName bool
+}
+
+// watchedResources are the resources whose conditions and events are part of the timeline
+var watchedResources = []watchedResource{
+	{gvr: schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "clusters"}, kind: "Cluster", byName: true},
+	{gvr: schema.GroupVersionResource{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta1", Resource: "kubeadmcontrolplanes"}, kind: "KubeadmControlPlane"},
+	{gvr: schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "machinedeployments"}, kind: "MachineDeployment"},
+	{gvr: schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "machines"}, kind: "Machine"},
+	{gvr: schema.GroupVersionResource{Group: "run.tanzu.vmware.com", Version: "v1alpha3", Resource: "clusterbootstraps"}, kind: "ClusterBootstrap", byName: true},
+}
+
+var eventsGVR = schema.GroupVersionResource{Version: "v1", Resource: "events"}
+
+// Watch calls the handler with the timeline of a cluster: the transitions of the conditions of its Cluster,
+// KubeadmControlPlane, MachineDeployments, Machines and ClusterBootstrap, and the Kubernetes Events of these
+// objects. The current timeline is handled first, sorted by time, the following entries are handled as they occur.
+// The handler is not called concurrently.
+func Watch(ctx context.Context, client dynamic.Interface, options Options, handler func(Entry)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r := newRecorder(options.ClusterName, handler)
+	// the informers stop asynchronously, the handler must not be called once Watch returns
+	defer r.close()
+
+	// The handlers of the informers are notified asynchronously, even after the informers synced, so the
+	// current timeline is built from the stores of the synced informers and the handlers are only added
+	// to follow the changes. Added handlers are first notified of the objects in the stores, which are
+	// not newer than the objects already handled and do not record entries again.
+	informers := make([]cache.SharedIndexInformer, len(watchedResources))
+	var synced []cache.InformerSynced
+	for i, resource := range watchedResources {
+		resource := resource
+		tweak := func(listOptions *metav1.ListOptions) {
+			listOptions.LabelSelector = clusterNameLabel + "=" + options.ClusterName
+		}
+		if resource.byName {
+			tweak = func(listOptions *metav1.ListOptions) {
+				listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", options.ClusterName).String()
+			}
+		}
+		informers[i] = dynamicinformer.NewFilteredDynamicInformer(client, resource.gvr, options.Namespace, 0, cache.Indexers{}, tweak).Informer()
+		go informers[i].Run(ctx.Done())
+		synced = append(synced, informers[i].HasSynced)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return errors.New("unable to sync the cluster objects")
+	}
+	for i, informer := range informers {
+		for _, obj := range informer.GetStore().List() {
+			r.objectChanged(watchedResources[i], obj)
+		}
+	}
+
+	// events are listed once the objects are known, so that they can be matched with them
+	eventInformer := dynamicinformer.NewFilteredDynamicInformer(client, eventsGVR, options.Namespace, 0, cache.Indexers{}, nil).Informer()
+	go eventInformer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), eventInformer.HasSynced) {
+		return errors.New("unable to sync the cluster events")
+	}
+	for _, obj := range eventInformer.GetStore().List() {
+		r.eventChanged(obj)
+	}
+
+	r.flush()
+	if !options.Follow {
+		return nil
+	}
+
+	for i, informer := range informers {
+		resource := watchedResources[i]
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { r.objectChanged(resource, obj) },
+			UpdateFunc: func(_, obj interface{}) { r.objectChanged(resource, obj) },
+			DeleteFunc: func(obj interface{}) { r.objectDeleted(resource, obj) },
+		})
+	}
+	eventInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.eventChanged,
+		UpdateFunc: func(_, obj interface{}) { r.eventChanged(obj) },
+	})
+	<-ctx.Done()
+	return nil
+}
+
+// conditionState is the state of a condition the recorder compares to detect transitions
+type conditionState struct {
+	status string
+	reason string
+}
+
+// recorder turns the changes of the objects and events into timeline entries. The entries of the
+// current timeline are buffered until flush sorts them, the following ones are handled immediately.
+type recorder struct {
+	sync.Mutex
+	clusterName string
+	handler     func(Entry)
+	// conditions are the last known conditions of the objects, by object and condition type
+	conditions map[string]map[string]conditionState
+	// objects are the objects of the cluster, selected by their name or their cluster name label. Deleted
+	// objects are kept, so that their last events are still matched.
+	objects map[string]bool
+	// events are the last known counts of the events, by UID
+	events  map[string]int64
+	flushed bool
+	closed  bool
+	pending []Entry
+}
+
+func newRecorder(clusterName string, handler func(Entry)) *recorder {
+	return &recorder{
+		clusterName: clusterName,
+		handler:     handler,
+		conditions:  map[string]map[string]conditionState{},
+		objects:     map[string]bool{},
+		events:      map[string]int64{},
+	}
+}
+
+func objectKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// record handles the entries, or buffers them until the current timeline is flushed
+func (r *recorder) record(entries ...Entry) {
+	if r.closed {
+		return
+	}
+	if !r.flushed {
+		r.pending = append(r.pending, entries...)
+		return
+	}
+	for i := range entries {
+		r.handler(entries[i])
+	}
+}
+
+// flush handles the buffered entries sorted by time
+func (r *recorder) flush() {
+	r.Lock()
+	defer r.Unlock()
+	sort.SliceStable(r.pending, func(i, j int) bool { return r.pending[i].Time.Before(r.pending[j].Time) })
+	r.flushed = true
+	entries := r.pending
+	r.pending = nil
+	r.record(entries...)
+}
+
+// close drops the following entries
+func (r *recorder) close() {
+	r.Lock()
+	defer r.Unlock()
+	r.closed = true
+}
+
+func (r *recorder) objectChanged(resource watchedResource, o interface{}) {
+	obj, ok := o.(*unstructured.Unstructured)
+	if !ok || (resource.byName && obj.GetName() != r.clusterName) {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	key := objectKey(resource.kind, obj.GetNamespace(), obj.GetName())
+	r.objects[key] = true
+	known := r.conditions[key]
+	current := map[string]conditionState{}
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	var entries []Entry
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		status, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		state := conditionState{status: status, reason: reason}
+		current[conditionType] = state
+		if previous, found := known[conditionType]; found && previous == state {
+			continue
+		}
+		message, _, _ := unstructured.NestedString(condition, "message")
+		lastTransitionTime, _, _ := unstructured.NestedString(condition, "lastTransitionTime")
+		entries = append(entries, Entry{
+			Time:      parseTime(lastTransitionTime),
+			Kind:      resource.kind,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Source:    SourceCondition,
+			Type:      conditionType,
+			Status:    status,
+			Reason:    reason,
+			Message:   message,
+		})
+	}
+	r.conditions[key] = current
+	r.record(entries...)
+}
+
+func (r *recorder) objectDeleted(resource watchedResource, o interface{}) {
+	if tombstone, ok := o.(cache.DeletedFinalStateUnknown); ok {
+		o = tombstone.Obj
+	}
+	obj, ok := o.(*unstructured.Unstructured)
+	if !ok || (resource.byName && obj.GetName() != r.clusterName) {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	key := objectKey(resource.kind, obj.GetNamespace(), obj.GetName())
+	r.objects[key] = true
+	delete(r.conditions, key)
+	r.record(Entry{
+		Time:      time.Now(),
+		Kind:      resource.kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Source:    SourceObject,
+		Type:      "Deleted",
+	})
+}
+
+func (r *recorder) eventChanged(o interface{}) {
+	event, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	kind, _, _ := unstructured.NestedString(event.Object, "involvedObject", "kind")
+	namespace, _, _ := unstructured.NestedString(event.Object, "involvedObject", "namespace")
+	name, _, _ := unstructured.NestedString(event.Object, "involvedObject", "name")
+
+	r.Lock()
+	defer r.Unlock()
+	if !r.involves(kind, namespace, name) {
+		return
+	}
+	count, _, _ := unstructured.NestedInt64(event.Object, "count")
+	if previous, found := r.events[string(event.GetUID())]; found && previous == count {
+		return
+	}
+	r.events[string(event.GetUID())] = count
+
+	eventType, _, _ := unstructured.NestedString(event.Object, "type")
+	reason, _, _ := unstructured.NestedString(event.Object, "reason")
+	message, _, _ := unstructured.NestedString(event.Object, "message")
+	r.record(Entry{
+		Time:      eventTime(event),
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Source:    SourceEvent,
+		Type:      eventType,
+		Reason:    reason,
+		Message:   message,
+	})
+}
+
+// involves returns true if an event is about an object of the cluster. The objects which are not named
+// after the cluster are matched by their cluster name label, through the objects of the informers,
+// rather than by their name, which may start with the name of another cluster, e.g. wc-md.
+func (r *recorder) involves(kind, namespace, name string) bool {
+	for _, resource := range watchedResources {
+		if resource.kind != kind {
+			continue
+		}
+		if resource.byName {
+			return name == r.clusterName
+		}
+		return r.objects[objectKey(kind, namespace, name)]
+	}
+	return false
+}
+
+// eventTime returns the time the event last occurred
+func eventTime(event *unstructured.Unstructured) time.Time {
+	for _, field := range []string{"lastTimestamp", "eventTime", "firstTimestamp"} {
+		if value, _, _ := unstructured.NestedString(event.Object, field); value != "" {
+			return parseTime(value)
+		}
+	}
+	return event.GetCreationTimestamp().Time
+}
+
+// parseTime parses an API time, the current time is returned if it is not valid
+func parseTime(value string) time.Time {
+	for _, layout := range []string{time.RFC3339, metav1.RFC3339Micro} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
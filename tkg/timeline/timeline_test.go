@@ -0,0 +1,208 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package timeline_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/timeline"
+)
+
+const (
+	clusterName = "wc"
+	namespace   = "default"
+)
+
+var (
+	clustersGVR = schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "clusters"}
+	machinesGVR = schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "machines"}
+
+	listKinds = map[schema.GroupVersionResource]string{
+		clustersGVR: "ClusterList",
+		{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta1", Resource: "kubeadmcontrolplanes"}: "KubeadmControlPlaneList",
+		{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "machinedeployments"}:                "MachineDeploymentList",
+		machinesGVR: "MachineList",
+		{Group: "run.tanzu.vmware.com", Version: "v1alpha3", Resource: "clusterbootstraps"}: "ClusterBootstrapList",
+		{Version: "v1", Resource: "events"}:                                                 "EventList",
+	}
+
+	start = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+)
+
+func at(minutes int) time.Time {
+	return start.Add(time.Duration(minutes) * time.Minute)
+}
+
+func condition(conditionType, status, reason string, transition time.Time) interface{} {
+	c := map[string]interface{}{
+		"type":               conditionType,
+		"status":             status,
+		"lastTransitionTime": transition.Format(time.RFC3339),
+	}
+	if reason != "" {
+		c["reason"] = reason
+		c["message"] = reason + " message"
+	}
+	return c
+}
+
+func object(apiVersion, kind, name, cluster string, conditions ...interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"labels":    map[string]interface{}{"cluster.x-k8s.io/cluster-name": cluster},
+		},
+		"status": map[string]interface{}{"conditions": conditions},
+	}}
+}
+
+func event(name, kind, involvedName, eventType, reason string, count int64, last time.Time) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Event",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"uid":       name,
+		},
+		"involvedObject": map[string]interface{}{
+			"kind":      kind,
+			"name":      involvedName,
+			"namespace": namespace,
+		},
+		"type":          eventType,
+		"reason":        reason,
+		"message":       reason + " message",
+		"count":         count,
+		"lastTimestamp": last.Format(time.RFC3339),
+	}}
+}
+
+// newClient returns a fake dynamic client which counts the established watches
+func newClient(watches *int32, objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objects...)
+	client.PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := client.Tracker().Watch(action.GetResource(), action.GetNamespace())
+		atomic.AddInt32(watches, 1)
+		return true, w, err
+	})
+	return client
+}
+
+var _ = Describe("Watch", func() {
+	var objects []runtime.Object
+
+	BeforeEach(func() {
+		objects = []runtime.Object{
+			object("cluster.x-k8s.io/v1beta1", "Cluster", clusterName, clusterName,
+				condition("Ready", "False", "WaitingForControlPlane", at(3)),
+				condition("InfrastructureReady", "True", "", at(1)),
+			),
+			object("cluster.x-k8s.io/v1beta1", "Cluster", "other", "other",
+				condition("Ready", "True", "", at(1)),
+			),
+			object("cluster.x-k8s.io/v1beta1", "Machine", "wc-md-0-abcde", clusterName,
+				condition("BootstrapReady", "True", "", at(2)),
+			),
+			object("cluster.x-k8s.io/v1beta1", "Machine", "other-md-0-abcde", "other",
+				condition("BootstrapReady", "True", "", at(2)),
+			),
+			// the name of the machine of the wc-md cluster starts with the name of the wc cluster
+			object("cluster.x-k8s.io/v1beta1", "Machine", "wc-md-md-0-fghij", "wc-md",
+				condition("BootstrapReady", "True", "", at(2)),
+			),
+			event("machine-event", "Machine", "wc-md-0-abcde", "Warning", "FailedCreate", 1, at(4)),
+			event("other-event", "Machine", "other-md-0-abcde", "Warning", "FailedCreate", 1, at(4)),
+			event("prefix-event", "Machine", "wc-md-md-0-fghij", "Warning", "FailedCreate", 1, at(4)),
+			event("pod-event", "Pod", "wc-pod", "Normal", "Started", 1, at(4)),
+		}
+	})
+
+	It("handles the current timeline of the cluster sorted by time", func() {
+		var (
+			watches int32
+			entries []timeline.Entry
+		)
+		err := timeline.Watch(context.Background(), newClient(&watches, objects...),
+			timeline.Options{ClusterName: clusterName, Namespace: namespace},
+			func(entry timeline.Entry) { entries = append(entries, entry) })
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(entries).To(HaveLen(4))
+		Expect(entries[0]).To(Equal(timeline.Entry{
+			Time: at(1), Kind: "Cluster", Namespace: namespace, Name: clusterName,
+			Source: timeline.SourceCondition, Type: "InfrastructureReady", Status: "True",
+		}))
+		Expect(entries[1].Object()).To(Equal("Machine/wc-md-0-abcde"))
+		Expect(entries[1].Type).To(Equal("BootstrapReady"))
+		Expect(entries[2].Object()).To(Equal("Cluster/wc"))
+		Expect(entries[2].Reason).To(Equal("WaitingForControlPlane"))
+		Expect(entries[3]).To(Equal(timeline.Entry{
+			Time: at(4), Kind: "Machine", Namespace: namespace, Name: "wc-md-0-abcde",
+			Source: timeline.SourceEvent, Type: "Warning", Reason: "FailedCreate", Message: "FailedCreate message",
+		}))
+	})
+
+	It("follows the condition transitions, the events and the deletions", func() {
+		var watches int32
+		client := newClient(&watches, objects...)
+		entries := make(chan timeline.Entry, 20)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- timeline.Watch(ctx, client, timeline.Options{ClusterName: clusterName, Namespace: namespace, Follow: true},
+				func(entry timeline.Entry) { entries <- entry })
+		}()
+		for i := 0; i < 4; i++ {
+			Eventually(entries).Should(Receive())
+		}
+		Eventually(func() int32 { return atomic.LoadInt32(&watches) }).Should(BeNumerically("==", 6))
+
+		cluster := object("cluster.x-k8s.io/v1beta1", "Cluster", clusterName, clusterName,
+			condition("Ready", "True", "", at(5)),
+			condition("InfrastructureReady", "True", "", at(1)),
+		)
+		_, err := client.Resource(clustersGVR).Namespace(namespace).Update(ctx, cluster, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		var entry timeline.Entry
+		Eventually(entries).Should(Receive(&entry))
+		Expect(entry.Object()).To(Equal("Cluster/wc"))
+		Expect(entry.Type).To(Equal("Ready"))
+		Expect(entry.Status).To(Equal("True"))
+		Expect(entry.Time).To(Equal(at(5)))
+
+		repeated := event("machine-event", "Machine", "wc-md-0-abcde", "Warning", "FailedCreate", 2, at(6))
+		_, err = client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "events"}).Namespace(namespace).Update(ctx, repeated, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Eventually(entries).Should(Receive(&entry))
+		Expect(entry.Source).To(Equal(timeline.SourceEvent))
+		Expect(entry.Time).To(Equal(at(6)))
+
+		err = client.Resource(machinesGVR).Namespace(namespace).Delete(ctx, "wc-md-0-abcde", metav1.DeleteOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Eventually(entries).Should(Receive(&entry))
+		Expect(entry.Source).To(Equal(timeline.SourceObject))
+		Expect(entry.Type).To(Equal("Deleted"))
+		Expect(entry.Object()).To(Equal("Machine/wc-md-0-abcde"))
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		Consistently(entries).ShouldNot(Receive())
+	})
+})
@@ -70,6 +70,12 @@ type Client interface {
 	GetManagementPackagesVersion() (string, error)
 	// GetKappControllerPackageImage returns kapp-controller package image
 	GetKappControllerPackageImage() (string, error)
+	// WithTKGConfigReaderWriter returns a copy of the client reading the given TKG configuration
+	WithTKGConfigReaderWriter(tkgConfigReaderWriter tkgconfigreaderwriter.TKGConfigReaderWriter) Client
+}
+
+func (c *client) WithTKGConfigReaderWriter(tkgConfigReaderWriter tkgconfigreaderwriter.TKGConfigReaderWriter) Client {
+	return New(c.configDir, tkgConfigReaderWriter)
 }
 
 func (c *client) TKGConfigReaderWriter() tkgconfigreaderwriter.TKGConfigReaderWriter {
@@ -58,4 +58,7 @@ type Client interface {
 
 	// GetClusterConfigurationDirectory returns the directory path where cluster configuration files will be stored
 	GetClusterConfigurationDirectory() (string, error)
+
+	// GetConfigProfilesDirectory returns the directory path where configuration profiles are stored
+	GetConfigProfilesDirectory() (string, error)
 }
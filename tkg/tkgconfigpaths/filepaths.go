@@ -160,3 +160,12 @@ func (c *client) GetClusterConfigurationDirectory() (string, error) {
 	}
 	return filepath.Join(tkgDir, constants.TKGClusterConfigFileDirForUI), nil
 }
+
+// GetConfigProfilesDirectory returns the directory path where configuration profiles are stored
+func (c *client) GetConfigProfilesDirectory() (string, error) {
+	tkgDir, err := c.GetTKGDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(tkgDir, constants.ConfigProfilesFolderName), nil
+}
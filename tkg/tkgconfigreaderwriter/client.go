@@ -65,10 +65,20 @@ func (c *client) TKGConfigReaderWriter() TKGConfigReaderWriter {
 // Please use this function causiously as it might not be required for your usecase as
 // most of the clients has readerwrite client
 func NewReaderWriterFromConfigFile(clusterConfigPath, tkgConfigPath string) (TKGConfigReaderWriter, error) {
-	rw := newTKGConfigReaderWriter()
+	return NewReaderWriterFromConfigFileWithProfiles(clusterConfigPath, tkgConfigPath, "")
+}
+
+// NewReaderWriterFromConfigFileWithProfiles returns new reader writer from config file, resolving the configuration
+// profiles extended by the cluster config file from profilesDir instead of the profiles directory next to the tkg config file
+// NOTE: the same restrictions as NewReaderWriterFromConfigFile apply
+func NewReaderWriterFromConfigFileWithProfiles(clusterConfigPath, tkgConfigPath, profilesDir string) (TKGConfigReaderWriter, error) {
+	rw := &tkgConfigReaderWriter{}
 	if err := rw.Init(tkgConfigPath); err != nil {
 		return nil, errors.Wrap(err, "error initializing tkg config")
 	}
+	if profilesDir != "" {
+		rw.profilesDir = profilesDir
+	}
 	if err := rw.MergeInConfig(clusterConfigPath); err != nil {
 		return nil, errors.Wrap(err, "error initializing cluster config")
 	}
@@ -0,0 +1,134 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgconfigreaderwriter
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigProfileExtendsKey is the configuration key naming the profile a configuration file extends
+const ConfigProfileExtendsKey = "extends"
+
+// ConfigLayer is a configuration file taking part in a resolved configuration
+type ConfigLayer struct {
+	// Name of the profile, or the path of the configuration file which is not a profile
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path" yaml:"path"`
+}
+
+// ResolvedConfig is the configuration of a file merged with the profiles it extends
+type ResolvedConfig struct {
+	// Layers lists the configuration files from the base profile to the extending file
+	Layers []ConfigLayer `json:"layers" yaml:"layers"`
+	// Values holds the merged configuration values
+	Values map[string]interface{} `json:"values" yaml:"values"`
+	// Sources maps each configuration key to the name of the layer its value comes from
+	Sources map[string]string `json:"sources" yaml:"sources"`
+}
+
+// Keys returns the sorted configuration keys
+func (r *ResolvedConfig) Keys() []string {
+	keys := make([]string, 0, len(r.Values))
+	for key := range r.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ResolveConfigProfiles reads a configuration file and the chain of profiles it extends.
+// A profile is referred to by name and read from <profilesDir>/<name>.yaml, or by a path
+// relative to the extending file. The values of a layer replace the values of the same
+// keys in the layers it extends.
+func ResolveConfigProfiles(configFilePath, profilesDir string) (*ResolvedConfig, error) {
+	var layers []ConfigLayer
+	var layerValues []map[string]interface{}
+	visited := map[string]bool{}
+
+	layer := ConfigLayer{Name: configFilePath, Path: configFilePath}
+	for {
+		absPath, err := filepath.Abs(layer.Path)
+		if err != nil {
+			return nil, err
+		}
+		if visited[absPath] {
+			return nil, errors.Errorf("configuration profile %q is extended in a cycle", layer.Name)
+		}
+		visited[absPath] = true
+
+		values, err := readConfigLayer(layer.Path)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+		layerValues = append(layerValues, values)
+
+		extends, ok := values[ConfigProfileExtendsKey]
+		if !ok {
+			break
+		}
+		name, ok := extends.(string)
+		if !ok || name == "" {
+			return nil, errors.Errorf("invalid %s value in configuration file %q, expected the name of a profile", ConfigProfileExtendsKey, layer.Path)
+		}
+		layer = profileLayer(name, filepath.Dir(layer.Path), profilesDir)
+	}
+
+	resolved := &ResolvedConfig{Values: map[string]interface{}{}, Sources: map[string]string{}}
+	for i := len(layers) - 1; i >= 0; i-- {
+		resolved.Layers = append(resolved.Layers, layers[i])
+		for key, value := range layerValues[i] {
+			if key == ConfigProfileExtendsKey {
+				continue
+			}
+			resolved.Values[key] = value
+			resolved.Sources[key] = layers[i].Name
+		}
+	}
+	return resolved, nil
+}
+
+// profileLayer returns the layer of a profile name, names which look like
+// file paths are resolved relative to the directory of the extending file
+func profileLayer(name, baseDir, profilesDir string) ConfigLayer {
+	if strings.ContainsAny(name, `/\`) || strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml") {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		return ConfigLayer{Name: name, Path: path}
+	}
+	return ConfigLayer{Name: name, Path: filepath.Join(profilesDir, name+".yaml")}
+}
+
+func readConfigLayer(path string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("configuration profile file %q does not exist", path)
+		}
+		return nil, errors.Wrapf(err, "unable to read configuration file %q", path)
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse configuration file %q", path)
+	}
+	return values, nil
+}
+
+// hasConfigProfile returns true if the configuration file extends a profile
+func hasConfigProfile(configFilePath string) bool {
+	values, err := readConfigLayer(configFilePath)
+	if err != nil {
+		return false
+	}
+	_, ok := values[ConfigProfileExtendsKey]
+	return ok
+}
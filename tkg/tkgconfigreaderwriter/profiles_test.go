@@ -0,0 +1,112 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgconfigreaderwriter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+)
+
+func writeConfigFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestResolveConfigProfiles(t *testing.T) {
+	dir := t.TempDir()
+	profilesDir := filepath.Join(dir, constants.ConfigProfilesFolderName)
+	writeConfigFiles(t, dir, map[string]string{
+		"profiles/base-vsphere.yaml": "INFRASTRUCTURE_PROVIDER: vsphere\nCLUSTER_PLAN: dev\nVSPHERE_DATACENTER: /dc0\n",
+		"profiles/prod-dc1.yaml":     "extends: base-vsphere\nCLUSTER_PLAN: prod\nVSPHERE_DATACENTER: /dc1\n",
+		"clusters/wc.yaml":           "extends: prod-dc1\nCLUSTER_NAME: wc\nVSPHERE_DATACENTER: /dc1-b\n",
+		"clusters/relative.yaml":     "extends: ../profiles/prod-dc1.yaml\nCLUSTER_NAME: relative\n",
+		"clusters/cycle.yaml":        "extends: cycle-a\n",
+		"profiles/cycle-a.yaml":      "extends: cycle-b\n",
+		"profiles/cycle-b.yaml":      "extends: cycle-a\n",
+		"clusters/missing.yaml":      "extends: does-not-exist\n",
+		"clusters/invalid.yaml":      "extends:\n- base-vsphere\n",
+	})
+
+	resolved, err := ResolveConfigProfiles(filepath.Join(dir, "clusters/wc.yaml"), profilesDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantValues := map[string]interface{}{
+		"INFRASTRUCTURE_PROVIDER": "vsphere",
+		"CLUSTER_PLAN":            "prod",
+		"VSPHERE_DATACENTER":      "/dc1-b",
+		"CLUSTER_NAME":            "wc",
+	}
+	if !reflect.DeepEqual(resolved.Values, wantValues) {
+		t.Errorf("Values = %v, want %v", resolved.Values, wantValues)
+	}
+	wantSources := map[string]string{
+		"INFRASTRUCTURE_PROVIDER": "base-vsphere",
+		"CLUSTER_PLAN":            "prod-dc1",
+		"VSPHERE_DATACENTER":      filepath.Join(dir, "clusters/wc.yaml"),
+		"CLUSTER_NAME":            filepath.Join(dir, "clusters/wc.yaml"),
+	}
+	if !reflect.DeepEqual(resolved.Sources, wantSources) {
+		t.Errorf("Sources = %v, want %v", resolved.Sources, wantSources)
+	}
+	if len(resolved.Layers) != 3 || resolved.Layers[0].Name != "base-vsphere" || resolved.Layers[1].Name != "prod-dc1" {
+		t.Errorf("unexpected layers %v", resolved.Layers)
+	}
+	if keys := resolved.Keys(); keys[0] != "CLUSTER_NAME" || len(keys) != 4 {
+		t.Errorf("unexpected keys %v", keys)
+	}
+
+	resolved, err = ResolveConfigProfiles(filepath.Join(dir, "clusters/relative.yaml"), profilesDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Values["CLUSTER_PLAN"] != "prod" || resolved.Sources["INFRASTRUCTURE_PROVIDER"] != "base-vsphere" {
+		t.Errorf("unexpected resolution of relative profile %v", resolved.Values)
+	}
+
+	for file, wantErr := range map[string]string{
+		"clusters/cycle.yaml":   "cycle",
+		"clusters/missing.yaml": "does not exist",
+		"clusters/invalid.yaml": "expected the name of a profile",
+	} {
+		_, err := ResolveConfigProfiles(filepath.Join(dir, file), profilesDir)
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("ResolveConfigProfiles(%s) error = %v, want %q", file, err, wantErr)
+		}
+	}
+}
+
+func TestMergeInConfigWithProfiles(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{
+		"config.yaml":                "BAR: bar\n",
+		"profiles/base-vsphere.yaml": "CLUSTER_PLAN: dev\nVSPHERE_DATACENTER: /dc0\n",
+		"cluster.yaml":               "extends: base-vsphere\nVSPHERE_DATACENTER: /dc1\n",
+	})
+
+	rw, err := NewReaderWriterFromConfigFile(filepath.Join(dir, "cluster.yaml"), filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{"BAR": "bar", "CLUSTER_PLAN": "dev", "VSPHERE_DATACENTER": "/dc1"} {
+		if got, err := rw.Get(key); err != nil || got != want {
+			t.Errorf("Get(%s) = %q, %v, want %q", key, got, err, want)
+		}
+	}
+	if _, err := rw.Get(ConfigProfileExtendsKey); err == nil {
+		t.Errorf("the %s key should not be a configuration variable", ConfigProfileExtendsKey)
+	}
+}
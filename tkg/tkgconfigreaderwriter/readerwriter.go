@@ -4,17 +4,23 @@
 package tkgconfigreaderwriter
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 )
 
 // tkgConfigReaderWriter is a customized implementation of viperReader in clusterctl repo to make it compatible with
 // tkg configuration file with some additional functionality to update configuration file
 type tkgConfigReaderWriter struct {
 	viperStore *viper.Viper
+	// profilesDir is the directory holding the configuration profiles extended by configuration files
+	profilesDir string
 }
 
 //go:generate counterfeiter -o ../fakes/readerwriter.go --fake-name TKGConfigReaderWriter . TKGConfigReaderWriter
@@ -36,6 +42,7 @@ func newTKGConfigReaderWriter() TKGConfigReaderWriter {
 // Init initialize the readerWriter
 func (v *tkgConfigReaderWriter) Init(tkgConfigFile string) error {
 	v.viperStore = viper.New()
+	v.profilesDir = filepath.Join(filepath.Dir(tkgConfigFile), constants.ConfigProfilesFolderName)
 
 	// Configure for reading environment variables as well, and more specifically:
 	// AutomaticEnv force viper to check for an environment variable any time a v.viperStore.Get request is made.
@@ -63,6 +70,13 @@ func (v *tkgConfigReaderWriter) MergeInConfig(configFilePath string) error {
 	// Use path file from the flag.
 	v.viperStore.SetConfigFile(configFilePath)
 	v.viperStore.SetConfigType("yaml")
+	if hasConfigProfile(configFilePath) {
+		resolved, err := ResolveConfigProfiles(configFilePath, v.profilesDir)
+		if err != nil {
+			return errors.Wrapf(err, "Error resolving configuration profiles of %q", configFilePath)
+		}
+		return v.viperStore.MergeConfigMap(resolved.Values)
+	}
 	// If a path file is found, read it in.
 	if err := v.viperStore.MergeInConfig(); err != nil {
 		return errors.Wrapf(err, "Error reading configuration file %q", v.viperStore.ConfigFileUsed())
@@ -90,3 +104,50 @@ func (v *tkgConfigReaderWriter) SetMap(data map[string]string) {
 func (v *tkgConfigReaderWriter) UnmarshalKey(key string, rawval interface{}) error {
 	return v.viperStore.UnmarshalKey(key, rawval)
 }
+
+// overlayReaderWriter reads through to the wrapped reader writer and keeps the values set through it in memory,
+// leaving the wrapped reader writer unchanged
+type overlayReaderWriter struct {
+	base   TKGConfigReaderWriter
+	values map[string]string
+}
+
+// NewOverlayReaderWriter returns a copy of readerWriter. The values set through the copy are not written to
+// readerWriter, while the values of readerWriter that were not set through the copy are still read from it.
+func NewOverlayReaderWriter(readerWriter TKGConfigReaderWriter) TKGConfigReaderWriter {
+	return &overlayReaderWriter{base: readerWriter, values: map[string]string{}}
+}
+
+// Init is a no-op, the overlay is initialized from the wrapped reader writer
+func (v *overlayReaderWriter) Init(string) error {
+	return nil
+}
+
+func (v *overlayReaderWriter) MergeInConfig(configFilePath string) error {
+	return errors.Errorf("unable to merge configuration file %q into a copy of the configuration", configFilePath)
+}
+
+func (v *overlayReaderWriter) Get(key string) (string, error) {
+	// viper keys are case insensitive
+	if value, ok := v.values[strings.ToLower(key)]; ok {
+		return value, nil
+	}
+	return v.base.Get(key)
+}
+
+func (v *overlayReaderWriter) Set(key, value string) {
+	v.values[strings.ToLower(key)] = value
+}
+
+func (v *overlayReaderWriter) SetMap(data map[string]string) {
+	for key, val := range data {
+		v.Set(key, val)
+	}
+}
+
+func (v *overlayReaderWriter) UnmarshalKey(key string, rawval interface{}) error {
+	if value, ok := v.values[strings.ToLower(key)]; ok {
+		return yaml.Unmarshal([]byte(value), rawval)
+	}
+	return v.base.UnmarshalKey(key, rawval)
+}
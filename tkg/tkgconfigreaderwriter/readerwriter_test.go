@@ -160,3 +160,31 @@ func Test_MergeInConfig_MissingFile(t *testing.T) {
 		t.Error("Expected error retrieving fakes/config/config1.yaml")
 	}
 }
+
+func Test_overlayReaderWriter(t *testing.T) {
+	base, err := NewReaderWriterFromConfigFile("../fakes/config/config.yaml", "../fakes/config/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlay := NewOverlayReaderWriter(base)
+
+	if got, err := overlay.Get("BAR"); err != nil || got != "bar" {
+		t.Errorf("Get() of a value of the wrapped reader writer = %q, %v, want %q", got, err, "bar")
+	}
+
+	overlay.Set("BAR", "overlay")
+	overlay.SetMap(map[string]string{"NEW_KEY": "new"})
+	if got, _ := overlay.Get("bar"); got != "overlay" {
+		t.Errorf("Get() of a value set through the overlay = %q, want %q", got, "overlay")
+	}
+	if got, _ := overlay.Get("NEW_KEY"); got != "new" {
+		t.Errorf("Get() of a value set through the overlay = %q, want %q", got, "new")
+	}
+
+	if got, _ := base.Get("BAR"); got != "bar" {
+		t.Errorf("the wrapped reader writer was changed, Get() = %q, want %q", got, "bar")
+	}
+	if _, err := base.Get("NEW_KEY"); err == nil {
+		t.Error("the wrapped reader writer was changed, NEW_KEY is set")
+	}
+}
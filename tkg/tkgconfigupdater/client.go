@@ -84,6 +84,12 @@ type Client interface {
 	GetProvidersChecksum() (string, error)
 	// GetPopulatedProvidersChecksumFromFile reads and returns the checksum from providers.sha256sum file in the providers directory
 	GetPopulatedProvidersChecksumFromFile() (string, error)
+	// WithTKGConfigReaderWriter returns a copy of the client reading and updating the given TKG configuration
+	WithTKGConfigReaderWriter(tkgConfigReaderWriter tkgconfigreaderwriter.TKGConfigReaderWriter) Client
+}
+
+func (c *client) WithTKGConfigReaderWriter(tkgConfigReaderWriter tkgconfigreaderwriter.TKGConfigReaderWriter) Client {
+	return New(c.configDir, c.providerGetter, tkgConfigReaderWriter)
 }
 
 func (c *client) TKGConfigReaderWriter() tkgconfigreaderwriter.TKGConfigReaderWriter {
@@ -3,6 +3,7 @@
 package tkgctl
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -12,8 +13,26 @@ import (
 	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
 )
 
+func Test_AddOverlayPack_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "AddOverlayPack",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(AddOverlayPackOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(&yamlprocessor.OverlayPack{}),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
 func Test_AddRegion_Signature(t *testing.T) {
 	tkgClientVal := reflect.ValueOf(&tkgctl{})
 	enforce := EnforceMethodParams{
@@ -44,6 +63,38 @@ func Test_ConfigCluster_Signature(t *testing.T) {
 	enforceMethodSignature(&enforce, t)
 }
 
+func Test_ConvertClusterConfig_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "ConvertClusterConfig",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(ConvertClusterConfigOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(&ConvertClusterConfigResult{}),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_DiffClusterTemplate_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "DiffClusterTemplate",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(DiffClusterTemplateOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(&yamlprocessor.ManifestDiff{}),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
 func Test_CreateAWSCloudFormationStack_Signature(t *testing.T) {
 	tkgClientVal := reflect.ValueOf(&tkgctl{})
 	enforce := EnforceMethodParams{
@@ -119,6 +170,209 @@ func Test_DeleteRegion_Signature(t *testing.T) {
 	enforceMethodSignature(&enforce, t)
 }
 
+func Test_BackupRegion_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "BackupRegion",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(BackupRegionOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_RestoreRegion_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "RestoreRegion",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(RestoreRegionOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_ShowClusterConfig_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "ShowClusterConfig",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(ShowClusterConfigOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(&tkgconfigreaderwriter.ResolvedConfig{}),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_DiagnoseCluster_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "DiagnoseCluster",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(DiagnoseClusterOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(""),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_DiagnoseRegion_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "DiagnoseRegion",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(DiagnoseRegionOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(""),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_WatchClusterTimeline_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "WatchClusterTimeline",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf((*context.Context)(nil)).Elem(),
+			reflect.TypeOf(WatchClusterTimelineOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_GetClusterCertificates_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "GetClusterCertificates",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(GetClusterCertificatesOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf([]client.ClusterCertificate{}),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_RotateClusterCertificates_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "RotateClusterCertificates",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(RotateClusterCertificatesOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_PauseCluster_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "PauseCluster",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(PauseClusterOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_ResumeCluster_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "ResumeCluster",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(ResumeClusterOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_UpgradeClusters_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "UpgradeClusters",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(UpgradeClustersOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(&client.BulkClusterOperationResult{}),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_ScaleClusters_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "ScaleClusters",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(ScaleClustersOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(&client.BulkClusterOperationResult{}),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
+func Test_SetMachineHealthChecks_Signature(t *testing.T) {
+	tkgClientVal := reflect.ValueOf(&tkgctl{})
+	enforce := EnforceMethodParams{
+		Target:     tkgClientVal,
+		MethodName: "SetMachineHealthChecks",
+		ParamTypes: []reflect.Type{
+			reflect.TypeOf(SetMachineHealthChecksOptions{}),
+		},
+		ReturnTypes: []reflect.Type{
+			reflect.TypeOf(&client.BulkClusterOperationResult{}),
+			reflect.TypeOf((*error)(nil)).Elem(),
+		},
+	}
+	enforceMethodSignature(&enforce, t)
+}
+
 func Test_GetCEIP_Signature(t *testing.T) {
 	tkgClientVal := reflect.ValueOf(&tkgctl{})
 	enforce := EnforceMethodParams{
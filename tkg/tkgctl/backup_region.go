@@ -0,0 +1,66 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/backup"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// BackupRegionOptions backup region options
+type BackupRegionOptions struct {
+	ClusterName string
+	// Location is a local file path or an s3://bucket/key URL
+	Location   string
+	Passphrase string
+	S3Options  backup.S3Options
+}
+
+// RestoreRegionOptions restore region options
+type RestoreRegionOptions struct {
+	ClusterName string
+	// Location is a local file path or an s3://bucket/key URL
+	Location   string
+	Passphrase string
+	S3Options  backup.S3Options
+}
+
+// BackupRegion saves the workload clusters managed by a management cluster to an encrypted backup
+func (t *tkgctl) BackupRegion(options BackupRegionOptions) error {
+	log.V(1).Infof("\nBacking up management cluster %s...\n", options.ClusterName)
+
+	err := t.tkgClient.BackupManagementCluster(client.BackupManagementClusterOptions{
+		ClusterName: options.ClusterName,
+		Location:    options.Location,
+		Passphrase:  options.Passphrase,
+		S3Options:   options.S3Options,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to back up management cluster")
+	}
+
+	log.Infof("\nManagement cluster backed up to %s\n", options.Location)
+	return nil
+}
+
+// RestoreRegion restores the workload clusters of a backup on a management cluster
+func (t *tkgctl) RestoreRegion(options RestoreRegionOptions) error {
+	log.V(1).Infof("\nRestoring backup %s to management cluster %s...\n", options.Location, options.ClusterName)
+
+	err := t.tkgClient.RestoreManagementCluster(client.RestoreManagementClusterOptions{
+		ClusterName: options.ClusterName,
+		Location:    options.Location,
+		Passphrase:  options.Passphrase,
+		S3Options:   options.S3Options,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to restore management cluster")
+	}
+
+	log.Infof("\nManagement cluster restored!\n")
+	return nil
+}
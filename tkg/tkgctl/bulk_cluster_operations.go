@@ -0,0 +1,297 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// BulkClusterOptions selects the workload clusters of a bulk cluster operation and controls its rollout
+type BulkClusterOptions struct {
+	// LabelSelector selects the workload clusters by their labels, e.g. env=dev
+	LabelSelector string
+	// Namespace restricts the selection to a namespace. All namespaces are selected if not specified.
+	Namespace string
+	// MaxConcurrent is the number of clusters operated on at the same time, 1 if not specified
+	MaxConcurrent int
+	// MaxFailures is the number of failed clusters tolerated before the remaining clusters are skipped
+	MaxFailures int
+	SkipPrompt  bool
+	// OnProgress, if set, is called each time the status of a cluster changes
+	OnProgress func(progress client.BulkClusterProgress)
+}
+
+// UpgradeClustersOptions options for upgrading the workload clusters selected by a label selector
+type UpgradeClustersOptions struct {
+	BulkClusterOptions
+	TkrVersion string
+	// ResolveTkrVersion, if set, returns the TKr version a cluster is upgraded to, e.g. from the TKr name prefix
+	// the user provided. TkrVersion is used for all the clusters otherwise.
+	ResolveTkrVersion func(cluster client.ClusterInfo) (string, error)
+	Timeout           time.Duration
+	OSName            string
+	OSVersion         string
+	OSArch            string
+	// Tanzu edition (either tce or tkg)
+	Edition string
+}
+
+// ScaleClustersOptions options for scaling the workload clusters selected by a label selector
+type ScaleClustersOptions struct {
+	BulkClusterOptions
+	WorkerCount       int32
+	ControlPlaneCount int32
+	NodePoolName      string
+}
+
+// SetMachineHealthChecksOptions options for setting the MachineHealthCheck of the workload clusters selected by a
+// label selector. The MachineHealthCheck of each cluster is named after the cluster.
+type SetMachineHealthChecksOptions struct {
+	BulkClusterOptions
+	MatchLabels         string
+	UnhealthyConditions string
+	NodeStartupTimeout  string
+}
+
+// UpgradeClusters upgrades the workload clusters selected by a label selector, reusing the single cluster upgrade
+//
+//nolint:gocritic
+func (t *tkgctl) UpgradeClusters(options UpgradeClustersOptions) (*client.BulkClusterOperationResult, error) {
+	clusters, err := t.selectClusters(options.BulkClusterOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// upgrade requires minimum 15 minutes timeout
+	minTimeoutReq := 15 * time.Minute
+	if options.Timeout < minTimeoutReq {
+		log.V(6).Infof("timeout duration of at least 15 minutes is required, using default timeout %v", constants.DefaultLongRunningOperationTimeout)
+		options.Timeout = constants.DefaultLongRunningOperationTimeout
+	}
+	defer t.restoreAfterSettingTimeout(options.Timeout)()
+
+	isPacific, err := t.tkgClient.IsPacificManagementCluster()
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve the versions of all the clusters before upgrading any
+	tkrVersions := make([]string, len(clusters))
+	k8sVersions := make([]string, len(clusters))
+	resolved := map[string][2]string{}
+	for i := range clusters {
+		tkrVersion := options.TkrVersion
+		if options.ResolveTkrVersion != nil {
+			if tkrVersion, err = options.ResolveTkrVersion(clusters[i]); err != nil {
+				return nil, errors.Wrapf(err, "unable to determine the TKr version of cluster '%s/%s'", clusters[i].Namespace, clusters[i].Name)
+			}
+		}
+		versions, ok := resolved[tkrVersion]
+		if !ok {
+			// For TKGS kubernetesVersion will be same as TkrVersion
+			versions = [2]string{tkrVersion, tkrVersion}
+			if !isPacific {
+				if versions[0], versions[1], err = t.getAndDownloadTkrIfNeeded(tkrVersion); err != nil {
+					return nil, errors.Wrapf(err, "unable to determine the TKr version and kubernetes version based on '%v'", tkrVersion)
+				}
+			}
+			resolved[tkrVersion] = versions
+		}
+		tkrVersions[i], k8sVersions[i] = versions[0], versions[1]
+	}
+
+	if !options.SkipPrompt {
+		var msg strings.Builder
+		fmt.Fprintf(&msg, "Upgrading %d workload clusters:\n", len(clusters))
+		for i := range clusters {
+			fmt.Fprintf(&msg, "  %s/%s to kubernetes version '%s'\n", clusters[i].Namespace, clusters[i].Name, k8sVersions[i])
+		}
+		msg.WriteString("Are you sure?")
+		if err := askForConfirmation(msg.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	index := make(map[string]int, len(clusters))
+	for i := range clusters {
+		index[clusters[i].Namespace+"/"+clusters[i].Name] = i
+	}
+	return t.runBulkClusterOperation(clusters, options.BulkClusterOptions, func(tkgClient client.Client, cluster client.ClusterInfo) error {
+		i := index[cluster.Namespace+"/"+cluster.Name]
+		tkgClient.ConfigureTimeout(options.Timeout)
+		return tkgClient.UpgradeCluster(&client.UpgradeClusterOptions{
+			ClusterName:       cluster.Name,
+			Namespace:         cluster.Namespace,
+			KubernetesVersion: k8sVersions[i],
+			TkrVersion:        tkrVersions[i],
+			Kubeconfig:        t.kubeconfig,
+			IsRegionalCluster: false,
+			OSName:            options.OSName,
+			OSVersion:         options.OSVersion,
+			OSArch:            options.OSArch,
+			Edition:           options.Edition,
+			IsTKGSCluster:     isPacific,
+		})
+	})
+}
+
+// ScaleClusters scales the workload clusters selected by a label selector, reusing the single cluster scaling
+//
+//nolint:gocritic
+func (t *tkgctl) ScaleClusters(options ScaleClustersOptions) (*client.BulkClusterOperationResult, error) {
+	if options.ControlPlaneCount <= 0 && options.WorkerCount <= 0 {
+		return nil, errors.New("incorrect machine counts provided. Machine count value for control-plane and workers must be greater than 0")
+	}
+
+	clusters, err := t.selectClusters(options.BulkClusterOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Scaling %d workload clusters: %s. Are you sure?", len(clusters), clusterKeys(clusters))); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.runBulkClusterOperation(clusters, options.BulkClusterOptions, func(tkgClient client.Client, cluster client.ClusterInfo) error {
+		return tkgClient.ScaleCluster(client.ScaleClusterOptions{
+			Kubeconfig:        t.kubeconfig,
+			Namespace:         cluster.Namespace,
+			ClusterName:       cluster.Name,
+			WorkerCount:       options.WorkerCount,
+			ControlPlaneCount: options.ControlPlaneCount,
+			NodePoolName:      options.NodePoolName,
+		})
+	})
+}
+
+// SetMachineHealthChecks applies a machine health check to the workload clusters selected by a label selector,
+// reusing the single cluster machine health check
+//
+//nolint:gocritic
+func (t *tkgctl) SetMachineHealthChecks(options SetMachineHealthChecksOptions) (*client.BulkClusterOperationResult, error) {
+	clusters, err := t.selectClusters(options.BulkClusterOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Setting the MachineHealthCheck of %d workload clusters: %s. Are you sure?", len(clusters), clusterKeys(clusters))); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.runBulkClusterOperation(clusters, options.BulkClusterOptions, func(tkgClient client.Client, cluster client.ClusterInfo) error {
+		return tkgClient.SetMachineHealthCheck(newSetMachineHealthCheckOptions(SetMachineHealthCheckOptions{
+			ClusterName:         cluster.Name,
+			Namespace:           cluster.Namespace,
+			MatchLabels:         options.MatchLabels,
+			UnhealthyConditions: options.UnhealthyConditions,
+			NodeStartupTimeout:  options.NodeStartupTimeout,
+		}))
+	})
+}
+
+// selectClusters returns the workload clusters matching the label selector, sorted by namespace and name
+func (t *tkgctl) selectClusters(options BulkClusterOptions) ([]client.ClusterInfo, error) { //nolint:gocritic
+	if options.LabelSelector == "" {
+		return nil, errors.New("a label selector is required to select the clusters")
+	}
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid label selector '%s'", options.LabelSelector)
+	}
+
+	clusters, err := t.GetClusters(ListTKGClustersOptions{
+		Namespace:     options.Namespace,
+		AllNamespaces: options.Namespace == "",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []client.ClusterInfo
+	for i := range clusters {
+		if selector.Matches(labels.Set(clusters[i].Labels)) {
+			selected = append(selected, clusters[i])
+		}
+	}
+	if len(selected) == 0 {
+		return nil, errors.Errorf("no workload cluster matches the label selector '%s'", options.LabelSelector)
+	}
+	return selected, nil
+}
+
+// runBulkClusterOperation runs operation on the clusters and logs the progress and the summary. The single cluster
+// code paths keep their state in the configuration of the tkg client, so each concurrent operation gets a tkg client
+// of its own.
+func (t *tkgctl) runBulkClusterOperation(clusters []client.ClusterInfo, options BulkClusterOptions, //nolint:gocritic
+	operation func(tkgClient client.Client, cluster client.ClusterInfo) error) (*client.BulkClusterOperationResult, error) {
+
+	tkgClients := make(chan client.Client, len(clusters))
+	tkgClients <- t.tkgClient
+	getTKGClient := func() (client.Client, error) {
+		select {
+		case tkgClient := <-tkgClients:
+			return tkgClient, nil
+		default:
+			if t.newTKGClient == nil {
+				return t.tkgClient, nil
+			}
+			return t.newTKGClient()
+		}
+	}
+
+	total := len(clusters)
+	result := client.RunBulkClusterOperation(clusters, client.BulkClusterOperationOptions{
+		MaxConcurrent: options.MaxConcurrent,
+		MaxFailures:   options.MaxFailures,
+		OnProgress: func(progress client.BulkClusterProgress) {
+			switch progress.Status {
+			case client.BulkClusterFailed:
+				log.Warningf("Cluster '%s/%s': %s after %s: %s", progress.Namespace, progress.Name, progress.Status,
+					progress.Duration.Round(time.Second), progress.Error)
+			case client.BulkClusterSucceeded:
+				log.Infof("Cluster '%s/%s': %s after %s", progress.Namespace, progress.Name, progress.Status, progress.Duration.Round(time.Second))
+			default:
+				log.Infof("Cluster '%s/%s': %s", progress.Namespace, progress.Name, progress.Status)
+			}
+			if options.OnProgress != nil {
+				options.OnProgress(progress)
+			}
+		},
+	}, func(cluster client.ClusterInfo) error {
+		tkgClient, err := getTKGClient()
+		if err != nil {
+			return errors.Wrap(err, "unable to create tkg client")
+		}
+		defer func() { tkgClients <- tkgClient }()
+		return operation(tkgClient, cluster)
+	})
+
+	succeeded := result.Count(client.BulkClusterSucceeded)
+	failed := result.Count(client.BulkClusterFailed)
+	skipped := result.Count(client.BulkClusterSkipped)
+	log.Infof("%d of %d clusters succeeded, %d failed, %d skipped", succeeded, total, failed, skipped)
+	if failed > 0 || skipped > 0 {
+		return result, errors.Errorf("%d of %d clusters failed, %d skipped", failed, total, skipped)
+	}
+	return result, nil
+}
+
+func clusterKeys(clusters []client.ClusterInfo) string {
+	keys := make([]string, len(clusters))
+	for i := range clusters {
+		keys[i] = clusters[i].Namespace + "/" + clusters[i].Name
+	}
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+var _ = Describe("Unit test for bulk cluster operations", func() {
+	var (
+		ctl       tkgctl
+		tkgClient *fakes.Client
+		bulk      BulkClusterOptions
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		tkgClient.ListTKGClustersReturns([]client.ClusterInfo{
+			{Name: "wc-3", Namespace: "dev", Labels: map[string]string{"env": "dev"}},
+			{Name: "wc-1", Namespace: "default", Labels: map[string]string{"env": "dev"}},
+			{Name: "wc-2", Namespace: "default", Labels: map[string]string{"env": "prod"}},
+			{Name: "wc-4", Namespace: "dev", Labels: map[string]string{"env": "dev"}},
+		}, nil)
+		ctl = tkgctl{
+			configDir:         testingDir,
+			tkgClient:         tkgClient,
+			kubeconfig:        "./kube",
+			featureGateHelper: &fakes.FakeFeatureGateHelper{},
+		}
+		bulk = BulkClusterOptions{LabelSelector: "env=dev", SkipPrompt: true}
+	})
+
+	Context("ScaleClusters", func() {
+		It("should scale the selected clusters in order", func() {
+			result, err := ctl.ScaleClusters(ScaleClustersOptions{BulkClusterOptions: bulk, WorkerCount: 3})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Count(client.BulkClusterSucceeded)).To(Equal(3))
+
+			Expect(tkgClient.ScaleClusterCallCount()).To(Equal(3))
+			var scaled []string
+			for i := 0; i < tkgClient.ScaleClusterCallCount(); i++ {
+				options := tkgClient.ScaleClusterArgsForCall(i)
+				Expect(options.WorkerCount).To(Equal(int32(3)))
+				scaled = append(scaled, options.Namespace+"/"+options.ClusterName)
+			}
+			Expect(scaled).To(Equal([]string{"default/wc-1", "dev/wc-3", "dev/wc-4"}))
+		})
+
+		It("should stop once more clusters failed than tolerated", func() {
+			tkgClient.ScaleClusterReturns(errors.New("scale failed"))
+			result, err := ctl.ScaleClusters(ScaleClustersOptions{BulkClusterOptions: bulk, WorkerCount: 3})
+			Expect(err).To(MatchError("1 of 3 clusters failed, 2 skipped"))
+			Expect(tkgClient.ScaleClusterCallCount()).To(Equal(1))
+			Expect(result.Clusters[0].Error).To(Equal("scale failed"))
+		})
+
+		It("should fail when no cluster matches the label selector", func() {
+			bulk.LabelSelector = "env=staging"
+			_, err := ctl.ScaleClusters(ScaleClustersOptions{BulkClusterOptions: bulk, WorkerCount: 3})
+			Expect(err).To(MatchError("no workload cluster matches the label selector 'env=staging'"))
+		})
+
+		It("should fail when the label selector is invalid", func() {
+			bulk.LabelSelector = "env in (dev"
+			_, err := ctl.ScaleClusters(ScaleClustersOptions{BulkClusterOptions: bulk, WorkerCount: 3})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid label selector 'env in (dev'"))
+		})
+	})
+
+	Context("SetMachineHealthChecks", func() {
+		It("should set the MachineHealthCheck of the selected clusters", func() {
+			bulk.Namespace = "dev"
+			_, err := ctl.SetMachineHealthChecks(SetMachineHealthChecksOptions{
+				BulkClusterOptions:  bulk,
+				UnhealthyConditions: "Ready:False:5m,Ready:Unknown:5m",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tkgClient.ListTKGClustersArgsForCall(0).Namespace).To(Equal("dev"))
+			Expect(tkgClient.SetMachineHealthCheckCallCount()).To(Equal(3))
+			options := tkgClient.SetMachineHealthCheckArgsForCall(0)
+			Expect(options.ClusterName).To(Equal("wc-1"))
+			Expect(options.MachineHealthCheckName).To(BeEmpty())
+			Expect(options.UnhealthyConditions).To(Equal([]string{"Ready:False:5m", "Ready:Unknown:5m"}))
+		})
+	})
+
+	Context("UpgradeClusters", func() {
+		It("should upgrade each selected cluster to its resolved TKr version", func() {
+			tkgClient.IsPacificManagementClusterReturns(true, nil)
+			_, err := ctl.UpgradeClusters(UpgradeClustersOptions{
+				BulkClusterOptions: bulk,
+				ResolveTkrVersion: func(cluster client.ClusterInfo) (string, error) {
+					return "v1.24.9---vmware.1-tkg." + cluster.Name, nil
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tkgClient.UpgradeClusterCallCount()).To(Equal(3))
+			options := tkgClient.UpgradeClusterArgsForCall(1)
+			Expect(options.ClusterName).To(Equal("wc-3"))
+			Expect(options.Namespace).To(Equal("dev"))
+			Expect(options.TkrVersion).To(Equal("v1.24.9---vmware.1-tkg.wc-3"))
+			Expect(options.IsTKGSCluster).To(BeTrue())
+		})
+
+		It("should not upgrade any cluster when the TKr version of a cluster cannot be resolved", func() {
+			tkgClient.IsPacificManagementClusterReturns(true, nil)
+			_, err := ctl.UpgradeClusters(UpgradeClustersOptions{
+				BulkClusterOptions: bulk,
+				ResolveTkrVersion: func(cluster client.ClusterInfo) (string, error) {
+					if cluster.Name == "wc-4" {
+						return "", errors.New("no compatible TKr")
+					}
+					return "v1.24.9---vmware.1-tkg.1", nil
+				},
+			})
+			Expect(err).To(MatchError("unable to determine the TKr version of cluster 'dev/wc-4': no compatible TKr"))
+			Expect(tkgClient.UpgradeClusterCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -41,6 +41,8 @@ type tkgctl struct {
 	providerGetter           providerinterface.ProviderInterface
 	tkgConfigReaderWriter    tkgconfigreaderwriter.TKGConfigReaderWriter
 	featureGateHelper        FeatureGateHelper
+	// newTKGClient creates a tkg client of its own for each concurrent operation of a bulk cluster operation
+	newTKGClient func() (client.Client, error)
 }
 
 // LoggingOptions options to configure logging with tkgctl client
@@ -167,6 +169,13 @@ func New(options Options) (TKGClient, error) { //nolint:gocritic
 	}
 	allClients.ConfigClient.TKGConfigReaderWriter().Set(constants.ConfigVariableDefaultBomFile, defaultBoMFileName)
 	clusterClientOptions := clusterclient.Options{GetClientInterval: 2 * time.Second, GetClientTimeout: 5 * time.Second}
+	newTKGClient := func() (client.Client, error) {
+		workerClient, err := New(options)
+		if err != nil {
+			return nil, err
+		}
+		return workerClient.(*tkgctl).tkgClient, nil
+	}
 	return &tkgctl{
 		configDir:                options.ConfigDir,
 		kubeconfig:               options.KubeConfig,
@@ -180,6 +189,7 @@ func New(options Options) (TKGClient, error) { //nolint:gocritic
 		providerGetter:           options.ProviderGetter,
 		tkgConfigReaderWriter:    allClients.ConfigClient.TKGConfigReaderWriter(),
 		featureGateHelper:        NewFeatureGateHelper(&clusterClientOptions, options.KubeContext, options.KubeConfig),
+		newTKGClient:             newTKGClient,
 	}, nil
 }
 
@@ -0,0 +1,67 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// GetClusterCertificatesOptions get cluster certificates options
+type GetClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// RotateClusterCertificatesOptions rotate cluster certificates options
+type RotateClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+	// SkipWait returns once the rollout of the control plane is started
+	SkipWait   bool
+	SkipPrompt bool
+}
+
+// GetClusterCertificates returns the expiry dates of the kubeadm certificates of the control plane machines and
+// of the certificate authorities of a workload cluster, sorted by expiry
+func (t *tkgctl) GetClusterCertificates(options GetClusterCertificatesOptions) ([]client.ClusterCertificate, error) {
+	certificates, err := t.tkgClient.GetClusterCertificates(client.GetClusterCertificatesOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster certificates")
+	}
+	return certificates, nil
+}
+
+// RotateClusterCertificates renews the kubeadm certificates of the control plane nodes of a workload cluster by
+// rolling out its control plane
+func (t *tkgctl) RotateClusterCertificates(options RotateClusterCertificatesOptions) error {
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Rotating the certificates of workload cluster '%s' replaces all its control plane nodes. Are you sure?", options.ClusterName)); err != nil {
+			return err
+		}
+	}
+
+	err := t.tkgClient.RotateClusterCertificates(client.RotateClusterCertificatesOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+		SkipWait:    options.SkipWait,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to rotate cluster certificates")
+	}
+
+	if options.SkipWait {
+		log.Infof("\nControl plane rollout of workload cluster %s started, the certificates are renewed as the machines are replaced\n", options.ClusterName)
+	} else {
+		log.Infof("\nCertificates of workload cluster %s renewed\n", options.ClusterName)
+	}
+	return nil
+}
@@ -0,0 +1,687 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/utils/pointer"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/util/topology"
+)
+
+const (
+	defaultMachineDeploymentClass = "tkg-worker"
+	clusterAttributeMetadataName  = "metadata.name"
+	clusterAttributeMetadataNS    = "metadata.namespace"
+	clusterAttributePodCIDRs      = "spec.clusterNetwork.pods.cidrBlocks"
+	clusterAttributeServiceCIDRs  = "spec.clusterNetwork.services.cidrBlocks"
+	clusterAttributeVersion       = "spec.topology.version"
+	clusterAttributeCPReplicas    = "spec.topology.controlPlane.replicas"
+	clusterAttributeProxy         = "spec.topology.variables.proxy"
+	clusterAttributeTLSValidation = "spec.topology.variables.imageRepository.tlsCertificateValidation"
+)
+
+var regExMachineDeploymentAttribute = regexp.MustCompile(`^spec\.topology\.workers\.machineDeployments\.([0-9]+)\.(.+)$`)
+
+// legacyVariablesConsumedByConversion are legacy variables which have no Cluster attribute of their own,
+// but are used to build the Cluster object, so they are not reported as unmapped
+var legacyVariablesConsumedByConversion = map[string]bool{
+	constants.ConfigVariableProviderType:             true,
+	constants.ConfigVariableClusterPlan:              true,
+	constants.ConfigVariableIPFamily:                 true,
+	constants.ConfigVariableVsphereUsername:          true,
+	constants.ConfigVariableVspherePassword:          true,
+	constants.ConfigVariableInfraProvider:            true,
+	constants.ConfigVariableWorkerMachineCount:       true,
+	constants.ConfigVariableWorkerMachineCount0:      true,
+	constants.ConfigVariableIsWindowsWorkloadCluster: true,
+}
+
+// awsNodeAzVariables are the availability zones of the machineDeployments of AWS prod plan clusters, which default
+// to the zones a, b and c of AWS_REGION
+var awsNodeAzVariables = []string{constants.ConfigVariableAWSNodeAz, constants.ConfigVariableAWSNodeAz1, constants.ConfigVariableAWSNodeAz2}
+
+// workerMachineCountVariables are the replicas of the machineDeployments of prod plan clusters
+var workerMachineCountVariables = []string{constants.ConfigVariableWorkerMachineCount0, constants.ConfigVariableWorkerMachineCount1, constants.ConfigVariableWorkerMachineCount2}
+
+// ConvertClusterConfigOptions has the options to convert a legacy cluster configuration file
+type ConvertClusterConfigOptions struct {
+	// ClusterConfigFile is the legacy cluster configuration file with flat configuration variables
+	ClusterConfigFile string
+	// ClusterName overrides the CLUSTER_NAME of the configuration file
+	ClusterName string
+	// Namespace overrides the NAMESPACE of the configuration file
+	Namespace string
+}
+
+// ConvertClusterConfigResult is the ClusterClass based Cluster object built from a legacy cluster configuration file
+type ConvertClusterConfigResult struct {
+	Cluster *capi.Cluster
+	// VSphereCredentials is the Secret holding VSPHERE_USERNAME and VSPHERE_PASSWORD, vSphere clusters only
+	VSphereCredentials *corev1.Secret
+	// UnmappedVariables are the configuration variables which have no equivalent in the Cluster object
+	UnmappedVariables []string
+}
+
+// ConvertClusterConfig converts a legacy cluster configuration file into a ClusterClass based Cluster object,
+// mapping the configuration variables onto the Cluster topology variables and machineDeployment overrides
+func (t *tkgctl) ConvertClusterConfig(options ConvertClusterConfigOptions) (*ConvertClusterConfigResult, error) {
+	if options.ClusterConfigFile == "" {
+		return nil, errors.New("cluster configuration file is required")
+	}
+	content, err := os.ReadFile(options.ClusterConfigFile)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Unable to read input file: %v ", options.ClusterConfigFile))
+	}
+	isClusterClassBased, _, err := CheckIfInputFileIsClusterClassBased(options.ClusterConfigFile)
+	if err == nil && isClusterClassBased {
+		return nil, errors.Errorf("input file %v is already a ClusterClass based Cluster configuration", options.ClusterConfigFile)
+	}
+
+	legacyVars := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &legacyVars); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Input file content is not yaml formatted, file path: %v", options.ClusterConfigFile))
+	}
+	if options.ClusterName != "" {
+		legacyVars[constants.ConfigVariableClusterName] = options.ClusterName
+	}
+	if options.Namespace != "" {
+		legacyVars[constants.ConfigVariableNamespace] = options.Namespace
+	}
+	return convertLegacyClusterConfig(legacyVars, t.clusterClassVariableSchemas)
+}
+
+// clusterClassVariableSchemas reads the schemas of the variables of the default ClusterClass of the infrastructure
+// provider from the provider templates in the TKG providers directory. The annotations of the ClusterClass ytt
+// template are YAML comments, so that the variable definitions can be read without processing the template.
+func (t *tkgctl) clusterClassVariableSchemas(providerName string) (map[string]capi.JSONSchemaProps, error) {
+	providersDir, err := t.tkgConfigPathsClient.GetTKGProvidersDirectory()
+	if err != nil {
+		return nil, err
+	}
+	templatePaths, err := filepath.Glob(filepath.Join(providersDir, "infrastructure-"+providerName, "*", "cconly", "base.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	if len(templatePaths) == 0 {
+		return nil, errors.Errorf("unable to find the ClusterClass of infrastructure provider %q in %s", providerName, providersDir)
+	}
+	// use the ClusterClass of the latest provider version
+	sort.Slice(templatePaths, func(i, j int) bool {
+		return providerVersionOfTemplate(templatePaths[i]).LessThan(providerVersionOfTemplate(templatePaths[j]))
+	})
+	templatePath := templatePaths[len(templatePaths)-1]
+	content, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the ClusterClass template %s", templatePath)
+	}
+	for _, document := range strings.Split(string(content), "\n---") {
+		clusterClass := &capi.ClusterClass{}
+		if err := yaml.Unmarshal([]byte(document), clusterClass); err != nil || clusterClass.Kind != "ClusterClass" {
+			continue
+		}
+		schemas := map[string]capi.JSONSchemaProps{}
+		for i := range clusterClass.Spec.Variables {
+			schemas[clusterClass.Spec.Variables[i].Name] = clusterClass.Spec.Variables[i].Schema.OpenAPIV3Schema
+		}
+		return schemas, nil
+	}
+	return nil, errors.Errorf("no ClusterClass found in the ClusterClass template %s", templatePath)
+}
+
+// providerVersionOfTemplate returns the provider version of providers/infrastructure-<name>/<version>/cconly/base.yaml
+func providerVersionOfTemplate(templatePath string) *version.Version {
+	v, err := version.ParseSemantic(filepath.Base(filepath.Dir(filepath.Dir(templatePath))))
+	if err != nil {
+		return version.MustParseSemantic("v0.0.0")
+	}
+	return v
+}
+
+// convertLegacyClusterConfig builds the Cluster object from the legacy configuration variables, using the
+// infrastructure specific Cluster attribute to legacy variable mapping in reverse. The values of the variables are
+// converted to the types of the variable schemas of the ClusterClass of the infrastructure provider.
+func convertLegacyClusterConfig(legacyVars map[string]interface{}, variableSchemas func(providerName string) (map[string]capi.JSONSchemaProps, error)) (*ConvertClusterConfigResult, error) {
+	infraProvider := legacyVariableString(legacyVars, constants.ConfigVariableInfraProvider)
+	if infraProvider == "" {
+		return nil, errors.Errorf("%s is required to convert the cluster configuration", constants.ConfigVariableInfraProvider)
+	}
+	providerName, _, err := client.ParseProviderName(infraProvider)
+	if err != nil {
+		return nil, err
+	}
+	clusterAttributePathToLegacyVarNameMap, ok := constants.InfrastructureSpecificVariableMappingMap[providerName]
+	if !ok {
+		return nil, errors.Errorf("converting the cluster configuration of infrastructure provider %q is not supported", providerName)
+	}
+	if legacyVariableString(legacyVars, constants.ConfigVariableClusterName) == "" {
+		return nil, errors.Errorf("%s is required to convert the cluster configuration", constants.ConfigVariableClusterName)
+	}
+
+	// several Cluster attributes may map to the same legacy variable
+	legacyVarNameToClusterAttributePaths := map[string][]string{}
+	for attributePath, legacyVarName := range clusterAttributePathToLegacyVarNameMap {
+		if legacyVarName != "" {
+			legacyVarNameToClusterAttributePaths[legacyVarName] = append(legacyVarNameToClusterAttributePaths[legacyVarName], attributePath)
+		}
+	}
+
+	className := legacyVariableString(legacyVars, constants.ConfigVariableClusterClass)
+	if className == "" {
+		className = fmt.Sprintf("tkg-%s-default-%s", providerName, constants.DefaultClusterClassVersion)
+	}
+	cluster := &capi.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: capi.GroupVersion.String(),
+			Kind:       constants.KindCluster,
+		},
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultNamespace},
+		Spec: capi.ClusterSpec{
+			Topology: &capi.Topology{
+				Class:   className,
+				Version: legacyVariableString(legacyVars, constants.ConfigVariableKubernetesVersion),
+				Workers: &capi.WorkersTopology{},
+			},
+		},
+	}
+
+	proxyEnabled, _ := strconv.ParseBool(legacyVariableString(legacyVars, constants.TKGHTTPProxyEnabled))
+
+	clusterVariables := map[string]interface{}{}
+	mdOverrides := map[int]map[string]interface{}{}
+	var unmapped []string
+	for _, legacyVarName := range sortedLegacyVariableNames(legacyVars) {
+		value := legacyVars[legacyVarName]
+		if value == nil || fmt.Sprintf("%v", value) == "" {
+			continue
+		}
+		attributePaths, ok := legacyVarNameToClusterAttributePaths[legacyVarName]
+		if !ok {
+			if !legacyVariablesConsumedByConversion[legacyVarName] {
+				unmapped = append(unmapped, legacyVarName)
+			}
+			continue
+		}
+		sort.Strings(attributePaths)
+		for _, attributePath := range attributePaths {
+			if strings.HasPrefix(attributePath, clusterAttributeProxy) && !proxyEnabled {
+				continue
+			}
+			if err := setClusterAttribute(cluster, clusterVariables, mdOverrides, attributePath, legacyVarName, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// TKG_IP_FAMILY has no Cluster attribute, the primary IP family is given by the network.ipv6Primary variable
+	ipFamily := legacyVariableString(legacyVars, constants.ConfigVariableIPFamily)
+	if _, ok := legacyVars[constants.TKGIPV6Primary]; !ok && strings.HasPrefix(ipFamily, constants.IPv6Family) {
+		if err := setClusterAttribute(cluster, clusterVariables, mdOverrides, "spec.topology.variables.network.ipv6Primary", constants.ConfigVariableIPFamily, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyClusterPlan(cluster, legacyVars, providerName); err != nil {
+		return nil, err
+	}
+
+	schemas, err := variableSchemas(providerName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the cluster variable schemas")
+	}
+	for _, name := range sortedLegacyVariableNames(clusterVariables) {
+		value, err := coerceVariableValue(name, clusterVariables[name], schemas[name])
+		if err != nil {
+			return nil, err
+		}
+		if err := topology.SetVariable(cluster, name, value); err != nil {
+			return nil, errors.Wrapf(err, "unable to set the cluster variable %s", name)
+		}
+	}
+	for _, overrides := range mdOverrides {
+		for name := range overrides {
+			if overrides[name], err = coerceVariableValue(name, overrides[name], schemas[name]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := setMachineDeploymentOverrides(cluster, mdOverrides); err != nil {
+		return nil, err
+	}
+
+	result := &ConvertClusterConfigResult{
+		Cluster:           cluster,
+		UnmappedVariables: unmapped,
+	}
+	// VSPHERE_USERNAME and VSPHERE_PASSWORD do not have mapping to any Cluster variables, the Secret named after the cluster
+	// in the cluster namespace is where the ClusterClass based cluster creation retrieves them from
+	if providerName == constants.InfrastructureProviderVSphere {
+		result.VSphereCredentials = vsphereCredentialsSecret(cluster, legacyVars)
+	}
+	return result, nil
+}
+
+// applyClusterPlan sets the Cluster attributes derived from CLUSTER_PLAN which are not given by the legacy variables:
+// the control plane replicas and the machineDeployments, one per availability zone for the prod plan.
+func applyClusterPlan(cluster *capi.Cluster, legacyVars map[string]interface{}, providerName string) error {
+	isProdPlan := client.IsProdPlan(legacyVariableString(legacyVars, constants.ConfigVariableClusterPlan))
+	if cluster.Spec.Topology.ControlPlane.Replicas == nil {
+		replicas := int32(constants.DefaultDevControlPlaneMachineCount)
+		if isProdPlan {
+			replicas = constants.DefaultProdControlPlaneMachineCount
+		}
+		cluster.Spec.Topology.ControlPlane.Replicas = pointer.Int32(replicas)
+	}
+
+	isWindowsCluster, _ := strconv.ParseBool(legacyVariableString(legacyVars, constants.ConfigVariableIsWindowsWorkloadCluster))
+	if !isProdPlan || providerName == constants.InfrastructureProviderDocker || isWindowsCluster {
+		md := ensureMachineDeployment(cluster, 0)
+		if md.Replicas != nil {
+			return nil
+		}
+		replicas := int32(constants.DefaultDevWorkerMachineCount)
+		for _, legacyVarName := range []string{constants.ConfigVariableWorkerMachineCount0, constants.ConfigVariableWorkerMachineCount} {
+			if value := legacyVariableString(legacyVars, legacyVarName); value != "" {
+				var err error
+				if replicas, err = legacyValueToInt32(legacyVarName, value); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		md.Replicas = pointer.Int32(replicas)
+		return nil
+	}
+
+	// as for legacy prod plan clusters, the workers are distributed across the availability zones unless the
+	// replicas of every machineDeployment are given
+	workerCounts := make([]int32, len(workerMachineCountVariables))
+	predefinedDistribution := true
+	for i, legacyVarName := range workerMachineCountVariables {
+		value := legacyVariableString(legacyVars, legacyVarName)
+		if value == "" {
+			predefinedDistribution = false
+			break
+		}
+		var err error
+		if workerCounts[i], err = legacyValueToInt32(legacyVarName, value); err != nil {
+			return err
+		}
+	}
+	if !predefinedDistribution {
+		workerMachineCount := int32(constants.DefaultProdWorkerMachineCount)
+		if value := legacyVariableString(legacyVars, constants.ConfigVariableWorkerMachineCount); value != "" {
+			var err error
+			if workerMachineCount, err = legacyValueToInt32(constants.ConfigVariableWorkerMachineCount, value); err != nil {
+				return err
+			}
+		}
+		for i, count := range client.DistributeWorkersAcrossAZs(int64(workerMachineCount)) {
+			workerCounts[i] = int32(count)
+		}
+	}
+
+	region := legacyVariableString(legacyVars, constants.ConfigVariableAWSRegion)
+	for i := range workerCounts {
+		md := ensureMachineDeployment(cluster, i)
+		md.Replicas = pointer.Int32(workerCounts[i])
+		if providerName != constants.InfrastructureProviderAWS || md.FailureDomain != nil {
+			continue
+		}
+		if az := legacyVariableString(legacyVars, awsNodeAzVariables[i]); az != "" {
+			md.FailureDomain = pointer.String(az)
+		} else if region != "" {
+			md.FailureDomain = pointer.String(region + string(rune('a'+i)))
+		}
+	}
+	return nil
+}
+
+// coerceVariableValue converts the legacy variable value to the type of the cluster variable schema, as values of
+// the legacy configuration file such as quoted numbers may not have the type expected by the ClusterClass
+func coerceVariableValue(path string, value interface{}, schema capi.JSONSchemaProps) (interface{}, error) {
+	invalid := func(err error) error {
+		return errors.Wrapf(err, "invalid value %v of cluster variable %s, expected %s", value, path, schema.Type)
+	}
+	switch schema.Type {
+	case "integer":
+		switch v := value.(type) {
+		case string:
+			number, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, invalid(err)
+			}
+			return number, nil
+		case float64:
+			if v != float64(int64(v)) {
+				return nil, invalid(errors.New("not an integer"))
+			}
+			return int64(v), nil
+		}
+	case "number":
+		if v, ok := value.(string); ok {
+			number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, invalid(err)
+			}
+			return number, nil
+		}
+	case "boolean":
+		if v, ok := value.(string); ok {
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, invalid(err)
+			}
+			return b, nil
+		}
+	case "string":
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool, int, int64:
+			return fmt.Sprintf("%v", v), nil
+		}
+	case "array":
+		list, ok := value.([]interface{})
+		if !ok || schema.Items == nil {
+			return value, nil
+		}
+		coerced := make([]interface{}, len(list))
+		for i := range list {
+			var err error
+			if coerced[i], err = coerceVariableValue(fmt.Sprintf("%s.%d", path, i), list[i], *schema.Items); err != nil {
+				return nil, err
+			}
+		}
+		return coerced, nil
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value, nil
+		}
+		coerced := make(map[string]interface{}, len(object))
+		for key, fieldValue := range object {
+			fieldSchema, ok := schema.Properties[key]
+			if !ok && schema.AdditionalProperties != nil {
+				fieldSchema, ok = *schema.AdditionalProperties, true
+			}
+			if !ok {
+				coerced[key] = fieldValue
+				continue
+			}
+			var err error
+			if coerced[key], err = coerceVariableValue(path+"."+key, fieldValue, fieldSchema); err != nil {
+				return nil, err
+			}
+		}
+		return coerced, nil
+	}
+	return value, nil
+}
+
+// setClusterAttribute assigns the legacy variable value to the Cluster attribute. The topology variables and machineDeployment overrides
+// are collected in clusterVariables and mdOverrides, and set once all the legacy variables are processed.
+func setClusterAttribute(cluster *capi.Cluster, clusterVariables map[string]interface{}, mdOverrides map[int]map[string]interface{}, attributePath, legacyVarName string, value interface{}) error {
+	if constants.ClusterAttributesWithArrayTypeValue[attributePath] {
+		value = commaSeparatedValueToArray(value)
+	}
+
+	switch attributePath {
+	case clusterAttributeMetadataName:
+		cluster.Name = fmt.Sprintf("%v", value)
+		return nil
+	case clusterAttributeMetadataNS:
+		cluster.Namespace = fmt.Sprintf("%v", value)
+		return nil
+	case clusterAttributePodCIDRs:
+		ensureClusterNetwork(cluster).Pods = &capi.NetworkRanges{CIDRBlocks: toStringSlice(value)}
+		return nil
+	case clusterAttributeServiceCIDRs:
+		ensureClusterNetwork(cluster).Services = &capi.NetworkRanges{CIDRBlocks: toStringSlice(value)}
+		return nil
+	case constants.TopologyClass, clusterAttributeVersion:
+		// already set while creating the Cluster object
+		return nil
+	case clusterAttributeCPReplicas:
+		replicas, err := legacyValueToInt32(legacyVarName, value)
+		if err != nil {
+			return err
+		}
+		cluster.Spec.Topology.ControlPlane.Replicas = pointer.Int32(replicas)
+		return nil
+	case clusterAttributeProxy:
+		// TKG_HTTP_PROXY_ENABLED only tells whether the proxy variable is set, its content comes from the other proxy settings
+		return nil
+	case clusterAttributeTLSValidation:
+		// TKG_CUSTOM_IMAGE_REPOSITORY_SKIP_TLS_VERIFY is the opposite of imageRepository.tlsCertificateValidation
+		skipTLSVerify, err := strconv.ParseBool(fmt.Sprintf("%v", value))
+		if err != nil {
+			return errors.Wrapf(err, "invalid value %v of %s", value, legacyVarName)
+		}
+		value = !skipTLSVerify
+	}
+
+	if strings.HasPrefix(attributePath, constants.TopologyVariablesTrust+".") {
+		// trust is an array of certificates each having a name and data
+		trustName := strings.TrimPrefix(attributePath, constants.TopologyVariablesTrust+".")
+		trust, _ := clusterVariables["trust"].([]interface{})
+		clusterVariables["trust"] = append(trust, map[string]interface{}{"name": trustName, "data": value})
+		return nil
+	}
+
+	if strings.HasPrefix(attributePath, constants.TopologyVariables+".") {
+		segments := strings.Split(strings.TrimPrefix(attributePath, constants.TopologyVariables+"."), ".")
+		clusterVariables[segments[0]] = setNestedVariableValue(clusterVariables[segments[0]], segments[1:], value)
+		return nil
+	}
+
+	if matches := regExMachineDeploymentAttribute.FindStringSubmatch(attributePath); matches != nil {
+		mdIndex, _ := strconv.Atoi(matches[1])
+		md := ensureMachineDeployment(cluster, mdIndex)
+		switch field := matches[2]; {
+		case field == "replicas":
+			replicas, err := legacyValueToInt32(legacyVarName, value)
+			if err != nil {
+				return err
+			}
+			md.Replicas = pointer.Int32(replicas)
+		case field == "failureDomain":
+			md.FailureDomain = pointer.String(fmt.Sprintf("%v", value))
+		case strings.HasPrefix(field, "variables.overrides."):
+			segments := strings.Split(strings.TrimPrefix(field, "variables.overrides."), ".")
+			if mdOverrides[mdIndex] == nil {
+				mdOverrides[mdIndex] = map[string]interface{}{}
+			}
+			mdOverrides[mdIndex][segments[0]] = setNestedVariableValue(mdOverrides[mdIndex][segments[0]], segments[1:], value)
+		default:
+			return errors.Errorf("unsupported Cluster attribute %s for %s", attributePath, legacyVarName)
+		}
+		return nil
+	}
+
+	return errors.Errorf("unsupported Cluster attribute %s for %s", attributePath, legacyVarName)
+}
+
+// setMachineDeploymentOverrides sets the machineDeployment overrides on top of the cluster variable value, so that
+// an override of a nested field keeps the other fields of the cluster variable.
+func setMachineDeploymentOverrides(cluster *capi.Cluster, mdOverrides map[int]map[string]interface{}) error {
+	mdIndexes := make([]int, 0, len(mdOverrides))
+	for mdIndex := range mdOverrides {
+		mdIndexes = append(mdIndexes, mdIndex)
+	}
+	sort.Ints(mdIndexes)
+	for _, mdIndex := range mdIndexes {
+		for _, name := range sortedLegacyVariableNames(mdOverrides[mdIndex]) {
+			var value interface{}
+			if err := topology.GetVariable(cluster, name, &value); err != nil {
+				return errors.Wrapf(err, "unable to get the cluster variable %s", name)
+			}
+			value = mergeVariableValues(value, mdOverrides[mdIndex][name])
+			if err := topology.SetMDVariable(cluster, mdIndex, name, value); err != nil {
+				return errors.Wrapf(err, "unable to set the variable %s of machineDeployment %d", name, mdIndex)
+			}
+		}
+	}
+	return nil
+}
+
+// setNestedVariableValue sets value at the path given by segments in the variable value current. Numeric segments are array indexes.
+func setNestedVariableValue(current interface{}, segments []string, value interface{}) interface{} {
+	if len(segments) == 0 {
+		return value
+	}
+	if index, err := strconv.Atoi(segments[0]); err == nil {
+		list, _ := current.([]interface{})
+		for len(list) <= index {
+			list = append(list, nil)
+		}
+		list[index] = setNestedVariableValue(list[index], segments[1:], value)
+		return list
+	}
+	object, ok := current.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+	object[segments[0]] = setNestedVariableValue(object[segments[0]], segments[1:], value)
+	return object
+}
+
+// mergeVariableValues returns a copy of base where the fields of override are replaced
+func mergeVariableValues(base, override interface{}) interface{} {
+	baseObject, ok1 := base.(map[string]interface{})
+	overrideObject, ok2 := override.(map[string]interface{})
+	if !ok1 || !ok2 {
+		return override
+	}
+	merged := runtime.DeepCopyJSONValue(baseObject).(map[string]interface{})
+	for key, value := range overrideObject {
+		merged[key] = mergeVariableValues(merged[key], value)
+	}
+	return merged
+}
+
+func ensureMachineDeployment(cluster *capi.Cluster, mdIndex int) *capi.MachineDeploymentTopology {
+	workers := cluster.Spec.Topology.Workers
+	for i := len(workers.MachineDeployments); i <= mdIndex; i++ {
+		workers.MachineDeployments = append(workers.MachineDeployments, capi.MachineDeploymentTopology{
+			Class: defaultMachineDeploymentClass,
+			Name:  fmt.Sprintf("md-%d", i),
+		})
+	}
+	return &workers.MachineDeployments[mdIndex]
+}
+
+func ensureClusterNetwork(cluster *capi.Cluster) *capi.ClusterNetwork {
+	if cluster.Spec.ClusterNetwork == nil {
+		cluster.Spec.ClusterNetwork = &capi.ClusterNetwork{}
+	}
+	return cluster.Spec.ClusterNetwork
+}
+
+func vsphereCredentialsSecret(cluster *capi.Cluster, legacyVars map[string]interface{}) *corev1.Secret {
+	username := legacyVariableString(legacyVars, constants.ConfigVariableVsphereUsername)
+	password := legacyVariableString(legacyVars, constants.ConfigVariableVspherePassword)
+	if username == "" && password == "" {
+		return nil
+	}
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.Name,
+			Namespace: cluster.Namespace,
+		},
+		StringData: map[string]string{
+			"username": username,
+			"password": password,
+		},
+	}
+}
+
+// YAML returns the multi-document YAML of the Cluster object and the vSphere credentials Secret, which can be used
+// as input file of the cluster creation
+func (r *ConvertClusterConfigResult) YAML() ([]byte, error) {
+	objects := []runtime.Object{r.Cluster}
+	if r.VSphereCredentials != nil {
+		objects = append(objects, r.VSphereCredentials)
+	}
+	var documents []string
+	for _, obj := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to convert the object to unstructured")
+		}
+		unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(content, "status")
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to marshal the object to yaml")
+		}
+		documents = append(documents, string(data))
+	}
+	return []byte(strings.Join(documents, "---\n")), nil
+}
+
+func legacyVariableString(legacyVars map[string]interface{}, name string) string {
+	if value, ok := legacyVars[name]; ok && value != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return ""
+}
+
+func legacyValueToInt32(legacyVarName string, value interface{}) (int32, error) {
+	number, err := strconv.ParseInt(fmt.Sprintf("%v", value), 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid value %v of %s", value, legacyVarName)
+	}
+	return int32(number), nil
+}
+
+func commaSeparatedValueToArray(value interface{}) []interface{} {
+	var result []interface{}
+	for _, element := range strings.Split(fmt.Sprintf("%v", value), ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+func toStringSlice(value interface{}) []string {
+	var result []string
+	if list, ok := value.([]interface{}); ok {
+		for _, element := range list {
+			result = append(result, fmt.Sprintf("%v", element))
+		}
+	}
+	return result
+}
+
+func sortedLegacyVariableNames(vars map[string]interface{}) []string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
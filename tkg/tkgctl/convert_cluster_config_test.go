@@ -0,0 +1,249 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigpaths"
+	"github.com/vmware-tanzu/tanzu-framework/util/topology"
+)
+
+var _ = Describe("Legacy cluster configuration to ClusterClass based Cluster conversion", func() {
+	var (
+		tkgCtl     *tkgctl
+		dir        string
+		configFile string
+	)
+
+	writeConfig := func(content string) {
+		Expect(os.WriteFile(configFile, []byte(content), constants.ConfigFilePermissions)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "convert-cluster-config")
+		Expect(err).ToNot(HaveOccurred())
+		configFile = filepath.Join(dir, "cluster-config.yaml")
+		providersDir, err := filepath.Abs("../../providers")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.Symlink(providersDir, filepath.Join(dir, constants.LocalProvidersFolderName))).To(Succeed())
+		tkgCtl = &tkgctl{tkgConfigPathsClient: tkgconfigpaths.New(dir)}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should map the AWS configuration variables onto the topology variables and machineDeployment overrides", func() {
+		writeConfig(`
+INFRASTRUCTURE_PROVIDER: aws
+CLUSTER_NAME: aws-cluster
+NAMESPACE: ns1
+CLUSTER_PLAN: prod
+KUBERNETES_VERSION: v1.23.5+vmware.1
+CLUSTER_CIDR: 100.96.0.0/11
+CONTROL_PLANE_MACHINE_COUNT: 3
+AWS_REGION: us-west-2
+AWS_NODE_AZ: us-west-2a
+AWS_NODE_AZ_1: us-west-2b
+NODE_MACHINE_TYPE: m5.large
+NODE_MACHINE_TYPE_1: m5.xlarge
+AWS_NODE_OS_DISK_SIZE_GIB: 80
+WORKER_MACHINE_COUNT_0: 2
+WORKER_MACHINE_COUNT_1: 1
+WORKER_MACHINE_COUNT_2: 1
+TKG_HTTP_PROXY_ENABLED: true
+TKG_HTTP_PROXY: http://10.0.0.1:3128
+TKG_NO_PROXY: .svc,10.0.0.0/8
+TKG_PROXY_CA_CERT: Y2VydA==
+ENABLE_MHC: true
+`)
+		result, err := tkgCtl.ConvertClusterConfig(ConvertClusterConfigOptions{ClusterConfigFile: configFile})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.UnmappedVariables).To(Equal([]string{"ENABLE_MHC"}))
+		Expect(result.VSphereCredentials).To(BeNil())
+
+		cluster := result.Cluster
+		Expect(cluster.Name).To(Equal("aws-cluster"))
+		Expect(cluster.Namespace).To(Equal("ns1"))
+		Expect(cluster.Spec.ClusterNetwork.Pods.CIDRBlocks).To(Equal([]string{"100.96.0.0/11"}))
+		Expect(cluster.Spec.Topology.Class).To(Equal("tkg-aws-default-" + constants.DefaultClusterClassVersion))
+		Expect(cluster.Spec.Topology.Version).To(Equal("v1.23.5+vmware.1"))
+		Expect(*cluster.Spec.Topology.ControlPlane.Replicas).To(Equal(int32(3)))
+		Expect(cluster.Spec.Topology.Workers.MachineDeployments).To(HaveLen(3))
+		Expect(cluster.Spec.Topology.Workers.MachineDeployments[0].Name).To(Equal("md-0"))
+		Expect(*cluster.Spec.Topology.Workers.MachineDeployments[0].Replicas).To(Equal(int32(2)))
+		Expect(*cluster.Spec.Topology.Workers.MachineDeployments[1].Replicas).To(Equal(int32(1)))
+		Expect(*cluster.Spec.Topology.Workers.MachineDeployments[2].Replicas).To(Equal(int32(1)))
+		Expect(*cluster.Spec.Topology.Workers.MachineDeployments[1].FailureDomain).To(Equal("us-west-2b"))
+		Expect(*cluster.Spec.Topology.Workers.MachineDeployments[2].FailureDomain).To(Equal("us-west-2c"))
+
+		var region string
+		Expect(topology.GetVariable(cluster, "region", &region)).To(Succeed())
+		Expect(region).To(Equal("us-west-2"))
+
+		var proxy map[string]interface{}
+		Expect(topology.GetVariable(cluster, "proxy", &proxy)).To(Succeed())
+		Expect(proxy).To(HaveKeyWithValue("httpProxy", "http://10.0.0.1:3128"))
+		Expect(proxy).To(HaveKeyWithValue("noProxy", []interface{}{".svc", "10.0.0.0/8"}))
+
+		var trust []map[string]interface{}
+		Expect(topology.GetVariable(cluster, "trust", &trust)).To(Succeed())
+		Expect(trust).To(ConsistOf(map[string]interface{}{"name": "proxy", "data": "Y2VydA=="}))
+
+		var network struct {
+			Subnets []struct {
+				Az string `json:"az"`
+			} `json:"subnets"`
+		}
+		Expect(topology.GetVariable(cluster, "network", &network)).To(Succeed())
+		Expect(network.Subnets).To(HaveLen(2))
+		Expect(network.Subnets[1].Az).To(Equal("us-west-2b"))
+
+		var worker0, worker1 map[string]interface{}
+		Expect(topology.GetMDVariable(cluster, 0, "worker", &worker0)).To(Succeed())
+		Expect(topology.GetMDVariable(cluster, 1, "worker", &worker1)).To(Succeed())
+		Expect(worker0).To(HaveKeyWithValue("instanceType", "m5.large"))
+		Expect(worker1).To(HaveKeyWithValue("instanceType", "m5.xlarge"))
+		Expect(worker1).To(HaveKeyWithValue("rootVolume", map[string]interface{}{"sizeGiB": float64(80)}))
+	})
+
+	It("should include the vSphere credentials Secret and skip the proxy settings when the proxy is not enabled", func() {
+		writeConfig(`
+INFRASTRUCTURE_PROVIDER: vsphere
+CLUSTER_NAME: vsphere-cluster
+VSPHERE_SERVER: 10.0.0.10
+VSPHERE_USERNAME: administrator@vsphere.local
+VSPHERE_PASSWORD: secret
+WORKER_MACHINE_COUNT: 3
+TKG_CUSTOM_IMAGE_REPOSITORY_SKIP_TLS_VERIFY: true
+TKG_HTTP_PROXY_ENABLED: false
+TKG_HTTP_PROXY: http://10.0.0.1:3128
+`)
+		result, err := tkgCtl.ConvertClusterConfig(ConvertClusterConfigOptions{ClusterConfigFile: configFile, Namespace: "ns2"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.UnmappedVariables).To(BeEmpty())
+
+		cluster := result.Cluster
+		Expect(cluster.Namespace).To(Equal("ns2"))
+		Expect(*cluster.Spec.Topology.Workers.MachineDeployments[0].Replicas).To(Equal(int32(3)))
+		Expect(cluster.Spec.Topology.Variables).ToNot(ContainElement(HaveField("Name", "proxy")))
+
+		var imageRepository map[string]interface{}
+		Expect(topology.GetVariable(cluster, "imageRepository", &imageRepository)).To(Succeed())
+		Expect(imageRepository).To(HaveKeyWithValue("tlsCertificateValidation", false))
+
+		Expect(result.VSphereCredentials).ToNot(BeNil())
+		Expect(result.VSphereCredentials.Name).To(Equal("vsphere-cluster"))
+		Expect(result.VSphereCredentials.Namespace).To(Equal("ns2"))
+		Expect(result.VSphereCredentials.StringData).To(HaveKeyWithValue("username", "administrator@vsphere.local"))
+
+		content, err := result.YAML()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(configFile, content, constants.ConfigFilePermissions)).To(Succeed())
+		isClusterClassBased, clusterObj, err := CheckIfInputFileIsClusterClassBased(configFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isClusterClassBased).To(BeTrue())
+		Expect(clusterObj.GetName()).To(Equal("vsphere-cluster"))
+		Expect(string(content)).ToNot(ContainSubstring("creationTimestamp"))
+	})
+
+	It("should derive the control plane replicas and the machineDeployments of each availability zone from the prod plan", func() {
+		writeConfig(`
+INFRASTRUCTURE_PROVIDER: vsphere
+CLUSTER_NAME: vsphere-prod
+CLUSTER_PLAN: prod
+WORKER_MACHINE_COUNT: 5
+VSPHERE_AZ_0: az-0
+VSPHERE_AZ_1: az-1
+VSPHERE_AZ_2: az-2
+`)
+		result, err := tkgCtl.ConvertClusterConfig(ConvertClusterConfigOptions{ClusterConfigFile: configFile})
+		Expect(err).ToNot(HaveOccurred())
+
+		cluster := result.Cluster
+		Expect(*cluster.Spec.Topology.ControlPlane.Replicas).To(Equal(int32(constants.DefaultProdControlPlaneMachineCount)))
+		mds := cluster.Spec.Topology.Workers.MachineDeployments
+		Expect(mds).To(HaveLen(3))
+		for i, replicas := range []int32{2, 2, 1} {
+			Expect(mds[i].Name).To(Equal(fmt.Sprintf("md-%d", i)))
+			Expect(*mds[i].Replicas).To(Equal(replicas))
+			Expect(*mds[i].FailureDomain).To(Equal(fmt.Sprintf("az-%d", i)))
+		}
+	})
+
+	It("should derive a single machineDeployment and control plane replica from the dev plan", func() {
+		writeConfig(`
+INFRASTRUCTURE_PROVIDER: aws
+CLUSTER_NAME: aws-dev
+CLUSTER_PLAN: dev
+AWS_REGION: us-west-2
+WORKER_MACHINE_COUNT: 2
+`)
+		result, err := tkgCtl.ConvertClusterConfig(ConvertClusterConfigOptions{ClusterConfigFile: configFile})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.UnmappedVariables).To(BeEmpty())
+
+		cluster := result.Cluster
+		Expect(*cluster.Spec.Topology.ControlPlane.Replicas).To(Equal(int32(constants.DefaultDevControlPlaneMachineCount)))
+		Expect(cluster.Spec.Topology.Workers.MachineDeployments).To(HaveLen(1))
+		Expect(*cluster.Spec.Topology.Workers.MachineDeployments[0].Replicas).To(Equal(int32(2)))
+	})
+
+	It("should convert quoted values to the types of the ClusterClass variables", func() {
+		writeConfig(`
+INFRASTRUCTURE_PROVIDER: vsphere
+CLUSTER_NAME: vsphere-cluster
+VSPHERE_CONTROL_PLANE_NUM_CPUS: "4"
+VSPHERE_CONTROL_PLANE_MEM_MIB: "8192"
+VSPHERE_WORKER_DISK_GIB: '40'
+VSPHERE_DATACENTER: 1234
+TKG_CUSTOM_IMAGE_REPOSITORY_SKIP_TLS_VERIFY: "false"
+`)
+		result, err := tkgCtl.ConvertClusterConfig(ConvertClusterConfigOptions{ClusterConfigFile: configFile})
+		Expect(err).ToNot(HaveOccurred())
+
+		var controlPlane, worker map[string]interface{}
+		Expect(topology.GetVariable(result.Cluster, "controlPlane", &controlPlane)).To(Succeed())
+		Expect(controlPlane).To(HaveKeyWithValue("machine", map[string]interface{}{"numCPUs": float64(4), "memoryMiB": float64(8192)}))
+		Expect(topology.GetVariable(result.Cluster, "worker", &worker)).To(Succeed())
+		Expect(worker).To(HaveKeyWithValue("machine", map[string]interface{}{"diskGiB": float64(40)}))
+
+		var vcenter map[string]interface{}
+		Expect(topology.GetVariable(result.Cluster, "vcenter", &vcenter)).To(Succeed())
+		Expect(vcenter).To(HaveKeyWithValue("datacenter", "1234"))
+
+		var imageRepository map[string]interface{}
+		Expect(topology.GetVariable(result.Cluster, "imageRepository", &imageRepository)).To(Succeed())
+		Expect(imageRepository).To(HaveKeyWithValue("tlsCertificateValidation", true))
+	})
+
+	It("should fail on values not matching the type of the ClusterClass variables", func() {
+		writeConfig(`
+INFRASTRUCTURE_PROVIDER: vsphere
+CLUSTER_NAME: vsphere-cluster
+VSPHERE_CONTROL_PLANE_NUM_CPUS: four
+`)
+		_, err := tkgCtl.ConvertClusterConfig(ConvertClusterConfigOptions{ClusterConfigFile: configFile})
+		Expect(err).To(MatchError(ContainSubstring("invalid value four of cluster variable controlPlane.machine.numCPUs, expected integer")))
+	})
+
+	It("should fail without infrastructure provider", func() {
+		writeConfig("CLUSTER_NAME: c1\n")
+		_, err := tkgCtl.ConvertClusterConfig(ConvertClusterConfigOptions{ClusterConfigFile: configFile})
+		Expect(err).To(MatchError(ContainSubstring("INFRASTRUCTURE_PROVIDER is required")))
+	})
+
+	It("should fail when the input file is already ClusterClass based", func() {
+		_, err := tkgCtl.ConvertClusterConfig(ConvertClusterConfigOptions{ClusterConfigFile: inputFileVsphere})
+		Expect(err).To(MatchError(ContainSubstring("already a ClusterClass based Cluster configuration")))
+	})
+})
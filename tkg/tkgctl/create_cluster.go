@@ -230,7 +230,13 @@ func (t *tkgctl) getCreateClusterOptions(name string, cc *CreateClusterOptions,
 		return client.CreateClusterOptions{}, errors.New("required config variable 'CLUSTER_PLAN' not set")
 	}
 
-	definitionParser := yamlprocessor.InjectDefinitionParser(yamlprocessor.NewYttDefinitionParser(yamlprocessor.InjectTKGDir(t.configDir)))
+	// the overlay packs pinned to the management cluster apply to the templates of its clusters
+	overlayPackPaths, err := t.overlayPackPaths()
+	if err != nil {
+		return client.CreateClusterOptions{}, errors.Wrap(err, "unable to get the overlay packs of the management cluster")
+	}
+	definitionParser := yamlprocessor.InjectDefinitionParser(yamlprocessor.NewYttDefinitionParser(
+		yamlprocessor.InjectTKGDir(t.configDir), yamlprocessor.InjectOverlayPackPaths(overlayPackPaths)))
 
 	configOptions := client.ClusterConfigOptions{
 		ClusterName:              name,
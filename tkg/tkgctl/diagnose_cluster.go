@@ -0,0 +1,62 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// DiagnoseClusterOptions diagnose cluster options
+type DiagnoseClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	// OutputFile is the path of the bundle, <cluster>-diagnostics-<time>.tar.gz if empty
+	OutputFile string
+	// LogTailLines limits the container logs to their last lines, all lines are collected if not positive
+	LogTailLines int64
+}
+
+// DiagnoseRegionOptions diagnose region options
+type DiagnoseRegionOptions struct {
+	ClusterName string
+	// OutputFile is the path of the bundle, <cluster>-diagnostics-<time>.tar.gz if empty
+	OutputFile string
+	// LogTailLines limits the container logs to their last lines, all lines are collected if not positive
+	LogTailLines int64
+}
+
+// DiagnoseCluster collects a diagnostics bundle of a workload cluster and returns its path
+func (t *tkgctl) DiagnoseCluster(options DiagnoseClusterOptions) (string, error) {
+	bundle, err := t.tkgClient.DiagnoseCluster(client.DiagnoseClusterOptions{
+		ClusterName:  options.ClusterName,
+		Namespace:    options.Namespace,
+		OutputFile:   options.OutputFile,
+		LogTailLines: options.LogTailLines,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to diagnose workload cluster")
+	}
+
+	log.Infof("\nDiagnostics bundle of workload cluster %s written to %s\n", options.ClusterName, bundle)
+	return bundle, nil
+}
+
+// DiagnoseRegion collects a diagnostics bundle of a management cluster and returns its path
+func (t *tkgctl) DiagnoseRegion(options DiagnoseRegionOptions) (string, error) {
+	bundle, err := t.tkgClient.DiagnoseCluster(client.DiagnoseClusterOptions{
+		ClusterName:         options.ClusterName,
+		OutputFile:          options.OutputFile,
+		LogTailLines:        options.LogTailLines,
+		IsManagementCluster: true,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to diagnose management cluster")
+	}
+
+	log.Infof("\nDiagnostics bundle of management cluster %s written to %s\n", options.ClusterName, bundle)
+	return bundle, nil
+}
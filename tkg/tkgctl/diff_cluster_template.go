@@ -0,0 +1,153 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigpaths"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+// TemplateSource is a providers bundle used to render cluster templates
+type TemplateSource struct {
+	// ProvidersDir is the providers directory, or the TKG directory containing it
+	ProvidersDir string
+	// BOM is the TKG BOM file name used while rendering the templates, it defaults to the one of the cluster configuration
+	BOM string
+}
+
+// DiffClusterTemplateOptions has the options to compare the cluster templates rendered by two providers bundles
+type DiffClusterTemplateOptions struct {
+	ClusterConfigFile string
+	// InfrastructureProvider is the infrastructure provider name with an optional version, it defaults to
+	// INFRASTRUCTURE_PROVIDER of the cluster configuration. The latest version of each providers bundle is used when omitted.
+	InfrastructureProvider string
+	// Plan defaults to CLUSTER_PLAN of the cluster configuration
+	Plan string
+	From TemplateSource
+	To   TemplateSource
+}
+
+// DiffClusterTemplate renders the cluster configuration through the templates of two providers bundles
+// and returns the structured diff of the resulting manifests
+func (t *tkgctl) DiffClusterTemplate(options DiffClusterTemplateOptions) (*yamlprocessor.ManifestDiff, error) {
+	if options.ClusterConfigFile == "" {
+		return nil, errors.New("cluster configuration file is required")
+	}
+	if options.From.ProvidersDir == "" || options.To.ProvidersDir == "" {
+		return nil, errors.New("the providers directories to compare are required")
+	}
+	// configuration profiles extended by the cluster configuration are the ones of the TKG directory in use
+	profilesDir, _ := tkgconfigpaths.New(t.configDir).GetConfigProfilesDirectory()
+	from, err := renderClusterTemplate(&options, options.From, profilesDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to render the cluster template with the providers in %s", options.From.ProvidersDir)
+	}
+	to, err := renderClusterTemplate(&options, options.To, profilesDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to render the cluster template with the providers in %s", options.To.ProvidersDir)
+	}
+	return yamlprocessor.DiffManifests(from, to)
+}
+
+// renderClusterTemplate renders the cluster configuration with the cluster template definition of the providers bundle,
+// using the configuration defaults of the providers bundle
+func renderClusterTemplate(options *DiffClusterTemplateOptions, source TemplateSource, profilesDir string) ([]byte, error) {
+	tkgDir, err := templateSourceTKGDir(source.ProvidersDir)
+	if err != nil {
+		return nil, err
+	}
+	providersDir := filepath.Join(tkgDir, constants.LocalProvidersFolderName)
+
+	readerWriter, err := tkgconfigreaderwriter.NewReaderWriterFromConfigFileWithProfiles(options.ClusterConfigFile, filepath.Join(providersDir, constants.TKGConfigDefaultFileName), profilesDir)
+	if err != nil {
+		return nil, err
+	}
+	if source.BOM != "" {
+		readerWriter.Set(constants.ConfigVariableDefaultBomFile, source.BOM)
+	}
+
+	infraProvider := options.InfrastructureProvider
+	if infraProvider == "" {
+		infraProvider, _ = readerWriter.Get(constants.ConfigVariableInfraProvider)
+	}
+	providerName, providerVersion, err := client.ParseProviderName(infraProvider)
+	if err != nil || providerName == "" {
+		return nil, errors.Errorf("invalid infrastructure provider %q, set %s or use the infrastructure option", infraProvider, constants.ConfigVariableInfraProvider)
+	}
+	plan := options.Plan
+	if plan == "" {
+		plan, _ = readerWriter.Get(constants.ConfigVariableClusterPlan)
+	}
+	if plan == "" {
+		return nil, errors.Errorf("required config variable '%s' not set", constants.ConfigVariableClusterPlan)
+	}
+	readerWriter.Set(constants.ConfigVariableClusterPlan, plan)
+	readerWriter.Set(constants.ConfigVariableProviderType, providerName)
+
+	providerDir := filepath.Join(providersDir, "infrastructure-"+providerName)
+	if providerVersion == "" {
+		providerVersion, err = latestProviderVersion(providerDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	yttProcessor := yamlprocessor.NewYttProcessor(yamlprocessor.InjectDefinitionParser(yamlprocessor.NewYttDefinitionParser(yamlprocessor.InjectTKGDir(tkgDir))))
+	templateDefinition, err := os.ReadFile(filepath.Join(providerDir, providerVersion, yttProcessor.GetTemplateName(providerVersion, plan)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the template definition of plan %s of provider %s:%s", plan, providerName, providerVersion)
+	}
+	return yttProcessor.Process(templateDefinition, readerWriter.Get)
+}
+
+// templateSourceTKGDir returns the directory containing the providers directory, the template definition paths are relative to it
+func templateSourceTKGDir(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if filepath.Base(dir) == constants.LocalProvidersFolderName {
+		return filepath.Dir(dir), nil
+	}
+	if info, err := os.Stat(filepath.Join(dir, constants.LocalProvidersFolderName)); err == nil && info.IsDir() {
+		return dir, nil
+	}
+	return "", errors.Errorf("%s is not a providers directory", dir)
+}
+
+// latestProviderVersion returns the latest provider version of the providers bundle
+func latestProviderVersion(providerDir string) (string, error) {
+	entries, err := os.ReadDir(providerDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read the provider directory %s", providerDir)
+	}
+	var latest *version.Version
+	latestName := ""
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "v") {
+			continue
+		}
+		v, err := version.ParseSemantic(entry.Name())
+		if err != nil {
+			continue
+		}
+		if latest == nil || latest.LessThan(v) {
+			latest, latestName = v, entry.Name()
+		}
+	}
+	if latestName == "" {
+		return "", errors.Errorf("no provider version found in %s", providerDir)
+	}
+	return latestName, nil
+}
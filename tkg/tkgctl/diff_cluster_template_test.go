@@ -0,0 +1,118 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+var _ = Describe("Cluster template render diff", func() {
+	var (
+		tkgCtl            *tkgctl
+		dir               string
+		clusterConfigFile string
+	)
+
+	// writeProvidersBundle creates a providers bundle with a docker dev plan rendering a Deployment
+	writeProvidersBundle := func(name, providerVersion, image string, defaultWorkerCount int) string {
+		tkgDir := filepath.Join(dir, name)
+		yttDir := filepath.Join("providers", "infrastructure-docker", providerVersion, "ytt")
+		files := map[string]string{
+			filepath.Join("providers", "config_default.yaml"): fmt.Sprintf("WORKER_MACHINE_COUNT: %d\n", defaultWorkerCount),
+			filepath.Join("providers", "infrastructure-docker", providerVersion, "cluster-template-definition-dev.yaml"): fmt.Sprintf(`
+apiVersion: providers.tanzu.vmware.com/v1alpha1
+kind: TemplateDefinition
+spec:
+  paths:
+  - path: %s
+`, yttDir),
+			filepath.Join(yttDir, "values.yaml"): `#@data/values
+---
+CLUSTER_NAME:
+WORKER_MACHINE_COUNT:
+`,
+			filepath.Join(yttDir, "template.yaml"): fmt.Sprintf(`#@ load("@ytt:data", "data")
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: #@ data.values.CLUSTER_NAME
+  namespace: default
+spec:
+  replicas: #@ data.values.WORKER_MACHINE_COUNT
+  template:
+    spec:
+      containers:
+      - name: manager
+        image: %s
+`, image),
+		}
+		for path, content := range files {
+			Expect(os.MkdirAll(filepath.Dir(filepath.Join(tkgDir, path)), 0o700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tkgDir, path), []byte(content), 0o600)).To(Succeed())
+		}
+		return tkgDir
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "diff-cluster-template")
+		Expect(err).ToNot(HaveOccurred())
+		clusterConfigFile = filepath.Join(dir, "cluster-config.yaml")
+		Expect(os.WriteFile(clusterConfigFile, []byte("CLUSTER_NAME: c1\nINFRASTRUCTURE_PROVIDER: docker\nCLUSTER_PLAN: dev\n"), 0o600)).To(Succeed())
+		tkgCtl = &tkgctl{}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should report the field changes of the resources rendered by the two providers bundles", func() {
+		from := writeProvidersBundle("from", "v1.0.0", "manager:v1", 1)
+		to := writeProvidersBundle("to", "v1.1.0", "manager:v2", 2)
+
+		diff, err := tkgCtl.DiffClusterTemplate(DiffClusterTemplateOptions{
+			ClusterConfigFile: clusterConfigFile,
+			From:              TemplateSource{ProvidersDir: from},
+			To:                TemplateSource{ProvidersDir: filepath.Join(to, "providers")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.Resources).To(HaveLen(1))
+		Expect(diff.Resources[0].Kind).To(Equal("Deployment"))
+		Expect(diff.Resources[0].Name).To(Equal("c1"))
+		Expect(diff.Resources[0].Fields).To(ConsistOf(
+			yamlprocessor.FieldChange{Path: "spec.replicas", Change: yamlprocessor.ChangeTypeModified, From: float64(1), To: float64(2)},
+			yamlprocessor.FieldChange{Path: "spec.template.spec.containers[name=manager].image", Change: yamlprocessor.ChangeTypeModified, From: "manager:v1", To: "manager:v2"},
+		))
+	})
+
+	It("should report no changes when the providers bundles render the same manifests", func() {
+		from := writeProvidersBundle("from", "v1.0.0", "manager:v1", 1)
+		diff, err := tkgCtl.DiffClusterTemplate(DiffClusterTemplateOptions{
+			ClusterConfigFile: clusterConfigFile,
+			From:              TemplateSource{ProvidersDir: from},
+			To:                TemplateSource{ProvidersDir: from},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.IsEmpty()).To(BeTrue())
+	})
+
+	It("should fail when the plan is not available in a providers bundle", func() {
+		from := writeProvidersBundle("from", "v1.0.0", "manager:v1", 1)
+		_, err := tkgCtl.DiffClusterTemplate(DiffClusterTemplateOptions{
+			ClusterConfigFile: clusterConfigFile,
+			Plan:              "prod",
+			From:              TemplateSource{ProvidersDir: from},
+			To:                TemplateSource{ProvidersDir: from},
+		})
+		Expect(err).To(MatchError(ContainSubstring("unable to read the template definition of plan prod of provider docker:v1.0.0")))
+	})
+})
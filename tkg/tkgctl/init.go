@@ -47,6 +47,7 @@ type InitRegionOptions struct {
 	DeployTKGonVsphere7         bool
 	SkipPrompt                  bool
 	GenerateOnly                bool
+	Resume                      bool
 }
 
 const (
@@ -58,6 +59,10 @@ const (
 //nolint:gocritic,gocyclo,funlen
 func (t *tkgctl) Init(options InitRegionOptions) error {
 	var err error
+	if options.Resume && (options.UI || options.GenerateOnly) {
+		return errors.New("resuming the management cluster creation is not supported with the UI or a dry-run")
+	}
+
 	options.ClusterConfigFile, err = t.ensureClusterConfigFile(options.ClusterConfigFile)
 	if err != nil {
 		return err
@@ -130,7 +135,8 @@ func (t *tkgctl) Init(options InitRegionOptions) error {
 	}
 
 	if optionsIR.LaunchUI {
-		err := server.Serve(optionsIR, t.appConfig, t.TKGConfigReaderWriter(), options.Timeout, options.Bind, options.Browser)
+		err := server.Serve(optionsIR, t.appConfig, t.TKGConfigReaderWriter(), options.Timeout, options.Bind, options.Browser,
+			t.managementClusterClientFactory(optionsIR.Edition))
 		if err != nil {
 			return errors.Wrap(err, "failed to start Kickstart UI")
 		}
@@ -358,6 +364,7 @@ func (t *tkgctl) populateClientInitRegionOptions(options *InitRegionOptions, nod
 		VsphereControlPlaneEndpoint: options.VsphereControlPlaneEndpoint,
 		Edition:                     options.Edition,
 		AdditionalTKGManifests:      options.AdditionalTKGManifests,
+		Resume:                      options.Resume,
 	}
 }
 
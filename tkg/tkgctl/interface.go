@@ -4,6 +4,8 @@
 package tkgctl
 
 import (
+	"context"
+
 	capiv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
@@ -12,14 +14,22 @@ import (
 	tkgsv1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha2"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
 )
 
 // TKGClient implements TKG client
 type TKGClient interface {
+	// AddOverlayPack fetches the overlay pack and pins it to the current management cluster
+	AddOverlayPack(options AddOverlayPackOptions) (*yamlprocessor.OverlayPack, error)
 	// AddRegion adds region
 	AddRegion(options AddRegionOptions) error
+	// BackupRegion saves the workload clusters managed by a management cluster to an encrypted backup
+	BackupRegion(options BackupRegionOptions) error
 	// ConfigCluster prints cluster template to stdout
 	ConfigCluster(configClusterOption CreateClusterOptions) error
+	// ConvertClusterConfig converts a legacy cluster configuration file into a ClusterClass based Cluster object
+	ConvertClusterConfig(options ConvertClusterConfigOptions) (*ConvertClusterConfigResult, error)
 	// CreateAWSCloudFormationStack create aws cloud formation stack
 	CreateAWSCloudFormationStack(clusterConfigFile string) error
 	// CreateCluster create tkg cluster
@@ -28,6 +38,24 @@ type TKGClient interface {
 	DeleteCluster(options DeleteClustersOptions) error
 	// DeleteMachineHealthCheck deletes MHC on cluster
 	DeleteMachineHealthCheck(options DeleteMachineHealthCheckOptions) error
+	// DiagnoseCluster collects a diagnostics bundle of a workload cluster and returns its path
+	DiagnoseCluster(options DiagnoseClusterOptions) (string, error)
+	// DiagnoseRegion collects a diagnostics bundle of a management cluster and returns its path
+	DiagnoseRegion(options DiagnoseRegionOptions) (string, error)
+	// WatchClusterTimeline calls a handler with the condition transitions and events of the objects of a workload cluster
+	WatchClusterTimeline(ctx context.Context, options WatchClusterTimelineOptions) error
+	// GetClusterCertificates returns the expiry dates of the control plane certificates of a workload cluster
+	GetClusterCertificates(options GetClusterCertificatesOptions) ([]client.ClusterCertificate, error)
+	// RotateClusterCertificates renews the control plane certificates of a workload cluster
+	RotateClusterCertificates(options RotateClusterCertificatesOptions) error
+	// PauseCluster pauses the reconciliation of a workload cluster and of its add-ons
+	PauseCluster(options PauseClusterOptions) error
+	// ResumeCluster resumes the reconciliation of a workload cluster paused by PauseCluster
+	ResumeCluster(options ResumeClusterOptions) error
+	// DiffClusterTemplate compares the cluster templates rendered by two providers bundles
+	DiffClusterTemplate(options DiffClusterTemplateOptions) (*yamlprocessor.ManifestDiff, error)
+	// DeleteOverlayPack removes the overlay pack from the current management cluster
+	DeleteOverlayPack(name string) error
 	// DeleteRegion deletes management cluster
 	DeleteRegion(options DeleteRegionOptions) error
 	// GetCEIP returns CEIP status set on management cluster
@@ -42,6 +70,8 @@ type TKGClient interface {
 	GenerateAWSCloudFormationTemplate(clusterConfigFile string) (string, error)
 	// GetCredentials saves cluster credentials to a file
 	GetCredentials(options GetWorkloadClusterCredentialsOptions) error
+	// GetOverlayPacks returns the overlay packs pinned to the current management cluster
+	GetOverlayPacks() ([]yamlprocessor.OverlayPack, error)
 	// GetKubernetesVersions returns supported k8s versions
 	GetKubernetesVersions() (*client.KubernetesVersionsInfo, error)
 	// GetMachineHealthCheck return machinehealthcheck configuration for the cluster
@@ -52,20 +82,32 @@ type TKGClient interface {
 	Init(options InitRegionOptions) error
 	// ScaleCluster scales cluster
 	ScaleCluster(options ScaleClusterOptions) error
+	// ScaleClusters scales the workload clusters selected by a label selector
+	ScaleClusters(options ScaleClustersOptions) (*client.BulkClusterOperationResult, error)
 	// SetCeip sets CEIP to the management cluster
 	SetCeip(ceipOptIn, isProd, labels string) error
 	// SetMachineHealthCheck apply machine health check to the cluster
 	SetMachineHealthCheck(options SetMachineHealthCheckOptions) error
+	// SetMachineHealthChecks applies a machine health check to the workload clusters selected by a label selector
+	SetMachineHealthChecks(options SetMachineHealthChecksOptions) (*client.BulkClusterOperationResult, error)
 	// GetMachineDeployments gets machine deployments from a cluster
 	GetMachineDeployments(options client.GetMachineDeploymentOptions) ([]capi.MachineDeployment, error)
 	// SetMachineDeployment applies a machine deployment to the cluster
 	SetMachineDeployment(options *client.SetMachineDeploymentOptions) error
 	// DeleteMachineDeployment deletes a machine deployment from the cluster
 	DeleteMachineDeployment(options client.DeleteMachineDeploymentOptions) error
+	// RestoreRegion restores the workload clusters of a backup on a management cluster
+	RestoreRegion(options RestoreRegionOptions) error
+	// ShowClusterConfig resolves a cluster configuration file with the configuration profiles it extends
+	ShowClusterConfig(options ShowClusterConfigOptions) (*tkgconfigreaderwriter.ResolvedConfig, error)
 	// SetRegion sets active management cluster
 	SetRegion(options SetRegionOptions) error
+	// ValidateCluster runs all the configuration checks of the workload cluster and reports the problems found
+	ValidateCluster(cc CreateClusterOptions) (*client.ValidationReport, error)
 	// UpgradeCluster upgrade tkg workload cluster
 	UpgradeCluster(options UpgradeClusterOptions) error
+	// UpgradeClusters upgrades the workload clusters selected by a label selector
+	UpgradeClusters(options UpgradeClustersOptions) (*client.BulkClusterOperationResult, error)
 	// UpgradeRegion upgrades management cluster
 	UpgradeRegion(options UpgradeRegionOptions) error
 	// Updates management cluster
@@ -0,0 +1,119 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/utils"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+// AddOverlayPackOptions has the options to add an overlay pack to the current management cluster
+type AddOverlayPackOptions struct {
+	// Name is the name of the overlay pack
+	Name string
+	// Image is the OCI image with tag of the overlay pack
+	Image string
+}
+
+// AddOverlayPack fetches the overlay pack from its OCI image and pins it to the current management cluster,
+// so that it is applied to the templates of the clusters created by the management cluster
+func (t *tkgctl) AddOverlayPack(options AddOverlayPackOptions) (*yamlprocessor.OverlayPack, error) {
+	if options.Name == "" || options.Image == "" {
+		return nil, errors.New("overlay pack name and image are required")
+	}
+	lock, err := t.tkgClient.GetOverlayPackLock()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := t.overlayPacksDir()
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Fetching overlay pack %s from %s...", options.Name, options.Image)
+	pack, err := yamlprocessor.AddOverlayPack(dir, lock, options.Name, options.Image, carvelhelpers.DownloadImageBundleAndSaveFilesToDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.tkgClient.SaveOverlayPackLock(lock); err != nil {
+		return nil, err
+	}
+	return pack, nil
+}
+
+// GetOverlayPacks returns the overlay packs pinned to the current management cluster
+func (t *tkgctl) GetOverlayPacks() ([]yamlprocessor.OverlayPack, error) {
+	lock, err := t.tkgClient.GetOverlayPackLock()
+	if err != nil {
+		return nil, err
+	}
+	return lock.Packs, nil
+}
+
+// DeleteOverlayPack removes the overlay pack from the current management cluster
+func (t *tkgctl) DeleteOverlayPack(name string) error {
+	lock, err := t.tkgClient.GetOverlayPackLock()
+	if err != nil {
+		return err
+	}
+	dir, err := t.overlayPacksDir()
+	if err != nil {
+		return err
+	}
+	if err := yamlprocessor.DeleteOverlayPack(dir, lock, name); err != nil {
+		return err
+	}
+	return t.tkgClient.SaveOverlayPackLock(lock)
+}
+
+// overlayPackPaths returns the paths of the overlay packs pinned to the current management cluster, after
+// fetching the ones missing from the local cache or not matching their pinned checksum
+func (t *tkgctl) overlayPackPaths() ([]string, error) {
+	currentRegion, err := t.tkgClient.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	if currentRegion.ClusterName == "" {
+		return nil, nil
+	}
+	// vSphere with Tanzu management clusters have no overlay packs
+	isPacific, err := t.tkgClient.IsPacificManagementCluster()
+	if err != nil {
+		return nil, err
+	}
+	if isPacific {
+		return nil, nil
+	}
+	lock, err := t.tkgClient.GetOverlayPackLock()
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil || len(lock.Packs) == 0 {
+		return nil, nil
+	}
+	dir, err := t.overlayPacksDir()
+	if err != nil {
+		return nil, err
+	}
+	return yamlprocessor.SyncOverlayPacks(dir, lock, carvelhelpers.DownloadImageBundleAndSaveFilesToDir)
+}
+
+// overlayPacksDir returns the local directory caching the overlay packs pinned to the current management cluster
+func (t *tkgctl) overlayPacksDir() (string, error) {
+	currentRegion, err := t.tkgClient.GetCurrentRegionContext()
+	if err != nil {
+		return "", errors.Wrap(err, "cannot get current management cluster context")
+	}
+	if currentRegion.ClusterName == "" {
+		return "", errors.New("no current management cluster")
+	}
+	server, err := utils.GetClusterServerFromKubeconfigAndContext(currentRegion.SourceFilePath, currentRegion.ContextName)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot get the API server of the current management cluster")
+	}
+	return yamlprocessor.OverlayPacksDir(t.configDir, currentRegion.ClusterName, server), nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+var _ = Describe("Unit test for overlay packs", func() {
+	var (
+		ctl       tkgctl
+		tkgClient *fakes.Client
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		tkgClient.GetCurrentRegionContextReturns(region.RegionContext{
+			ClusterName:    "mgmt",
+			ContextName:    "queen-anne-context",
+			SourceFilePath: getConfigFilePath(),
+		}, nil)
+		ctl = tkgctl{
+			configDir: testingDir,
+			tkgClient: tkgClient,
+		}
+	})
+
+	It("should pin the overlay packs in the management cluster", func() {
+		tkgClient.GetOverlayPackLockReturns(&yamlprocessor.OverlayPackLock{ResourceVersion: "42"}, nil)
+		err := ctl.DeleteOverlayPack("labels")
+		Expect(err).To(MatchError(ContainSubstring("overlay pack labels not found")))
+		Expect(tkgClient.SaveOverlayPackLockCallCount()).To(BeZero())
+
+		tkgClient.GetOverlayPackLockReturns(&yamlprocessor.OverlayPackLock{
+			Packs:           []yamlprocessor.OverlayPack{{Name: "labels"}},
+			ResourceVersion: "42",
+		}, nil)
+		Expect(ctl.DeleteOverlayPack("labels")).To(Succeed())
+		Expect(tkgClient.SaveOverlayPackLockCallCount()).To(Equal(1))
+		lock := tkgClient.SaveOverlayPackLockArgsForCall(0)
+		Expect(lock.Packs).To(BeEmpty())
+		Expect(lock.ResourceVersion).To(Equal("42"))
+	})
+
+	It("should return the error getting the overlay packs when creating a cluster", func() {
+		tkgClient.GetOverlayPackLockReturns(nil, errors.New("connection refused"))
+		_, err := ctl.getCreateClusterOptions("wc", &CreateClusterOptions{Plan: "dev"}, false)
+		Expect(err).To(MatchError(ContainSubstring("unable to get the overlay packs of the management cluster: connection refused")))
+	})
+
+	It("should not get the overlay packs of a vSphere with Tanzu management cluster", func() {
+		tkgClient.IsPacificManagementClusterReturns(true, nil)
+		_, err := ctl.getCreateClusterOptions("wc", &CreateClusterOptions{Plan: "dev"}, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tkgClient.GetOverlayPackLockCallCount()).To(BeZero())
+	})
+
+	It("should key the overlay packs directory by the management cluster API server", func() {
+		dir, err := ctl.overlayPacksDir()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dir).To(Equal(yamlprocessor.OverlayPacksDir(testingDir, "mgmt", "https://pig.org:443")))
+	})
+})
@@ -0,0 +1,65 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"os/user"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+)
+
+// PauseClusterOptions pause cluster options
+type PauseClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	// PausedBy is recorded as the user pausing the cluster, the current OS user if not specified
+	PausedBy string
+	Reason   string
+}
+
+// ResumeClusterOptions resume cluster options
+type ResumeClusterOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// PauseCluster pauses the Cluster API reconciliation of a workload cluster, the reconciliation of its ClusterBootstrap
+// and its PackageInstalls, recording who paused the cluster and why
+func (t *tkgctl) PauseCluster(options PauseClusterOptions) error {
+	if options.PausedBy == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return errors.Wrap(err, "unable to determine the current user")
+		}
+		options.PausedBy = currentUser.Username
+	}
+
+	err := t.tkgClient.PauseCluster(client.PauseClusterOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+		PausedBy:    options.PausedBy,
+		Reason:      options.Reason,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to pause cluster")
+	}
+	log.Infof("Workload cluster '%s' is paused", options.ClusterName)
+	return nil
+}
+
+// ResumeCluster resumes the reconciliation of a workload cluster paused by PauseCluster
+func (t *tkgctl) ResumeCluster(options ResumeClusterOptions) error {
+	err := t.tkgClient.ResumeCluster(client.ResumeClusterOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to resume cluster")
+	}
+	log.Infof("Workload cluster '%s' is resumed", options.ClusterName)
+	return nil
+}
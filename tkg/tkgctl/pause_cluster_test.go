@@ -0,0 +1,62 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"os/user"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+)
+
+var _ = Describe("Unit test for pause and resume cluster", func() {
+	var (
+		ctl       tkgctl
+		tkgClient *fakes.Client
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		ctl = tkgctl{
+			configDir:  testingDir,
+			tkgClient:  tkgClient,
+			kubeconfig: "./kube",
+		}
+	})
+
+	Context("PauseCluster", func() {
+		It("should record the current user as the user pausing the cluster", func() {
+			currentUser, err := user.Current()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = ctl.PauseCluster(PauseClusterOptions{ClusterName: "wc", Namespace: "dev", Reason: "maintenance"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tkgClient.PauseClusterCallCount()).To(Equal(1))
+			Expect(tkgClient.PauseClusterArgsForCall(0)).To(Equal(client.PauseClusterOptions{
+				ClusterName: "wc",
+				Namespace:   "dev",
+				PausedBy:    currentUser.Username,
+				Reason:      "maintenance",
+			}))
+		})
+
+		It("should return an error if the cluster cannot be paused", func() {
+			tkgClient.PauseClusterReturns(errors.New("not found"))
+			err := ctl.PauseCluster(PauseClusterOptions{ClusterName: "wc", PausedBy: "alice"})
+			Expect(err).To(MatchError("unable to pause cluster: not found"))
+		})
+	})
+
+	Context("ResumeCluster", func() {
+		It("should resume the cluster", func() {
+			err := ctl.ResumeCluster(ResumeClusterOptions{ClusterName: "wc", Namespace: "dev"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tkgClient.ResumeClusterArgsForCall(0)).To(Equal(client.ResumeClusterOptions{ClusterName: "wc", Namespace: "dev"}))
+		})
+	})
+})
@@ -24,7 +24,19 @@ type SetMachineHealthCheckOptions struct {
 //
 //nolint:gocritic
 func (t *tkgctl) SetMachineHealthCheck(options SetMachineHealthCheckOptions) error {
-	optionsSMHC := client.SetMachineHealthCheckOptions{
+	err := t.tkgClient.SetMachineHealthCheck(newSetMachineHealthCheckOptions(options))
+	if err != nil {
+		return err
+	}
+
+	log.Info("The MachineHealthCheck was set successfully")
+
+	return nil
+}
+
+//nolint:gocritic
+func newSetMachineHealthCheckOptions(options SetMachineHealthCheckOptions) *client.SetMachineHealthCheckOptions {
+	optionsSMHC := &client.SetMachineHealthCheckOptions{
 		ClusterName:            options.ClusterName,
 		MachineHealthCheckName: options.MachineHealthCheckName,
 		Namespace:              options.Namespace,
@@ -38,13 +50,5 @@ func (t *tkgctl) SetMachineHealthCheck(options SetMachineHealthCheckOptions) err
 	if options.UnhealthyConditions != "" {
 		optionsSMHC.UnhealthyConditions = strings.Split(options.UnhealthyConditions, ",")
 	}
-
-	err := t.tkgClient.SetMachineHealthCheck(&optionsSMHC)
-	if err != nil {
-		return err
-	}
-
-	log.Info("The MachineHealthCheck was set successfully")
-
-	return nil
+	return optionsSMHC
 }
@@ -0,0 +1,28 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigpaths"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+)
+
+// ShowClusterConfigOptions show cluster config options
+type ShowClusterConfigOptions struct {
+	ClusterConfigFile string
+}
+
+// ShowClusterConfig resolves a cluster configuration file with the configuration profiles it extends
+func (t *tkgctl) ShowClusterConfig(options ShowClusterConfigOptions) (*tkgconfigreaderwriter.ResolvedConfig, error) {
+	if options.ClusterConfigFile == "" {
+		return nil, errors.New("cluster configuration file is required")
+	}
+	profilesDir, err := tkgconfigpaths.New(t.configDir).GetConfigProfilesDirectory()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get configuration profiles directory")
+	}
+	return tkgconfigreaderwriter.ResolveConfigProfiles(options.ClusterConfigFile, profilesDir)
+}
@@ -0,0 +1,106 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clientcreator"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/types"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/vc"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/handlers"
+)
+
+// uiManagementClusterClient serves the management cluster operations of the
+// installer UI using a tkgctl client scoped to a single management cluster context
+type uiManagementClusterClient struct {
+	tkgctl      *tkgctl
+	clusterName string
+	edition     string
+}
+
+// managementClusterClientFactory returns a factory which creates tkgctl backed
+// clients for the management clusters listed by the installer UI
+func (t *tkgctl) managementClusterClientFactory(edition string) handlers.ManagementClusterClientFactory {
+	return func(rc region.RegionContext) (handlers.ManagementClusterClient, error) {
+		scoped, err := t.forRegionContext(rc)
+		if err != nil {
+			return nil, err
+		}
+		return &uiManagementClusterClient{tkgctl: scoped, clusterName: rc.ClusterName, edition: edition}, nil
+	}
+}
+
+// forRegionContext returns a copy of the tkgctl client which targets the given management cluster context
+func (t *tkgctl) forRegionContext(rc region.RegionContext) (*tkgctl, error) {
+	allClients, err := clientcreator.CreateAllClients(t.appConfig, t.tkgConfigReaderWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	tkgClient, err := client.New(client.Options{
+		ClusterCtlClient:         allClients.ClusterCtlClient,
+		ReaderWriterConfigClient: allClients.ConfigClient,
+		RegionManager:            allClients.RegionManager,
+		TKGConfigDir:             t.configDir,
+		Timeout:                  constants.DefaultOperationTimeout,
+		FeaturesClient:           allClients.FeaturesClient,
+		TKGConfigProvidersClient: allClients.TKGConfigProvidersClient,
+		TKGBomClient:             allClients.TKGBomClient,
+		TKGConfigUpdater:         allClients.TKGConfigUpdaterClient,
+		TKGPathsClient:           allClients.TKGConfigPathsClient,
+		ClusterKubeConfig: &types.ClusterKubeConfig{
+			File:    rc.SourceFilePath,
+			Context: rc.ContextName,
+		},
+		ClusterClientFactory: clusterclient.NewClusterClientFactory(),
+		VcClientFactory:      vc.NewVcClientFactory(),
+		FeatureFlagClient:    allClients.FeatureFlagClient,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create client for management cluster %q", rc.ClusterName)
+	}
+
+	clusterClientOptions := clusterclient.Options{GetClientInterval: 2 * time.Second, GetClientTimeout: 5 * time.Second}
+	scoped := *t
+	scoped.kubeconfig = rc.SourceFilePath
+	scoped.kubecontext = rc.ContextName
+	scoped.tkgClient = tkgClient
+	scoped.featureGateHelper = NewFeatureGateHelper(&clusterClientOptions, rc.ContextName, rc.SourceFilePath)
+	return &scoped, nil
+}
+
+// GetClusters lists the management cluster and the workload clusters in all namespaces
+func (c *uiManagementClusterClient) GetClusters() ([]client.ClusterInfo, error) {
+	return c.tkgctl.GetClusters(ListTKGClustersOptions{
+		IncludeMC:     true,
+		AllNamespaces: true,
+	})
+}
+
+// DeleteManagementCluster deletes the management cluster without prompting for confirmation
+func (c *uiManagementClusterClient) DeleteManagementCluster(force bool) error {
+	return c.tkgctl.DeleteRegion(DeleteRegionOptions{
+		ClusterName: c.clusterName,
+		Force:       force,
+		SkipPrompt:  true,
+		Timeout:     constants.DefaultLongRunningOperationTimeout,
+	})
+}
+
+// UpgradeManagementCluster upgrades the management cluster without prompting for confirmation
+func (c *uiManagementClusterClient) UpgradeManagementCluster() error {
+	return c.tkgctl.UpgradeRegion(UpgradeRegionOptions{
+		ClusterName: c.clusterName,
+		SkipPrompt:  true,
+		Timeout:     constants.DefaultLongRunningOperationTimeout,
+		Edition:     c.edition,
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+)
+
+// ValidateCluster runs all the configuration checks of the workload cluster without creating it
+// and returns the report of the problems found
+//
+//nolint:gocritic
+func (t *tkgctl) ValidateCluster(cc CreateClusterOptions) (*client.ValidationReport, error) {
+	isTKGSCluster, err := t.tkgClient.IsPacificManagementCluster()
+	if err != nil {
+		return nil, err
+	}
+	if isTKGSCluster {
+		return nil, errors.New("validating the configuration of clusters created by the Tanzu Kubernetes Cluster service for vSphere is not supported")
+	}
+	isInputFileClusterClassBased, err := t.processWorkloadClusterInputFile(&cc, isTKGSCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.ClusterConfigFile, err = t.ensureClusterConfigFile(cc.ClusterConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// configures missing create cluster options from config file variables
+	if err := t.configureCreateClusterOptionsFromConfigFile(&cc); err != nil {
+		return nil, err
+	}
+
+	options, err := t.getCreateClusterOptions(cc.ClusterName, &cc, isInputFileClusterClassBased)
+	if err != nil {
+		return nil, err
+	}
+	options.TKRVersion, options.KubernetesVersion, err = t.getAndDownloadTkrIfNeeded(cc.TkrVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to determine the TKr version and kubernetes version based on '%v'", cc.TkrVersion)
+	}
+
+	return t.tkgClient.ValidateWorkloadClusterConfiguration(&options)
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"context"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/timeline"
+)
+
+// WatchClusterTimelineOptions watch cluster timeline options
+type WatchClusterTimelineOptions struct {
+	ClusterName string
+	Namespace   string
+	// Follow keeps watching the cluster until the context is done
+	Follow bool
+	// Handler is called with each entry of the timeline
+	Handler func(timeline.Entry)
+}
+
+// WatchClusterTimeline calls the handler with the condition transitions and the events of the objects of a
+// workload cluster, sorted by time. With Follow, the new entries are handled until the context is done.
+func (t *tkgctl) WatchClusterTimeline(ctx context.Context, options WatchClusterTimelineOptions) error {
+	return t.tkgClient.WatchClusterTimeline(ctx, client.WatchClusterTimelineOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+		Follow:      options.Follow,
+		Handler:     options.Handler,
+	})
+}
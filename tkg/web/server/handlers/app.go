@@ -6,6 +6,7 @@ package handlers
 
 import (
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/edition"
@@ -26,6 +27,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/docker"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/features"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/ldap"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/management"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/provider"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/vsphere"
 
@@ -48,7 +50,16 @@ type App struct {
 	aviClient             aviClient.Client
 	ldapClient            ldapClient.Client
 	TKGConfigReaderWriter tkgconfigreaderwriter.TKGConfigReaderWriter
-	clusterConfigFile     string
+	// ManagementClusterClientFactory creates clients for operations on existing management clusters
+	ManagementClusterClientFactory ManagementClusterClientFactory
+	clusterConfigFile              string
+
+	// lock guards the fields below, which are shared by the concurrent requests
+	lock sync.Mutex
+	// sendingLogsToUI tells whether the logs are already sent to the UI
+	sendingLogsToUI bool
+	// managementClusterOperation is the management cluster operation in progress, if any
+	managementClusterOperation string
 }
 
 // ConfigureHandlers configures API handlers func
@@ -67,6 +78,7 @@ func (app *App) ConfigureHandlers(api middleware.RoutableAPI) { // nolint:funlen
 	a.ServerShutdown = func() {}
 
 	a.ProviderGetProviderHandler = provider.GetProviderHandlerFunc(app.GetProvider)
+	a.ProviderValidateManagementClusterConfigurationHandler = provider.ValidateManagementClusterConfigurationHandlerFunc(app.ValidateManagementClusterConfiguration)
 	a.VsphereSetVSphereEndpointHandler = vsphere.SetVSphereEndpointHandlerFunc(app.SetVSphereEndpoint)
 	a.VsphereGetVSphereDatacentersHandler = vsphere.GetVSphereDatacentersHandlerFunc(app.GetVSphereDatacenters)
 	a.VsphereGetVSphereDatastoresHandler = vsphere.GetVSphereDatastoresHandlerFunc(app.GetVSphereDatastores)
@@ -127,11 +139,26 @@ func (app *App) ConfigureHandlers(api middleware.RoutableAPI) { // nolint:funlen
 	a.LdapVerifyLdapUserSearchHandler = ldap.VerifyLdapUserSearchHandlerFunc(app.VerifyUserSearch)
 	a.LdapVerifyLdapGroupSearchHandler = ldap.VerifyLdapGroupSearchHandlerFunc(app.VerifyGroupSearch)
 	a.LdapVerifyLdapCloseConnectionHandler = ldap.VerifyLdapCloseConnectionHandlerFunc(app.VerifyLdapCloseConnection)
+
+	a.ManagementGetManagementClustersHandler = management.GetManagementClustersHandlerFunc(app.GetManagementClusters)
+	a.ManagementGetManagementClusterHandler = management.GetManagementClusterHandlerFunc(app.GetManagementCluster)
+	a.ManagementGetWorkloadClustersHandler = management.GetWorkloadClustersHandlerFunc(app.GetWorkloadClusters)
+	a.ManagementDeleteManagementClusterHandler = management.DeleteManagementClusterHandlerFunc(app.DeleteManagementCluster)
+	a.ManagementUpgradeManagementClusterHandler = management.UpgradeManagementClusterHandlerFunc(app.UpgradeManagementCluster)
 }
 
 // StartSendingLogsToUI creates logchannel passes it to tkg logger
-// retrieves the logs through logChannel and passes it to webSocket
+// retrieves the logs through logChannel and passes it to webSocket. The logs are only sent
+// once, subsequent calls return immediately.
 func (app *App) StartSendingLogsToUI() {
+	app.lock.Lock()
+	if app.sendingLogsToUI {
+		app.lock.Unlock()
+		return
+	}
+	app.sendingLogsToUI = true
+	app.lock.Unlock()
+
 	logChannel := make(chan []byte)
 	log.SetChannel(logChannel)
 	for logMsg := range logChannel {
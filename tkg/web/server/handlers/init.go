@@ -38,6 +38,16 @@ const sleepTimeForLogsPropogation = 2 * time.Second
 
 // CreateVSphereRegionalCluster creates vSphere management cluster
 func (app *App) CreateVSphereRegionalCluster(params vsphere.CreateVSphereRegionalClusterParams) middleware.Responder {
+	if err := app.startManagementClusterOperation(stepCreateManagementCluster); err != nil {
+		return vsphere.NewCreateVSphereRegionalClusterBadRequest().WithPayload(Err(err))
+	}
+	creating := false
+	defer func() {
+		if !creating {
+			app.endManagementClusterOperation()
+		}
+	}()
+
 	vsphereConfig, err := tkgconfigproviders.New(app.AppConfig.TKGConfigDir, app.TKGConfigReaderWriter).NewVSphereConfig(params.Params)
 	if err != nil {
 		return vsphere.NewCreateVSphereRegionalClusterInternalServerError().WithPayload(Err(err))
@@ -83,8 +93,10 @@ func (app *App) CreateVSphereRegionalCluster(params vsphere.CreateVSphereRegiona
 	if err := c.ConfigureAndValidateManagementClusterConfiguration(&app.InitOptions, false); err != nil {
 		return vsphere.NewCreateVSphereRegionalClusterInternalServerError().WithPayload(Err(errors.New(err.Message)))
 	}
+	creating = true
 	go app.StartSendingLogsToUI()
 	go func() {
+		defer app.endManagementClusterOperation()
 		err := c.InitRegion(&app.InitOptions)
 		if err != nil {
 			log.Error(err, "unable to set up management cluster, ")
@@ -108,6 +120,16 @@ func (app *App) CreateVSphereRegionalCluster(params vsphere.CreateVSphereRegiona
 
 // CreateAWSRegionalCluster creates aws management cluster
 func (app *App) CreateAWSRegionalCluster(params aws.CreateAWSRegionalClusterParams) middleware.Responder {
+	if err := app.startManagementClusterOperation(stepCreateManagementCluster); err != nil {
+		return aws.NewCreateAWSRegionalClusterBadRequest().WithPayload(Err(err))
+	}
+	creating := false
+	defer func() {
+		if !creating {
+			app.endManagementClusterOperation()
+		}
+	}()
+
 	if app.awsClient == nil {
 		return aws.NewCreateAWSRegionalClusterInternalServerError().WithPayload(Err(errors.New("aws client is not initialized properly")))
 	}
@@ -160,9 +182,11 @@ func (app *App) CreateAWSRegionalCluster(params aws.CreateAWSRegionalClusterPara
 	if err := c.ConfigureAndValidateManagementClusterConfiguration(&app.InitOptions, false); err != nil {
 		return aws.NewCreateAWSRegionalClusterInternalServerError().WithPayload(Err(errors.New(err.Message)))
 	}
+	creating = true
 	go app.StartSendingLogsToUI()
 
 	go func() {
+		defer app.endManagementClusterOperation()
 		if params.Params.CreateCloudFormationStack {
 			err = c.CreateAWSCloudFormationStack()
 			if err != nil {
@@ -193,6 +217,16 @@ func (app *App) CreateAWSRegionalCluster(params aws.CreateAWSRegionalClusterPara
 
 // CreateAzureRegionalCluster creates azure management cluster
 func (app *App) CreateAzureRegionalCluster(params azure.CreateAzureRegionalClusterParams) middleware.Responder {
+	if err := app.startManagementClusterOperation(stepCreateManagementCluster); err != nil {
+		return azure.NewCreateAzureRegionalClusterBadRequest().WithPayload(Err(err))
+	}
+	creating := false
+	defer func() {
+		if !creating {
+			app.endManagementClusterOperation()
+		}
+	}()
+
 	if app.azureClient == nil {
 		return azure.NewCreateAzureRegionalClusterInternalServerError().WithPayload(Err(errors.New("azure client is not initialized properly")))
 	}
@@ -272,8 +306,10 @@ func (app *App) CreateAzureRegionalCluster(params azure.CreateAzureRegionalClust
 	if err := c.ConfigureAndValidateManagementClusterConfiguration(&app.InitOptions, false); err != nil {
 		return azure.NewCreateAzureRegionalClusterInternalServerError().WithPayload(Err(errors.New(err.Message)))
 	}
+	creating = true
 	go app.StartSendingLogsToUI()
 	go func() {
+		defer app.endManagementClusterOperation()
 		err := c.InitRegion(&app.InitOptions)
 		if err != nil {
 			log.Error(err, "unable to set up management cluster, ")
@@ -297,6 +333,16 @@ func (app *App) CreateAzureRegionalCluster(params azure.CreateAzureRegionalClust
 
 // CreateDockerRegionalCluster creates docker management cluster
 func (app *App) CreateDockerRegionalCluster(params docker.CreateDockerRegionalClusterParams) middleware.Responder {
+	if err := app.startManagementClusterOperation(stepCreateManagementCluster); err != nil {
+		return docker.NewCreateDockerRegionalClusterBadRequest().WithPayload(Err(err))
+	}
+	creating := false
+	defer func() {
+		if !creating {
+			app.endManagementClusterOperation()
+		}
+	}()
+
 	dockerConfig, err := tkgconfigproviders.New(app.AppConfig.TKGConfigDir, app.TKGConfigReaderWriter).NewDockerConfig(params.Params)
 	if err != nil {
 		return docker.NewCreateDockerRegionalClusterInternalServerError().WithPayload(Err(err))
@@ -341,8 +387,10 @@ func (app *App) CreateDockerRegionalCluster(params docker.CreateDockerRegionalCl
 		return docker.NewCreateDockerRegionalClusterInternalServerError().WithPayload(Err(errors.New(err.Message)))
 	}
 
+	creating = true
 	go app.StartSendingLogsToUI()
 	go func() {
+		defer app.endManagementClusterOperation()
 		err := c.InitRegion(&app.InitOptions)
 		if err != nil {
 			log.Error(err, "unable to set up management cluster, ")
@@ -0,0 +1,300 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clientcreator"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/models"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/management"
+)
+
+// progress statuses understood by the UI
+const (
+	progressStatusRunning    = "running"
+	progressStatusFailed     = "failed"
+	progressStatusSuccessful = "successful"
+)
+
+const (
+	stepCreateManagementCluster  = "Create management cluster"
+	stepDeleteManagementCluster  = "Delete management cluster"
+	stepUpgradeManagementCluster = "Upgrade management cluster"
+)
+
+// OperationRequestHeader must be set on the requests deleting or upgrading management clusters. Browsers only send
+// custom headers on cross-origin requests after a CORS preflight, which the server does not allow, so other sites
+// cannot start these operations from the browser of the user.
+const OperationRequestHeader = "X-Requested-With"
+
+// ManagementClusterClient performs day-2 operations against a single management cluster
+type ManagementClusterClient interface {
+	// GetClusters lists the workload clusters and the management cluster itself
+	GetClusters() ([]client.ClusterInfo, error)
+	// DeleteManagementCluster deletes the management cluster
+	DeleteManagementCluster(force bool) error
+	// UpgradeManagementCluster upgrades the management cluster to the current TKG version
+	UpgradeManagementCluster() error
+}
+
+// ManagementClusterClientFactory creates a ManagementClusterClient for the given management cluster context
+type ManagementClusterClientFactory func(regionContext region.RegionContext) (ManagementClusterClient, error)
+
+// GetManagementClusters lists the management clusters known to the tkg config
+func (app *App) GetManagementClusters(params management.GetManagementClustersParams) middleware.Responder {
+	regionContexts, err := app.listRegionContexts()
+	if err != nil {
+		return management.NewGetManagementClustersInternalServerError().WithPayload(Err(err))
+	}
+
+	res := make([]*models.ManagementClusterInfo, 0, len(regionContexts))
+	for _, rc := range regionContexts {
+		res = append(res, &models.ManagementClusterInfo{
+			Name:             rc.ClusterName,
+			Context:          rc.ContextName,
+			Kubeconfig:       rc.SourceFilePath,
+			Status:           string(rc.Status),
+			IsCurrentContext: rc.IsCurrentContext,
+		})
+	}
+	return management.NewGetManagementClustersOK().WithPayload(res)
+}
+
+// GetManagementCluster returns the status and health of a management cluster
+func (app *App) GetManagementCluster(params management.GetManagementClusterParams) middleware.Responder {
+	rc, found, err := app.getRegionContext(params.ClusterName)
+	if err != nil {
+		return management.NewGetManagementClusterInternalServerError().WithPayload(Err(err))
+	}
+	if !found {
+		return management.NewGetManagementClusterNotFound().WithPayload(Err(errors.Errorf("management cluster %q not found", params.ClusterName)))
+	}
+
+	status := &models.ManagementClusterStatus{
+		Name:    rc.ClusterName,
+		Context: rc.ContextName,
+		Status:  string(rc.Status),
+	}
+
+	// A management cluster whose deployment failed or which cannot be reached
+	// is reported as unhealthy rather than as an API error.
+	if rc.Status == region.Failed {
+		status.Message = "management cluster deployment failed"
+		return management.NewGetManagementClusterOK().WithPayload(status)
+	}
+	c, err := app.ManagementClusterClientFactory(rc)
+	if err != nil {
+		return management.NewGetManagementClusterInternalServerError().WithPayload(Err(err))
+	}
+	clusters, err := c.GetClusters()
+	if err != nil {
+		status.Message = err.Error()
+		return management.NewGetManagementClusterOK().WithPayload(status)
+	}
+	for i := range clusters {
+		if clusters[i].Name != rc.ClusterName || !hasRole(clusters[i].Roles, client.TkgLabelClusterRoleManagement) {
+			continue
+		}
+		status.Phase = clusters[i].Status
+		status.Plan = clusters[i].Plan
+		status.KubernetesVersion = clusters[i].K8sVersion
+		status.ControlPlaneCount = clusters[i].ControlPlaneCount
+		status.WorkerCount = clusters[i].WorkerCount
+		status.Healthy = clusters[i].Status == "running"
+		return management.NewGetManagementClusterOK().WithPayload(status)
+	}
+	status.Message = "management cluster object not found on the cluster"
+	return management.NewGetManagementClusterOK().WithPayload(status)
+}
+
+// GetWorkloadClusters lists the workload clusters of a management cluster
+func (app *App) GetWorkloadClusters(params management.GetWorkloadClustersParams) middleware.Responder {
+	rc, found, err := app.getRegionContext(params.ClusterName)
+	if err != nil {
+		return management.NewGetWorkloadClustersInternalServerError().WithPayload(Err(err))
+	}
+	if !found {
+		return management.NewGetWorkloadClustersNotFound().WithPayload(Err(errors.Errorf("management cluster %q not found", params.ClusterName)))
+	}
+
+	c, err := app.ManagementClusterClientFactory(rc)
+	if err != nil {
+		return management.NewGetWorkloadClustersInternalServerError().WithPayload(Err(err))
+	}
+	clusters, err := c.GetClusters()
+	if err != nil {
+		return management.NewGetWorkloadClustersInternalServerError().WithPayload(Err(err))
+	}
+
+	res := make([]*models.WorkloadClusterInfo, 0, len(clusters))
+	for i := range clusters {
+		if hasRole(clusters[i].Roles, client.TkgLabelClusterRoleManagement) {
+			continue
+		}
+		res = append(res, &models.WorkloadClusterInfo{
+			Name:              clusters[i].Name,
+			Namespace:         clusters[i].Namespace,
+			Status:            clusters[i].Status,
+			Plan:              clusters[i].Plan,
+			KubernetesVersion: clusters[i].K8sVersion,
+			Tkr:               clusters[i].TKR,
+			ControlPlaneCount: clusters[i].ControlPlaneCount,
+			WorkerCount:       clusters[i].WorkerCount,
+			Roles:             clusters[i].Roles,
+			Labels:            clusters[i].Labels,
+		})
+	}
+	return management.NewGetWorkloadClustersOK().WithPayload(res)
+}
+
+// DeleteManagementCluster starts deleting a management cluster, progress is streamed over the websocket
+func (app *App) DeleteManagementCluster(params management.DeleteManagementClusterParams) middleware.Responder {
+	if err := checkOperationRequest(params.HTTPRequest); err != nil {
+		return management.NewDeleteManagementClusterBadRequest().WithPayload(Err(err))
+	}
+	rc, found, err := app.getRegionContext(params.ClusterName)
+	if err != nil {
+		return management.NewDeleteManagementClusterInternalServerError().WithPayload(Err(err))
+	}
+	if !found {
+		return management.NewDeleteManagementClusterNotFound().WithPayload(Err(errors.Errorf("management cluster %q not found", params.ClusterName)))
+	}
+
+	c, err := app.ManagementClusterClientFactory(rc)
+	if err != nil {
+		return management.NewDeleteManagementClusterInternalServerError().WithPayload(Err(err))
+	}
+	force := params.Force != nil && *params.Force
+
+	if err := app.startManagementClusterOperation(stepDeleteManagementCluster); err != nil {
+		return management.NewDeleteManagementClusterConflict().WithPayload(Err(err))
+	}
+	go app.StartSendingLogsToUI()
+	go func() {
+		defer app.endManagementClusterOperation()
+		log.SendProgressUpdate(progressStatusRunning, stepDeleteManagementCluster, []string{stepDeleteManagementCluster})
+		if err := c.DeleteManagementCluster(force); err != nil {
+			log.Error(err, "unable to delete management cluster, ")
+			log.SendProgressUpdate(progressStatusFailed, stepDeleteManagementCluster, []string{stepDeleteManagementCluster})
+			return
+		}
+		log.SendProgressUpdate(progressStatusSuccessful, stepDeleteManagementCluster, []string{stepDeleteManagementCluster})
+	}()
+
+	return management.NewDeleteManagementClusterOK().WithPayload("started deleting management cluster")
+}
+
+// UpgradeManagementCluster starts upgrading a management cluster, progress is streamed over the websocket
+func (app *App) UpgradeManagementCluster(params management.UpgradeManagementClusterParams) middleware.Responder {
+	if err := checkOperationRequest(params.HTTPRequest); err != nil {
+		return management.NewUpgradeManagementClusterBadRequest().WithPayload(Err(err))
+	}
+	rc, found, err := app.getRegionContext(params.ClusterName)
+	if err != nil {
+		return management.NewUpgradeManagementClusterInternalServerError().WithPayload(Err(err))
+	}
+	if !found {
+		return management.NewUpgradeManagementClusterNotFound().WithPayload(Err(errors.Errorf("management cluster %q not found", params.ClusterName)))
+	}
+	if rc.Status == region.Failed {
+		return management.NewUpgradeManagementClusterBadRequest().WithPayload(Err(errors.Errorf("deployment failed for management cluster %q", params.ClusterName)))
+	}
+
+	c, err := app.ManagementClusterClientFactory(rc)
+	if err != nil {
+		return management.NewUpgradeManagementClusterInternalServerError().WithPayload(Err(err))
+	}
+
+	if err := app.startManagementClusterOperation(stepUpgradeManagementCluster); err != nil {
+		return management.NewUpgradeManagementClusterConflict().WithPayload(Err(err))
+	}
+	go app.StartSendingLogsToUI()
+	go func() {
+		defer app.endManagementClusterOperation()
+		log.SendProgressUpdate(progressStatusRunning, stepUpgradeManagementCluster, []string{stepUpgradeManagementCluster})
+		if err := c.UpgradeManagementCluster(); err != nil {
+			log.Error(err, "unable to upgrade management cluster, ")
+			log.SendProgressUpdate(progressStatusFailed, stepUpgradeManagementCluster, []string{stepUpgradeManagementCluster})
+			return
+		}
+		log.SendProgressUpdate(progressStatusSuccessful, stepUpgradeManagementCluster, []string{stepUpgradeManagementCluster})
+	}()
+
+	return management.NewUpgradeManagementClusterOK().WithPayload("started upgrading management cluster")
+}
+
+// checkOperationRequest rejects the requests which other sites may have forged: those without the
+// OperationRequestHeader and those whose origin does not match the host they are sent to
+func checkOperationRequest(r *http.Request) error {
+	if r == nil || r.Header.Get(OperationRequestHeader) == "" {
+		return errors.Errorf("the %s header is required", OperationRequestHeader)
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		originURL, err := url.Parse(origin)
+		if err != nil || originURL.Host != r.Host {
+			return errors.Errorf("origin %q does not match host %q", origin, r.Host)
+		}
+	}
+	return nil
+}
+
+// startManagementClusterOperation marks the operation as in progress, it fails when another operation is in progress
+// as the operations share the progress updates and the logs sent to the UI
+func (app *App) startManagementClusterOperation(operation string) error {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	if app.managementClusterOperation != "" {
+		return errors.Errorf("cannot start %q, %q is in progress", operation, app.managementClusterOperation)
+	}
+	app.managementClusterOperation = operation
+	return nil
+}
+
+// endManagementClusterOperation marks the operation in progress as ended
+func (app *App) endManagementClusterOperation() {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	app.managementClusterOperation = ""
+}
+
+func (app *App) listRegionContexts() ([]region.RegionContext, error) {
+	if app.ManagementClusterClientFactory == nil {
+		return nil, errors.New("management cluster operations are not supported by this server")
+	}
+	allClients, err := clientcreator.CreateAllClients(app.AppConfig, app.TKGConfigReaderWriter)
+	if err != nil {
+		return nil, err
+	}
+	return allClients.RegionManager.ListRegionContexts()
+}
+
+func (app *App) getRegionContext(clusterName string) (region.RegionContext, bool, error) {
+	regionContexts, err := app.listRegionContexts()
+	if err != nil {
+		return region.RegionContext{}, false, err
+	}
+	for _, rc := range regionContexts {
+		if rc.ClusterName == clusterName {
+			return rc, true, nil
+		}
+	}
+	return region.RegionContext{}, false, nil
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
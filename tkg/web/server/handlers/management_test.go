@@ -0,0 +1,202 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/fakes"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/region"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/types"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/docker"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/management"
+)
+
+func TestHandlers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Handlers Suite")
+}
+
+type regionManagerFactory struct {
+	manager region.Manager
+}
+
+func (f *regionManagerFactory) CreateManager(string) (region.Manager, error) {
+	return f.manager, nil
+}
+
+// fakeManagementClusterClient blocks the delete and upgrade operations until they are released
+type fakeManagementClusterClient struct {
+	clusters []client.ClusterInfo
+	started  chan string
+	release  chan error
+}
+
+func (c *fakeManagementClusterClient) GetClusters() ([]client.ClusterInfo, error) {
+	return c.clusters, nil
+}
+
+func (c *fakeManagementClusterClient) DeleteManagementCluster(force bool) error {
+	c.started <- "delete"
+	return <-c.release
+}
+
+func (c *fakeManagementClusterClient) UpgradeManagementCluster() error {
+	c.started <- "upgrade"
+	return <-c.release
+}
+
+// operationRequest returns a request starting a management cluster operation sent by the UI
+func operationRequest(method, path string) *http.Request {
+	r := httptest.NewRequest(method, "http://127.0.0.1:8080"+path, http.NoBody)
+	r.Header.Set(OperationRequestHeader, "XMLHttpRequest")
+	r.Header.Set("Origin", "http://127.0.0.1:8080")
+	return r
+}
+
+var _ = Describe("Management cluster handlers", func() {
+	var (
+		tempDir       string
+		regionManager *fakes.RegionManager
+		mcClient      *fakeManagementClusterClient
+		app           *App
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "handlers")
+		Expect(err).ToNot(HaveOccurred())
+		configFile := filepath.Join(tempDir, "config.yaml")
+		Expect(os.WriteFile(configFile, []byte("{}\n"), 0o600)).To(Succeed())
+		readerWriter, err := tkgconfigreaderwriter.NewReaderWriterFromConfigFile(configFile, configFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		regionManager = &fakes.RegionManager{}
+		regionManager.ListRegionContextsReturns([]region.RegionContext{
+			{ClusterName: "mc1", ContextName: "mc1-admin@mc1", Status: region.Success, IsCurrentContext: true},
+			{ClusterName: "mc2", ContextName: "mc2-admin@mc2", Status: region.Failed},
+		}, nil)
+		mcClient = &fakeManagementClusterClient{
+			clusters: []client.ClusterInfo{
+				{Name: "mc1", Status: "running", Plan: "dev", Roles: []string{client.TkgLabelClusterRoleManagement}},
+				{Name: "wc1", Namespace: "default", Status: "running", Plan: "prod"},
+			},
+			started: make(chan string, 1),
+			release: make(chan error),
+		}
+		app = &App{
+			AppConfig: types.AppConfig{
+				TKGConfigDir:      tempDir,
+				TKGSettingsFile:   configFile,
+				CustomizerOptions: types.CustomizerOptions{RegionManagerFactory: &regionManagerFactory{manager: regionManager}},
+			},
+			TKGConfigReaderWriter: readerWriter,
+			ManagementClusterClientFactory: func(regionContext region.RegionContext) (ManagementClusterClient, error) {
+				return mcClient, nil
+			},
+			// keep the logs of the tests out of the websocket
+			sendingLogsToUI: true,
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("should list the management clusters", func() {
+		res, ok := app.GetManagementClusters(management.GetManagementClustersParams{}).(*management.GetManagementClustersOK)
+		Expect(ok).To(BeTrue())
+		Expect(res.Payload).To(HaveLen(2))
+		Expect(res.Payload[0].Name).To(Equal("mc1"))
+		Expect(res.Payload[0].IsCurrentContext).To(BeTrue())
+	})
+
+	It("should return the health of a management cluster", func() {
+		res, ok := app.GetManagementCluster(management.GetManagementClusterParams{ClusterName: "mc1"}).(*management.GetManagementClusterOK)
+		Expect(ok).To(BeTrue())
+		Expect(res.Payload.Healthy).To(BeTrue())
+		Expect(res.Payload.Plan).To(Equal("dev"))
+
+		res, ok = app.GetManagementCluster(management.GetManagementClusterParams{ClusterName: "mc2"}).(*management.GetManagementClusterOK)
+		Expect(ok).To(BeTrue())
+		Expect(res.Payload.Healthy).To(BeFalse())
+		Expect(res.Payload.Message).To(Equal("management cluster deployment failed"))
+
+		_, ok = app.GetManagementCluster(management.GetManagementClusterParams{ClusterName: "mc3"}).(*management.GetManagementClusterNotFound)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should list the workload clusters of a management cluster", func() {
+		res, ok := app.GetWorkloadClusters(management.GetWorkloadClustersParams{ClusterName: "mc1"}).(*management.GetWorkloadClustersOK)
+		Expect(ok).To(BeTrue())
+		Expect(res.Payload).To(HaveLen(1))
+		Expect(res.Payload[0].Name).To(Equal("wc1"))
+	})
+
+	It("should not upgrade a management cluster whose deployment failed", func() {
+		_, ok := app.UpgradeManagementCluster(management.UpgradeManagementClusterParams{HTTPRequest: operationRequest(http.MethodPost, "/api/management/mc2/upgrade"), ClusterName: "mc2"}).(*management.UpgradeManagementClusterBadRequest)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should reject the operations requests which may be forged by other sites", func() {
+		r := operationRequest(http.MethodPost, "/api/management/mc1/upgrade")
+		r.Header.Del(OperationRequestHeader)
+		res, ok := app.UpgradeManagementCluster(management.UpgradeManagementClusterParams{HTTPRequest: r, ClusterName: "mc1"}).(*management.UpgradeManagementClusterBadRequest)
+		Expect(ok).To(BeTrue())
+		Expect(res.Payload.Message).To(ContainSubstring("the X-Requested-With header is required"))
+
+		r = operationRequest(http.MethodPost, "/api/management/mc1/upgrade")
+		r.Header.Set("Origin", "https://example.com")
+		res, ok = app.UpgradeManagementCluster(management.UpgradeManagementClusterParams{HTTPRequest: r, ClusterName: "mc1"}).(*management.UpgradeManagementClusterBadRequest)
+		Expect(ok).To(BeTrue())
+		Expect(res.Payload.Message).To(ContainSubstring(`origin "https://example.com" does not match host "127.0.0.1:8080"`))
+
+		r = operationRequest(http.MethodDelete, "/api/management/mc1")
+		r.Header.Set("Origin", "https://example.com")
+		_, ok = app.DeleteManagementCluster(management.DeleteManagementClusterParams{HTTPRequest: r, ClusterName: "mc1"}).(*management.DeleteManagementClusterBadRequest)
+		Expect(ok).To(BeTrue())
+		Consistently(mcClient.started).ShouldNot(Receive())
+	})
+
+	It("should not create a management cluster while an operation is in progress", func() {
+		_, ok := app.UpgradeManagementCluster(management.UpgradeManagementClusterParams{HTTPRequest: operationRequest(http.MethodPost, "/api/management/mc1/upgrade"), ClusterName: "mc1"}).(*management.UpgradeManagementClusterOK)
+		Expect(ok).To(BeTrue())
+		Eventually(mcClient.started).Should(Receive(Equal("upgrade")))
+
+		res, ok := app.CreateDockerRegionalCluster(docker.CreateDockerRegionalClusterParams{}).(*docker.CreateDockerRegionalClusterBadRequest)
+		Expect(ok).To(BeTrue())
+		Expect(res.Payload.Message).To(ContainSubstring(`cannot start "Create management cluster", "Upgrade management cluster" is in progress`))
+		mcClient.release <- nil
+	})
+
+	It("should reject the operations started while another one is in progress", func() {
+		_, ok := app.DeleteManagementCluster(management.DeleteManagementClusterParams{HTTPRequest: operationRequest(http.MethodDelete, "/api/management/mc1"), ClusterName: "mc1"}).(*management.DeleteManagementClusterOK)
+		Expect(ok).To(BeTrue())
+		Eventually(mcClient.started).Should(Receive(Equal("delete")))
+
+		conflict, ok := app.UpgradeManagementCluster(management.UpgradeManagementClusterParams{HTTPRequest: operationRequest(http.MethodPost, "/api/management/mc1/upgrade"), ClusterName: "mc1"}).(*management.UpgradeManagementClusterConflict)
+		Expect(ok).To(BeTrue())
+		Expect(conflict.Payload.Message).To(ContainSubstring(`"Delete management cluster" is in progress`))
+		_, ok = app.DeleteManagementCluster(management.DeleteManagementClusterParams{HTTPRequest: operationRequest(http.MethodDelete, "/api/management/mc1"), ClusterName: "mc1"}).(*management.DeleteManagementClusterConflict)
+		Expect(ok).To(BeTrue())
+
+		// a failed operation ends the operation in progress as well
+		mcClient.release <- errors.New("delete failed")
+		Eventually(func() interface{} {
+			return app.UpgradeManagementCluster(management.UpgradeManagementClusterParams{HTTPRequest: operationRequest(http.MethodPost, "/api/management/mc1/upgrade"), ClusterName: "mc1"})
+		}).Should(BeAssignableToTypeOf(&management.UpgradeManagementClusterOK{}))
+		Eventually(mcClient.started).Should(Receive(Equal("upgrade")))
+		mcClient.release <- nil
+	})
+})
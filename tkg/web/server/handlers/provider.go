@@ -7,6 +7,10 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/pkg/errors"
 
+	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clientcreator"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigbom"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/models"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/provider"
@@ -30,3 +34,60 @@ func (app *App) GetProvider(params provider.GetProviderParams) middleware.Respon
 
 	return provider.NewGetProviderOK().WithPayload(&providerInfo)
 }
+
+// ValidateManagementClusterConfiguration runs all the configuration checks of the provider against the applied
+// TKG configuration and reports every problem found
+func (app *App) ValidateManagementClusterConfiguration(params provider.ValidateManagementClusterConfigurationParams) middleware.Responder {
+	allClients, err := clientcreator.CreateAllClients(app.AppConfig, app.TKGConfigReaderWriter)
+	if err != nil {
+		return provider.NewValidateManagementClusterConfigurationInternalServerError().WithPayload(Err(err))
+	}
+
+	c, err := client.New(client.Options{
+		ClusterCtlClient:         allClients.ClusterCtlClient,
+		ReaderWriterConfigClient: allClients.ConfigClient,
+		RegionManager:            allClients.RegionManager,
+		TKGConfigDir:             app.AppConfig.TKGConfigDir,
+		Timeout:                  app.TKGTimeout,
+		FeaturesClient:           allClients.FeaturesClient,
+		TKGConfigProvidersClient: allClients.TKGConfigProvidersClient,
+		TKGBomClient:             allClients.TKGBomClient,
+		TKGConfigUpdater:         allClients.TKGConfigUpdaterClient,
+		TKGPathsClient:           allClients.TKGConfigPathsClient,
+		ClusterClientFactory:     clusterclient.NewClusterClientFactory(),
+		FeatureFlagClient:        getFeatureFlagClient(),
+	})
+	if err != nil {
+		return provider.NewValidateManagementClusterConfigurationInternalServerError().WithPayload(Err(err))
+	}
+
+	// the configuration is expected to have been applied to the TKG configuration file beforehand
+	options := app.InitOptions
+	options.InfrastructureProvider = params.Provider
+	options.ClusterName, _ = app.TKGConfigReaderWriter.Get(constants.ConfigVariableClusterName)
+	options.Plan, _ = app.TKGConfigReaderWriter.Get(constants.ConfigVariableClusterPlan)
+	options.CniType, _ = app.TKGConfigReaderWriter.Get(constants.ConfigVariableCNI)
+	options.VsphereControlPlaneEndpoint, _ = app.TKGConfigReaderWriter.Get(constants.ConfigVariableVsphereControlPlaneEndpoint)
+
+	report, err := c.ValidateManagementClusterConfiguration(&options)
+	if err != nil {
+		return provider.NewValidateManagementClusterConfigurationBadRequest().WithPayload(Err(err))
+	}
+
+	payload := &models.ValidationReport{
+		ClusterName: report.ClusterName,
+		Provider:    report.Provider,
+		Checks:      report.Checks,
+		Findings:    []*models.ValidationFinding{},
+	}
+	for _, finding := range report.Findings {
+		payload.Findings = append(payload.Findings, &models.ValidationFinding{
+			Check:       finding.Check,
+			Severity:    string(finding.Severity),
+			ConfigKey:   finding.ConfigKey,
+			Message:     finding.Message,
+			Remediation: finding.Remediation,
+		})
+	}
+	return provider.NewValidateManagementClusterConfigurationOK().WithPayload(payload)
+}
@@ -473,6 +473,215 @@ func init() {
         }
       }
     },
+    "/api/management": {
+      "get": {
+        "tags": [
+          "management"
+        ],
+        "summary": "Retrieve list of management clusters and their contexts",
+        "operationId": "getManagementClusters",
+        "responses": {
+          "200": {
+            "description": "Successful retrieval of management clusters",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/ManagementClusterInfo"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/management/{clusterName}": {
+      "get": {
+        "tags": [
+          "management"
+        ],
+        "summary": "Retrieve the status and health of a management cluster",
+        "operationId": "getManagementCluster",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the management cluster",
+            "name": "clusterName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Successful retrieval of management cluster status",
+            "schema": {
+              "$ref": "#/definitions/ManagementClusterStatus"
+            }
+          },
+          "404": {
+            "description": "Management cluster not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "management"
+        ],
+        "summary": "Delete a management cluster",
+        "operationId": "deleteManagementCluster",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the management cluster",
+            "name": "clusterName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "Delete the management cluster even if it still has workload clusters",
+            "name": "force",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Deleting management cluster started successfully",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Management cluster not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "Another operation is in progress on the management clusters",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/management/{clusterName}/clusters": {
+      "get": {
+        "tags": [
+          "management"
+        ],
+        "summary": "Retrieve list of workload clusters of a management cluster",
+        "operationId": "getWorkloadClusters",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the management cluster",
+            "name": "clusterName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Successful retrieval of workload clusters",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/WorkloadClusterInfo"
+              }
+            }
+          },
+          "404": {
+            "description": "Management cluster not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/management/{clusterName}/upgrade": {
+      "post": {
+        "tags": [
+          "management"
+        ],
+        "summary": "Upgrade a management cluster to the current TKG version",
+        "operationId": "upgradeManagementCluster",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the management cluster",
+            "name": "clusterName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Upgrading management cluster started successfully",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Management cluster not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "Another operation is in progress on the management clusters",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/api/providers": {
       "get": {
         "tags": [
@@ -1793,6 +2002,44 @@ func init() {
         }
       }
     },
+    "/api/providers/validation": {
+      "get": {
+        "tags": [
+          "provider"
+        ],
+        "summary": "Validate the management cluster configuration and report every problem found",
+        "operationId": "validateManagementClusterConfiguration",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Infrastructure provider",
+            "name": "provider",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Successful operation",
+            "schema": {
+              "$ref": "#/definitions/ValidationReport"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/api/providers/vsphere": {
       "post": {
         "tags": [
@@ -3315,6 +3562,61 @@ func init() {
         }
       }
     },
+    "ManagementClusterInfo": {
+      "type": "object",
+      "properties": {
+        "context": {
+          "type": "string"
+        },
+        "isCurrentContext": {
+          "type": "boolean"
+        },
+        "kubeconfig": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        }
+      }
+    },
+    "ManagementClusterStatus": {
+      "type": "object",
+      "properties": {
+        "context": {
+          "type": "string"
+        },
+        "controlPlaneCount": {
+          "type": "string"
+        },
+        "healthy": {
+          "type": "boolean"
+        },
+        "kubernetesVersion": {
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "phase": {
+          "type": "string"
+        },
+        "plan": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        },
+        "workerCount": {
+          "type": "string"
+        }
+      }
+    },
     "NodeType": {
       "type": "object",
       "properties": {
@@ -3545,6 +3847,49 @@ func init() {
         }
       }
     },
+    "ValidationFinding": {
+      "type": "object",
+      "properties": {
+        "check": {
+          "type": "string"
+        },
+        "configKey": {
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        },
+        "remediation": {
+          "type": "string"
+        },
+        "severity": {
+          "type": "string"
+        }
+      }
+    },
+    "ValidationReport": {
+      "type": "object",
+      "properties": {
+        "checks": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "clusterName": {
+          "type": "string"
+        },
+        "findings": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ValidationFinding"
+          }
+        },
+        "provider": {
+          "type": "string"
+        }
+      }
+    },
     "VsphereRegionalClusterParams": {
       "type": "object",
       "properties": {
@@ -3626,6 +3971,47 @@ func init() {
         }
       }
     },
+    "WorkloadClusterInfo": {
+      "type": "object",
+      "properties": {
+        "controlPlaneCount": {
+          "type": "string"
+        },
+        "kubernetesVersion": {
+          "type": "string"
+        },
+        "labels": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "plan": {
+          "type": "string"
+        },
+        "roles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "status": {
+          "type": "string"
+        },
+        "tkr": {
+          "type": "string"
+        },
+        "workerCount": {
+          "type": "string"
+        }
+      }
+    },
     "providerInfo": {
       "type": "object",
       "properties": {
@@ -3958,7 +4344,180 @@ func init() {
           "401": {
             "description": "Incorrect credentials",
             "schema": {
-              "$ref": "#/definitions/Error"
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/ldap/connect": {
+      "post": {
+        "tags": [
+          "ldap"
+        ],
+        "summary": "Validate LDAP connection",
+        "operationId": "verifyLdapConnect",
+        "parameters": [
+          {
+            "description": "LDAP configuration",
+            "name": "credentials",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/LdapParams"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Verified LDAP credentials successfully",
+            "schema": {
+              "$ref": "#/definitions/LdapTestResult"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "401": {
+            "description": "Incorrect credentials",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/ldap/disconnect": {
+      "post": {
+        "tags": [
+          "ldap"
+        ],
+        "summary": "Validate if the LDAP connection can be closed",
+        "operationId": "verifyLdapCloseConnection",
+        "responses": {
+          "201": {
+            "description": "Verified LDAP credentials successfully"
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "401": {
+            "description": "Incorrect credentials",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/ldap/groups/search": {
+      "post": {
+        "tags": [
+          "ldap"
+        ],
+        "summary": "Validate LDAP Group Search configuration",
+        "operationId": "verifyLdapGroupSearch",
+        "responses": {
+          "200": {
+            "description": "Verified LDAP credentials successfully",
+            "schema": {
+              "$ref": "#/definitions/LdapTestResult"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "401": {
+            "description": "Incorrect credentials",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/ldap/users/search": {
+      "post": {
+        "tags": [
+          "ldap"
+        ],
+        "summary": "Validate LDAP User Search configuration",
+        "operationId": "verifyLdapUserSearch",
+        "responses": {
+          "200": {
+            "description": "Verified LDAP credentials successfully",
+            "schema": {
+              "$ref": "#/definitions/LdapTestResult"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "401": {
+            "description": "Incorrect credentials",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/management": {
+      "get": {
+        "tags": [
+          "management"
+        ],
+        "summary": "Retrieve list of management clusters and their contexts",
+        "operationId": "getManagementClusters",
+        "responses": {
+          "200": {
+            "description": "Successful retrieval of management clusters",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/ManagementClusterInfo"
+              }
             }
           },
           "500": {
@@ -3970,38 +4529,31 @@ func init() {
         }
       }
     },
-    "/api/ldap/connect": {
-      "post": {
+    "/api/management/{clusterName}": {
+      "get": {
         "tags": [
-          "ldap"
+          "management"
         ],
-        "summary": "Validate LDAP connection",
-        "operationId": "verifyLdapConnect",
+        "summary": "Retrieve the status and health of a management cluster",
+        "operationId": "getManagementCluster",
         "parameters": [
           {
-            "description": "LDAP configuration",
-            "name": "credentials",
-            "in": "body",
-            "schema": {
-              "$ref": "#/definitions/LdapParams"
-            }
+            "type": "string",
+            "description": "Name of the management cluster",
+            "name": "clusterName",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "Verified LDAP credentials successfully",
-            "schema": {
-              "$ref": "#/definitions/LdapTestResult"
-            }
-          },
-          "400": {
-            "description": "Bad request",
+            "description": "Successful retrieval of management cluster status",
             "schema": {
-              "$ref": "#/definitions/Error"
+              "$ref": "#/definitions/ManagementClusterStatus"
             }
           },
-          "401": {
-            "description": "Incorrect credentials",
+          "404": {
+            "description": "Management cluster not found",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -4013,18 +4565,34 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/api/ldap/disconnect": {
-      "post": {
+      },
+      "delete": {
         "tags": [
-          "ldap"
+          "management"
+        ],
+        "summary": "Delete a management cluster",
+        "operationId": "deleteManagementCluster",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the management cluster",
+            "name": "clusterName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "Delete the management cluster even if it still has workload clusters",
+            "name": "force",
+            "in": "query"
+          }
         ],
-        "summary": "Validate if the LDAP connection can be closed",
-        "operationId": "verifyLdapCloseConnection",
         "responses": {
-          "201": {
-            "description": "Verified LDAP credentials successfully"
+          "200": {
+            "description": "Deleting management cluster started successfully",
+            "schema": {
+              "type": "string"
+            }
           },
           "400": {
             "description": "Bad request",
@@ -4032,8 +4600,14 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           },
-          "401": {
-            "description": "Incorrect credentials",
+          "404": {
+            "description": "Management cluster not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "Another operation is in progress on the management clusters",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -4047,28 +4621,34 @@ func init() {
         }
       }
     },
-    "/api/ldap/groups/search": {
-      "post": {
+    "/api/management/{clusterName}/clusters": {
+      "get": {
         "tags": [
-          "ldap"
+          "management"
+        ],
+        "summary": "Retrieve list of workload clusters of a management cluster",
+        "operationId": "getWorkloadClusters",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the management cluster",
+            "name": "clusterName",
+            "in": "path",
+            "required": true
+          }
         ],
-        "summary": "Validate LDAP Group Search configuration",
-        "operationId": "verifyLdapGroupSearch",
         "responses": {
           "200": {
-            "description": "Verified LDAP credentials successfully",
+            "description": "Successful retrieval of workload clusters",
             "schema": {
-              "$ref": "#/definitions/LdapTestResult"
-            }
-          },
-          "400": {
-            "description": "Bad request",
-            "schema": {
-              "$ref": "#/definitions/Error"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/WorkloadClusterInfo"
+              }
             }
           },
-          "401": {
-            "description": "Incorrect credentials",
+          "404": {
+            "description": "Management cluster not found",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -4082,18 +4662,27 @@ func init() {
         }
       }
     },
-    "/api/ldap/users/search": {
+    "/api/management/{clusterName}/upgrade": {
       "post": {
         "tags": [
-          "ldap"
+          "management"
+        ],
+        "summary": "Upgrade a management cluster to the current TKG version",
+        "operationId": "upgradeManagementCluster",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the management cluster",
+            "name": "clusterName",
+            "in": "path",
+            "required": true
+          }
         ],
-        "summary": "Validate LDAP User Search configuration",
-        "operationId": "verifyLdapUserSearch",
         "responses": {
           "200": {
-            "description": "Verified LDAP credentials successfully",
+            "description": "Upgrading management cluster started successfully",
             "schema": {
-              "$ref": "#/definitions/LdapTestResult"
+              "type": "string"
             }
           },
           "400": {
@@ -4102,8 +4691,14 @@ func init() {
               "$ref": "#/definitions/Error"
             }
           },
-          "401": {
-            "description": "Incorrect credentials",
+          "404": {
+            "description": "Management cluster not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "Another operation is in progress on the management clusters",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -5437,6 +6032,44 @@ func init() {
         }
       }
     },
+    "/api/providers/validation": {
+      "get": {
+        "tags": [
+          "provider"
+        ],
+        "summary": "Validate the management cluster configuration and report every problem found",
+        "operationId": "validateManagementClusterConfiguration",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Infrastructure provider",
+            "name": "provider",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Successful operation",
+            "schema": {
+              "$ref": "#/definitions/ValidationReport"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/api/providers/vsphere": {
       "post": {
         "tags": [
@@ -6959,6 +7592,61 @@ func init() {
         }
       }
     },
+    "ManagementClusterInfo": {
+      "type": "object",
+      "properties": {
+        "context": {
+          "type": "string"
+        },
+        "isCurrentContext": {
+          "type": "boolean"
+        },
+        "kubeconfig": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        }
+      }
+    },
+    "ManagementClusterStatus": {
+      "type": "object",
+      "properties": {
+        "context": {
+          "type": "string"
+        },
+        "controlPlaneCount": {
+          "type": "string"
+        },
+        "healthy": {
+          "type": "boolean"
+        },
+        "kubernetesVersion": {
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "phase": {
+          "type": "string"
+        },
+        "plan": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        },
+        "workerCount": {
+          "type": "string"
+        }
+      }
+    },
     "NodeType": {
       "type": "object",
       "properties": {
@@ -7189,6 +7877,49 @@ func init() {
         }
       }
     },
+    "ValidationFinding": {
+      "type": "object",
+      "properties": {
+        "check": {
+          "type": "string"
+        },
+        "configKey": {
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        },
+        "remediation": {
+          "type": "string"
+        },
+        "severity": {
+          "type": "string"
+        }
+      }
+    },
+    "ValidationReport": {
+      "type": "object",
+      "properties": {
+        "checks": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "clusterName": {
+          "type": "string"
+        },
+        "findings": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ValidationFinding"
+          }
+        },
+        "provider": {
+          "type": "string"
+        }
+      }
+    },
     "VsphereRegionalClusterParams": {
       "type": "object",
       "properties": {
@@ -7270,6 +8001,47 @@ func init() {
         }
       }
     },
+    "WorkloadClusterInfo": {
+      "type": "object",
+      "properties": {
+        "controlPlaneCount": {
+          "type": "string"
+        },
+        "kubernetesVersion": {
+          "type": "string"
+        },
+        "labels": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "plan": {
+          "type": "string"
+        },
+        "roles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "status": {
+          "type": "string"
+        },
+        "tkr": {
+          "type": "string"
+        },
+        "workerCount": {
+          "type": "string"
+        }
+      }
+    },
     "providerInfo": {
       "type": "object",
       "properties": {
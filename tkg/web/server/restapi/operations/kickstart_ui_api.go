@@ -26,6 +26,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/edition"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/features"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/ldap"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/management"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/provider"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/ui"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/web/server/restapi/operations/vsphere"
@@ -81,6 +82,9 @@ func NewKickstartUIAPI(spec *loads.Document) *KickstartUIAPI {
 		VsphereCreateVSphereRegionalClusterHandler: vsphere.CreateVSphereRegionalClusterHandlerFunc(func(params vsphere.CreateVSphereRegionalClusterParams) middleware.Responder {
 			return middleware.NotImplemented("operation VsphereCreateVSphereRegionalCluster has not yet been implemented")
 		}),
+		ManagementDeleteManagementClusterHandler: management.DeleteManagementClusterHandlerFunc(func(params management.DeleteManagementClusterParams) middleware.Responder {
+			return middleware.NotImplemented("operation ManagementDeleteManagementCluster has not yet been implemented")
+		}),
 		AwsExportTKGConfigForAWSHandler: aws.ExportTKGConfigForAWSHandlerFunc(func(params aws.ExportTKGConfigForAWSParams) middleware.Responder {
 			return middleware.NotImplemented("operation AwsExportTKGConfigForAWS has not yet been implemented")
 		}),
@@ -141,6 +145,12 @@ func NewKickstartUIAPI(spec *loads.Document) *KickstartUIAPI {
 		FeaturesGetFeatureFlagsHandler: features.GetFeatureFlagsHandlerFunc(func(params features.GetFeatureFlagsParams) middleware.Responder {
 			return middleware.NotImplemented("operation FeaturesGetFeatureFlags has not yet been implemented")
 		}),
+		ManagementGetManagementClusterHandler: management.GetManagementClusterHandlerFunc(func(params management.GetManagementClusterParams) middleware.Responder {
+			return middleware.NotImplemented("operation ManagementGetManagementCluster has not yet been implemented")
+		}),
+		ManagementGetManagementClustersHandler: management.GetManagementClustersHandlerFunc(func(params management.GetManagementClustersParams) middleware.Responder {
+			return middleware.NotImplemented("operation ManagementGetManagementClusters has not yet been implemented")
+		}),
 		ProviderGetProviderHandler: provider.GetProviderHandlerFunc(func(params provider.GetProviderParams) middleware.Responder {
 			return middleware.NotImplemented("operation ProviderGetProvider has not yet been implemented")
 		}),
@@ -183,6 +193,9 @@ func NewKickstartUIAPI(spec *loads.Document) *KickstartUIAPI {
 		VsphereGetVsphereThumbprintHandler: vsphere.GetVsphereThumbprintHandlerFunc(func(params vsphere.GetVsphereThumbprintParams) middleware.Responder {
 			return middleware.NotImplemented("operation VsphereGetVsphereThumbprint has not yet been implemented")
 		}),
+		ManagementGetWorkloadClustersHandler: management.GetWorkloadClustersHandlerFunc(func(params management.GetWorkloadClustersParams) middleware.Responder {
+			return middleware.NotImplemented("operation ManagementGetWorkloadClusters has not yet been implemented")
+		}),
 		AwsImportTKGConfigForAWSHandler: aws.ImportTKGConfigForAWSHandlerFunc(func(params aws.ImportTKGConfigForAWSParams) middleware.Responder {
 			return middleware.NotImplemented("operation AwsImportTKGConfigForAWS has not yet been implemented")
 		}),
@@ -204,6 +217,12 @@ func NewKickstartUIAPI(spec *loads.Document) *KickstartUIAPI {
 		VsphereSetVSphereEndpointHandler: vsphere.SetVSphereEndpointHandlerFunc(func(params vsphere.SetVSphereEndpointParams) middleware.Responder {
 			return middleware.NotImplemented("operation VsphereSetVSphereEndpoint has not yet been implemented")
 		}),
+		ManagementUpgradeManagementClusterHandler: management.UpgradeManagementClusterHandlerFunc(func(params management.UpgradeManagementClusterParams) middleware.Responder {
+			return middleware.NotImplemented("operation ManagementUpgradeManagementCluster has not yet been implemented")
+		}),
+		ProviderValidateManagementClusterConfigurationHandler: provider.ValidateManagementClusterConfigurationHandlerFunc(func(params provider.ValidateManagementClusterConfigurationParams) middleware.Responder {
+			return middleware.NotImplemented("operation ProviderValidateManagementClusterConfiguration has not yet been implemented")
+		}),
 		AviVerifyAccountHandler: avi.VerifyAccountHandlerFunc(func(params avi.VerifyAccountParams) middleware.Responder {
 			return middleware.NotImplemented("operation AviVerifyAccount has not yet been implemented")
 		}),
@@ -275,6 +294,8 @@ type KickstartUIAPI struct {
 	DockerCreateDockerRegionalClusterHandler docker.CreateDockerRegionalClusterHandler
 	// VsphereCreateVSphereRegionalClusterHandler sets the operation handler for the create v sphere regional cluster operation
 	VsphereCreateVSphereRegionalClusterHandler vsphere.CreateVSphereRegionalClusterHandler
+	// ManagementDeleteManagementClusterHandler sets the operation handler for the delete management cluster operation
+	ManagementDeleteManagementClusterHandler management.DeleteManagementClusterHandler
 	// AwsExportTKGConfigForAWSHandler sets the operation handler for the export t k g config for a w s operation
 	AwsExportTKGConfigForAWSHandler aws.ExportTKGConfigForAWSHandler
 	// AzureExportTKGConfigForAzureHandler sets the operation handler for the export t k g config for azure operation
@@ -315,6 +336,10 @@ type KickstartUIAPI struct {
 	AzureGetAzureVnetsHandler azure.GetAzureVnetsHandler
 	// FeaturesGetFeatureFlagsHandler sets the operation handler for the get feature flags operation
 	FeaturesGetFeatureFlagsHandler features.GetFeatureFlagsHandler
+	// ManagementGetManagementClusterHandler sets the operation handler for the get management cluster operation
+	ManagementGetManagementClusterHandler management.GetManagementClusterHandler
+	// ManagementGetManagementClustersHandler sets the operation handler for the get management clusters operation
+	ManagementGetManagementClustersHandler management.GetManagementClustersHandler
 	// ProviderGetProviderHandler sets the operation handler for the get provider operation
 	ProviderGetProviderHandler provider.GetProviderHandler
 	// EditionGetTanzuEditionHandler sets the operation handler for the get tanzu edition operation
@@ -343,6 +368,8 @@ type KickstartUIAPI struct {
 	VsphereGetVSphereResourcePoolsHandler vsphere.GetVSphereResourcePoolsHandler
 	// VsphereGetVsphereThumbprintHandler sets the operation handler for the get vsphere thumbprint operation
 	VsphereGetVsphereThumbprintHandler vsphere.GetVsphereThumbprintHandler
+	// ManagementGetWorkloadClustersHandler sets the operation handler for the get workload clusters operation
+	ManagementGetWorkloadClustersHandler management.GetWorkloadClustersHandler
 	// AwsImportTKGConfigForAWSHandler sets the operation handler for the import t k g config for a w s operation
 	AwsImportTKGConfigForAWSHandler aws.ImportTKGConfigForAWSHandler
 	// AzureImportTKGConfigForAzureHandler sets the operation handler for the import t k g config for azure operation
@@ -357,6 +384,10 @@ type KickstartUIAPI struct {
 	AzureSetAzureEndpointHandler azure.SetAzureEndpointHandler
 	// VsphereSetVSphereEndpointHandler sets the operation handler for the set v sphere endpoint operation
 	VsphereSetVSphereEndpointHandler vsphere.SetVSphereEndpointHandler
+	// ManagementUpgradeManagementClusterHandler sets the operation handler for the upgrade management cluster operation
+	ManagementUpgradeManagementClusterHandler management.UpgradeManagementClusterHandler
+	// ProviderValidateManagementClusterConfigurationHandler sets the operation handler for the validate management cluster configuration operation
+	ProviderValidateManagementClusterConfigurationHandler provider.ValidateManagementClusterConfigurationHandler
 	// AviVerifyAccountHandler sets the operation handler for the verify account operation
 	AviVerifyAccountHandler avi.VerifyAccountHandler
 	// LdapVerifyLdapBindHandler sets the operation handler for the verify ldap bind operation
@@ -476,6 +507,10 @@ func (o *KickstartUIAPI) Validate() error {
 		unregistered = append(unregistered, "vsphere.CreateVSphereRegionalClusterHandler")
 	}
 
+	if o.ManagementDeleteManagementClusterHandler == nil {
+		unregistered = append(unregistered, "management.DeleteManagementClusterHandler")
+	}
+
 	if o.AwsExportTKGConfigForAWSHandler == nil {
 		unregistered = append(unregistered, "aws.ExportTKGConfigForAWSHandler")
 	}
@@ -556,6 +591,14 @@ func (o *KickstartUIAPI) Validate() error {
 		unregistered = append(unregistered, "features.GetFeatureFlagsHandler")
 	}
 
+	if o.ManagementGetManagementClusterHandler == nil {
+		unregistered = append(unregistered, "management.GetManagementClusterHandler")
+	}
+
+	if o.ManagementGetManagementClustersHandler == nil {
+		unregistered = append(unregistered, "management.GetManagementClustersHandler")
+	}
+
 	if o.ProviderGetProviderHandler == nil {
 		unregistered = append(unregistered, "provider.GetProviderHandler")
 	}
@@ -612,6 +655,10 @@ func (o *KickstartUIAPI) Validate() error {
 		unregistered = append(unregistered, "vsphere.GetVsphereThumbprintHandler")
 	}
 
+	if o.ManagementGetWorkloadClustersHandler == nil {
+		unregistered = append(unregistered, "management.GetWorkloadClustersHandler")
+	}
+
 	if o.AwsImportTKGConfigForAWSHandler == nil {
 		unregistered = append(unregistered, "aws.ImportTKGConfigForAWSHandler")
 	}
@@ -640,6 +687,14 @@ func (o *KickstartUIAPI) Validate() error {
 		unregistered = append(unregistered, "vsphere.SetVSphereEndpointHandler")
 	}
 
+	if o.ManagementUpgradeManagementClusterHandler == nil {
+		unregistered = append(unregistered, "management.UpgradeManagementClusterHandler")
+	}
+
+	if o.ProviderValidateManagementClusterConfigurationHandler == nil {
+		unregistered = append(unregistered, "provider.ValidateManagementClusterConfigurationHandler")
+	}
+
 	if o.AviVerifyAccountHandler == nil {
 		unregistered = append(unregistered, "avi.VerifyAccountHandler")
 	}
@@ -817,6 +872,11 @@ func (o *KickstartUIAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/api/providers/vsphere/create"] = vsphere.NewCreateVSphereRegionalCluster(o.context, o.VsphereCreateVSphereRegionalClusterHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/api/management/{clusterName}"] = management.NewDeleteManagementCluster(o.context, o.ManagementDeleteManagementClusterHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -917,6 +977,16 @@ func (o *KickstartUIAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/api/features"] = features.NewGetFeatureFlags(o.context, o.FeaturesGetFeatureFlagsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/management/{clusterName}"] = management.NewGetManagementCluster(o.context, o.ManagementGetManagementClusterHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/management"] = management.NewGetManagementClusters(o.context, o.ManagementGetManagementClustersHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -987,6 +1057,11 @@ func (o *KickstartUIAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/api/providers/vsphere/thumbprint"] = vsphere.NewGetVsphereThumbprint(o.context, o.VsphereGetVsphereThumbprintHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/management/{clusterName}/clusters"] = management.NewGetWorkloadClusters(o.context, o.ManagementGetWorkloadClustersHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -1022,6 +1097,16 @@ func (o *KickstartUIAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/api/providers/vsphere"] = vsphere.NewSetVSphereEndpoint(o.context, o.VsphereSetVSphereEndpointHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/management/{clusterName}/upgrade"] = management.NewUpgradeManagementCluster(o.context, o.ManagementUpgradeManagementClusterHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/providers/validation"] = provider.NewValidateManagementClusterConfiguration(o.context, o.ProviderValidateManagementClusterConfigurationHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +20,7 @@ import (
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 
 	"github.com/vmware-tanzu/tanzu-framework/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
 	servermanifest "github.com/vmware-tanzu/tanzu-framework/tkg/manifest/server"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/tkgconfigreaderwriter"
 	"github.com/vmware-tanzu/tanzu-framework/tkg/types"
@@ -31,7 +33,7 @@ import (
 // Serve serve the kickstart UI
 // nolint:gocritic
 func Serve(initOptions client.InitRegionOptions, appConfig types.AppConfig, tkgConfigReaderWriter tkgconfigreaderwriter.TKGConfigReaderWriter,
-	tkgTimeOut time.Duration, bind string, browser string) error {
+	tkgTimeOut time.Duration, bind string, browser string, managementClusterClientFactory handlers.ManagementClusterClientFactory) error {
 
 	swaggerSpec, err := loads.Analyzed(restapi.FlatSwaggerJSON, "2.0")
 	if err != nil {
@@ -54,8 +56,13 @@ func Serve(initOptions client.InitRegionOptions, appConfig types.AppConfig, tkgC
 	server.Browser = browser
 
 	ws.InitWebsocketUpgrader(server.Host)
+	logEventsFile := filepath.Join(appConfig.TKGConfigDir, constants.LogFolderName, constants.UILogEventsFileName)
+	if err := ws.InitLogStore(logEventsFile, ws.DefaultLogStoreCapacity); err != nil {
+		server.Logf("Unable to persist the UI log events to %s, error: %s\n", logEventsFile, err)
+	}
 
-	app := &handlers.App{InitOptions: initOptions, AppConfig: appConfig, TKGTimeout: tkgTimeOut, TKGConfigReaderWriter: tkgConfigReaderWriter}
+	app := &handlers.App{InitOptions: initOptions, AppConfig: appConfig, TKGTimeout: tkgTimeOut, TKGConfigReaderWriter: tkgConfigReaderWriter,
+		ManagementClusterClientFactory: managementClusterClientFactory}
 	app.ConfigureHandlers(api)
 	server.SetAPI(api)
 	server.SetHandler(api.Serve(FileServerMiddleware))
@@ -97,6 +104,8 @@ func FileServerMiddleware(handler http.Handler) http.Handler {
 			handler.ServeHTTP(w, r)
 		} else if strings.HasPrefix(r.URL.Path, "/api/ldap") {
 			handler.ServeHTTP(w, r)
+		} else if strings.HasPrefix(r.URL.Path, "/api/management") {
+			handler.ServeHTTP(w, r)
 		} else {
 			w.Header().Set("Cache-Control", "no-store")
 			w.Header().Set("Pragma", "no-cache")
@@ -0,0 +1,209 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultLogStoreCapacity is the number of log events kept for replay
+const DefaultLogStoreCapacity = 10000
+
+// maxLogEventSize is the size of the largest log event restored from the file
+const maxLogEventSize = 1024 * 1024
+
+// log levels of the log events in increasing order of severity,
+// events without a known level are treated as INFO
+var logLevelSeverity = map[string]int{
+	"INFO":  0,
+	"WARN":  1,
+	"ERROR": 2,
+	"FATAL": 3,
+}
+
+// logEvent is a single message sent over the websocket. Offset is the
+// position of the event in the log stream and can be used by clients
+// to resume the stream after reconnecting.
+type logEvent struct {
+	Offset int64           `json:"offset"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+
+	level string
+}
+
+// logStore keeps the most recent log events in memory and mirrors them
+// to a ring-buffer file which never holds more than twice its capacity
+type logStore struct {
+	capacity int
+	events   []*logEvent
+	next     int64
+
+	file          string
+	fileLineCount int
+}
+
+func newLogStore(capacity int) *logStore {
+	if capacity <= 0 {
+		capacity = DefaultLogStoreCapacity
+	}
+	return &logStore{capacity: capacity}
+}
+
+// setFile restores the events persisted to the given file by a previous run, if any,
+// and persists all further events to it
+func (s *logStore) setFile(file string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return errors.Wrap(err, "unable to create log event directory")
+	}
+	if err := s.load(file); err != nil {
+		return err
+	}
+	if err := s.rewriteFile(file); err != nil {
+		return err
+	}
+	s.file = file
+	return nil
+}
+
+// load restores the most recent events of the file, the offsets of the
+// events added afterwards follow the last restored one
+func (s *logStore) load(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "unable to read log events from %s", file)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogEventSize)
+	for scanner.Scan() {
+		event := &logEvent{}
+		// skip the lines which are not events, e.g. a line truncated by a crash
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil || event.Type == "" || event.Offset < s.next {
+			continue
+		}
+		data := struct {
+			LogType string `json:"logType"`
+		}{}
+		_ = json.Unmarshal(event.Data, &data)
+		event.level = data.LogType
+
+		s.events = append(s.events, event)
+		s.next = event.Offset + 1
+	}
+	if len(s.events) > s.capacity {
+		s.events = s.events[len(s.events)-s.capacity:]
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "unable to read log events from %s", file)
+	}
+	return nil
+}
+
+// add converts a message sent by the tkg logger to a log event and stores it
+func (s *logStore) add(msg []byte) *logEvent {
+	event := newLogEvent(msg)
+	event.Offset = s.next
+	s.next++
+
+	s.events = append(s.events, event)
+	if len(s.events) > s.capacity {
+		s.events = s.events[len(s.events)-s.capacity:]
+	}
+	if s.file != "" {
+		s.persist(event)
+	}
+	return event
+}
+
+// since returns the stored events starting from the given offset, if the
+// offset is no longer available all stored events are returned
+func (s *logStore) since(offset int64) []*logEvent {
+	if len(s.events) == 0 || offset <= s.events[0].Offset {
+		return s.events
+	}
+	i := offset - s.events[0].Offset
+	if i >= int64(len(s.events)) {
+		return nil
+	}
+	return s.events[i:]
+}
+
+func (s *logStore) persist(event *logEvent) {
+	if s.fileLineCount >= 2*s.capacity {
+		if err := s.rewriteFile(s.file); err != nil {
+			s.file = ""
+		}
+		return
+	}
+	f, err := os.OpenFile(s.file, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		s.file = ""
+		return
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if _, err := f.Write(append(line, '\n')); err == nil {
+		s.fileLineCount++
+	}
+}
+
+// rewriteFile replaces the file content with the events held in memory
+func (s *logStore) rewriteFile(file string) error {
+	var buf bytes.Buffer
+	for _, event := range s.events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := os.WriteFile(file, buf.Bytes(), 0o600); err != nil {
+		return errors.Wrapf(err, "unable to write log events to %s", file)
+	}
+	s.fileLineCount = len(s.events)
+	return nil
+}
+
+func newLogEvent(msg []byte) *logEvent {
+	update := struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(msg, &update); err != nil || update.Type == "" || len(update.Data) == 0 {
+		// not a tkg logger message, forward it as a plain log message
+		data, _ := json.Marshal(map[string]string{"message": string(msg)})
+		return &logEvent{Type: "log", Data: data}
+	}
+
+	data := struct {
+		LogType string `json:"logType"`
+	}{}
+	_ = json.Unmarshal(update.Data, &data)
+	return &logEvent{Type: update.Type, Data: update.Data, level: data.LogType}
+}
+
+// matches returns true if the event should be sent to a client which only
+// wants log messages of at least minLevel, other event types are always sent
+func (e *logEvent) matches(minLevel string) bool {
+	if minLevel == "" || e.Type != "log" {
+		return true
+	}
+	return logLevelSeverity[e.level] >= logLevelSeverity[minLevel]
+}
@@ -0,0 +1,199 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestWS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Websocket Suite")
+}
+
+func logMsg(logType, message string) []byte {
+	return []byte(fmt.Sprintf(`{"type":"log","data":{"message":%q,"logType":%q}}`, message, logType))
+}
+
+var _ = Describe("logStore", func() {
+	var (
+		s       *logStore
+		tempDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "ws-log-store")
+		Expect(err).ToNot(HaveOccurred())
+		s = newLogStore(3)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("assigns increasing offsets and keeps only the most recent events", func() {
+		for i := 0; i < 5; i++ {
+			s.add(logMsg("INFO", fmt.Sprintf("message %d", i)))
+		}
+		events := s.since(0)
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].Offset).To(Equal(int64(2)))
+		Expect(events[2].Offset).To(Equal(int64(4)))
+	})
+
+	It("replays events from the requested offset", func() {
+		for i := 0; i < 3; i++ {
+			s.add(logMsg("INFO", fmt.Sprintf("message %d", i)))
+		}
+		Expect(s.since(1)).To(HaveLen(2))
+		Expect(s.since(2)[0].Offset).To(Equal(int64(2)))
+		Expect(s.since(3)).To(BeEmpty())
+	})
+
+	It("wraps messages which are not tkg log messages", func() {
+		event := s.add([]byte("plain text"))
+		Expect(event.Type).To(Equal("log"))
+		Expect(string(event.Data)).To(ContainSubstring("plain text"))
+	})
+
+	It("filters log messages by level but always matches other event types", func() {
+		warn := s.add(logMsg("WARN", "warning"))
+		info := s.add(logMsg("INFO", "info"))
+		progress := s.add([]byte(`{"type":"progress","data":{"status":"running"}}`))
+
+		Expect(warn.matches("WARN")).To(BeTrue())
+		Expect(warn.matches("ERROR")).To(BeFalse())
+		Expect(info.matches("")).To(BeTrue())
+		Expect(info.matches("WARN")).To(BeFalse())
+		Expect(progress.matches("FATAL")).To(BeTrue())
+	})
+
+	It("persists events to a file bounded to twice the capacity", func() {
+		file := filepath.Join(tempDir, "logs", "ui-events.log")
+		Expect(s.setFile(file)).To(Succeed())
+
+		for i := 0; i < 10; i++ {
+			s.add(logMsg("INFO", fmt.Sprintf("message %d", i)))
+		}
+
+		content, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		Expect(len(lines)).To(BeNumerically("<=", 6))
+
+		last := &logEvent{}
+		Expect(json.Unmarshal([]byte(lines[len(lines)-1]), last)).To(Succeed())
+		Expect(last.Offset).To(Equal(int64(9)))
+	})
+
+	It("restores the events persisted by a previous run", func() {
+		file := filepath.Join(tempDir, "logs", "ui-events.log")
+		Expect(s.setFile(file)).To(Succeed())
+		for i := 0; i < 5; i++ {
+			s.add(logMsg("WARN", fmt.Sprintf("message %d", i)))
+		}
+		// a line truncated by a crash is skipped
+		f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0o600)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.WriteString(`{"offset":5,"type":"log","da`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+
+		restored := newLogStore(3)
+		Expect(restored.setFile(file)).To(Succeed())
+		events := restored.since(0)
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].Offset).To(Equal(int64(2)))
+		Expect(events[2].Offset).To(Equal(int64(4)))
+		Expect(events[2].matches("WARN")).To(BeTrue())
+		Expect(events[2].matches("ERROR")).To(BeFalse())
+		Expect(restored.add(logMsg("INFO", "message 5")).Offset).To(Equal(int64(5)))
+
+		content, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strings.Split(strings.TrimSpace(string(content)), "\n")).To(HaveLen(4))
+	})
+})
+
+var _ = Describe("Websocket", func() {
+	var server *httptest.Server
+
+	dial := func(query string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?"+query, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return conn
+	}
+
+	receive := func(conn *websocket.Conn) *logEvent {
+		event := &logEvent{}
+		Expect(conn.SetReadDeadline(time.Now().Add(5 * time.Second))).To(Succeed())
+		Expect(conn.ReadJSON(event)).To(Succeed())
+		return event
+	}
+
+	BeforeEach(func() {
+		mu.Lock()
+		store = newLogStore(10)
+		mu.Unlock()
+		server = httptest.NewServer(http.HandlerFunc(HandleWebsocketRequest))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("replays the stored events and sends the new ones in order", func() {
+		SendLog(logMsg("INFO", "message 0"))
+		SendLog(logMsg("INFO", "message 1"))
+
+		conn := dial("offset=1")
+		defer conn.Close()
+		Expect(receive(conn).Offset).To(Equal(int64(1)))
+
+		for i := 2; i < 2+clientQueueSize/2; i++ {
+			SendLog(logMsg("INFO", fmt.Sprintf("message %d", i)))
+		}
+		for i := 2; i < 2+clientQueueSize/2; i++ {
+			Expect(receive(conn).Offset).To(Equal(int64(i)))
+		}
+	})
+
+	It("disconnects the clients which do not keep up without blocking the others", func() {
+		slow := dial("")
+		defer slow.Close()
+		Eventually(func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(wsConnections)
+		}).Should(Equal(1))
+
+		// the slow client never reads, SendLog must not block on it
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 2*clientQueueSize+10000; i++ {
+				SendLog(logMsg("INFO", strings.Repeat("x", 1024)))
+			}
+		}()
+		Eventually(done, 30*time.Second).Should(BeClosed())
+		Eventually(func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(wsConnections)
+		}).Should(BeZero())
+	})
+})
@@ -8,6 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/gorilla/websocket"
@@ -15,11 +18,35 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/tkg/log"
 )
 
+// query parameters accepted by the websocket endpoint
+const (
+	// offsetParam resumes the log stream from the given offset
+	offsetParam = "offset"
+	// levelParam only sends log messages of at least the given level (INFO, WARN, ERROR, FATAL)
+	levelParam = "level"
+)
+
 var upgrader = websocket.Upgrader{}
 
+// clientQueueSize is the number of events queued for a websocket client, a client
+// which falls further behind is disconnected and can resume from its last offset
+const clientQueueSize = 1000
+
+// wsClient is a connected websocket along with the log level it subscribed to
+type wsClient struct {
+	conn     *websocket.Conn
+	minLevel string
+	// events are the events to write to the connection, in order. It is closed once the client is removed.
+	events chan *logEvent
+}
+
 var (
-	wsConnections []*websocket.Conn
-	logs          = [][]byte{}
+	// mu guards the log store and the connections. The events are queued to the
+	// clients while holding it, so that every client receives them in order, and
+	// written to the connections by the goroutine of each client.
+	mu            sync.Mutex
+	wsConnections []*wsClient
+	store         = newLogStore(DefaultLogStoreCapacity)
 )
 
 // InitWebsocketUpgrader initializes the upgrader and configures the
@@ -32,23 +59,46 @@ func InitWebsocketUpgrader(hostBind string) {
 	}
 }
 
+// InitLogStore restores the log events persisted to the given file for replay and
+// persists all further events to it, keeping at most twice capacity events in it
+func InitLogStore(file string, capacity int) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	store = newLogStore(capacity)
+	return store.setFile(file)
+}
+
 // HandleWebsocketRequest handles the websocket request coming from clients
 // upgrade normal http request to websocket request and stores the connection
+//
+// The log events stored so far are replayed on connection, starting from the
+// "offset" query parameter if provided. The "level" query parameter filters
+// out log messages below the given level.
 func HandleWebsocketRequest(w http.ResponseWriter, r *http.Request) {
+	offset, minLevel, err := parseStreamOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ws, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.ForceWriteToStdErr([]byte(fmt.Sprintf("web socket upgrade error: %s", err.Error())))
 		return
 	}
-	wsConnections = append(wsConnections, ws)
+	client := &wsClient{conn: ws, minLevel: minLevel, events: make(chan *logEvent, clientQueueSize)}
 
 	log.ForceWriteToStdErr([]byte("web socket connection established\n"))
 
-	sendPendingLogsOnConnection(ws)
+	mu.Lock()
+	pending := append([]*logEvent(nil), store.since(offset)...)
+	wsConnections = append(wsConnections, client)
+	mu.Unlock()
+	go writeEvents(client, pending)
 
 	ws.SetCloseHandler(func(code int, text string) error {
-		deleteWSConnection(ws)
-		ws.Close()
+		removeClient(client)
 		return nil
 	})
 
@@ -58,54 +108,89 @@ func HandleWebsocketRequest(w http.ResponseWriter, r *http.Request) {
 			break
 		}
 	}
+	removeClient(client)
 }
 
-func sendPendingLogsOnConnection(ws *websocket.Conn) {
-	log.ForceWriteToStdErr([]byte(fmt.Sprintf("sending pending %v logs to UI\n", len(logs))))
-	for _, logMsg := range logs {
-		err := ws.WriteMessage(1, logMsg)
-		if err != nil {
-			log.ForceWriteToStdErr([]byte("fail to write log message to web socket"))
-			break
+func parseStreamOptions(r *http.Request) (int64, string, error) {
+	var offset int64
+	var err error
+
+	query := r.URL.Query()
+	if v := query.Get(offsetParam); v != "" {
+		offset, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || offset < 0 {
+			return 0, "", fmt.Errorf("invalid %s %q, must be a non-negative integer", offsetParam, v)
 		}
 	}
-
-	logs = [][]byte{}
+	minLevel := strings.ToUpper(query.Get(levelParam))
+	if _, ok := logLevelSeverity[minLevel]; minLevel != "" && !ok {
+		return 0, "", fmt.Errorf("invalid %s %q, must be one of INFO, WARN, ERROR, FATAL", levelParam, query.Get(levelParam))
+	}
+	return offset, minLevel, nil
 }
 
-func deleteWSConnection(conn *websocket.Conn) {
-	index := -1
-	for i, ws := range wsConnections {
-		if conn == ws {
-			index = i
-			break
+// writeEvents writes the pending events, then the events queued to the client, to its connection
+func writeEvents(client *wsClient, pending []*logEvent) {
+	log.ForceWriteToStdErr([]byte(fmt.Sprintf("sending pending %v logs to UI\n", len(pending))))
+	for _, event := range pending {
+		if !writeEvent(client, event) {
+			return
 		}
 	}
-	if index != -1 {
-		wsConnections = append(wsConnections[:index], wsConnections[index+1:]...)
+	for event := range client.events {
+		if !writeEvent(client, event) {
+			return
+		}
 	}
 }
 
-// SendLog send the log message to all the connected websocket clients
-func SendLog(logMsg []byte) {
-	var err error
+func writeEvent(client *wsClient, event *logEvent) bool {
+	if !event.matches(client.minLevel) {
+		return true
+	}
+	if err := client.conn.WriteJSON(event); err != nil {
+		// when client connection is closed
+		if !errors.Is(err, syscall.EPIPE) && !errors.Is(err, syscall.ECONNRESET) {
+			log.ForceWriteToStdErr([]byte("fail to write log message to web socket"))
+		}
+		removeClient(client)
+		return false
+	}
+	return true
+}
 
-	if len(wsConnections) == 0 {
-		logs = append(logs, logMsg)
-		return
+// removeClient closes the connection of the client and stops writing events to it
+func removeClient(client *wsClient) {
+	mu.Lock()
+	defer mu.Unlock()
+	deleteWSConnection(client)
+}
+
+// deleteWSConnection removes the client from the connections, mu must be held
+func deleteWSConnection(client *wsClient) {
+	for i, c := range wsConnections {
+		if c == client {
+			wsConnections = append(wsConnections[:i], wsConnections[i+1:]...)
+			close(client.events)
+			client.conn.Close()
+			return
+		}
 	}
+}
 
-	for _, ws := range wsConnections {
-		err = ws.WriteMessage(1, logMsg)
-		if err != nil {
-			// when client connection is closed
-			if errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
-				deleteWSConnection(ws)
-				continue
-			}
+// SendLog stores the log message and queues it to all the connected websocket clients
+func SendLog(logMsg []byte) {
+	mu.Lock()
+	defer mu.Unlock()
 
-			log.ForceWriteToStdErr([]byte("fail to write log message to web socket"))
-			break
+	event := store.add(logMsg)
+
+	for _, client := range append([]*wsClient(nil), wsConnections...) {
+		select {
+		case client.events <- event:
+		default:
+			log.ForceWriteToStdErr([]byte("web socket client is too slow to receive the log messages, closing its connection\n"))
+			deleteWSConnection(client)
 		}
 	}
 }
@@ -114,6 +114,7 @@ export class DeployProgressComponent extends BasicSubscriber implements OnInit {
      * @method process websocket data
      *  if data is a line of log, push to logs array
      *  if data is status update, update deployment status
+     *  the final result entry is ignored, its status is also sent as a status update
      * @param {object} data websocket entry from backend
      */
     processData(data) {
@@ -124,7 +125,7 @@ export class DeployProgressComponent extends BasicSubscriber implements OnInit {
                 type: this.convertLogType(data.data.logType),
                 timestamp: data.data.message.slice(1, 20)
             };
-        } else {
+        } else if (data.type !== 'result') {
             this.curStatus.msg = data.data.message;
             this.curStatus.status = data.data.status;
 
@@ -142,8 +143,8 @@ export class DeployProgressComponent extends BasicSubscriber implements OnInit {
             }
 
             this.curStatus.totalCount = data.data.totalPhases ? data.data.totalPhases.length : 0;
-            return null;
         }
+        return null;
     }
 
     /**
@@ -0,0 +1,261 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlprocessor
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+// ChangeType is the type of change of a resource or a field between two manifests
+type ChangeType string
+
+// manifest change type constants
+const (
+	ChangeTypeAdded    ChangeType = "added"
+	ChangeTypeRemoved  ChangeType = "removed"
+	ChangeTypeModified ChangeType = "modified"
+)
+
+// FieldChange is the change of a field of a Kubernetes resource
+type FieldChange struct {
+	// Path is the path of the field, list items having a name are identified by their name, e.g. spec.containers[name=manager].image
+	Path   string      `json:"path" yaml:"path"`
+	Change ChangeType  `json:"change" yaml:"change"`
+	From   interface{} `json:"from,omitempty" yaml:"from,omitempty"`
+	To     interface{} `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+// ResourceDiff is the change of a Kubernetes resource between two manifests
+type ResourceDiff struct {
+	APIVersion string        `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string        `json:"kind" yaml:"kind"`
+	Namespace  string        `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name       string        `json:"name" yaml:"name"`
+	Change     ChangeType    `json:"change" yaml:"change"`
+	Fields     []FieldChange `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// ManifestDiff is the structured diff of the Kubernetes resources of two manifests
+type ManifestDiff struct {
+	Resources []ResourceDiff `json:"resources" yaml:"resources"`
+}
+
+// IsEmpty tells whether the manifests have the same resources
+func (d *ManifestDiff) IsEmpty() bool {
+	return len(d.Resources) == 0
+}
+
+// String returns the diff grouped by resource, one field change per line
+func (d *ManifestDiff) String() string {
+	var sb strings.Builder
+	for _, resource := range d.Resources {
+		symbol := map[ChangeType]string{ChangeTypeAdded: "+", ChangeTypeRemoved: "-", ChangeTypeModified: "~"}[resource.Change]
+		fmt.Fprintf(&sb, "%s %s %s\n", symbol, resource.Kind, resourceName(resource.Namespace, resource.Name))
+		for _, field := range resource.Fields {
+			switch field.Change {
+			case ChangeTypeAdded:
+				fmt.Fprintf(&sb, "    + %s: %v\n", field.Path, field.To)
+			case ChangeTypeRemoved:
+				fmt.Fprintf(&sb, "    - %s: %v\n", field.Path, field.From)
+			default:
+				fmt.Fprintf(&sb, "    ~ %s: %v -> %v\n", field.Path, field.From, field.To)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// DiffManifests compares the Kubernetes resources of two multi-document YAML manifests. Resources are matched by
+// kind, group, namespace and name, and the changes of the modified resources are reported field by field.
+func DiffManifests(from, to []byte) (*ManifestDiff, error) {
+	fromObjs, err := manifestResources(from)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the original manifest")
+	}
+	toObjs, err := manifestResources(to)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the new manifest")
+	}
+
+	keys := make([]string, 0, len(fromObjs)+len(toObjs))
+	for key := range fromObjs {
+		keys = append(keys, key)
+	}
+	for key := range toObjs {
+		if _, ok := fromObjs[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	diff := &ManifestDiff{Resources: []ResourceDiff{}}
+	for _, key := range keys {
+		fromObj, inFrom := fromObjs[key]
+		toObj, inTo := toObjs[key]
+		switch {
+		case !inFrom:
+			diff.Resources = append(diff.Resources, newResourceDiff(toObj, ChangeTypeAdded, nil))
+		case !inTo:
+			diff.Resources = append(diff.Resources, newResourceDiff(fromObj, ChangeTypeRemoved, nil))
+		default:
+			var fields []FieldChange
+			diffValues("", fromObj.Object, toObj.Object, &fields)
+			if len(fields) != 0 {
+				diff.Resources = append(diff.Resources, newResourceDiff(toObj, ChangeTypeModified, fields))
+			}
+		}
+	}
+	return diff, nil
+}
+
+func manifestResources(manifest []byte) (map[string]*unstructured.Unstructured, error) {
+	objs, err := utilyaml.ToUnstructured(manifest)
+	if err != nil {
+		return nil, err
+	}
+	resources := make(map[string]*unstructured.Unstructured, len(objs))
+	for i := range objs {
+		obj := &objs[i]
+		gvk := obj.GroupVersionKind()
+		// resources are keyed by kind first so that the diff is grouped by kind
+		key := fmt.Sprintf("%s/%s/%s/%s", gvk.Kind, gvk.Group, obj.GetNamespace(), obj.GetName())
+		if _, ok := resources[key]; ok {
+			return nil, errors.Errorf("duplicate resource %s %s", gvk.Kind, resourceName(obj.GetNamespace(), obj.GetName()))
+		}
+		resources[key] = obj
+	}
+	return resources, nil
+}
+
+func newResourceDiff(obj *unstructured.Unstructured, change ChangeType, fields []FieldChange) ResourceDiff {
+	return ResourceDiff{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Change:     change,
+		Fields:     fields,
+	}
+}
+
+// diffValues appends the field changes between the from and to values found at path
+func diffValues(path string, from, to interface{}, fields *[]FieldChange) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+	switch {
+	case from == nil:
+		*fields = append(*fields, FieldChange{Path: path, Change: ChangeTypeAdded, To: to})
+		return
+	case to == nil:
+		*fields = append(*fields, FieldChange{Path: path, Change: ChangeTypeRemoved, From: from})
+		return
+	}
+
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		keys := make([]string, 0, len(fromMap)+len(toMap))
+		for key := range fromMap {
+			keys = append(keys, key)
+		}
+		for key := range toMap {
+			if _, ok := fromMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			diffValues(joinFieldPath(path, key), fromMap[key], toMap[key], fields)
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]interface{})
+	toList, toIsList := to.([]interface{})
+	if fromIsList && toIsList {
+		diffLists(path, fromList, toList, fields)
+		return
+	}
+
+	*fields = append(*fields, FieldChange{Path: path, Change: ChangeTypeModified, From: from, To: to})
+}
+
+// diffLists compares lists of named items, such as containers or variables, by name and other lists by index
+func diffLists(path string, from, to []interface{}, fields *[]FieldChange) {
+	fromNamed, fromOK := namedListItems(from)
+	toNamed, toOK := namedListItems(to)
+	if !fromOK || !toOK {
+		for i := 0; i < len(from) || i < len(to); i++ {
+			var fromItem, toItem interface{}
+			if i < len(from) {
+				fromItem = from[i]
+			}
+			if i < len(to) {
+				toItem = to[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), fromItem, toItem, fields)
+		}
+		return
+	}
+
+	names := make([]string, 0, len(fromNamed)+len(toNamed))
+	for name := range fromNamed {
+		names = append(names, name)
+	}
+	for name := range toNamed {
+		if _, ok := fromNamed[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		diffValues(fmt.Sprintf("%s[name=%s]", path, name), fromNamed[name], toNamed[name], fields)
+	}
+}
+
+// namedListItems indexes the list items by name, if all of them are objects with a unique name
+func namedListItems(list []interface{}) (map[string]interface{}, bool) {
+	items := make(map[string]interface{}, len(list))
+	for _, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		if _, exists := items[name]; exists {
+			return nil, false
+		}
+		items[name] = item
+	}
+	return items, len(items) != 0
+}
+
+func joinFieldPath(path, key string) string {
+	if strings.Contains(key, ".") {
+		key = fmt.Sprintf("[%s]", key)
+		return path + key
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func resourceName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
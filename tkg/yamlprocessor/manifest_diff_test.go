@@ -0,0 +1,134 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlprocessor_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+const manifestBefore = `
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: c1
+  namespace: default
+  labels:
+    tkg.tanzu.vmware.com/cluster-name: c1
+spec:
+  clusterNetwork:
+    pods:
+      cidrBlocks: ["100.96.0.0/11"]
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: manager
+  namespace: system
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+        image: manager:v1
+      - name: proxy
+        image: proxy:v1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: removed
+  namespace: default
+`
+
+const manifestAfter = `
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: c1
+  namespace: default
+  labels:
+    tkg.tanzu.vmware.com/cluster-name: c1
+spec:
+  clusterNetwork:
+    pods:
+      cidrBlocks: ["100.96.0.0/11"]
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: manager
+  namespace: system
+  annotations:
+    owner: tkg
+spec:
+  template:
+    spec:
+      containers:
+      - name: proxy
+        image: proxy:v1
+      - name: manager
+        image: manager:v2
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: added
+  namespace: default
+`
+
+var _ = Describe("DiffManifests", func() {
+	It("reports the added, removed and modified resources with their field changes", func() {
+		diff, err := yamlprocessor.DiffManifests([]byte(manifestBefore), []byte(manifestAfter))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.IsEmpty()).To(BeFalse())
+		Expect(diff.Resources).To(HaveLen(3))
+
+		changes := map[string]yamlprocessor.ResourceDiff{}
+		for _, resource := range diff.Resources {
+			changes[resource.Kind+"/"+resource.Name] = resource
+		}
+		Expect(changes["ConfigMap/removed"].Change).To(Equal(yamlprocessor.ChangeTypeRemoved))
+		Expect(changes["Secret/added"].Change).To(Equal(yamlprocessor.ChangeTypeAdded))
+
+		deployment := changes["Deployment/manager"]
+		Expect(deployment.Change).To(Equal(yamlprocessor.ChangeTypeModified))
+		Expect(deployment.Fields).To(ConsistOf(
+			yamlprocessor.FieldChange{Path: "metadata.annotations", Change: yamlprocessor.ChangeTypeAdded, To: map[string]interface{}{"owner": "tkg"}},
+			yamlprocessor.FieldChange{Path: "spec.template.spec.containers[name=manager].image", Change: yamlprocessor.ChangeTypeModified, From: "manager:v1", To: "manager:v2"},
+		))
+		Expect(diff.String()).To(ContainSubstring("~ spec.template.spec.containers[name=manager].image: manager:v1 -> manager:v2"))
+	})
+
+	It("identifies fields whose name contains dots", func() {
+		diff, err := yamlprocessor.DiffManifests([]byte(manifestBefore), []byte(`
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: c1
+  namespace: default
+  labels:
+    tkg.tanzu.vmware.com/cluster-name: c2
+spec:
+  clusterNetwork:
+    pods:
+      cidrBlocks: ["100.96.0.0/11", "fd00:100:96::/48"]
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.Resources).To(HaveLen(3))
+		Expect(diff.Resources[0].Kind).To(Equal("Cluster"))
+		Expect(diff.Resources[0].Fields).To(ConsistOf(
+			yamlprocessor.FieldChange{Path: "metadata.labels[tkg.tanzu.vmware.com/cluster-name]", Change: yamlprocessor.ChangeTypeModified, From: "c1", To: "c2"},
+			yamlprocessor.FieldChange{Path: "spec.clusterNetwork.pods.cidrBlocks[1]", Change: yamlprocessor.ChangeTypeAdded, To: "fd00:100:96::/48"},
+		))
+	})
+
+	It("returns an empty diff for identical manifests", func() {
+		diff, err := yamlprocessor.DiffManifests([]byte(manifestBefore), []byte(manifestBefore))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff.IsEmpty()).To(BeTrue())
+	})
+})
@@ -0,0 +1,225 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlprocessor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/constants"
+)
+
+var overlayPackNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// OverlayPack is a versioned set of ytt overlays fetched from an OCI image, which is applied on top
+// of the provider templates of every cluster created by the management cluster it is pinned to.
+type OverlayPack struct {
+	Name string `json:"name" yaml:"name"`
+	// Image is the OCI image with tag the overlay pack was fetched from
+	Image string `json:"image" yaml:"image"`
+	// Checksum is the sha256 checksum of the overlay pack files, verified each time the overlay pack is applied
+	Checksum  string    `json:"checksum" yaml:"checksum"`
+	FetchedAt time.Time `json:"fetchedAt" yaml:"fetchedAt"`
+}
+
+// OverlayPackLock is the list of the overlay packs pinned to a management cluster, which is stored in
+// the management cluster so that every user of the management cluster applies the same overlay packs
+type OverlayPackLock struct {
+	Packs []OverlayPack `yaml:"packs,omitempty"`
+	// ResourceVersion is the version of the management cluster object the lock was read from, it is used
+	// to detect the concurrent changes of the lock when saving it
+	ResourceVersion string `yaml:"-"`
+}
+
+// OverlayPacksDir returns the local directory caching the overlay packs pinned to the management cluster.
+// The directory is keyed by the name and the API server of the management cluster, so that management
+// clusters with the same name do not share their overlay packs.
+func OverlayPacksDir(tkgDir, managementClusterName, managementClusterServer string) string {
+	serverHash := sha256.Sum256([]byte(managementClusterServer))
+	return filepath.Join(tkgDir, constants.OverlayPacksFolderName, managementClusterName+"-"+hex.EncodeToString(serverHash[:])[:12])
+}
+
+// ParseOverlayPackLock parses the overlay pack lock. An empty lock is returned for empty data.
+func ParseOverlayPackLock(data []byte) (*OverlayPackLock, error) {
+	lock := &OverlayPackLock{}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, errors.Wrap(err, "unable to parse the overlay packs lock")
+	}
+	// the lock is shared by every user of the management cluster, its names are used as paths of the local cache
+	for i := range lock.Packs {
+		if err := validateOverlayPackName(lock.Packs[i].Name); err != nil {
+			return nil, errors.Wrap(err, "invalid overlay packs lock")
+		}
+	}
+	return lock, nil
+}
+
+// validateOverlayPackName ensures the overlay pack name is a directory name within the overlay packs directory
+func validateOverlayPackName(name string) error {
+	if !overlayPackNameRegexp.MatchString(name) {
+		return errors.Errorf("invalid overlay pack name %q, it must consist of lower case alphanumeric characters or '-'", name)
+	}
+	return nil
+}
+
+// Marshal returns the overlay pack lock as yaml, with the overlay packs sorted by name
+func (l *OverlayPackLock) Marshal() ([]byte, error) {
+	sort.Slice(l.Packs, func(i, j int) bool { return l.Packs[i].Name < l.Packs[j].Name })
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal the overlay packs lock")
+	}
+	return data, nil
+}
+
+// Get returns the overlay pack with the given name
+func (l *OverlayPackLock) Get(name string) (*OverlayPack, bool) {
+	for i := range l.Packs {
+		if l.Packs[i].Name == name {
+			return &l.Packs[i], true
+		}
+	}
+	return nil, false
+}
+
+// AddOverlayPack fetches the overlay pack image into the overlay packs directory using download, and pins it in the lock.
+// An overlay pack with the same name is replaced. The caller is responsible for saving the lock.
+func AddOverlayPack(dir string, lock *OverlayPackLock, name, image string, download func(image, dir string) error) (*OverlayPack, error) {
+	if err := validateOverlayPackName(name); err != nil {
+		return nil, err
+	}
+	checksum, err := fetchOverlayPack(dir, name, image, "", download)
+	if err != nil {
+		return nil, err
+	}
+
+	pack := OverlayPack{Name: name, Image: image, Checksum: checksum, FetchedAt: time.Now().UTC()}
+	if existing, ok := lock.Get(name); ok {
+		*existing = pack
+	} else {
+		lock.Packs = append(lock.Packs, pack)
+	}
+	return &pack, nil
+}
+
+// DeleteOverlayPack removes the overlay pack from the lock and from the overlay packs directory.
+// The caller is responsible for saving the lock.
+func DeleteOverlayPack(dir string, lock *OverlayPackLock, name string) error {
+	if err := validateOverlayPackName(name); err != nil {
+		return err
+	}
+	packs := lock.Packs[:0]
+	for _, pack := range lock.Packs {
+		if pack.Name != name {
+			packs = append(packs, pack)
+		}
+	}
+	if len(packs) == len(lock.Packs) {
+		return errors.Errorf("overlay pack %s not found", name)
+	}
+	lock.Packs = packs
+	return os.RemoveAll(filepath.Join(dir, name))
+}
+
+// SyncOverlayPacks returns the paths of the overlay packs pinned in the lock within the overlay packs directory.
+// The overlay packs missing from the directory, or whose checksum does not match the lock, are fetched again
+// from their pinned image using download, and must then match the pinned checksum.
+func SyncOverlayPacks(dir string, lock *OverlayPackLock, download func(image, dir string) error) ([]string, error) {
+	paths := make([]string, 0, len(lock.Packs))
+	for _, pack := range lock.Packs {
+		if err := validateOverlayPackName(pack.Name); err != nil {
+			return nil, err
+		}
+		packDir := filepath.Join(dir, pack.Name)
+		checksum, err := OverlayPackChecksum(packDir)
+		if err != nil || checksum != pack.Checksum {
+			if _, err := fetchOverlayPack(dir, pack.Name, pack.Image, pack.Checksum, download); err != nil {
+				return nil, err
+			}
+		}
+		paths = append(paths, packDir)
+	}
+	return paths, nil
+}
+
+// fetchOverlayPack fetches the overlay pack image into the overlay packs directory using download and returns
+// its checksum. When expectedChecksum is set, the fetched overlay pack must match it.
+func fetchOverlayPack(dir, name, image, expectedChecksum string, download func(image, dir string) error) (string, error) {
+	if err := validateOverlayPackName(name); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, constants.DefaultDirectoryPermissions); err != nil {
+		return "", err
+	}
+
+	// fetch into a temporary directory first so that a failed fetch leaves the cached overlay pack untouched
+	tmpDir, err := os.MkdirTemp(dir, "."+name)
+	if err != nil {
+		return "", errors.Wrap(err, "error creating temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := download(image, tmpDir); err != nil {
+		return "", errors.Wrapf(err, "unable to fetch overlay pack %s from %s", name, image)
+	}
+	checksum, err := OverlayPackChecksum(tmpDir)
+	if err != nil {
+		return "", err
+	}
+	if expectedChecksum != "" && checksum != expectedChecksum {
+		return "", errors.Errorf("checksum of overlay pack %s fetched from %s does not match the pinned checksum, expected %s but got %s. Add the overlay pack again to pin its current content", name, image, expectedChecksum, checksum)
+	}
+
+	packDir := filepath.Join(dir, name)
+	if err := os.RemoveAll(packDir); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpDir, packDir); err != nil {
+		return "", errors.Wrapf(err, "unable to save overlay pack %s", name)
+	}
+	return checksum, nil
+}
+
+// OverlayPackChecksum computes the sha256 checksum of the files of the overlay pack directory,
+// taking into account their relative paths
+func OverlayPackChecksum(packDir string) (string, error) {
+	var relPaths []string
+	err := filepath.WalkDir(packDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			relPath, err := filepath.Rel(packDir, path)
+			if err != nil {
+				return err
+			}
+			relPaths = append(relPaths, filepath.ToSlash(relPath))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read overlay pack %s", packDir)
+	}
+	sort.Strings(relPaths)
+
+	hash := sha256.New()
+	for _, relPath := range relPaths {
+		data, err := os.ReadFile(filepath.Join(packDir, filepath.FromSlash(relPath)))
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to read overlay pack %s", packDir)
+		}
+		fileHash := sha256.Sum256(data)
+		fmt.Fprintf(hash, "%s  %s\n", hex.EncodeToString(fileHash[:]), relPath)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
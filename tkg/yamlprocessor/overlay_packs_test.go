@@ -0,0 +1,195 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlprocessor_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/tkg/yamlprocessor"
+)
+
+var _ = Describe("Overlay packs", func() {
+	var (
+		tkgDir     string
+		packsDir   string
+		lock       *yamlprocessor.OverlayPackLock
+		overlay    string
+		downloaded []string
+	)
+
+	download := func(image, dir string) error {
+		downloaded = append(downloaded, image)
+		return os.WriteFile(filepath.Join(dir, "overlay.yaml"), []byte(overlay), 0o600)
+	}
+
+	BeforeEach(func() {
+		var err error
+		tkgDir, err = os.MkdirTemp("", "tkg-cli")
+		Expect(err).ToNot(HaveOccurred())
+		packsDir = yamlprocessor.OverlayPacksDir(tkgDir, "mgmt", "https://10.0.0.1:6443")
+		lock = &yamlprocessor.OverlayPackLock{}
+		overlay = "#@ load(\"@ytt:overlay\", \"overlay\")\n"
+		downloaded = nil
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tkgDir)
+	})
+
+	It("should key the overlay packs directory by the management cluster name and API server", func() {
+		Expect(filepath.Base(packsDir)).To(HavePrefix("mgmt-"))
+		Expect(yamlprocessor.OverlayPacksDir(tkgDir, "mgmt", "https://10.0.0.2:6443")).ToNot(Equal(packsDir))
+		Expect(yamlprocessor.OverlayPacksDir(tkgDir, "mgmt", "https://10.0.0.1:6443")).To(Equal(packsDir))
+	})
+
+	It("should fetch and pin the overlay pack in the lock", func() {
+		pack, err := yamlprocessor.AddOverlayPack(packsDir, lock, "labels", "registry.example.com/overlays/labels:v1", download)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(downloaded).To(Equal([]string{"registry.example.com/overlays/labels:v1"}))
+		Expect(pack.Checksum).To(HavePrefix("sha256:"))
+		Expect(filepath.Join(packsDir, "labels", "overlay.yaml")).To(BeAnExistingFile())
+		Expect(lock.Packs).To(HaveLen(1))
+		Expect(lock.Packs[0].Image).To(Equal("registry.example.com/overlays/labels:v1"))
+		Expect(lock.Packs[0].Checksum).To(Equal(pack.Checksum))
+
+		overlay += "#! v2\n"
+		updated, err := yamlprocessor.AddOverlayPack(packsDir, lock, "labels", "registry.example.com/overlays/labels:v2", download)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Checksum).ToNot(Equal(pack.Checksum))
+		Expect(lock.Packs).To(HaveLen(1))
+		Expect(lock.Packs[0].Image).To(Equal("registry.example.com/overlays/labels:v2"))
+	})
+
+	It("should round trip the lock", func() {
+		_, err := yamlprocessor.AddOverlayPack(packsDir, lock, "labels", "registry.example.com/overlays/labels:v1", download)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = yamlprocessor.AddOverlayPack(packsDir, lock, "annotations", "registry.example.com/overlays/annotations:v1", download)
+		Expect(err).ToNot(HaveOccurred())
+		data, err := lock.Marshal()
+		Expect(err).ToNot(HaveOccurred())
+
+		parsed, err := yamlprocessor.ParseOverlayPackLock(data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.Packs).To(HaveLen(2))
+		Expect(parsed.Packs[0].Name).To(Equal("annotations"))
+		Expect(parsed.Packs[1].Checksum).To(Equal(lock.Packs[1].Checksum))
+
+		empty, err := yamlprocessor.ParseOverlayPackLock(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(empty.Packs).To(BeEmpty())
+	})
+
+	It("should keep the pinned overlay pack when fetching fails", func() {
+		_, err := yamlprocessor.AddOverlayPack(packsDir, lock, "labels", "registry.example.com/overlays/labels:v1", download)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = yamlprocessor.AddOverlayPack(packsDir, lock, "labels", "registry.example.com/overlays/labels:v2", func(image, dir string) error {
+			return errors.New("unauthorized")
+		})
+		Expect(err).To(MatchError(ContainSubstring("unauthorized")))
+		Expect(filepath.Join(packsDir, "labels", "overlay.yaml")).To(BeAnExistingFile())
+		Expect(lock.Packs[0].Image).To(Equal("registry.example.com/overlays/labels:v1"))
+	})
+
+	It("should reject invalid overlay pack names", func() {
+		_, err := yamlprocessor.AddOverlayPack(packsDir, lock, "../labels", "registry.example.com/overlays/labels:v1", download)
+		Expect(err).To(MatchError(ContainSubstring("invalid overlay pack name")))
+	})
+
+	It("should reject locks with invalid overlay pack names", func() {
+		_, err := yamlprocessor.ParseOverlayPackLock([]byte("packs:\n- name: ../../.ssh\n  image: registry.example.com/overlays/labels:v1\n"))
+		Expect(err).To(MatchError(ContainSubstring(`invalid overlay pack name "../../.ssh"`)))
+	})
+
+	It("should not touch the filesystem for invalid overlay pack names", func() {
+		outside := filepath.Join(tkgDir, "outside")
+		Expect(os.MkdirAll(outside, 0o700)).To(Succeed())
+		lock.Packs = []yamlprocessor.OverlayPack{{Name: "../../outside", Image: "registry.example.com/overlays/labels:v1"}}
+
+		_, err := yamlprocessor.SyncOverlayPacks(packsDir, lock, download)
+		Expect(err).To(MatchError(ContainSubstring("invalid overlay pack name")))
+		Expect(yamlprocessor.DeleteOverlayPack(packsDir, lock, "../../outside")).To(MatchError(ContainSubstring("invalid overlay pack name")))
+		Expect(outside).To(BeADirectory())
+		Expect(downloaded).To(BeEmpty())
+	})
+
+	It("should delete the overlay pack", func() {
+		_, err := yamlprocessor.AddOverlayPack(packsDir, lock, "labels", "registry.example.com/overlays/labels:v1", download)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(yamlprocessor.DeleteOverlayPack(packsDir, lock, "labels")).To(Succeed())
+		Expect(filepath.Join(packsDir, "labels")).ToNot(BeADirectory())
+		Expect(lock.Packs).To(BeEmpty())
+		Expect(yamlprocessor.DeleteOverlayPack(packsDir, lock, "labels")).To(MatchError(ContainSubstring("not found")))
+	})
+
+	Context("when syncing the overlay packs", func() {
+		BeforeEach(func() {
+			_, err := yamlprocessor.AddOverlayPack(packsDir, lock, "labels", "registry.example.com/overlays/labels:v1", download)
+			Expect(err).ToNot(HaveOccurred())
+			downloaded = nil
+		})
+
+		It("should use the cached overlay packs matching the lock", func() {
+			paths, err := yamlprocessor.SyncOverlayPacks(packsDir, lock, download)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(paths).To(Equal([]string{filepath.Join(packsDir, "labels")}))
+			Expect(downloaded).To(BeEmpty())
+		})
+
+		It("should fetch the overlay packs missing from the cache", func() {
+			otherDir := yamlprocessor.OverlayPacksDir(tkgDir, "mgmt", "https://10.0.0.2:6443")
+			paths, err := yamlprocessor.SyncOverlayPacks(otherDir, lock, download)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(paths).To(Equal([]string{filepath.Join(otherDir, "labels")}))
+			Expect(downloaded).To(Equal([]string{"registry.example.com/overlays/labels:v1"}))
+			Expect(filepath.Join(otherDir, "labels", "overlay.yaml")).To(BeAnExistingFile())
+		})
+
+		It("should fetch the overlay packs not matching the lock again", func() {
+			Expect(os.WriteFile(filepath.Join(packsDir, "labels", "overlay.yaml"), []byte("tampered"), 0o600)).To(Succeed())
+			_, err := yamlprocessor.SyncOverlayPacks(packsDir, lock, download)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(downloaded).To(Equal([]string{"registry.example.com/overlays/labels:v1"}))
+			data, err := os.ReadFile(filepath.Join(packsDir, "labels", "overlay.yaml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(overlay))
+		})
+
+		It("should fail when the fetched overlay pack does not match the pinned checksum", func() {
+			Expect(os.RemoveAll(filepath.Join(packsDir, "labels"))).To(Succeed())
+			overlay += "#! changed upstream\n"
+			_, err := yamlprocessor.SyncOverlayPacks(packsDir, lock, download)
+			Expect(err).To(MatchError(ContainSubstring("checksum of overlay pack labels fetched from registry.example.com/overlays/labels:v1 does not match the pinned checksum")))
+		})
+	})
+
+	Context("when parsing template definitions", func() {
+		It("should add the overlay packs after the template definition paths", func() {
+			dirRelPath := filepath.Join("providers", "aws", "v0.1", "ytt")
+			Expect(os.MkdirAll(filepath.Join(tkgDir, dirRelPath), os.ModePerm)).To(Succeed())
+			templateDef := []byte(fmt.Sprintf(`
+apiVersion: providers.tanzu.vmware.com/v1alpha1
+kind: TemplateDefinition
+spec:
+  paths:
+  - path: '%s'`, dirRelPath))
+			_, err := yamlprocessor.AddOverlayPack(packsDir, lock, "labels", "registry.example.com/overlays/labels:v1", download)
+			Expect(err).ToNot(HaveOccurred())
+			packPaths, err := yamlprocessor.SyncOverlayPacks(packsDir, lock, download)
+			Expect(err).ToNot(HaveOccurred())
+
+			ydp := yamlprocessor.NewYttDefinitionParser(yamlprocessor.InjectTKGDir(tkgDir), yamlprocessor.InjectOverlayPackPaths(packPaths))
+			paths, err := ydp.ParsePath(templateDef)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(paths).To(HaveLen(2))
+			Expect(paths[1].Path).To(Equal(filepath.Join(packsDir, "labels")))
+		})
+	})
+})
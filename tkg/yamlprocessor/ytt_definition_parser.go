@@ -28,6 +28,8 @@ func init() {
 // YTTDefinitionParser is a struct for parsing ytt definitions
 type YTTDefinitionParser struct {
 	tkgDir func() string
+	// overlayPackPaths are the paths of the overlay packs applied on top of the template definition paths
+	overlayPackPaths []string
 }
 
 // YttDefinitionParserOpts a type for defining functions that modify the ytt parser
@@ -43,6 +45,14 @@ func InjectTKGDir(path string) YttDefinitionParserOpts {
 	}
 }
 
+// InjectOverlayPackPaths is a YttDefinitionParserOpts that adds the paths of the
+// overlay packs, as returned by SyncOverlayPacks, to the paths of the template definitions.
+func InjectOverlayPackPaths(paths []string) YttDefinitionParserOpts {
+	return func(dp *YTTDefinitionParser) {
+		dp.overlayPackPaths = paths
+	}
+}
+
 var _ DefinitionParser = &YTTDefinitionParser{}
 
 // NewYttDefinitionParser returns a YTTDefinitionParser
@@ -86,6 +96,14 @@ func (y *YTTDefinitionParser) ParsePath(artifact []byte) ([]v1alpha1.PathInfo, e
 		allPaths = append(allPaths, path)
 	}
 
+	// overlay packs come last so that their overlays apply on top of the provider templates
+	for _, packPath := range y.overlayPackPaths {
+		if err := y.validatePath(packPath); err != nil {
+			return nil, err
+		}
+		allPaths = append(allPaths, v1alpha1.PathInfo{Path: packPath})
+	}
+
 	return allPaths, nil
 }
 